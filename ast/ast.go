@@ -10,6 +10,7 @@ func newAST() *AST {
 	return &AST{
 		Types:   make(map[string]ConfigType),
 		Actions: make(map[string]Action),
+		Events:  make(map[string]Event),
 	}
 }
 
@@ -20,6 +21,7 @@ func newAST() *AST {
 type AST struct {
 	Types   map[string]ConfigType
 	Actions map[string]Action
+	Events  map[string]Event
 }
 
 func (a *AST) RangeTypes(fn func(configType ConfigType)) {
@@ -75,12 +77,26 @@ func (a *AST) RangeActions(fn func(action Action)) {
 	}
 }
 
+func (a *AST) RangeEvents(fn func(event Event)) {
+	var keys []string
+	for key := range a.Events {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, caseInsensitiveSort(keys))
+	for _, key := range keys {
+		fn(a.Events[key])
+	}
+}
+
 func Parse(
 	stateConfigData map[interface{}]interface{},
 	actionsConfigData map[interface{}]interface{},
 	responsesConfigData map[interface{}]interface{},
+	eventsConfigData map[interface{}]interface{},
+	indexesConfigData map[interface{}]interface{},
 ) *AST {
-	return buildASTStructure(stateConfigData, actionsConfigData, responsesConfigData).
+	return buildASTStructure(stateConfigData, actionsConfigData, responsesConfigData, eventsConfigData).
+		fillInIndexes(indexesConfigData).
 		fillInReferences().
 		fillInParentalInfo()
 }
@@ -92,6 +108,7 @@ func buildASTStructure(
 	stateConfigData map[interface{}]interface{},
 	actionsConfigData map[interface{}]interface{},
 	responsesConfigData map[interface{}]interface{},
+	eventsConfigData map[interface{}]interface{},
 ) *AST {
 	ast := newAST()
 	for key, value := range stateConfigData {
@@ -117,9 +134,44 @@ func buildASTStructure(
 		}
 	}
 
+	for key, value := range eventsConfigData {
+		objectValue := value.(map[interface{}]interface{})
+		eventName := getSring(key)
+
+		event := newEvent(eventName)
+		// events are structured just like responses
+		event.Fields = buildResponeStructure(objectValue)
+		ast.Events[eventName] = event
+	}
+
 	return ast
 }
 
+// fillInIndexes marks the fields listed in the indexes config,
+// e.g. {"item": ["name"]}
+func (a *AST) fillInIndexes(indexesConfigData map[interface{}]interface{}) *AST {
+	for key, value := range indexesConfigData {
+		configType, ok := a.Types[getSring(key)]
+		if !ok {
+			continue
+		}
+		fieldNames, ok := value.([]interface{})
+		if !ok {
+			continue
+		}
+		for _, fieldName := range fieldNames {
+			field, ok := configType.Fields[getSring(fieldName)]
+			if !ok {
+				continue
+			}
+			field.IsIndexed = true
+			configType.Fields[field.Name] = field
+		}
+	}
+
+	return a
+}
+
 func buildTypeStructure(configTypeData map[interface{}]interface{}, typeName string) ConfigType {
 	configType := newConfigType(typeName)
 
@@ -128,12 +180,14 @@ func buildTypeStructure(configTypeData map[interface{}]interface{}, typeName str
 		valueString := getSring(value)
 
 		field := Field{
-			ValueTypes:      make(map[string]*ConfigType),
-			Name:            fieldName,
-			HasSliceValue:   isSliceValue(valueString),
-			HasPointerValue: isPointerValue(valueString),
-			ValueString:     valueString,
-			HasAnyValue:     isAnyValue(valueString),
+			ValueTypes:       make(map[string]*ConfigType),
+			Name:             fieldName,
+			HasSliceValue:    isSliceValue(valueString),
+			HasSetValue:      isSetValue(valueString),
+			HasPointerValue:  isPointerValue(valueString),
+			ValueString:      valueString,
+			HasAnyValue:      isAnyValue(valueString),
+			HasOptionalValue: isOptionalValue(valueString),
 		}
 
 		configType.Fields[fieldName] = field
@@ -226,6 +280,13 @@ func (a *AST) fillInReferences() *AST {
 		a.Actions[actionName] = action
 	}
 
+	for eventName, event := range a.Events {
+		for _, field := range event.Fields {
+			a.assignFieldTypeReference(&field)
+		}
+		a.Events[eventName] = event
+	}
+
 	return a
 }
 
@@ -242,7 +303,7 @@ func (a *AST) assignFieldTypeReference(field *Field) {
 		} else {
 			// TODO: maybe be more explicit
 			// IDs of types (eg. playerID) are treated this way as well
-			field.ValueTypes[extractValueType(field.ValueString)] = &ConfigType{Name: extractValueType(field.ValueString), IsBasicType: true}
+			field.ValueTypes[extractValueType(field.ValueString)] = &ConfigType{Name: basicTypeName(extractValueType(field.ValueString)), IsBasicType: true}
 		}
 	}
 }
@@ -57,8 +57,14 @@ func TestStateConfigAST(t *testing.T) {
 		},
 	}
 
+	eventsData := map[interface{}]interface{}{
+		"residentMovedIn": map[interface{}]interface{}{
+			"resident": "personID",
+		},
+	}
+
 	t.Run("should build the structure of AST", func(t *testing.T) {
-		actual := buildASTStructure(stateData, actionsData, responseData)
+		actual := buildASTStructure(stateData, actionsData, responseData, eventsData)
 
 		expected := &AST{
 			Actions: map[string]Action{
@@ -131,6 +137,20 @@ func TestStateConfigAST(t *testing.T) {
 					},
 				},
 			},
+			Events: map[string]Event{
+				"residentMovedIn": {
+					Name: "residentMovedIn",
+					Fields: map[string]Field{
+						"resident": {
+							Name:            "resident",
+							ValueString:     "personID",
+							HasSliceValue:   false,
+							HasPointerValue: false,
+							ValueTypes:      make(map[string]*ConfigType),
+						},
+					},
+				},
+			},
 			Types: map[string]ConfigType{
 				"house": {
 					Name: "house",
@@ -278,7 +298,7 @@ func TestStateConfigAST(t *testing.T) {
 
 	t.Run("should fill in references of AST", func(t *testing.T) {
 
-		actual := buildASTStructure(stateData, actionsData, responseData)
+		actual := buildASTStructure(stateData, actionsData, responseData, eventsData)
 		actual.fillInReferences().fillInParentalInfo()
 
 		houseType := actual.Types["house"]
@@ -398,6 +418,11 @@ func TestStateConfigAST(t *testing.T) {
 		assert.Equal(t, isValidAddressResponseValue.ValueTypes["bool"].Name, "bool")
 		assert.Equal(t, isValidAddressResponseValue.ValueTypes["bool"].IsBasicType, true)
 
+		residentMovedInEvent := actual.Events["residentMovedIn"]
+		residentEventField := residentMovedInEvent.Fields["resident"]
+		assert.Equal(t, residentEventField.ValueTypes["personID"].Name, "personID")
+		assert.Equal(t, residentEventField.ValueTypes["personID"].IsBasicType, true)
+
 		assert.Equal(t, personType.ReferencedBy, []*Field{&banField, &barField, &friendsField})
 		assert.Equal(t, addressType.ReferencedBy, []*Field{&banField, &barField})
 		assert.Equal(t, houseType.ReferencedBy, []*Field{&secondHomeField})
@@ -406,7 +431,7 @@ func TestStateConfigAST(t *testing.T) {
 
 	t.Run("should fill in parentalInfo", func(t *testing.T) {
 
-		actual := buildASTStructure(stateData, actionsData, responseData)
+		actual := buildASTStructure(stateData, actionsData, responseData, eventsData)
 		actual.fillInReferences().fillInParentalInfo()
 
 		assert.True(t, actual.Types["house"].IsRootType)
@@ -418,6 +443,114 @@ func TestStateConfigAST(t *testing.T) {
 		assert.True(t, actual.Types["city"].IsRootType)
 		assert.True(t, actual.Types["city"].IsLeafType)
 	})
+
+	t.Run("should fill in indexes", func(t *testing.T) {
+		indexesData := map[interface{}]interface{}{
+			"person": []interface{}{"name", "age"},
+		}
+
+		actual := buildASTStructure(stateData, actionsData, responseData, eventsData)
+		actual.fillInIndexes(indexesData).fillInReferences().fillInParentalInfo()
+
+		assert.True(t, actual.Types["person"].Fields["name"].IsIndexed)
+		assert.True(t, actual.Types["person"].Fields["age"].IsIndexed)
+		assert.False(t, actual.Types["person"].Fields["friends"].IsIndexed)
+		assert.False(t, actual.Types["city"].Fields["name"].IsIndexed)
+	})
+
+	t.Run("should build set fields like slice fields", func(t *testing.T) {
+		setStateData := map[interface{}]interface{}{
+			"person": map[interface{}]interface{}{
+				"nickNames": "set<string>",
+				"friends":   "set<*person>",
+				"pets":      "set<*anyOf<cat,dog>>",
+			},
+			"cat": map[interface{}]interface{}{
+				"name": "string",
+			},
+			"dog": map[interface{}]interface{}{
+				"name": "string",
+			},
+		}
+
+		actual := Parse(setStateData, map[interface{}]interface{}{}, map[interface{}]interface{}{}, map[interface{}]interface{}{}, map[interface{}]interface{}{})
+
+		nickNames := actual.Types["person"].Fields["nickNames"]
+		assert.True(t, nickNames.HasSliceValue)
+		assert.True(t, nickNames.HasSetValue)
+		assert.True(t, nickNames.ValueType().IsBasicType)
+		assert.Equal(t, "string", nickNames.ValueTypeName)
+
+		friends := actual.Types["person"].Fields["friends"]
+		assert.True(t, friends.HasSetValue)
+		assert.True(t, friends.HasPointerValue)
+		assert.Equal(t, "person", friends.ValueType().Name)
+		assert.Equal(t, "personFriendRef", friends.ValueTypeName)
+
+		pets := actual.Types["person"].Fields["pets"]
+		assert.True(t, pets.HasSetValue)
+		assert.True(t, pets.HasAnyValue)
+		assert.Equal(t, 2, len(pets.ValueTypes))
+		assert.Equal(t, "personPetRef", pets.ValueTypeName)
+	})
+
+	t.Run("should build optional fields like value fields", func(t *testing.T) {
+		optionalStateData := map[interface{}]interface{}{
+			"person": map[interface{}]interface{}{
+				"pet": "?cat",
+			},
+			"cat": map[interface{}]interface{}{
+				"name": "string",
+			},
+		}
+
+		actual := Parse(optionalStateData, map[interface{}]interface{}{}, map[interface{}]interface{}{}, map[interface{}]interface{}{}, map[interface{}]interface{}{})
+
+		pet := actual.Types["person"].Fields["pet"]
+		assert.True(t, pet.HasOptionalValue)
+		assert.False(t, pet.HasSliceValue)
+		assert.False(t, pet.HasPointerValue)
+		assert.Equal(t, "cat", pet.ValueType().Name)
+		assert.Equal(t, "cat", pet.ValueTypeName)
+		assert.False(t, actual.Types["cat"].IsRootType)
+	})
+
+	t.Run("should build time, duration, bytes and bigID fields with the types they are represented by", func(t *testing.T) {
+		aliasStateData := map[interface{}]interface{}{
+			"person": map[interface{}]interface{}{
+				"birthday":   "time",
+				"sleepTimes": "[]duration",
+				"avatar":     "bytes",
+				"accountID":  "bigID",
+			},
+		}
+		aliasActionsData := map[interface{}]interface{}{
+			"wakeUp": map[interface{}]interface{}{
+				"at": "time",
+			},
+		}
+
+		actual := Parse(aliasStateData, aliasActionsData, map[interface{}]interface{}{}, map[interface{}]interface{}{}, map[interface{}]interface{}{})
+
+		birthday := actual.Types["person"].Fields["birthday"]
+		assert.True(t, birthday.ValueType().IsBasicType)
+		assert.Equal(t, "time.Time", birthday.ValueTypeName)
+
+		sleepTimes := actual.Types["person"].Fields["sleepTimes"]
+		assert.True(t, sleepTimes.HasSliceValue)
+		assert.Equal(t, "Duration", sleepTimes.ValueType().Name)
+
+		avatar := actual.Types["person"].Fields["avatar"]
+		assert.Equal(t, "[]byte", avatar.ValueType().Name)
+		assert.False(t, avatar.HasSliceValue)
+
+		accountID := actual.Types["person"].Fields["accountID"]
+		assert.True(t, accountID.ValueType().IsBasicType)
+		assert.Equal(t, "BigID", accountID.ValueTypeName)
+
+		at := actual.Actions["wakeUp"].Params["at"]
+		assert.Equal(t, "time.Time", at.ValueType().Name)
+	})
 }
 
 // func namesInFieldSlice(fields []*Field) []string {
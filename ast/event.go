@@ -0,0 +1,26 @@
+package ast
+
+import "sort"
+
+func newEvent(name string) Event {
+	return Event{
+		Name:   name,
+		Fields: make(map[string]Field),
+	}
+}
+
+type Event struct {
+	Name   string
+	Fields map[string]Field
+}
+
+func (e *Event) RangeFields(fn func(field Field)) {
+	var keys []string
+	for key := range e.Fields {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, caseInsensitiveSort(keys))
+	for _, key := range keys {
+		fn(e.Fields[key])
+	}
+}
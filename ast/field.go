@@ -3,14 +3,17 @@ package ast
 import "sort"
 
 type Field struct {
-	Name            string
-	ValueTypes      map[string]*ConfigType // references the field's value's Type
-	Parent          *ConfigType            // references the field's parent (not use when field is action param)
-	ValueTypeName   string                 // the name of the to-be-generated type
-	ValueString     string                 // the original value represented as string (eg. "[]Person")
-	HasSliceValue   bool                   // if the value is a slice value (eg. []string)
-	HasPointerValue bool                   // if the value is a pointer value (eg. *foo, []*foo)
-	HasAnyValue     bool
+	Name             string
+	ValueTypes       map[string]*ConfigType // references the field's value's Type
+	Parent           *ConfigType            // references the field's parent (not use when field is action param)
+	ValueTypeName    string                 // the name of the to-be-generated type
+	ValueString      string                 // the original value represented as string (eg. "[]Person")
+	HasSliceValue    bool                   // if the value is a slice value (eg. []string, set<string>)
+	HasSetValue      bool                   // if the value is a set value, which is a slice value with unique elements (eg. set<string>)
+	HasPointerValue  bool                   // if the value is a pointer value (eg. *foo, []*foo)
+	HasAnyValue      bool
+	HasOptionalValue bool // if the value is an optional value, whose element may not exist (eg. ?foo)
+	IsIndexed        bool // if lookups by the field's value are indexed (only basic non-slice values)
 }
 
 func (f *Field) RangeValueTypes(fn func(configType *ConfigType)) {
@@ -22,15 +22,57 @@ func valueTypeNameSort(fields []*Field) func(i, j int) bool {
 	}
 }
 
+// types of the config which are treated as basic types,
+// but are represented by other types in the generated code
+var basicTypeAliases = map[string]string{
+	"time":     "time.Time",
+	"duration": "Duration",
+	"bytes":    "[]byte",
+	"bigID":    "BigID",
+}
+
+// "time" -> "time.Time"
+// "string" -> "string"
+func basicTypeName(typeName string) string {
+	if alias, ok := basicTypeAliases[typeName]; ok {
+		return alias
+	}
+	return typeName
+}
+
 // TODO: all this needs explanation
 
 // "[]string" -> true
+// "set<string>" -> true
 // "string" -> false
 func isSliceValue(valueString string) bool {
-	re := regexp.MustCompile(`\[\]`)
+	re := regexp.MustCompile(`\[\]|^set<`)
+	return re.MatchString(valueString)
+}
+
+// "set<string>" -> true
+// "[]string" -> false
+func isSetValue(valueString string) bool {
+	re := regexp.MustCompile(`^set<`)
+	return re.MatchString(valueString)
+}
+
+// "?foo" -> true
+// "foo" -> false
+func isOptionalValue(valueString string) bool {
+	re := regexp.MustCompile(`^\?`)
 	return re.MatchString(valueString)
 }
 
+// "set<*foo>" -> "*foo"
+// "[]*foo" -> "[]*foo"
+func withoutSet(valueString string) string {
+	if !isSetValue(valueString) {
+		return valueString
+	}
+	return strings.TrimSuffix(strings.TrimPrefix(valueString, "set<"), ">")
+}
+
 func isPointerValue(valueString string) bool {
 	re := regexp.MustCompile(`\*`)
 	return re.MatchString(valueString)
@@ -43,7 +85,7 @@ func isAnyValue(valueString string) bool {
 
 func extractAnyTypes(valueString string) []string {
 	re := regexp.MustCompile(`<.*>`)
-	s := re.FindString(valueString)
+	s := re.FindString(withoutSet(valueString))
 	typesRe := regexp.MustCompile(`[A-Za-z]+`)
 	types := typesRe.FindAllString(s, -1)
 	return types
@@ -53,7 +95,7 @@ func extractAnyTypes(valueString string) []string {
 // "float64" -> float64
 func extractValueType(valueString string) string {
 	re := regexp.MustCompile(`[A-Za-z]+[0-9]*`)
-	return re.FindString(valueString)
+	return re.FindString(withoutSet(valueString))
 }
 
 func getSring(value interface{}) string {
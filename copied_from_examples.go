@@ -5,106 +5,685 @@ package main
 const engine_only_import_decl string = `
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/mailru/easyjson"
+	"github.com/mailru/easyjson/jwriter"
+	"sort"
 	"strconv"
+	"strings"
 	"sync"
+	"time"
 )
 `
 
 const import_decl string = `
 
 import (
+	"bufio"
+	"bytes"
 	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/google/uuid"
+	"github.com/mailru/easyjson"
+	"github.com/mailru/easyjson/jwriter"
+	"io"
+	"io/ioutil"
 	"log"
+	"math"
+	"net"
 	"net/http"
 	"nhooyr.io/websocket"
+	"os"
+	"reflect"
+	"sort"
 	"strconv"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 `
 
-const imported_server_example_files string = `type Client struct {
+const imported_server_example_files string = `type adminClient struct {
+	ID			string	` + "`" +  `json:"id"` + "`" +  `
+	UserID			string	` + "`" +  `json:"userID"` + "`" +  `
+	Spectator		bool	` + "`" +  `json:"spectator"` + "`" +  `
+	Status			string	` + "`" +  `json:"status"` + "`" +  `
+	BufferedMessages	int	` + "`" +  `json:"bufferedMessages"` + "`" +  `
+	BufferSize		int	` + "`" +  `json:"bufferSize"` + "`" +  `
+}// adminClient describes a client in the response of GET /admin/clients
+// Status is "connected", "incoming" or "spectating"
+
+
+func (r *Room) findClient(clientID string) *Client {
+	for client := range r.clients {
+		if client.identity.ClientID == clientID {
+			return client
+		}
+	}
+	for client := range r.incomingClients {
+		if client.identity.ClientID == clientID {
+			return client
+		}
+	}
+	for _, client := range r.delayedSpectators() {
+		if client.identity.ClientID == clientID {
+			return client
+		}
+	}
+	return nil
+}
+
+func (r *Room) adminClients() []adminClient {
+	clients := make([]adminClient, 0, len(r.clients)+len(r.incomingClients))
+	add := func(client *Client, status string) {
+		clients = append(clients, adminClient{ID: client.identity.ClientID, UserID: client.identity.UserID, Spectator: client.identity.Spectator, Status: status, BufferedMessages: len(client.messageChannel), BufferSize: cap(client.messageChannel)})
+	}
+	for client := range r.clients {
+		add(client, "connected")
+	}
+	for client := range r.incomingClients {
+		add(client, "incoming")
+	}
+	for _, client := range r.delayedSpectators() {
+		add(client, "spectating")
+	}
+	return clients
+}
+
+func (s *Server) adminHandler() http.Handler {
+	handler := http.NewServeMux()
+	handler.HandleFunc("/admin/clients", s.adminClientsEndpoint)
+	handler.HandleFunc("/admin/kick", s.adminKickEndpoint)
+	handler.HandleFunc("/admin/action", s.adminActionEndpoint)
+	handler.HandleFunc("/admin/pause", s.adminPauseEndpoint)
+	handler.HandleFunc("/admin/resume", s.adminPauseEndpoint)
+	handler.HandleFunc("/admin/step", s.adminStepEndpoint)
+	handler.HandleFunc("/admin/snapshot", s.adminSnapshotEndpoint)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		err := s.options.AdminAuthenticate(r)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("authentication failed: %s", err), http.StatusUnauthorized)
+			return
+		}
+		handler.ServeHTTP(w, r)
+	})
+}
+
+func requireMethod(w http.ResponseWriter, r *http.Request, method string) bool {
+	if r.Method != method {
+		w.Header().Set("Allow", method)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return false
+	}
+	return true
+}
+
+func (s *Server) adminClientsEndpoint(w http.ResponseWriter, r *http.Request) {
+	if !requireMethod(w, r, http.MethodGet) {
+		return
+	}
+	var clients []adminClient
+	if !s.room.runOnRoom(func() {
+		clients = s.room.adminClients()
+	}) {
+		http.Error(w, "server is shut down", http.StatusServiceUnavailable)
+		return
+	}
+	clientsBytes, err := json.Marshal(clients)
+	if err != nil {
+		http.Error(w, "error marshalling clients", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(clientsBytes)
+}
+
+func (s *Server) adminKickEndpoint(w http.ResponseWriter, r *http.Request) {
+	if !requireMethod(w, r, http.MethodPost) {
+		return
+	}
+	clientID := r.URL.Query().Get("client")
+	var found bool
+	ok := s.room.runOnRoom(func() {
+		client := s.room.findClient(clientID)
+		if client == nil {
+			return
+		}
+		found = true
+		atomic.StoreInt32(&client.kicked, 1)
+		s.room.log(LogLevelInfo, "client kicked by admin", LogField{"client", client.id})
+		s.room.unregisterClient(client)
+	})
+	switch {
+	case !ok:
+		http.Error(w, "server is shut down", http.StatusServiceUnavailable)
+	case !found:
+		http.Error(w, "unknown client", http.StatusNotFound)
+	default:
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (s *Server) adminActionEndpoint(w http.ResponseWriter, r *http.Request) {
+	if !requireMethod(w, r, http.MethodPost) {
+		return
+	}
+	msgBytes, err := ioutil.ReadAll(io.LimitReader(r.Body, defaultReadLimit))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("error reading body: %s", err), http.StatusBadRequest)
+		return
+	}
+	var msg Message
+	err = msg.UnmarshalJSON(msgBytes)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("error parsing message: %s", err), http.StatusBadRequest)
+		return
+	}
+	clientID := r.URL.Query().Get("client")
+	var found bool
+	ok := s.room.runOnRoom(func() {
+		client := s.room.findClient(clientID)
+		if client == nil {
+			return
+		}
+		found = true
+		s.room.log(LogLevelInfo, "action injected by admin", LogField{"client", client.id}, LogField{"action", msg.Kind})
+		msg.client = client
+		client.forwardToRoom(msg)
+	})
+	switch {
+	case !ok:
+		http.Error(w, "server is shut down", http.StatusServiceUnavailable)
+	case !found:
+		http.Error(w, "unknown client", http.StatusNotFound)
+	default:
+		w.WriteHeader(http.StatusAccepted)
+	}
+}// adminActionEndpoint processes the message with the next tick as if the client sent it,
+// the client's limits do not apply
+
+
+func (s *Server) adminPauseEndpoint(w http.ResponseWriter, r *http.Request) {
+	if !requireMethod(w, r, http.MethodPost) {
+		return
+	}
+	paused := r.URL.Path == "/admin/pause"
+	ok := s.room.runOnRoom(func() {
+		s.room.paused = paused
+		s.room.log(LogLevelInfo, "tick loop paused by admin", LogField{"paused", paused})
+	})
+	if !ok {
+		http.Error(w, "server is shut down", http.StatusServiceUnavailable)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}// adminPauseEndpoint handles both /admin/pause and /admin/resume
+
+
+func (s *Server) adminStepEndpoint(w http.ResponseWriter, r *http.Request) {
+	if !requireMethod(w, r, http.MethodPost) {
+		return
+	}
+	var paused bool
+	ok := s.room.runOnRoom(func() {
+		paused = s.room.paused
+		if paused {
+			s.room.process(TickInfo{Delta: time.Second / time.Duration(s.room.fps)})
+		}
+	})
+	switch {
+	case !ok:
+		http.Error(w, "server is shut down", http.StatusServiceUnavailable)
+	case !paused:
+		http.Error(w, "tick loop is not paused", http.StatusConflict)
+	default:
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (s *Server) adminSnapshotEndpoint(w http.ResponseWriter, r *http.Request) {
+	if !requireMethod(w, r, http.MethodGet) {
+		return
+	}
+	var stateBytes []byte
+	var err error
+	ok := s.room.runOnRoom(func() {
+		tree := s.room.state.assembleTree(true)
+		stateBytes, err = s.room.state.marshalTree(tree)
+	})
+	if !ok {
+		http.Error(w, "server is shut down", http.StatusServiceUnavailable)
+		return
+	}
+	if err != nil {
+		http.Error(w, "error marshalling tree", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"snapshot-%d.json\"", s.room.clock.Now().Unix()))
+	w.Write(stateBytes)
+}
+
+const (
+	defaultClientBufferSize	= 32
+	defaultRoomBufferSize	= 1024
+)
+
+type BackpressurePolicy string	// BackpressurePolicy decides what happens to a client
+// whose message buffer is full
+
+
+const (
+	BackpressureDrop	BackpressurePolicy	= "drop"
+	BackpressureCoalesce	BackpressurePolicy	= "coalesce"
+	BackpressureResync	BackpressurePolicy	= "resync"
+)// BackpressureDrop disconnects the client
+// BackpressureResync skips all patches and sends the client
+// the complete current state as soon as the buffer has space again
+
+
+// easyjson:skip
+type BackpressureOptions struct {
+	Policy			BackpressurePolicy
+	ClientBufferSize	int
+	RoomBufferSize		int
+}// easyjson:skip
+// RoomBufferSize is the number of incoming messages and pending responses
+// which can be queued in the room, defaults to 1024
+
+
+func (o BackpressureOptions) withDefaults() BackpressureOptions {
+	if o.Policy == "" {
+		o.Policy = BackpressureDrop
+	}
+	if o.ClientBufferSize < 1 {
+		o.ClientBufferSize = defaultClientBufferSize
+	}
+	if o.RoomBufferSize < 1 {
+		o.RoomBufferSize = defaultRoomBufferSize
+	}
+	return o
+}
+
+// easyjson:skip
+type BackpressureMetrics struct {
+	ClientsDropped		uint64
+	PatchesCoalesced	uint64
+	PatchesSkipped		uint64
+	ClientsResynced		uint64
+	ResponsesDropped	uint64
+}// BackpressureMetrics counts how often the backpressure policies were applied
+// easyjson:skip
+// ResponsesDropped counts responses and events which could not be sent to a client
+
+
+func (m *BackpressureMetrics) snapshot() BackpressureMetrics {
+	return BackpressureMetrics{ClientsDropped: atomic.LoadUint64(&m.ClientsDropped), PatchesCoalesced: atomic.LoadUint64(&m.PatchesCoalesced), PatchesSkipped: atomic.LoadUint64(&m.PatchesSkipped), ClientsResynced: atomic.LoadUint64(&m.ClientsResynced), ResponsesDropped: atomic.LoadUint64(&m.ResponsesDropped)}
+}
+
+func mergePatches(earlier, later [ // mergePatches merges the JSON objects of two patches, values of the later
+// patch take precedence unless the operation kinds of the elements say otherwise
+]byte) ([]byte, error) {
+	earlierPatch, err := decodePatch(earlier)
+	if err != nil {
+		return nil, err
+	}
+	laterPatch, err := decodePatch(later)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(mergeObjects(earlierPatch, laterPatch))
+}
+
+func decodePatch(patch [ // decodePatch keeps numbers as json.Number so
+// large integers don't lose precision as float64
+]byte) (map[string]interface{}, error) {
+	var decoded map[string]interface{}
+	decoder := json.NewDecoder(bytes.NewReader(patch))
+	decoder.UseNumber()
+	if err := decoder.Decode(&decoded); err != nil {
+		return nil, fmt.Errorf("error unmarshalling patch for merging: %s", err)
+	}
+	return decoded, nil
+}
+
+var operationKindRanks = map // operationKindRanks decides which operation kind an element has after
+// merging, a deletion can't be undone and an update is never downgraded
+[OperationKind]int{OperationKindUnchanged: 0, OperationKindUpdate: 1, OperationKindDelete: 2}
+
+func operationKindOf(object map[string]interface{}) (OperationKind, bool) {
+	operationKind, ok := object["operationKind"].(string)
+	return OperationKind(operationKind), ok
+}
+
+func mergeObjects(earlier, later map[string]interface{}) map[string]interface{} {
+	if earlier == nil {
+		return later
+	}
+	earlierKind, earlierHasKind := operationKindOf(earlier)
+	laterKind, laterHasKind := operationKindOf(later)
+	if earlierHasKind && laterHasKind {
+		switch {
+		case earlierKind == OperationKindDelete:
+			return earlier
+		case laterKind == OperationKindDelete:
+			return later
+		}
+	}
+	for key, laterValue := range later {
+		earlierObject, earlierIsObject := earlier[key].(map[string]interface{})
+		laterObject, laterIsObject := laterValue.(map[string]interface{})
+		if earlierIsObject && laterIsObject {
+			earlier[key] = mergeObjects(earlierObject, laterObject)
+			continue
+		}
+		earlier[key] = laterValue
+	}
+	if earlierHasKind && operationKindRanks[earlierKind] > operationKindRanks[laterKind] {
+		earlier["operationKind"] = string(earlierKind)
+	}
+	return earlier
+}
+
+// easyjson:skip
+type Client struct {
 	room		*Room
 	conn		Connector
-	messageChannel	chan []byte
+	messageChannel	chan [ // easyjson:skip
+	]byte
 	id		uuid.UUID
+	identity	Identity
+	rateLimiter	*tokenBucket
+	violations	int32
+	kicked		int32
+	coalescedPatch	[ // coalescedPatch holds patches the client could not receive yet
+	]byte
+	awaitingResync	bool
+	pendingEvents	[ // pendingEvents holds events sent to the client before it
+	// received the current state or while its buffer was full
+	][]byte
+	logger	Logger
 }
 
-func newClient(websocketConnector Connector) (*Client, error) {
+func newClient(websocketConnector Connector, identity Identity) (*Client, error) {
 	clientID, err := uuid.NewRandom()
 	if err != nil {
 		return nil, fmt.Errorf("error generating client ID: %s", err)
 	}
-	c := Client{conn: websocketConnector, messageChannel: make(chan []byte, 32), id: clientID}
+	identity.ClientID = clientID.String()
+	c := Client{conn: websocketConnector, id: clientID, identity: identity}
 	return &c, nil
 }
+
 func (c *Client) discontinue() {
-	c.room.unregisterChannel <- c
-	c.conn.Close()
+	select {
+	case c.room.unregisterChannel <- c:
+	case <-c.room.doneChannel:
+	}
+	c.conn.Close(c.closeStatus())
 }
+
+func (c *Client) closeStatus() (websocket.StatusCode, string) {
+	if c.hasExceededViolations() {
+		return websocket.StatusPolicyViolation, "too many client limit violations"
+	}
+	if atomic.LoadInt32(&c.kicked) == 1 {
+		return websocket.StatusPolicyViolation, "kicked by admin"
+	}
+	select {
+	case <-c.room.shutdownChannel:
+		return websocket.StatusGoingAway, "server shutting down"
+	default:
+		return websocket.StatusNormalClosure, ""
+	}
+}// closeStatus tells the client why it is being disconnected
+
+
 func (c *Client) assignToRoom(room *Room) {
 	c.room = room
+	c.logger = withFields(room.logger, LogField{"client", c.id})
+	c.messageChannel = make(chan []byte, room.backpressure.ClientBufferSize)
+	if room.limits.ActionsPerSecond > 0 {
+		c.rateLimiter = newTokenBucket(room.limits.ActionsPerSecond, room.limits.ActionBurst)
+	}
+}
+
+func (c *Client) handleLimitViolation(violation string) {
+	c.logger.Log(LogLevelWarn, "client violated limit", LogField{"violation", violation})
+	c.sendError(clientLimitViolationError(violation))
+	atomic.AddInt32(&c.violations, 1)
+}// handleLimitViolation informs the client about the violation,
+// clients which exceed the maximum number of violations
+// are disconnected by the room
+
+
+func (c *Client) sendError(content [ // sendError queues an error message for the client without blocking
+]byte) {
+	select {
+	case c.room.pendingResponsesChannel <- Message{MessageKindError, content, c}:
+	default:
+		c.logger.Log(LogLevelWarn, "pending responses channel full, skipping response")
+		c.room.metrics.messageDropped("pending_responses")
+	}
+}
+
+func (c *Client) hasExceededViolations() bool {
+	maxViolations := c.room.limits.MaxViolations
+	return maxViolations > 0 && int(atomic.LoadInt32(&c.violations)) > maxViolations
 }
+
 func (c *Client) forwardToRoom(msg Message) {
 	select {
 	case c.room.clientMessageChannel <- msg:
 	default:
-		log.Println("room's message buffer full -> message dropped:")
-		log.Println(printMessage(msg))
+		c.logger.Log(LogLevelWarn, "room's message buffer full -> message dropped", LogField{"action", msg.Kind})
+		c.room.metrics.messageDropped("room")
 	}
 }
+
 func (c *Client) runReadMessages() {
 	defer c.discontinue()
 	for {
 		_, msgBytes, err := c.conn.ReadMessage()
 		if err != nil {
-			log.Printf("unregistering client due to error while reading connection: %s", err)
-			c.room.unregisterChannel <- c
+			c.logger.Log(LogLevelInfo, "unregistering client due to error while reading connection", LogField{"error", err})
 			break
 		}
-		var msg Message
-		err = msg.UnmarshalJSON(msgBytes)
-		if err != nil {
-			log.Printf("error parsing message \"%s\" with error %s", string(msgBytes), err)
-			c.room.pendingResponsesChannel <- Message{MessageKindError, messageUnmarshallingError(msgBytes, err), c}
-			continue
-		}
-		msg.client = c
-		c.forwardToRoom(msg)
+		c.handleMessageBytes(msgBytes)
+	}
+}
+
+func (c *Client) handleMessageBytes(msgBytes []byte) {
+	if c.hasExceededViolations() {
+		return
+	}
+	if maxMessageBytes := c.room.limits.MaxMessageBytes; maxMessageBytes > 0 && len(msgBytes) > maxMessageBytes {
+		c.handleLimitViolation(fmt.Sprintf("message exceeds %d bytes", maxMessageBytes))
+		return
+	}
+	if c.rateLimiter != nil && !c.rateLimiter.take() {
+		c.handleLimitViolation(fmt.Sprintf("more than %v actions per second", c.room.limits.ActionsPerSecond))
+		return
+	}
+	if c.identity.Spectator {
+		c.sendError([]byte(spectatorActionError))
+		return
+	}
+	var msg Message
+	err := msg.UnmarshalJSON(msgBytes)
+	if err != nil {
+		c.logger.Log(LogLevelWarn, "error parsing message", LogField{"message", string(msgBytes)}, LogField{"error", err})
+		c.room.pendingResponsesChannel <- Message{MessageKindError, messageUnmarshallingError(msgBytes, err), c}
+		return
 	}
+	msg.client = c
+	c.forwardToRoom(msg)
 }
+
 func (c *Client) runWriteMessages() {
+	defer c.room.connectedClients.Done()
 	defer c.discontinue()
 	for {
 		msg, ok := <-c.messageChannel
 		if !ok {
-			log.Printf("messageChannel of client %s has been closed", c.id)
+			c.logger.Log(LogLevelDebug, "messageChannel of client has been closed")
 			return
 		}
 		c.conn.WriteMessage(msg)
 	}
 }
 
+type Clock interface {
+	Now() time.Time
+	NewTimer(d time.Duration) Timer
+}// Clock is the source of time of the room,
+// it can be replaced e.g. to step ticks manually in tests
+
+
+type Timer interface {
+	C() <- // Timer sends the current time on its channel once it fires
+	chan time.Time
+	Reset(d time.Duration)
+	Stop()
+}// Reset must only be called after the timer fired
+
+
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) NewTimer(d time.Duration) Timer {
+	return realTimer{time.NewTimer(d)}
+}
+
+// easyjson:skip
+type realTimer struct{ timer *time.Timer }	// easyjson:skip
+
+
+func (t realTimer) C() <-chan time.Time {
+	return t.timer.C
+}
+
+func (t realTimer) Reset(d time.Duration) {
+	t.timer.Reset(d)
+}
+
+func (t realTimer) Stop() {
+	t.timer.Stop()
+}
+
+// easyjson:skip
+type ManualClock struct {
+	mu	sync.Mutex
+	now	time.Time
+	timers	[ // ManualClock only advances when Advance is called
+	// easyjson:skip
+	]*manualTimer
+}
+
+func NewManualClock(start time.Time) *ManualClock {
+	return &ManualClock{now: start}
+}
+
+func (c *ManualClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *ManualClock) NewTimer(d time.Duration) Timer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	timer := &manualTimer{clock: c, c: make(chan time.Time, 1)}
+	c.timers = append(c.timers, timer)
+	timer.reset(d)
+	return timer
+}
+
+func (c *ManualClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	for _, timer := // Advance moves the clock forward and fires all timers which are due
+	range c.timers {
+		timer.fireIfDue()
+	}
+}
+
+// easyjson:skip
+type manualTimer struct {
+	clock		*ManualClock
+	c		chan time.Time
+	deadline	time.Time
+	active		bool
+}// easyjson:skip
+
+
+func (t *manualTimer) C() <-chan time.Time {
+	return t.c
+}
+
+func (t *manualTimer) Reset(d time.Duration) {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	t.reset(d)
+}
+
+func (t *manualTimer) Stop() {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	t.active = false
+}
+
+func (t *manualTimer) reset(d time.Duration) {
+	t.deadline = t.clock.now.Add(d)
+	t.active = true
+	t.fireIfDue()
+}// reset and fireIfDue expect the clock to be locked
+
+
+func (t *manualTimer) fireIfDue() {
+	if !t.active || t.clock.now.Before(t.deadline) {
+		return
+	}
+	t.active = false
+	select {
+	case t.c <- t.clock.now:
+	default:
+	}
+}
+
 type Connector interface {
-	Close()
+	Close(code websocket.StatusCode, reason string)
 	ReadMessage() (messageType int, p []byte, err error)
 	WriteMessage(messageType []byte) error
 }
+
+// easyjson:skip
 type Connection struct {
 	Conn		*websocket.Conn
 	ctx		context.Context
 	cancelContext	context.CancelFunc
-}
+}// easyjson:skip
+
 
 func NewConnection(conn *websocket.Conn, r *http.Request) *Connection {
 	ctx, cancel := context.WithCancel(context.Background())
 	return &Connection{Conn: conn, ctx: ctx, cancelContext: cancel}
 }
-func (c *Connection) Close() {
-	c.Conn.Close(websocket.StatusNormalClosure, "")
+
+func (c *Connection) Close(code websocket.StatusCode, reason string) {
+	c.Conn.Close(code, reason)
 }
+
 func (c *Connection) ReadMessage() (int, []byte, error) {
 	msgType, msg, err := c.Conn.Read(c.ctx)
 	if err != nil {
@@ -112,6 +691,7 @@ func (c *Connection) ReadMessage() (int, []byte, error) {
 	}
 	return int(msgType), msg, nil
 }
+
 func (c *Connection) WriteMessage(msg []byte) error {
 	err := c.Conn.Write(c.ctx, websocket.MessageText, msg)
 	if err != nil {
@@ -119,250 +699,2488 @@ func (c *Connection) WriteMessage(msg []byte) error {
 	}
 	return nil
 }
-func homePageHandler(w http.ResponseWriter, r *http.Request) {
-	fmt.Fprintf(w, "Home Page")
+
+var (
+	errEventQueueFull	= errors.New("event queue full")
+	errEngineWithoutRoom	= errors.New("engine does not belong to a room")
+)
+
+type Event interface {
+	kind() MessageKind
+	MarshalJSON() ([ // Event is implemented by all events declared in the "events" section of the config
+	]byte, error)
 }
-func wsEndpoint(w http.ResponseWriter, r *http.Request, room *Room) {
-	log.SetFlags(log.LstdFlags | log.Lshortfile)
-	websocketConnection, err := websocket.Accept(w, r, &websocket.AcceptOptions{InsecureSkipVerify: true})
-	if err != nil {
-		log.Println(err)
-		return
-	}
-	c, err := newClient(NewConnection(websocketConnection, r))
-	if err != nil {
-		log.Println(err)
-		return
-	}
-	c.assignToRoom(room)
-	room.registerChannel <- c
-	go c.runReadMessages()
-	go c.runWriteMessages()
-	<-r.Context().Done()
+
+// easyjson:skip
+type eventDelivery struct {
+	message	[ // easyjson:skip
+	]byte
+	clientIDs	map // clientIDs is nil if the event is broadcast to all clients
+	[string]bool
 }
-func setupRoutes(actions Actions, sideEffects SideEffects, fps int) {
-	room := newRoom(actions, sideEffects, fps)
-	room.Deploy()
-	http.HandleFunc("/", homePageHandler)
-	http.HandleFunc("/inspect", inspectHandler)
-	http.HandleFunc("/ws", func(w http.ResponseWriter, r *http.Request) {
-		wsEndpoint(w, r, room)
-	})
-	http.HandleFunc("/state", func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Access-Control-Allow-Origin", "*")
-		tree := room.state.assembleTree(true)
-		stateBytes, err := tree.MarshalJSON()
-		if err != nil {
-			http.Error(w, "Error marshalling tree", 500)
-		}
-		fmt.Fprint(w, string(stateBytes))
-	})
+
+func (s *Server) SendEventToClient(clientID string, event Event) error {
+	return s.SendEventToClients([ // SendEventToClient queues the event for delivery to the client after the current tick
+	]string{clientID}, event)
 }
-func Start(actions Actions, sideEffects SideEffects, fps int, port int) error {
-	if fps < 1 {
-		setupRoutes(actions, sideEffects, 1)
-	} else {
-		setupRoutes(actions, sideEffects, fps)
+
+func (s *Server) SendEventToClients(clientIDs [ // SendEventToClients queues the event for delivery to the clients after the current tick,
+// unknown client IDs are ignored
+]string, event Event) error {
+	recipients := make(map[string]bool, len(clientIDs))
+	for _, clientID := range clientIDs {
+		recipients[clientID] = true
 	}
-	fmt.Printf("backent running on port %d\n", port)
-	err := http.ListenAndServe(fmt.Sprintf(":%d", port), nil)
-	return err
+	return s.room.queueEvent(event, recipients)
 }
 
-type MessageKind string
+func (s *Server) BroadcastEvent(event Event) error {
+	return s.room.queueEvent(event, nil)
+}// BroadcastEvent queues the event for delivery to all clients after the current tick
 
-const (
-	MessageKindError	MessageKind	= "error"
-	MessageKindCurrentState	MessageKind	= "currentState"
-	MessageKindUpdate	MessageKind	= "update"
-)
 
-type Message struct {
-	Kind	MessageKind	` + "`" +  `json:"kind"` + "`" +  `
-	Content	[]byte		` + "`" +  `json:"content"` + "`" +  `
+func (engine *Engine) SendEventToClient(clientID string, event Event) error {
+	return engine.SendEventToClients([ // SendEventToClient queues the event for delivery to the client after the current tick,
+	// it can be used in actions and side effects of the room the engine belongs to
+	]string{clientID}, event)
+}
+
+func (engine *Engine) SendEventToClients(clientIDs [ // SendEventToClients queues the event for delivery to the clients after the current tick,
+// unknown client IDs are ignored
+]string, event Event) error {
+	recipients := make(map[string]bool, len(clientIDs))
+	for _, clientID := range clientIDs {
+		recipients[clientID] = true
+	}
+	return engine.sendEvent(event, recipients)
+}
+
+func (engine *Engine) BroadcastEvent(event Event) error {
+	return engine.sendEvent(event, nil)
+}// BroadcastEvent queues the event for delivery to all clients after the current tick
+
+
+func (engine *Engine) sendEvent(event Event, clientIDs map[string]bool) error {
+	if engine.queueEvent == nil {
+		return errEngineWithoutRoom
+	}
+	message, err := eventMessage(event)
+	if err != nil {
+		return err
+	}
+	return engine.queueEvent(message, clientIDs)
+}
+
+func eventMessage(event Event) ([]byte, error) {
+	eventBytes, err := event.MarshalJSON()
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling event: %s", err)
+	}
+	eventMsg := Message{Kind: event.kind(), Content: eventBytes}
+	message, err := eventMsg.MarshalJSON()
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling event message: %s", err)
+	}
+	return message, nil
+}
+
+func (r *Room) queueEvent(event Event, clientIDs map // queueEvent is safe for concurrent use so events can be
+// sent from actions, side effects and other goroutines alike
+[string]bool) error {
+	message, err := eventMessage(event)
+	if err != nil {
+		return err
+	}
+	return r.queueEventMessage(message, clientIDs)
+}
+
+func (r *Room) queueEventMessage(message []byte, clientIDs map[string]bool) error {
+	select {
+	case r.eventsChannel <- eventDelivery{message, clientIDs}:
+		return nil
+	default:
+		r.metrics.messageDropped("events")
+		return errEventQueueFull
+	}
+}
+
+func (r *Room) handlePendingEvents() {
+	for client := // handlePendingEvents is called at the end of each tick so clients
+	// receive the events after the state they refer to
+	range r.clients {
+		r.sendPendingEvents(client)
+	}
+Exit:
+	for {
+		select {
+		case delivery := <-r.eventsChannel:
+			if delivery.clientIDs == nil {
+				r.recordFrame(delayedFrame{message: delivery.message})
+			}
+			for client := range r.clients {
+				if delivery.clientIDs != nil && !delivery.clientIDs[client.identity.ClientID] {
+					continue
+				}
+				if len(client.pendingEvents) > 0 {
+					r.queuePendingEvent(client, delivery.message)
+					continue
+				}
+				r.sendMessage(client, delivery.message)
+			}
+			for client := range r.incomingClients {
+				if delivery.clientIDs != nil && !delivery.clientIDs[client.identity.ClientID] {
+					continue
+				}
+				r.queuePendingEvent(client, delivery.message)
+			}
+			if r.spectators == nil {
+				continue
+			}
+			for client := range r.spectators.clients {
+				if delivery.clientIDs[client.identity.ClientID] {
+					r.sendMessage(client, delivery.message)
+				}
+			}
+		default:
+			break Exit
+		}
+	}
+}
+
+func (r *Room) queuePendingEvent(client *Client, message [ // queuePendingEvent holds at most as many
+// events per client as the room can queue
+]byte) {
+	if len(client.pendingEvents) >= r.backpressure.RoomBufferSize {
+		r.log(LogLevelWarn, "client's pending events full -> dropping event", LogField{"client", client.id})
+		atomic.AddUint64(&r.backpressureMetrics.ResponsesDropped, 1)
+		return
+	}
+	client.pendingEvents = append(client.pendingEvents, message)
+}
+
+func (r *Room) sendPendingEvents(client *Client) {
+	for len(client.pendingEvents) > 0 {
+		select {
+		case client.messageChannel <- client.pendingEvents[0]:
+			client.pendingEvents = client.pendingEvents[1:]
+		default:
+			return
+		}
+	}
+	client.pendingEvents = nil
+}// sendPendingEvents sends as many pending events as fit into the
+// client's buffer, the others are sent after the following ticks
+
+
+const defaultReadLimit = 32768	// the websocket library's default read limit in bytes
+
+
+// easyjson:skip
+type ClientLimits struct {
+	ActionsPerSecond	float64
+	ActionBurst		int
+	MaxMessageBytes		int
+	MaxPendingActions	int
+	MaxViolations		int
+}// ClientLimits restricts what a single client can send to the server.
+// Every limit with a zero value is disabled.
+// easyjson:skip
+// MaxViolations is how many violations of the above limits
+// a client may commit before it is disconnected
+
+
+func (l ClientLimits) readLimit() int {
+	if l.MaxMessageBytes < defaultReadLimit {
+		return defaultReadLimit
+	}
+	return l.MaxMessageBytes + defaultReadLimit
+}// readLimit is the read limit of connections, it stays above MaxMessageBytes so oversized
+// messages are read and reported, only messages beyond it close the connection
+
+
+// easyjson:skip
+type tokenBucket struct {
+	mu		sync.Mutex
+	tokens		float64
+	capacity	float64
+	refillRate	float64
+	lastRefill	time.Time
+}// tokenBucket is safe for concurrent use, as actions
+// sent via POST /action are handled by multiple goroutines
+// easyjson:skip
+
+
+func newTokenBucket(refillRate float64, capacity int) *tokenBucket {
+	if capacity < 1 {
+		capacity = int(math.Ceil(refillRate))
+	}
+	return &tokenBucket{tokens: float64(capacity), capacity: float64(capacity), refillRate: refillRate, lastRefill: time.Now()}
+}
+
+func (b *tokenBucket) take() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	now := time.Now()
+	b.tokens = math.Min(b.capacity, b.tokens+now.Sub(b.lastRefill).Seconds()*b.refillRate)
+	b.lastRefill = now
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+func clientLimitViolationError(violation string) []byte {
+	return []byte(fmt.Sprintf("client limit violated: %s", violation))
+}
+
+type LogLevel int
+
+const (
+	LogLevelDebug	LogLevel	= iota
+	LogLevelInfo
+	LogLevelWarn
+	LogLevelError
+)
+
+func (l LogLevel) String() string {
+	switch l {
+	case LogLevelDebug:
+		return "DEBUG"
+	case LogLevelInfo:
+		return "INFO"
+	case LogLevelWarn:
+		return "WARN"
+	case LogLevelError:
+		return "ERROR"
+	default:
+		return fmt.Sprintf("LEVEL(%d)", int(l))
+	}
+}
+
+type LogField struct {
+	Key	string
+	Value	interface{}
+}// LogField adds structured context like the client's ID to a log entry
+
+
+type Logger interface {
+	Log(level LogLevel, msg string, fields ...LogField)
+}// Logger receives all log entries of the server
+
+
+// easyjson:skip
+type stdLogger struct {
+	logger		*log.Logger
+	minLevel	LogLevel
+}// easyjson:skip
+
+
+func NewStdLogger(w io.Writer, minLevel LogLevel) Logger {
+	return &stdLogger{logger: log.New(w, "", log.LstdFlags), minLevel: minLevel}
+}// NewStdLogger writes all entries with at least the given level as lines
+// like ` + "`" +  `2021/01/02 15:04:05 INFO unregistering client room=... client=...` + "`" +  `
+
+
+func (l *stdLogger) Log(level LogLevel, msg string, fields ...LogField) {
+	if level < l.minLevel {
+		return
+	}
+	var line strings.Builder
+	line.WriteString(level.String())
+	line.WriteString(" ")
+	line.WriteString(msg)
+	for _, field := range fields {
+		fmt.Fprintf(&line, " %s=%v", field.Key, field.Value)
+	}
+	l.logger.Println(line.String())
+}
+
+type nopLogger struct{}
+
+func (nopLogger) Log(LogLevel, string, ...LogField) {
+}
+
+func NopLogger() Logger {
+	return nopLogger{}
+}// NopLogger discards all entries, e.g. to keep the output of tests clean
+
+
+// easyjson:skip
+type fieldLogger struct {
+	logger	Logger
+	fields	[ // easyjson:skip
+	]LogField
+}
+
+func withFields(logger Logger, fields ...LogField) Logger {
+	return &fieldLogger{logger: logger, fields: fields}
+}// withFields returns a logger which adds the fields to every entry
+
+
+func (l *fieldLogger) Log(level LogLevel, msg string, fields ...LogField) {
+	allFields := make([]LogField, 0, len(l.fields)+len(fields))
+	allFields = append(allFields, l.fields...)
+	allFields = append(allFields, fields...)
+	l.logger.Log(level, msg, allFields...)
+}
+
+func homePageHandler(w http.ResponseWriter, r *http.Request) {
+	fmt.Fprintf(w, "Home Page")
+}
+
+func (s *Server) wsEndpoint(w http.ResponseWriter, r *http.Request) {
+	room := s.room
+	select {
+	case <-room.shutdownChannel:
+		http.Error(w, "server is shutting down", http.StatusServiceUnavailable)
+		return
+	default:
+	}
+	identity, ok := s.authenticate(w, r)
+	if !ok || !applyMode(w, r, &identity) {
+		return
+	}
+	websocketConnection, err := websocket.Accept(w, r, s.acceptOptions())
+	if err != nil {
+		room.logger.Log(LogLevelWarn, "error accepting websocket connection", LogField{"error", err})
+		return
+	}
+	if readLimit := s.options.ClientLimits.readLimit(); readLimit > defaultReadLimit {
+		websocketConnection.SetReadLimit(int64(readLimit))
+	}
+	_, err = s.Connect(NewConnection(websocketConnection, r), identity)
+	if err != nil {
+		room.logger.Log(LogLevelWarn, "error connecting client", LogField{"error", err})
+		return
+	}
+	select {
+	case <-r.Context().Done():
+	case <-room.doneChannel:
+	}
+}
+
+func (s *Server) authenticate(w http.ResponseWriter, r *http.Request) (Identity, bool) {
+	if s.options.Authenticate == nil {
+		return Identity{}, true
+	}
+	identity, err := s.options.Authenticate(r)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("authentication failed: %s", err), http.StatusUnauthorized)
+		return Identity{}, false
+	}
+	return identity, true
+}// authenticate responds with an error if the request could not be authenticated
+
+
+func (s *Server) acceptOptions() *websocket.AcceptOptions {
+	if len(s.options.AllowedOrigins) == 0 {
+		return &websocket.AcceptOptions{InsecureSkipVerify: true}
+	}
+	return &websocket.AcceptOptions{OriginPatterns: s.options.AllowedOrigins}
+}
+
+func (s *Server) setupRoutes() *http.ServeMux {
+	handler := http.NewServeMux()
+	handler.HandleFunc("/", homePageHandler)
+	handler.HandleFunc("/inspect", inspectHandler)
+	handler.HandleFunc("/ws", s.wsEndpoint)
+	handler.HandleFunc("/sse", s.sseEndpoint)
+	handler.HandleFunc("/action", s.actionEndpoint)
+	handler.HandleFunc("/metrics", s.metricsHandler)
+	if s.options.AdminAuthenticate != nil {
+		handler.Handle("/admin/", s.adminHandler())
+	}
+	handler.HandleFunc("/state", s.stateEndpoint)
+	return handler
+}
+
+func Start(actions Actions, sideEffects SideEffects, fps int, port int) error {
+	server := NewServer(Options{Actions: actions, SideEffects: sideEffects, FPS: fps})
+	fmt.Printf("backent running on port %d\n", port)
+	err := http.ListenAndServe(fmt.Sprintf(":%d", port), server)
+	return err
+}
+
+var errConnectionClosed = errors.New("connection closed")
+
+// easyjson:skip
+type memoryPipe struct {
+	mu		sync.Mutex
+	closed		bool
+	closeCode	websocket.StatusCode
+	closeReason	string
+	closedChannel	chan struct{}
+}// memoryPipe is shared by both ends of a MemoryConnector pair
+// easyjson:skip
+// closedChannel wakes up blocked readers once the pipe is closed
+
+
+// easyjson:skip
+type MemoryConnector struct {
+	pipe	*memoryPipe
+	peer	*MemoryConnector
+	mu	sync.Mutex
+	inbox	[ // MemoryConnector is an in-memory Connector,
+	// messages written to one end of a pair can be read from the other
+	// easyjson:skip
+	// inbox is unbounded so writes never block
+	][]byte
+	notify	chan struct{}
+}// notify receives a value whenever the inbox is filled
+
+
+func NewMemoryConnectorPair() (*MemoryConnector, *MemoryConnector) {
+	pipe := &memoryPipe{closedChannel: make(chan struct{})}
+	a := &MemoryConnector{pipe: pipe, notify: make(chan struct{}, 1)}
+	b := &MemoryConnector{pipe: pipe, notify: make(chan struct{}, 1)}
+	a.peer = b
+	b.peer = a
+	return a, b
+}// NewMemoryConnectorPair returns two connected ends,
+// e.g. one for the server's client and one for the test
+
+
+func (c *MemoryConnector) Close(code websocket.StatusCode, reason string) {
+	c.pipe.mu.Lock()
+	defer c.pipe.mu.Unlock()
+	if c.pipe.closed {
+		return
+	}
+	c.pipe.closed = true
+	c.pipe.closeCode = code
+	c.pipe.closeReason = reason
+	close(c.pipe.closedChannel)
+}// Close closes both ends of the pair
+
+
+func (c *MemoryConnector) CloseStatus() (websocket.StatusCode, string, bool) {
+	c.pipe.mu.Lock()
+	defer c.pipe.mu.Unlock()
+	return c.pipe.closeCode, c.pipe.closeReason, c.pipe.closed
+}// CloseStatus returns the status the pair was closed with and whether it is closed
+
+
+func (c *MemoryConnector) isClosed() bool {
+	_, _, closed := c.CloseStatus()
+	return closed
+}
+
+func (c *MemoryConnector) ReadMessage() (int, [ // ReadMessage blocks until a message is available or the pair is closed
+]byte, error) {
+	for {
+		if msg, ok := c.tryRead(); ok {
+			return int(websocket.MessageText), msg, nil
+		}
+		select {
+		case <-c.notify:
+		case <-c.pipe.closedChannel:
+			if msg, ok := c.tryRead(); ok {
+				return int(websocket.MessageText), msg, nil
+			}
+			return 0, nil, errConnectionClosed
+		}
+	}
+}
+
+func (c *MemoryConnector) tryRead() ([ // tryRead returns the next message without blocking
+]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.inbox) == 0 {
+		return nil, false
+	}
+	msg := c.inbox[0]
+	c.inbox = c.inbox[1:]
+	return msg, true
+}
+
+func (c *MemoryConnector) WriteMessage(msg []byte) error {
+	if c.isClosed() {
+		return errConnectionClosed
+	}
+	peer := c.peer
+	peer.mu.Lock()
+	peer.inbox = append(peer.inbox, msg)
+	peer.mu.Unlock()
+	select {
+	case peer.notify <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+type MessageKind string
+
+const (
+	MessageKindError	MessageKind	= "error"
+	MessageKindCurrentState	MessageKind	= "currentState"
+	MessageKindUpdate	MessageKind	= "update"
+)
+
+type Message struct {
+	Kind	MessageKind	` + "`" +  `json:"kind"` + "`" +  `
+	Content	[]byte		` + "`" +  `json:"content"` + "`" +  `
 	client	*Client
 }
 
-func printMessage(msg Message) string {
-	b, err := msg.MarshalJSON()
+func printMessage(msg Message) string {
+	b, err := msg.MarshalJSON()
+	if err != nil {
+		return err.Error()
+	} else {
+		return string(b)
+	}
+}
+
+func messageUnmarshallingError(msgContent []byte, err error) []byte {
+	return []byte(fmt.Sprintf("error when unmarshalling received message content ` + "`" +  `%s` + "`" +  `: %s", msgContent, err))
+}
+
+func responseMarshallingError(msgContent []byte, err error) []byte {
+	return []byte(fmt.Sprintf("error when marshalling response to ` + "`" +  `%s` + "`" +  `: %s", msgContent, err))
+}
+
+var durationBuckets = []float64{0.0001, 0.0005, 0.001, 0.0025, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1}
+
+var byteBuckets = []float64{64, 256, 1024, 4096, 16384, 65536, 262144, 1048576}
+
+// easyjson:skip
+type histogram struct {
+	buckets	[ // easyjson:skip
+	]float64
+	counts	[]uint64
+	sum	float64
+	count	uint64
+}
+
+func newHistogram(buckets []float64) *histogram {
+	return &histogram{buckets: buckets, counts: make([]uint64, len(buckets))}
+}
+
+func (h *histogram) observe(value float64) {
+	for i, upperBound := range h.buckets {
+		if value <= upperBound {
+			h.counts[i]++
+		}
+	}
+	h.sum += value
+	h.count++
+}
+
+func (h *histogram) write(w io.Writer, name, labels string) {
+	labelPrefix := labels
+	if labelPrefix != "" {
+		labelPrefix += ","
+	}
+	for i, upperBound := range h.buckets {
+		fmt.Fprintf(w, "%s_bucket{%sle=\"%s\"} %d\n", name, labelPrefix, formatFloat(upperBound), h.counts[i])
+	}
+	fmt.Fprintf(w, "%s_bucket{%sle=\"+Inf\"} %d\n", name, labelPrefix, h.count)
+	fmt.Fprintf(w, "%s_sum%s %s\n", name, wrapLabels(labels), formatFloat(h.sum))
+	fmt.Fprintf(w, "%s_count%s %d\n", name, wrapLabels(labels), h.count)
+}
+
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+func wrapLabels(labels string) string {
+	if labels == "" {
+		return ""
+	}
+	return "{" + labels + "}"
+}
+
+func writeMetricHeader(w io.Writer, name, metricType, help string) {
+	fmt.Fprintf(w, "# HELP %s %s\n", name, help)
+	fmt.Fprintf(w, "# TYPE %s %s\n", name, metricType)
+}
+
+// easyjson:skip
+type serverMetrics struct {
+	mu			sync.Mutex
+	tickDuration		*histogram
+	assembleTreeDuration	*histogram
+	updateStateDuration	*histogram
+	patchBytes		*histogram
+	connectedClients	int
+	incomingClients		int
+	messagesDropped		map // serverMetrics is written by the room and read by the /metrics endpoint
+	// easyjson:skip
+	[string]uint64
+	actionCounts	map[MessageKind]uint64
+	actionDurations	map[MessageKind]*histogram
+	elementCounts	map[ElementKind]int
+}
+
+func newServerMetrics() *serverMetrics {
+	m := serverMetrics{tickDuration: newHistogram(durationBuckets), assembleTreeDuration: newHistogram(durationBuckets), updateStateDuration: newHistogram(durationBuckets), patchBytes: newHistogram(byteBuckets), messagesDropped: make(map[string]uint64), actionCounts: make(map[MessageKind]uint64), actionDurations: make(map[MessageKind]*histogram)}
+	for _, kind := range actionKinds {
+		m.actionDurations[kind] = newHistogram(durationBuckets)
+	}
+	return &m
+}
+
+func (m *serverMetrics) observeDuration(h *histogram, duration time.Duration) {
+	m.mu.Lock()
+	h.observe(duration.Seconds())
+	m.mu.Unlock()
+}
+
+func (m *serverMetrics) observePatch(patchBytes []byte) {
+	m.mu.Lock()
+	m.patchBytes.observe(float64(len(patchBytes)))
+	m.mu.Unlock()
+}
+
+func (m *serverMetrics) observeAction(kind MessageKind, duration time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	h, ok := m.actionDurations[kind]
+	if !ok {
+		return
+	}
+	m.actionCounts[kind]++
+	h.observe(duration.Seconds())
+}
+
+func (m *serverMetrics) messageDropped(buffer string) {
+	m.mu.Lock()
+	m.messagesDropped[buffer]++
+	m.mu.Unlock()
+}// messageDropped counts messages which did not fit into one of the room's buffers
+
+
+func (m *serverMetrics) setRoomStatus(connectedClients, incomingClients int, elementCounts map[ElementKind]int) {
+	m.mu.Lock()
+	m.connectedClients = connectedClients
+	m.incomingClients = incomingClients
+	m.elementCounts = elementCounts
+	m.mu.Unlock()
+}
+
+func (m *serverMetrics) write(w io.Writer, backpressureMetrics BackpressureMetrics) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	writeMetricHeader(w, "backent_tick_duration_seconds", "histogram", "Time spent processing a tick.")
+	m.tickDuration.write(w, "backent_tick_duration_seconds", "")
+	writeMetricHeader(w, "backent_assemble_tree_duration_seconds", "histogram", "Time spent assembling trees.")
+	m.assembleTreeDuration.write(w, "backent_assemble_tree_duration_seconds", "")
+	writeMetricHeader(w, "backent_update_state_duration_seconds", "histogram", "Time spent applying the patch to the state.")
+	m.updateStateDuration.write(w, "backent_update_state_duration_seconds", "")
+	writeMetricHeader(w, "backent_patch_bytes", "histogram", "Size of published patches in bytes.")
+	m.patchBytes.write(w, "backent_patch_bytes", "")
+	writeMetricHeader(w, "backent_connected_clients", "gauge", "Number of connected clients.")
+	fmt.Fprintf(w, "backent_connected_clients %d\n", m.connectedClients)
+	writeMetricHeader(w, "backent_incoming_clients", "gauge", "Number of clients waiting for the current state.")
+	fmt.Fprintf(w, "backent_incoming_clients %d\n", m.incomingClients)
+	writeMetricHeader(w, "backent_messages_dropped_total", "counter", "Messages dropped due to a full room buffer.")
+	for _, buffer := range []string{"room", "pending_responses", "events"} {
+		fmt.Fprintf(w, "backent_messages_dropped_total{buffer=%q} %d\n", buffer, m.messagesDropped[buffer])
+	}
+	writeMetricHeader(w, "backent_clients_dropped_total", "counter", "Clients disconnected due to a full buffer.")
+	fmt.Fprintf(w, "backent_clients_dropped_total %d\n", backpressureMetrics.ClientsDropped)
+	writeMetricHeader(w, "backent_patches_coalesced_total", "counter", "Patches coalesced for slow clients.")
+	fmt.Fprintf(w, "backent_patches_coalesced_total %d\n", backpressureMetrics.PatchesCoalesced)
+	writeMetricHeader(w, "backent_patches_skipped_total", "counter", "Patches skipped for clients awaiting a resync.")
+	fmt.Fprintf(w, "backent_patches_skipped_total %d\n", backpressureMetrics.PatchesSkipped)
+	writeMetricHeader(w, "backent_clients_resynced_total", "counter", "Complete states sent to clients which missed patches.")
+	fmt.Fprintf(w, "backent_clients_resynced_total %d\n", backpressureMetrics.ClientsResynced)
+	writeMetricHeader(w, "backent_responses_dropped_total", "counter", "Responses and events dropped due to a full client buffer.")
+	fmt.Fprintf(w, "backent_responses_dropped_total %d\n", backpressureMetrics.ResponsesDropped)
+	writeMetricHeader(w, "backent_actions_total", "counter", "Number of processed actions.")
+	for _, kind := range actionKinds {
+		fmt.Fprintf(w, "backent_actions_total{action=%q} %d\n", kind, m.actionCounts[kind])
+	}
+	writeMetricHeader(w, "backent_action_duration_seconds", "histogram", "Time spent processing actions.")
+	for _, kind := range actionKinds {
+		m.actionDurations[kind].write(w, "backent_action_duration_seconds", fmt.Sprintf("action=%q", kind))
+	}
+	writeMetricHeader(w, "backent_elements", "gauge", "Number of elements in the state.")
+	elementKinds := make([]string, 0, len(m.elementCounts))
+	for kind := range m.elementCounts {
+		elementKinds = append(elementKinds, string(kind))
+	}
+	sort.Strings(elementKinds)
+	for _, kind := range elementKinds {
+		fmt.Fprintf(w, "backent_elements{kind=%q} %d\n", kind, m.elementCounts[ElementKind(kind)])
+	}
+}
+
+func (s *Server) metricsHandler(w http.ResponseWriter, r *http.Request) {
+	var buf bytes.Buffer
+	s.room.metrics.write(&buf, s.BackpressureMetrics())
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	w.Write(buf.Bytes())
+}
+
+// easyjson:skip
+type Room struct {
+	clients	map // easyjson:skip
+	[*Client]bool
+	clientMessageChannel	chan Message
+	pendingResponsesChannel	chan Message
+	eventsChannel		chan eventDelivery
+	registerChannel		chan *Client
+	unregisterChannel	chan *Client
+	incomingClients		map[*Client]bool
+	state			*Engine
+	actions			Actions
+	sideEffects		SideEffects
+	fps			int
+	limits			ClientLimits
+	backpressure		BackpressureOptions
+	backpressureMetrics	*BackpressureMetrics
+	metrics			*serverMetrics
+	logger			Logger
+	scheduler		*Scheduler
+	spectators		*spectatorStream
+	clock			Clock
+	timestep		TimestepOptions
+	timer			Timer
+	tick			int
+	paused			bool
+	adminChannel		chan func()
+	shutdownChannel		chan struct{}
+	shutdownOnce		sync.Once
+	doneChannel		chan struct{}
+	connectedClients	sync.WaitGroup
+}
+
+func newRoom(options Options) *Room {
+	state := newEngine()
+	if options.StrictMode {
+		state.EnableStrictMode()
+	}
+	room := Room{logger: withFields(options.Logger, LogField{"room", uuid.New().String()}), clients: make(map[*Client]bool), clientMessageChannel: make(chan Message, options.Backpressure.RoomBufferSize), pendingResponsesChannel: make(chan Message, options.Backpressure.RoomBufferSize), eventsChannel: make(chan eventDelivery, options.Backpressure.RoomBufferSize), unregisterChannel: make(chan *Client), registerChannel: make(chan *Client), incomingClients: make(map[*Client]bool), state: state, sideEffects: options.SideEffects, actions: options.Actions, fps: options.FPS, limits: options.ClientLimits, backpressure: options.Backpressure, backpressureMetrics: &BackpressureMetrics{}, metrics: newServerMetrics(), scheduler: newScheduler(options.Clock.Now()), spectators: newSpectatorStream(options.SpectatorDelay), clock: options.Clock, timestep: options.Timestep, adminChannel: make(chan func()), shutdownChannel: make(chan struct{}), doneChannel: make(chan struct{})}
+	state.queueEvent = room.queueEventMessage
+	return &room
+}
+
+func (r *Room) runOnRoom(fn func()) bool {
+	done := make(chan struct{})
+	select {
+	case r.adminChannel <- func() {
+		fn()
+		close(done)
+	}:
+	case <-r.doneChannel:
+		return false
+	}
+	<-done
+	return true
+}// runOnRoom runs fn on the room's goroutine so it can safely access
+// the clients and the state, it returns false if the room is shut down
+
+
+func (r *Room) log(level LogLevel, msg string, fields ...LogField) {
+	r.logger.Log(level, msg, append(fields, LogField{"tick", r.tick})...)
+}// log adds the current tick to the entry
+
+
+func (r *Room) registerClient(client *Client) {
+	if r.isDelayedSpectator(client) {
+		r.spectators.incoming[client] = true
+	} else {
+		r.incomingClients[client] = true
+	}
+	if r.sideEffects.OnClientConnect != nil {
+		r.sideEffects.OnClientConnect(r.state, client.identity)
+	}
+}
+
+func (r *Room) promoteIncomingClient(client *Client) {
+	r.clients[client] = true
+	delete(r.incomingClients, client)
+}
+
+func (r *Room) unregisterClient(client *Client) {
+	if _, ok := r.clients[client]; ok {
+		r.log(LogLevelInfo, "unregistering client", LogField{"client", client.id})
+		close(client.messageChannel)
+		delete(r.clients, client)
+	} else if _, ok := r.incomingClients[client]; ok {
+		r.log(LogLevelInfo, "unregistering incoming client", LogField{"client", client.id})
+		close(client.messageChannel)
+		delete(r.incomingClients, client)
+	} else if r.spectators.has(client) {
+		r.log(LogLevelInfo, "unregistering spectator", LogField{"client", client.id})
+		close(client.messageChannel)
+		delete(r.spectators.clients, client)
+		delete(r.spectators.incoming, client)
+	}
+}
+
+func (r *Room) dropClient(client *Client) {
+	r.log(LogLevelWarn, "client's message buffer full -> dropping client", LogField{"client", client.id})
+	atomic.AddUint64(&r.backpressureMetrics.ClientsDropped, 1)
+	r.unregisterClient(client)
+}
+
+func (r *Room) handleFullBuffer(client *Client, patchBytes [ // handleFullBuffer applies the backpressure policy to a client
+// which could not receive the patch
+]byte) {
+	switch r.backpressure.Policy {
+	case BackpressureCoalesce:
+		client.coalescedPatch = patchBytes
+		atomic.AddUint64(&r.backpressureMetrics.PatchesCoalesced, 1)
+	case BackpressureResync:
+		r.log(LogLevelWarn, "client's message buffer full -> resyncing client", LogField{"client", client.id})
+		delete(r.clients, client)
+		r.incomingClients[client] = true
+		client.awaitingResync = true
+		atomic.AddUint64(&r.backpressureMetrics.PatchesSkipped, 1)
+	default:
+		r.dropClient(client)
+	}
+}
+
+func (r *Room) sendCoalescedPatch(client *Client, patchBytes [ // sendCoalescedPatch merges the patch into the client's coalesced patch
+// and tries to send it, patchBytes is nil if there is no new patch
+]byte) error {
+	if patchBytes != nil {
+		mergedPatch, err := mergePatches(client.coalescedPatch, patchBytes)
+		if err != nil {
+			return err
+		}
+		client.coalescedPatch = mergedPatch
+		atomic.AddUint64(&r.backpressureMetrics.PatchesCoalesced, 1)
+	}
+	stateUpdateBytes, err := stateUpdateMessage(client.coalescedPatch)
+	if err != nil {
+		return err
+	}
+	select {
+	case client.messageChannel <- stateUpdateBytes:
+		client.coalescedPatch = nil
+	default:
+	}
+	return nil
+}
+
+func (r *Room) broadcastPatchToClients(patchBytes []byte) error {
+	var stateUpdateBytes []byte
+	if patchBytes != nil {
+		var err error
+		stateUpdateBytes, err = stateUpdateMessage(patchBytes)
+		if err != nil {
+			return err
+		}
+	}
+	for client := range r.clients {
+		if client.coalescedPatch != nil {
+			if err := r.sendCoalescedPatch(client, patchBytes); err != nil {
+				r.log(LogLevelError, "error coalescing patch", LogField{"client", client.id}, LogField{"error", err})
+				r.unregisterClient(client)
+			}
+			continue
+		}
+		if stateUpdateBytes == nil {
+			continue
+		}
+		select {
+		case client.messageChannel <- stateUpdateBytes:
+		default:
+			r.handleFullBuffer(client, patchBytes)
+		}
+	}
+	if stateUpdateBytes != nil {
+		for client := range r.incomingClients {
+			if client.awaitingResync {
+				atomic.AddUint64(&r.backpressureMetrics.PatchesSkipped, 1)
+			}
+		}
+	}
+	return nil
+}
+
+func (r *Room) handleIncomingClients() error {
+	if len(r.incomingClients) == 0 {
+		return nil
+	}
+	assembleStart := time.Now()
+	tree := r.state.assembleTree(true)
+	r.metrics.observeDuration(r.metrics.assembleTreeDuration, time.Since(assembleStart))
+	stateBytes, err := r.state.marshalTree(tree)
+	if err != nil {
+		return fmt.Errorf("error marshalling tree for init request: %s", err)
+	}
+	currentStateMsg := Message{Kind: MessageKindCurrentState, Content: stateBytes}
+	response, err := currentStateMsg.MarshalJSON()
+	if err != nil {
+		return fmt.Errorf("error marshalling response message for init request: %s", err)
+	}
+	for client := range r.incomingClients {
+		select {
+		case client.messageChannel <- response:
+			r.promoteIncomingClient(client)
+			if client.awaitingResync {
+				client.awaitingResync = false
+				atomic.AddUint64(&r.backpressureMetrics.ClientsResynced, 1)
+			}
+			r.sendPendingEvents(client)
+		default:
+			if r.backpressure.Policy == BackpressureDrop {
+				r.dropClient(client)
+			}
+		}
+	}
+	return nil
+}
+
+func (r *Room) isRegistered(client *Client) bool {
+	if r.spectators.has(client) {
+		return true
+	}
+	return r.clients[client] || r.incomingClients[client]
+}
+
+func (r *Room) processFrame(tickInfo TickInfo) error {
+	r.processClientMessages()
+	r.scheduler.run(r.state, r.tick, r.clock.Now())
+	if r.sideEffects.OnFrameTick != nil {
+		r.sideEffects.OnFrameTick(r.state, tickInfo)
+	}
+	return nil
+}
+
+func (r *Room) processClientMessages() {
+	processedActions := make(map // processClientMessages runs the actions of all received
+	// messages and queues their responses
+	[*Client]int)
+Exit:
+	for {
+		select {
+		case msg := <-r.clientMessageChannel:
+			if r.limits.MaxPendingActions > 0 {
+				processedActions[msg.client]++
+				if processedActions[msg.client] > r.limits.MaxPendingActions {
+					msg.client.handleLimitViolation(fmt.Sprintf("more than %d actions per tick", r.limits.MaxPendingActions))
+					continue
+				}
+			}
+			actionStart := time.Now()
+			response, err := r.processClientMessage(msg)
+			r.metrics.observeAction(msg.Kind, time.Since(actionStart))
+			if err != nil {
+				r.log(LogLevelWarn, "error processing client message", LogField{"client", msg.client.id}, LogField{"action", msg.Kind}, LogField{"error", err})
+			}
+			if response.client == nil {
+				continue
+			}
+			select {
+			case r.pendingResponsesChannel <- response:
+			default:
+				r.log(LogLevelWarn, "pending responses channel full, skipping response", LogField{"client", msg.client.id}, LogField{"action", msg.Kind})
+				r.metrics.messageDropped("pending_responses")
+			}
+		default:
+			break Exit
+		}
+	}
+}
+
+func (r *Room) publishPatch() error {
+	r.state.emitChanges()
+	assembleStart := time.Now()
+	tree := r.state.assembleTree(false)
+	r.metrics.observeDuration(r.metrics.assembleTreeDuration, time.Since(assembleStart))
+	patchBytes, err := r.state.marshalTree(tree)
+	if err != nil {
+		return fmt.Errorf("error marshalling tree for patch: %s", err)
+	}
+	if len(patchBytes) == 2 {
+		patchBytes = nil
+	} else {
+		r.metrics.observePatch(patchBytes)
+		updateMessage, err := stateUpdateMessage(patchBytes)
+		if err != nil {
+			return err
+		}
+		r.recordFrame(delayedFrame{message: updateMessage, patch: patchBytes})
+	}
+	return r.broadcastPatchToClients(patchBytes)
+}
+
+func stateUpdateMessage(patchBytes []byte) ([]byte, error) {
+	stateUpdateMsg := Message{Kind: MessageKindUpdate, Content: patchBytes}
+	stateUpdateBytes, err := stateUpdateMsg.MarshalJSON()
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling state update message: %s", err)
+	}
+	return stateUpdateBytes, nil
+}
+
+func (r *Room) handlePendingResponses() {
+Exit:
+	for {
+		select {
+		case pendingResponse := <-r.pendingResponsesChannel:
+			if !r.isRegistered(pendingResponse.client) {
+				continue
+			}
+			response, err := pendingResponse.MarshalJSON()
+			if err != nil {
+				r.log(LogLevelError, "error marshalling pending response message", LogField{"client", pendingResponse.client.id}, LogField{"error", err})
+				continue
+			}
+			r.sendMessage(pendingResponse.client, response)
+		default:
+			break Exit
+		}
+	}
+}
+
+func (r *Room) sendMessage(client *Client, message [ // sendMessage sends responses and events which can
+// neither be coalesced nor resynced
+]byte) {
+	select {
+	case client.messageChannel <- message:
+	default:
+		if r.backpressure.Policy == BackpressureDrop {
+			r.dropClient(client)
+			return
+		}
+		r.log(LogLevelWarn, "client's message buffer full -> dropping message", LogField{"client", client.id})
+		atomic.AddUint64(&r.backpressureMetrics.ResponsesDropped, 1)
+	}
+}
+
+func (r *Room) disconnectViolatingClients() {
+	for client := // disconnectViolatingClients is called after pending responses are handled
+	// so clients receive the reports of their violations before being disconnected
+	range r.clients {
+		if client.hasExceededViolations() {
+			r.unregisterClient(client)
+		}
+	}
+	for client := range r.incomingClients {
+		if client.hasExceededViolations() {
+			r.unregisterClient(client)
+		}
+	}
+	for _, client := range r.delayedSpectators() {
+		if client.hasExceededViolations() {
+			r.unregisterClient(client)
+		}
+	}
+}
+
+func (r *Room) process(tickInfo TickInfo) {
+	r.tick++
+	tickInfo.Number = r.tick
+	tickStart := time.Now()
+	defer func() {
+		r.metrics.observeDuration(r.metrics.tickDuration, time.Since(tickStart))
+		r.metrics.setRoomStatus(len(r.clients), len(r.incomingClients), r.elementCounts())
+	}()
+	err := r.processFrame(tickInfo)
+	r.handlePendingResponses()
+	r.disconnectViolatingClients()
+	if err != nil {
+		r.log(LogLevelError, err.Error())
+	}
+	err = r.publishPatch()
+	if err != nil {
+		r.log(LogLevelError, err.Error())
+	}
+	for _, err := range r.state.Errors() {
+		r.log(LogLevelWarn, "mutation had no effect", LogField{"error", err})
+	}
+	updateStart := time.Now()
+	r.state.UpdateState()
+	r.metrics.observeDuration(r.metrics.updateStateDuration, time.Since(updateStart))
+	err = r.recordSnapshot()
+	if err != nil {
+		r.log(LogLevelError, err.Error())
+	}
+	err = r.handleIncomingClients()
+	if err != nil {
+		r.log(LogLevelError, err.Error())
+	}
+	r.handlePendingEvents()
+	r.publishDelayedFrames()
+}
+
+func (r *Room) run(nextTick time.Time) {
+	for {
+		select {
+		case client := <-r.registerChannel:
+			r.registerClient(client)
+		case client := <-r.unregisterChannel:
+			r.unregisterClient(client)
+		case fn := <-r.adminChannel:
+			fn()
+		case <-r.timer.C():
+			if r.paused {
+				nextTick = r.clock.Now().Add(time.Second / time.Duration(r.fps))
+			} else {
+				nextTick = r.processDueTicks(nextTick)
+			}
+			r.timer.Reset(nextTick.Sub(r.clock.Now()))
+		case <-r.shutdownChannel:
+			r.timer.Stop()
+			r.shutdown()
+			return
+		}
+	}
+}// run processes ticks by a fixed time step rather than the
+// time the previous tick finished, so slow ticks do not cause drift
+
+
+func (r *Room) initiateShutdown() {
+	r.shutdownOnce.Do(func() {
+		close(r.shutdownChannel)
+	})
+}// initiateShutdown signals the room to stop running,
+// it is safe to call it multiple times
+
+
+func (r *Room) shutdown() {
+	r.processClientMessages()
+	r.handlePendingResponses()
+	err := r.publishPatch()
+	if err != nil {
+		r.log(LogLevelError, err.Error())
+	}
+	r.handlePendingEvents()
+	for client := range r.clients {
+		r.unregisterClient(client)
+	}
+	for client := range r.incomingClients {
+		r.unregisterClient(client)
+	}
+	for _, client := range r.delayedSpectators() {
+		r.unregisterClient(client)
+	}
+	if r.sideEffects.OnShutdown != nil {
+		r.sideEffects.OnShutdown(r.state)
+	}
+	close(r.doneChannel)
+}
+
+func (r *Room) Deploy() {
+	if r.sideEffects.OnDeploy != nil {
+		r.sideEffects.OnDeploy(r.state)
+	}
+	step := time.Second / time.Duration(r.fps)
+	r.timer = r.clock.NewTimer(step)
+	go r.run(r.clock.Now().Add(step))
+}
+
+// easyjson:skip
+type Task struct {
+	fn		func(*Engine)
+	cancelled	int32
+	tickBased	bool
+	dueTime		time.Time
+	interval	time.Duration
+	dueTick		int
+	tickInterval	int
+}// Task is a handle of a scheduled function
+// easyjson:skip
+// a zero interval means the task runs only once
+
+
+func (t *Task) Cancel() {
+	atomic.StoreInt32(&t.cancelled, 1)
+}// Cancel prevents all future runs of the task,
+// it is safe to call it multiple times and from within the task itself
+
+
+func (t *Task) isCancelled() bool {
+	return atomic.LoadInt32(&t.cancelled) == 1
+}
+
+func (t *Task) isRepeating() bool {
+	return t.interval > 0 || t.tickInterval > 0
+}
+
+func (t *Task) isDue(tick int, now time.Time) bool {
+	if t.tickBased {
+		return tick >= t.dueTick
+	}
+	return !now.Before(t.dueTime)
+}
+
+func (t *Task) reschedule(tick int, now time.Time) {
+	if t.tickBased {
+		for t.dueTick <= tick {
+			t.dueTick += t.tickInterval
+		}
+		return
+	}
+	for !t.dueTime.After(now) {
+		t.dueTime = t.dueTime.Add(t.interval)
+	}
+}// reschedule moves the due time of repeating tasks past the current tick,
+// runs which were missed e.g. due to a low FPS are skipped
+
+
+// easyjson:skip
+type Scheduler struct {
+	mu	sync.Mutex
+	tasks	[ // Scheduler runs functions on the room's tick goroutine, so engine access
+	// stays single-threaded and all changes end up in the tick's patch.
+	// Its methods are safe for concurrent use.
+	// easyjson:skip
+	]*Task
+	tick	int
+	now	time.Time
+}
+
+func newScheduler(now time.Time) *Scheduler {
+	return &Scheduler{now: now}
+}
+
+func (s *Scheduler) scheduleAfter(delay time.Duration, task *Task) *Task {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	task.dueTime = s.now.Add(delay)
+	s.tasks = append(s.tasks, task)
+	return task
+}
+
+func (s *Scheduler) scheduleAfterTicks(ticks int, task *Task) *Task {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	task.tickBased = true
+	task.dueTick = s.tick + ticks
+	s.tasks = append(s.tasks, task)
+	return task
+}
+
+func (s *Scheduler) After(duration time.Duration, fn func(*Engine)) *Task {
+	return s.scheduleAfter(duration, &Task{fn: fn})
+}// After runs fn once in the first tick after the duration has passed
+
+
+func (s *Scheduler) Every(interval time.Duration, fn func(*Engine)) *Task {
+	if interval <= 0 {
+		panic("scheduler interval must be positive")
+	}
+	return s.scheduleAfter(interval, &Task{fn: fn, interval: interval})
+}// Every runs fn in the first tick after each interval
+
+
+func (s *Scheduler) AfterTicks(ticks int, fn func(*Engine)) *Task {
+	if ticks < 1 {
+		ticks = 1
+	}
+	return s.scheduleAfterTicks(ticks, &Task{fn: fn})
+}// AfterTicks runs fn once, the given number of ticks from now.
+// Unlike durations, ticks make the schedule deterministic for replays.
+
+
+func (s *Scheduler) EveryTicks(ticks int, fn func(*Engine)) *Task {
+	if ticks < 1 {
+		panic("scheduler interval must be positive")
+	}
+	return s.scheduleAfterTicks(ticks, &Task{fn: fn, tickInterval: ticks})
+}// EveryTicks runs fn every given number of ticks
+
+
+func (s *Scheduler) dueTasks(tick int, now time.Time) [ // dueTasks removes all due and cancelled tasks and returns the due ones
+]*Task {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tick = tick
+	s.now = now
+	var due []*Task
+	pending := s.tasks[:0]
+	for _, task := range s.tasks {
+		if task.isCancelled() {
+			continue
+		}
+		if task.isDue(tick, now) {
+			due = append(due, task)
+			if !task.isRepeating() {
+				continue
+			}
+		}
+		pending = append(pending, task)
+	}
+	s.tasks = pending
+	return due
+}
+
+func (s *Scheduler) run(engine *Engine, tick int, now time.Time) {
+	for _, task := // run is called by the room on every tick,
+	// tasks are run in the order they were scheduled
+	range s.dueTasks(tick, now) {
+		if task.isCancelled() {
+			continue
+		}
+		task.fn(engine)
+		if task.isRepeating() {
+			s.mu.Lock()
+			task.reschedule(tick, now)
+			s.mu.Unlock()
+		}
+	}
+}
+
+var errServerShutDown = errors.New("server is shut down")
+
+// easyjson:skip
+type Options struct {
+	Actions		Actions
+	SideEffects	SideEffects
+	FPS		int
+	Authenticate	func(r *http.Request) (Identity, error)
+	AllowedOrigins	[ // easyjson:skip
+	// AllowedOrigins holds host patterns of origins which are allowed to connect,
+	// all origins are allowed if empty
+	]string
+	ClientLimits		ClientLimits
+	Backpressure		BackpressureOptions
+	Timestep		TimestepOptions
+	SpectatorDelay		time.Duration
+	Clock			Clock
+	AdminAuthenticate	func(r *http.Request) error
+	Logger			Logger
+	StrictMode		bool
+	TCPWriteTimeout		time.Duration
+}// SpectatorDelay serves spectators the messages clients received
+// the given duration ago, e.g. to prevent ghosting in tournaments
+// TCPWriteTimeout is how long writing a single message to a TCP client
+// may take before the client is disconnected, defaults to 10 seconds
+
+
+func (o Options) withDefaults() Options {
+	if o.FPS < 1 {
+		o.FPS = 1
+	}
+	o.Backpressure = o.Backpressure.withDefaults()
+	o.Timestep = o.Timestep.withDefaults()
+	if o.Clock == nil {
+		o.Clock = realClock{}
+	}
+	if o.Logger == nil {
+		o.Logger = NewStdLogger(os.Stderr, LogLevelInfo)
+	}
+	if o.TCPWriteTimeout <= 0 {
+		o.TCPWriteTimeout = defaultTCPWriteTimeout
+	}
+	return o
+}
+
+type Identity struct {
+	ClientID	string
+	UserID		string
+	Claims		map // Identity describes who is behind a client connection.
+	// ClientID is assigned by the server, all other fields
+	// can be populated by the Authenticate hook.
+	[string]interface{}
+	Spectator	bool
+}// Spectator clients receive the state but cannot send actions,
+// it is also set by connecting with ` + "`" +  `?mode=spectate` + "`" +  `
+
+
+// easyjson:skip
+type Server struct {
+	room		*Room
+	options		Options
+	handler		*http.ServeMux
+	sseClients	map // easyjson:skip
+	// sseClients are looked up when actions are posted to /action
+	[string]*Client
+	sseClientsMu	sync.Mutex
+}
+
+func NewServer(options Options) *Server {
+	options = options.withDefaults()
+	room := newRoom(options)
+	room.Deploy()
+	s := Server{room: room, options: options, sseClients: make(map // NewServer deploys a room and returns a server which can be mounted
+	// as http.Handler, e.g. ` + "`" +  `http.ListenAndServe(":3496", state.NewServer(options))` + "`" +  `
+	[string]*Client)}
+	s.handler = s.setupRoutes()
+	return &s
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.handler.ServeHTTP(w, r)
+}
+
+func (s *Server) BackpressureMetrics() BackpressureMetrics {
+	return s.room.backpressureMetrics.snapshot()
+}// BackpressureMetrics returns how often the backpressure policies were applied
+
+
+func (s *Server) Connect(conn Connector, identity Identity) (string, error) {
+	c, err := newClient(conn, identity)
+	if err != nil {
+		return "", fmt.Errorf("error creating client: %s", err)
+	}
+	err = s.registerClient(c)
+	if err != nil {
+		return "", err
+	}
+	return c.identity.ClientID, nil
+}// Connect adds a client which communicates through the connector,
+// e.g. one end of NewMemoryConnectorPair, and returns its client ID
+
+
+func (s *Server) registerClient(c *Client) error {
+	c.assignToRoom(s.room)
+	s.room.connectedClients.Add(1)
+	select {
+	case s.room.registerChannel <- c:
+	case <-s.room.doneChannel:
+		s.room.connectedClients.Done()
+		c.conn.Close(c.closeStatus())
+		return errServerShutDown
+	}
+	go c.runReadMessages()
+	go c.runWriteMessages()
+	return nil
+}// registerClient adds the client to the room and starts reading and writing its connection
+
+
+func (s *Server) Scheduler() *Scheduler {
+	return s.room.scheduler
+}// Scheduler returns the room's scheduler for delayed and repeating tasks
+
+
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.room.initiateShutdown()
+	connectionsClosed := make(chan struct{})
+	go func() {
+		<-s.room.doneChannel
+		s.room.connectedClients.Wait()
+		close(connectionsClosed)
+	}()
+	select {
+	case <-connectionsClosed:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}// Shutdown stops the room's tick loop, processes the actions which were already
+// received, delivers all pending responses and changes, closes the
+// connections of all clients and runs the OnShutdown side effect.
+// It returns the context's error if the context expires before all
+// connections are closed.
+
+
+const spectatorActionError = "spectators cannot send actions"
+
+func applyMode(w http.ResponseWriter, r *http.Request, identity *Identity) bool {
+	switch mode := r.URL.Query().Get("mode"); mode {
+	case "":
+	case "spectate":
+		identity.Spectator = true
+	default:
+		http.Error(w, fmt.Sprintf("unknown mode \"%s\"", mode), http.StatusBadRequest)
+		return false
+	}
+	return true
+}// applyMode marks the identity as spectator for requests with ` + "`" +  `?mode=spectate` + "`" +  `
+
+
+// easyjson:skip
+type delayedFrame struct {
+	at	time.Time
+	message	[ // delayedFrame is either a message or a snapshot of the state,
+	// the patch of update messages is kept for catching up spectators
+	// easyjson:skip
+	]byte
+	patch		[]byte
+	snapshot	[]byte
+}
+
+// easyjson:skip
+type spectatorStream struct {
+	delay	time.Duration
+	frames	[ // spectatorStream replays the messages clients received to spectators
+	// once they are older than the delay. New spectators receive the latest
+	// released snapshot followed by all released messages recorded after it.
+	// easyjson:skip
+	]delayedFrame
+	released	int
+	lastSnapshot	time.Time
+	clients		map[*Client]bool
+	incoming	map[*Client]bool
+}
+
+func newSpectatorStream(delay time.Duration) *spectatorStream {
+	if delay <= 0 {
+		return nil
+	}
+	return &spectatorStream{delay: delay, clients: make(map // newSpectatorStream returns nil unless spectators are served with a delay
+	[*Client]bool), incoming: make(map[*Client]bool)}
+}
+
+func (stream *spectatorStream) has(client *Client) bool {
+	return stream != nil && (stream.clients[client] || stream.incoming[client])
+}
+
+func (r *Room) isDelayedSpectator(client *Client) bool {
+	return r.spectators != nil && client.identity.Spectator
+}
+
+func (r *Room) delayedSpectators() []*Client {
+	if r.spectators == nil {
+		return nil
+	}
+	clients := make([]*Client, 0, len(r.spectators.clients)+len(r.spectators.incoming))
+	for client := range r.spectators.clients {
+		clients = append(clients, client)
+	}
+	for client := range r.spectators.incoming {
+		clients = append(clients, client)
+	}
+	return clients
+}
+
+func (r *Room) recordFrame(frame delayedFrame) {
+	if r.spectators == nil {
+		return
+	}
+	frame.at = r.clock.Now()
+	r.spectators.frames = append(r.spectators.frames, frame)
+}// recordFrame keeps an update or broadcast event for delayed spectators
+
+
+func (r *Room) recordSnapshot() error {
+	if r.spectators == nil {
+		return nil
+	}
+	now := r.clock.Now()
+	if !r.spectators.lastSnapshot.IsZero() && now.Sub(r.spectators.lastSnapshot) < r.spectators.delay {
+		return nil
+	}
+	tree := r.state.assembleTree(true)
+	stateBytes, err := r.state.marshalTree(tree)
+	if err != nil {
+		return fmt.Errorf("error marshalling tree for spectator snapshot: %s", err)
+	}
+	snapshot, err := Message{Kind: MessageKindCurrentState, Content: stateBytes}.MarshalJSON()
+	if err != nil {
+		return fmt.Errorf("error marshalling spectator snapshot message: %s", err)
+	}
+	r.spectators.frames = append(r.spectators.frames, delayedFrame{at: now, snapshot: snapshot})
+	r.spectators.lastSnapshot = now
+	return nil
+}// recordSnapshot is called after the state was updated, a snapshot is
+// taken once per delay so a released snapshot is always available
+
+
+func (r *Room) sendDelayed(client *Client, message [ // sendDelayed drops spectators whose buffer is full regardless of the backpressure
+// policy, as coalescing or resyncing with the live state would defeat the delay
+]byte) bool {
+	select {
+	case client.messageChannel <- message:
+		return true
+	default:
+		r.dropClient(client)
+		return false
+	}
+}
+
+func (stream *spectatorStream) catchUpMessages(snapshotIndex int) ([ // catchUpMessages are the snapshot and all released patches after it merged into one
+// update by operation kind (see mergePatches), so elements created after the snapshot
+// stay created when they are updated later on. Like live clients, new spectators do
+// not receive events of the past
+][]byte, error) {
+	var mergedPatch []byte
+	for _, frame := range stream.frames[snapshotIndex+1 : stream.released] {
+		if frame.patch == nil {
+			continue
+		}
+		if mergedPatch == nil {
+			mergedPatch = frame.patch
+			continue
+		}
+		var err error
+		mergedPatch, err = mergePatches(mergedPatch, frame.patch)
+		if err != nil {
+			return nil, err
+		}
+	}
+	messages := [][]byte{stream.frames[snapshotIndex].snapshot}
+	if mergedPatch != nil {
+		updateMessage, err := stateUpdateMessage(mergedPatch)
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, updateMessage)
+	}
+	return messages, nil
+}
+
+func (r *Room) publishDelayedFrames() {
+	stream := r.spectators
+	if stream == nil {
+		return
+	}
+	cutoff := r.clock.Now().Add(-stream.delay)
+	for// publishDelayedFrames is called at the end of each tick
+	; stream.released < len(stream.frames); stream.released++ {
+		frame := stream.frames[stream.released]
+		if frame.at.After(cutoff) {
+			break
+		}
+		if frame.message == nil {
+			continue
+		}
+		for client := range stream.clients {
+			r.sendDelayed(client, frame.message)
+		}
+	}
+	latestSnapshot := -1
+	for i := stream.released - 1; i >= 0; i-- {
+		if stream.frames[i].snapshot != nil {
+			latestSnapshot = i
+			break
+		}
+	}
+	if latestSnapshot == -1 {
+		return
+	}
+	if len(stream.incoming) > 0 {
+		catchUpMessages, err := stream.catchUpMessages(latestSnapshot)
+		if err != nil {
+			r.log(LogLevelError, err.Error())
+			return
+		}
+	Incoming:
+		for client := range stream.incoming {
+			delete(stream.incoming, client)
+			stream.clients[client] = true
+			for _, message := range catchUpMessages {
+				if !r.sendDelayed(client, message) {
+					continue Incoming
+				}
+			}
+		}
+	}
+	stream.frames = stream.frames[latestSnapshot:]
+	stream.released -= latestSnapshot
+}
+
+// easyjson:skip
+type sseConnection struct {
+	mu		sync.Mutex
+	w		http.ResponseWriter
+	flusher		http.Flusher
+	closed		bool
+	closedChannel	chan struct{}
+}// sseConnection is a write-only Connector which streams messages as
+// Server-Sent Events, clients send their actions via POST /action
+// easyjson:skip
+
+
+func newSSEConnection(w http.ResponseWriter, flusher http.Flusher) *sseConnection {
+	return &sseConnection{w: w, flusher: flusher, closedChannel: make(chan struct{})}
+}
+
+func (c *sseConnection) Close(code websocket.StatusCode, reason string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return
+	}
+	c.write("close", [ // Close sends a "close" event, as EventSources
+	// would otherwise reconnect automatically
+	]byte(fmt.Sprintf(` + "`" +  `{"code":%d,"reason":%q}` + "`" +  `, code, reason)))
+	c.closed = true
+	close(c.closedChannel)
+}
+
+func (c *sseConnection) ReadMessage() (int, [ // ReadMessage blocks until the connection is closed
+]byte, error) {
+	<-c.closedChannel
+	return 0, nil, errConnectionClosed
+}
+
+func (c *sseConnection) WriteMessage(msg []byte) error {
+	return c.writeEvent("", msg)
+}
+
+func (c *sseConnection) writeEvent(event string, data []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return errConnectionClosed
+	}
+	c.write(event, data)
+	return nil
+}
+
+func (c *sseConnection) write(event string, data [ // write expects the connection to be locked,
+// data must not contain line breaks
+]byte) {
+	if event != "" {
+		fmt.Fprintf(c.w, "event: %s\n", event)
+	}
+	fmt.Fprintf(c.w, "data: %s\n\n", data)
+	c.flusher.Flush()
+}
+
+func (s *Server) sseEndpoint(w http.ResponseWriter, r *http.Request) {
+	room := s.room
+	select {
+	case <-room.shutdownChannel:
+		http.Error(w, "server is shutting down", http.StatusServiceUnavailable)
+		return
+	default:
+	}
+	identity, ok := s.authenticate(w, r)
+	if !ok || !applyMode(w, r, &identity) {
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming not supported", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	conn := newSSEConnection(w, flusher)
+	c, err := newClient(conn, identity)
+	if err != nil {
+		room.logger.Log(LogLevelError, "error creating client", LogField{"error", err})
+		return
+	}
+	conn.writeEvent("connected", []byte(fmt.Sprintf(` + "`" +  `{"clientID":%q}` + "`" +  `, c.identity.ClientID)))
+	s.sseClientsMu.Lock()
+	s.sseClients[c.identity.ClientID] = c
+	s.sseClientsMu.Unlock()
+	defer func() {
+		s.sseClientsMu.Lock()
+		delete(s.sseClients, c.identity.ClientID)
+		s.sseClientsMu.Unlock()
+	}()
+	err = s.registerClient(c)
+	if err != nil {
+		room.logger.Log(LogLevelWarn, "error connecting client", LogField{"error", err})
+		return
+	}
+	select {
+	case <-r.Context().Done():
+		conn.Close(websocket.StatusNormalClosure, "")
+	case <-conn.closedChannel:
+	}
+}
+
+func (s *Server) actionEndpoint(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	identity, ok := s.authenticate(w, r)
+	if !ok {
+		return
+	}
+	s.sseClientsMu.Lock()
+	c, ok := s.sseClients[r.URL.Query().Get("client")]
+	s.sseClientsMu.Unlock()
+	if !ok {
+		http.Error(w, "unknown client", http.StatusNotFound)
+		return
+	}
+	if s.options.Authenticate != nil && identity.UserID != c.identity.UserID {
+		http.Error(w, "client belongs to a different user", http.StatusForbidden)
+		return
+	}
+	readLimit := s.options.ClientLimits.readLimit()
+	msgBytes, err := ioutil.ReadAll(io.LimitReader(r.Body, int64(readLimit)+1))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("error reading body: %s", err), http.StatusBadRequest)
+		return
+	}
+	if len(msgBytes) > readLimit {
+		http.Error(w, fmt.Sprintf("message exceeds %d bytes", readLimit), http.StatusRequestEntityTooLarge)
+		return
+	}
+	c.handleMessageBytes(msgBytes)
+	w.WriteHeader(http.StatusAccepted)
+}// actionEndpoint accepts the same messages as the websocket endpoint
+// for clients connected via /sse, their responses are sent through the stream
+
+
+type stateQuery struct {
+	kind	string
+	id	int
+	path	string
+	depth	int
+}// stateQuery filters the elements returned by /state,
+// zero values do not filter
+// depth is the number of nested element levels included,
+// -1 includes all levels
+
+
+type errorResponse struct {
+	Error string ` + "`" +  `json:"error"` + "`" +  `
+}// errorResponse is the body of failed /state requests
+
+
+func writeJSONError(w http.ResponseWriter, status int, msg string) {
+	errorBytes, _ := json.Marshal(errorResponse{Error: msg})
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(errorBytes)
+}
+
+func treeKinds() map // treeKinds maps the element kinds to their keys in the tree's JSON
+[string]string {
+	kinds := make(map[string]string)
+	treeType := reflect.TypeOf(Tree{})
+	for i := 0; i < treeType.NumField(); i++ {
+		field := treeType.Field(i)
+		kinds[field.Name] = strings.Split(field.Tag.Get("json"), ",")[0]
+	}
+	return kinds
+}
+
+func parseStateQuery(r *http.Request) (stateQuery, error) {
+	params := r.URL.Query()
+	query := stateQuery{kind: params.Get("kind"), path: params.Get("path"), depth: -1}
+	if query.kind != "" {
+		if _, ok := treeKinds()[query.kind]; !ok {
+			return stateQuery{}, fmt.Errorf("unknown kind \"%s\"", query.kind)
+		}
+	}
+	if id := params.Get("id"); id != "" {
+		var err error
+		query.id, err = strconv.Atoi(id)
+		if err != nil || query.id <= 0 {
+			return stateQuery{}, fmt.Errorf("invalid id \"%s\"", id)
+		}
+	}
+	if depth := params.Get("depth"); depth != "" {
+		var err error
+		query.depth, err = strconv.Atoi(depth)
+		if err != nil || query.depth < 0 {
+			return stateQuery{}, fmt.Errorf("invalid depth \"%s\"", depth)
+		}
+	}
+	return query, nil
+}
+
+func (q stateQuery) filters() bool {
+	return q.kind != "" || q.id != 0 || q.path != ""
+}
+
+type elementLocation struct {
+	ID	int	` + "`" +  `json:"id"` + "`" +  `
+	Path	string	` + "`" +  `json:"path"` + "`" +  `
+}// elementLocation is the part of an element's core needed to find it in the tree
+
+
+func (engine *Engine) queryState(query stateQuery) ([ // queryState returns the tree's JSON, or only the elements matching the query
+// in the same structure, including those which are children of other elements
+]byte, int, error) {
+	treeBytes, err := engine.marshalTree(engine.assembleTree(true))
+	if err != nil {
+		return nil, http.StatusInternalServerError, fmt.Errorf("error marshalling tree: %s", err)
+	}
+	var tree map[string]interface{}
+	err = json.Unmarshal(treeBytes, &tree)
+	if err != nil {
+		return nil, http.StatusInternalServerError, fmt.Errorf("error unmarshalling tree: %s", err)
+	}
+	if !query.filters() {
+		if query.depth == -1 {
+			return treeBytes, http.StatusOK, nil
+		}
+		for _, elements := range tree {
+			for id, element := range elements.(map[string]interface{}) {
+				elements.(map[string]interface{})[id] = limitDepth(element, query.depth)
+			}
+		}
+		return marshalQueryResult(tree)
+	}
+	locations := make(map[string]map[string]elementLocation)
+	for _, state := range []State{engine.State, engine.Patch} {
+		stateBytes, err := json.Marshal(state)
+		if err != nil {
+			return nil, http.StatusInternalServerError, fmt.Errorf("error marshalling state: %s", err)
+		}
+		var stateLocations map[string]map[string]elementLocation
+		err = json.Unmarshal(stateBytes, &stateLocations)
+		if err != nil {
+			return nil, http.StatusInternalServerError, fmt.Errorf("error unmarshalling state: %s", err)
+		}
+		for key, kindLocations := range stateLocations {
+			if _, ok := locations[key]; !ok {
+				locations[key] = make(map[string]elementLocation)
+			}
+			for id, location := range kindLocations {
+				locations[key][id] = location
+			}
+		}
+	}
+	result := make(map[string]interface{})
+	for kind, key := range treeKinds() {
+		if query.kind != "" && query.kind != kind {
+			continue
+		}
+		for id, location := range locations[key] {
+			if query.id != 0 && query.id != location.ID {
+				continue
+			}
+			if query.path != "" && query.path != location.Path {
+				continue
+			}
+			element, ok := elementAtPath(tree, location.Path)
+			if !ok {
+				continue
+			}
+			if _, ok := result[key]; !ok {
+				result[key] = make(map[string]interface{})
+			}
+			result[key].(map[string]interface{})[id] = limitDepth(element, query.depth)
+		}
+	}
+	if len(result) == 0 && (query.id != 0 || query.path != "") {
+		return nil, http.StatusNotFound, fmt.Errorf("element not found")
+	}
+	return marshalQueryResult(result)
+}
+
+func marshalQueryResult(result map[string]interface{}) ([]byte, int, error) {
+	resultBytes, err := json.Marshal(result)
 	if err != nil {
-		return err.Error()
-	} else {
-		return string(b)
+		return nil, http.StatusInternalServerError, fmt.Errorf("error marshalling result: %s", err)
 	}
-}
-func messageUnmarshallingError(msgContent []byte, err error) []byte {
-	return []byte(fmt.Sprintf("error when unmarshalling received message content ` + "`" +  `%s` + "`" +  `: %s", msgContent, err))
-}
-func responseMarshallingError(msgContent []byte, err error) []byte {
-	return []byte(fmt.Sprintf("error when marshalling response to ` + "`" +  `%s` + "`" +  `: %s", msgContent, err))
+	return resultBytes, http.StatusOK, nil
 }
 
-type Room struct {
-	clients			map[*Client]bool
-	clientMessageChannel	chan Message
-	pendingResponsesChannel	chan Message
-	registerChannel		chan *Client
-	unregisterChannel	chan *Client
-	incomingClients		map[*Client]bool
-	state			*Engine
-	actions			Actions
-	sideEffects		SideEffects
-	fps			int
+func elementAtPath(tree map // elementAtPath follows paths like "$.player.3.items[5]" through the tree's JSON
+[string]interface{}, path string) (interface{}, bool) {
+	path = strings.TrimPrefix(path, "$")
+	path = strings.NewReplacer("[", ".", "]", "").Replace(path)
+	var current interface{} = tree
+	for _, segment := range strings.Split(strings.TrimPrefix(path, "."), ".") {
+		object, ok := current.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		current, ok = object[segment]
+		if !ok {
+			return nil, false
+		}
+	}
+	return current, true
 }
 
-func newRoom(a Actions, sideEffects SideEffects, fps int) *Room {
-	return &Room{clients: make(map[*Client]bool), clientMessageChannel: make(chan Message, 1024), pendingResponsesChannel: make(chan Message, 1024), unregisterChannel: make(chan *Client), registerChannel: make(chan *Client), incomingClients: make(map[*Client]bool), state: newEngine(), sideEffects: sideEffects, actions: a, fps: fps}
-}
-func (r *Room) registerClient(client *Client) {
-	r.incomingClients[client] = true
-}
-func (r *Room) promoteIncomingClient(client *Client) {
-	r.clients[client] = true
-	delete(r.incomingClients, client)
+func isReference(object map[string]interface{}) bool {
+	_, ok := object["elementKind"]
+	return ok
 }
-func (r *Room) unregisterClient(client *Client) {
-	if _, ok := r.clients[client]; ok {
-		log.Printf("unregistering client %s", client.id)
-		close(client.messageChannel)
-		delete(r.clients, client)
-	} else if _, ok := r.incomingClients[client]; ok {
-		log.Printf("unregistering incoming client %s", client.id)
-		close(client.messageChannel)
-		delete(r.incomingClients, client)
+
+func limitDepth(value interface{}, depth int) interface{} {
+	object, ok := value.(map // limitDepth replaces nested elements beyond the depth with their IDs
+	// and removes the referenced elements from references
+	[string]interface{})
+	if !ok || depth == -1 {
+		return value
 	}
-}
-func (r *Room) broadcastPatchToClients(stateUpdateBytes []byte) {
-	for client := range r.clients {
-		select {
-		case client.messageChannel <- stateUpdateBytes:
-		default:
-			log.Printf("client's message buffer full -> dropping client %s", client.id)
-			r.unregisterClient(client)
+	limited := make(map[string]interface{}, len(object))
+	for key, fieldValue := range object {
+		fieldObject, ok := fieldValue.(map[string]interface{})
+		if !ok {
+			limited[key] = fieldValue
+			continue
 		}
+		if _, isElement := fieldObject["id"]; isElement || isReference(object) {
+			if limitedField, include := limitNested(fieldObject, object, depth); include {
+				limited[key] = limitedField
+			}
+			continue
+		}
+		limitedMap := make(map[string]interface{}, len(fieldObject))
+		for id, mapValue := range fieldObject {
+			nested, ok := mapValue.(map[string]interface{})
+			if !ok {
+				limitedMap[id] = mapValue
+				continue
+			}
+			if limitedValue, include := limitNested(nested, fieldObject, depth); include {
+				limitedMap[id] = limitedValue
+			}
+		}
+		limited[key] = limitedMap
 	}
+	return limited
 }
-func (r *Room) handleIncomingClients() error {
-	if len(r.incomingClients) == 0 {
-		return nil
+
+func limitNested(nested, parent map[string]interface{}, depth int) (interface{}, bool) {
+	switch {
+	case isReference(parent):
+		if depth == 0 {
+			return nil, false
+		}
+		return limitDepth(nested, depth-1), true
+	case isReference(nested):
+		return limitDepth(nested, depth), true
+	case depth == 0:
+		return map[string]interface{}{"id": nested["id"]}, true
+	default:
+		return limitDepth(nested, depth-1), true
 	}
-	tree := r.state.assembleTree(true)
-	stateBytes, err := tree.MarshalJSON()
+}
+
+func (s *Server) stateEndpoint(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	query, err := parseStateQuery(r)
 	if err != nil {
-		return fmt.Errorf("error marshalling tree for init request: %s", err)
+		writeJSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	var stateBytes []byte
+	var status int
+	ok := s.room.runOnRoom(func() {
+		stateBytes, status, err = s.room.state.queryState(query)
+	})
+	if !ok {
+		writeJSONError(w, http.StatusServiceUnavailable, errServerShutDown.Error())
+		return
 	}
-	currentStateMsg := Message{Kind: MessageKindCurrentState, Content: stateBytes}
-	response, err := currentStateMsg.MarshalJSON()
 	if err != nil {
-		return fmt.Errorf("error marshalling response message for init request: %s", err)
+		writeJSONError(w, status, err.Error())
+		return
 	}
-	for client := range r.incomingClients {
-		select {
-		case client.messageChannel <- response:
-			r.promoteIncomingClient(client)
-		default:
-			log.Printf("client's message buffer full -> dropping client %s", client.id)
-			r.unregisterClient(client)
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(stateBytes)
+}
+
+const (
+	tcpHandshakeTimeout	= 10 * time.Second
+	defaultTCPWriteTimeout	= 10 * time.Second
+	tcpCloseWriteTimeout	= time.Second
+)
+
+const messageKindClose MessageKind = "close"	// messageKindClose is the last message written before
+// a TCP connection is closed by the server
+
+
+type tcpHandshake struct {
+	Headers	map // tcpHandshake is the first frame a TCP client sends,
+	// its headers are passed to the Authenticate hook
+	[string]string	` + "`" +  `json:"headers"` + "`" +  `
+	Mode	string	` + "`" +  `json:"mode"` + "`" +  `
+}// Mode is "spectate" for spectators
+
+
+// easyjson:skip
+type tcpConnection struct {
+	conn		net.Conn
+	reader		*bufio.Reader
+	readLimit	int
+	writeTimeout	time.Duration
+	writeMu		sync.Mutex
+	closeOnce	sync.Once
+}// tcpConnection is a Connector which frames each message
+// with its length as 4 byte big-endian unsigned integer
+// easyjson:skip
+// writeTimeout keeps a client which stops reading
+// from blocking its writer forever
+
+
+func newTCPConnection(conn net.Conn, readLimit int, writeTimeout time.Duration) *tcpConnection {
+	return &tcpConnection{conn: conn, reader: bufio.NewReader(conn), readLimit: readLimit, writeTimeout: writeTimeout}
+}
+
+func (c *tcpConnection) Close(code websocket.StatusCode, reason string) {
+	c.closeOnce.Do(func() {
+		content := [ // Close informs the client about the status before closing the connection
+		]byte(fmt.Sprintf(` + "`" +  `{"code":%d,"reason":%q}` + "`" +  `, code, reason))
+		closeMsg, err := Message{Kind: messageKindClose, Content: content}.MarshalJSON()
+		if err == nil {
+			c.writeFrame(closeMsg, tcpCloseWriteTimeout)
 		}
+		c.conn.Close()
+	})
+}
+
+func (c *tcpConnection) ReadMessage() (int, []byte, error) {
+	var header [4]byte
+	_, err := io.ReadFull(c.reader, header[:])
+	if err != nil {
+		return 0, nil, fmt.Errorf("error reading frame header: %s", err)
 	}
-	return nil
+	length := binary.BigEndian.Uint32(header[:])
+	if int64(length) > int64(c.readLimit) {
+		return 0, nil, fmt.Errorf("frame of %d bytes exceeds read limit of %d bytes", length, c.readLimit)
+	}
+	msg := make([]byte, length)
+	_, err = io.ReadFull(c.reader, msg)
+	if err != nil {
+		return 0, nil, fmt.Errorf("error reading frame: %s", err)
+	}
+	return int(websocket.MessageText), msg, nil
 }
-func (r *Room) processFrame() error {
-Exit:
+
+func (c *tcpConnection) WriteMessage(msg []byte) error {
+	return c.writeFrame(msg, c.writeTimeout)
+}
+
+func (c *tcpConnection) writeFrame(msg [ // writeFrame closes the connection if the frame can't be written in time,
+// as a partially written frame would corrupt all following ones
+]byte, timeout time.Duration) error {
+	frame := make([]byte, 4+len(msg))
+	binary.BigEndian.PutUint32(frame, uint32(len(msg)))
+	copy(frame[4:], msg)
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	c.conn.SetWriteDeadline(time.Now().Add(timeout))
+	_, err := c.conn.Write(frame)
+	if err != nil {
+		c.conn.Close()
+	}
+	return err
+}
+
+func (s *Server) ListenAndServeTCP(addr string) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	return s.ServeTCP(listener)
+}// ListenAndServeTCP listens on the TCP network address and serves clients
+// speaking length-prefixed frames, e.g. ` + "`" +  `go server.ListenAndServeTCP(":3497")` + "`" +  `
+
+
+func (s *Server) ServeTCP(listener net.Listener) error {
+	go func() {
+		<-s.room.shutdownChannel
+		listener.Close()
+	}()
 	for {
-		select {
-		case msg := <-r.clientMessageChannel:
-			response, err := r.processClientMessage(msg)
-			if err != nil {
-				log.Println("error processing client message:", err)
-			}
-			if response.client == nil {
-				continue
-			}
+		conn, err := listener.Accept()
+		if err != nil {
 			select {
-			case r.pendingResponsesChannel <- response:
+			case <-s.room.shutdownChannel:
+				return nil
 			default:
-				log.Printf("pending responses channel full, skipping response")
 			}
-		default:
-			break Exit
+			var netErr net.Error
+			if errors.As(err, &netErr) && netErr.Temporary() {
+				time.Sleep(10 * time.Millisecond)
+				continue
+			}
+			return err
 		}
+		go s.connectTCP(conn)
 	}
-	if r.sideEffects.OnFrameTick != nil {
-		r.sideEffects.OnFrameTick(r.state)
+}// ServeTCP accepts connections on the listener until it is closed,
+// the listener is closed when the server shuts down
+
+
+func (s *Server) connectTCP(netConn net.Conn) {
+	conn := newTCPConnection(netConn, s.options.ClientLimits.readLimit(), s.options.TCPWriteTimeout)
+	netConn.SetReadDeadline(time.Now().Add(tcpHandshakeTimeout))
+	_, handshakeBytes, err := conn.ReadMessage()
+	if err != nil {
+		s.room.logger.Log(LogLevelWarn, "error reading TCP handshake", LogField{"error", err})
+		conn.Close(websocket.StatusProtocolError, "handshake expected")
+		return
 	}
-	return nil
-}
-func (r *Room) publishPatch() error {
-	tree := r.state.assembleTree(false)
-	patchBytes, err := tree.MarshalJSON()
+	netConn.SetReadDeadline(time.Time{})
+	var handshake tcpHandshake
+	err = json.Unmarshal(handshakeBytes, &handshake)
 	if err != nil {
-		return fmt.Errorf("error marshalling tree for patch: %s", err)
+		conn.Close(websocket.StatusProtocolError, fmt.Sprintf("invalid handshake: %s", err))
+		return
 	}
-	if len(patchBytes) == 2 {
-		return nil
+	var identity Identity
+	if s.options.Authenticate != nil {
+		r, err := http.NewRequest(http.MethodGet, "/tcp", nil)
+		if err != nil {
+			conn.Close(websocket.StatusInternalError, "")
+			return
+		}
+		r.RemoteAddr = netConn.RemoteAddr().String()
+		for key, value := range handshake.Headers {
+			r.Header.Set(key, value)
+		}
+		identity, err = s.options.Authenticate(r)
+		if err != nil {
+			conn.Close(websocket.StatusPolicyViolation, fmt.Sprintf("authentication failed: %s", err))
+			return
+		}
 	}
-	stateUpdateMsg := Message{Kind: MessageKindUpdate, Content: patchBytes}
-	stateUpdateBytes, err := stateUpdateMsg.MarshalJSON()
+	switch handshake.Mode {
+	case "":
+	case "spectate":
+		identity.Spectator = true
+	default:
+		conn.Close(websocket.StatusProtocolError, fmt.Sprintf("unknown mode \"%s\"", handshake.Mode))
+		return
+	}
+	_, err = s.Connect(conn, identity)
 	if err != nil {
-		return fmt.Errorf("error marshalling state update message: %s", err)
+		s.room.logger.Log(LogLevelWarn, "error connecting client", LogField{"error", err})
 	}
-	r.broadcastPatchToClients(stateUpdateBytes)
-	return nil
 }
-func (r *Room) handlePendingResponses() {
-Exit:
-	for {
-		select {
-		case pendingResponse := <-r.pendingResponsesChannel:
-			response, err := pendingResponse.MarshalJSON()
-			if err != nil {
-				log.Printf("error marshalling pending response message: %s", err)
-				continue
+
+// easyjson:skip
+type TestRoom struct {
+	room		*Room
+	clock		*ManualClock
+	nextTick	time.Time
+	clients		[ // TestRoom runs a room synchronously on the calling goroutine,
+	// clients are connected through MemoryConnectors so actions can be
+	// tested end to end without networking or sleeps
+	// easyjson:skip
+	// clients are kept in the order they connected
+	// so their actions are processed deterministically
+	]*TestClient
+}
+
+func NewTestRoom(options Options) *TestRoom {
+	if options.Logger == nil {
+		options.Logger = NopLogger()
+	}
+	clock := NewManualClock(time.Unix(0, 0))
+	options.Clock = clock
+	options = options.withDefaults()
+	room := newRoom(options)
+	if room.sideEffects.OnDeploy != nil {
+		room.sideEffects.OnDeploy(room.state)
+	}
+	return &TestRoom{room: room, clock: clock, nextTick: clock.Now().Add(time.Second / time.Duration(options.FPS))}
+}// NewTestRoom deploys a room which only processes ticks when Tick is called.
+// The Clock option is replaced by a ManualClock and the Logger defaults to NopLogger().
+
+
+func (t *TestRoom) Engine() *Engine {
+	return t.room.state
+}// Engine gives access to the state for setting up and asserting,
+// changes made between ticks are published with the next tick
+
+
+func (t *TestRoom) Scheduler() *Scheduler {
+	return t.room.scheduler
+}
+
+func (t *TestRoom) BroadcastEvent(event Event) error {
+	return t.room.queueEvent(event, nil)
+}// BroadcastEvent queues the event for delivery to all clients after the next tick
+
+
+func (t *TestRoom) SendEventToClient(clientID string, event Event) error {
+	return t.room.queueEvent(event, map // SendEventToClient queues the event for delivery to the client after the next tick
+	[string]bool{clientID: true})
+}
+
+func (t *TestRoom) Connect(identity Identity) *TestClient {
+	serverConn, clientConn := NewMemoryConnectorPair()
+	c, err := newClient(serverConn, identity)
+	if err != nil {
+		panic(fmt.Sprintf("error creating client: %s", err))
+	}
+	c.assignToRoom(t.room)
+	t.room.registerClient(c)
+	testClient := TestClient{ID: c.identity.ClientID, client: c, conn: clientConn, serverConn: serverConn}
+	t.clients = append(t.clients, &testClient)
+	return &testClient
+}// Connect adds a client, it receives the current state with the next tick
+
+
+func (t *TestRoom) Tick() {
+	t.readClientMessages()
+	t.clock.Advance(t.nextTick.Sub(t.clock.Now()))
+	t.nextTick = t.room.processDueTicks(t.nextTick)
+	t.writeClientMessages()
+}// Tick advances the clock by one time step and processes the tick,
+// all messages of the tick are delivered to the clients once it returns
+
+
+func (t *TestRoom) Ticks(n int) {
+	for i := 0; i < n; i++ {
+		t.Tick()
+	}
+}// Ticks calls Tick n times
+
+
+func (t *TestRoom) Shutdown() {
+	t.readClientMessages()
+	t.room.initiateShutdown()
+	t.room.shutdown()
+	t.writeClientMessages()
+}// Shutdown delivers all pending messages, runs the OnShutdown side effect
+// and closes all connections
+
+
+func (t *TestRoom) readClientMessages() {
+	for _, testClient := // readClientMessages forwards all messages the clients sent since the last tick to the room
+	range t.clients {
+		c := testClient.client
+		if !t.room.isRegistered(c) {
+			continue
+		}
+		for {
+			msgBytes, ok := testClient.serverConn.tryRead()
+			if !ok {
+				break
 			}
+			c.handleMessageBytes(msgBytes)
+		}
+		if testClient.conn.isClosed() {
+			t.room.unregisterClient(c)
+		}
+	}
+}
+
+func (t *TestRoom) writeClientMessages() {
+	for _, testClient := // writeClientMessages writes the messages buffered for each client to its connection
+	range t.clients {
+		c := testClient.client
+		if testClient.conn.isClosed() {
+			continue
+		}
+	Drain:
+		for {
 			select {
-			case pendingResponse.client.messageChannel <- response:
+			case msg, ok := <-c.messageChannel:
+				if !ok {
+					c.conn.Close(c.closeStatus())
+					break Drain
+				}
+				c.conn.WriteMessage(msg)
 			default:
-				log.Printf("client's message buffer full -> dropping client %s", pendingResponse.client.id)
-				r.unregisterClient(pendingResponse.client)
+				break Drain
 			}
-		default:
-			break Exit
 		}
 	}
 }
-func (r *Room) process() {
-	err := r.processFrame()
-	r.handlePendingResponses()
-	if err != nil {
-		log.Println(err)
-	}
-	err = r.publishPatch()
+
+// easyjson:skip
+type TestClient struct {
+	ID		string
+	client		*Client
+	conn		*MemoryConnector
+	serverConn	*MemoryConnector
+}// TestClient is a client of a TestRoom
+// easyjson:skip
+// ID is the client ID assigned by the room
+
+
+func (c *TestClient) Send(kind MessageKind, params interface{ MarshalJSON() ([ // Send queues a message which is processed with the next tick
+]byte, error) }) error {
+	content, err := params.MarshalJSON()
 	if err != nil {
-		log.Println(err)
+		return fmt.Errorf("error marshalling params: %s", err)
 	}
-	r.state.UpdateState()
-	err = r.handleIncomingClients()
+	msgBytes, err := Message{Kind: kind, Content: content}.MarshalJSON()
 	if err != nil {
-		log.Println(err)
+		return fmt.Errorf("error marshalling message: %s", err)
 	}
+	return c.conn.WriteMessage(msgBytes)
 }
-func (r *Room) run() {
-	ticker := time.NewTicker(time.Second / time.Duration(r.fps))
+
+func (c *TestClient) Messages() [ // Messages returns all messages received since the last call
+]Message {
+	var messages []Message
 	for {
-		select {
-		case client := <-r.registerChannel:
-			r.registerClient(client)
-		case client := <-r.unregisterChannel:
-			r.unregisterClient(client)
-		case <-ticker.C:
-			r.process()
+		msgBytes, ok := c.conn.tryRead()
+		if !ok {
+			return messages
 		}
+		var msg Message
+		err := msg.UnmarshalJSON(msgBytes)
+		if err != nil {
+			panic(fmt.Sprintf("error unmarshalling message: %s", err))
+		}
+		messages = append(messages, msg)
 	}
 }
-func (r *Room) Deploy() {
-	if r.sideEffects.OnDeploy != nil {
-		r.sideEffects.OnDeploy(r.state)
+
+func (c *TestClient) Disconnect() {
+	c.conn.Close(websocket.StatusNormalClosure, "")
+}// Disconnect closes the connection, the client is unregistered with the next tick
+
+
+func (c *TestClient) CloseStatus() (websocket.StatusCode, string, bool) {
+	return c.conn.CloseStatus()
+}// CloseStatus returns the status the connection was closed with and whether it is closed
+
+
+const defaultMaxCatchUpTicks = 5
+
+type TickInfo struct {
+	Number	int
+	Delta	time.Duration
+	Overrun	time.Duration
+}// TickInfo is passed to OnFrameTick
+// Overrun is how late the tick started compared to its schedule. With the
+// system clock timer and scheduling jitter make it slightly positive even
+// while processing stays within the budget, only a ManualClock yields zero
+
+
+type CatchUpPolicy string	// CatchUpPolicy decides how the room catches up on ticks it missed
+// because processing took longer than the time step
+
+
+const (
+	CatchUpBurst	CatchUpPolicy	= "burst"
+	CatchUpSkip	CatchUpPolicy	= "skip"
+)// CatchUpBurst processes missed ticks back to back, with the
+// fixed time step as Delta. Ticks exceeding MaxCatchUpTicks are skipped.
+// CatchUpSkip processes a single tick whose Delta
+// covers the time of all missed ticks
+
+
+// easyjson:skip
+type TimestepOptions struct {
+	CatchUp		CatchUpPolicy
+	MaxCatchUpTicks	int
+}// easyjson:skip
+// MaxCatchUpTicks is the number of ticks processed at once
+// with CatchUpBurst, defaults to 5
+
+
+func (o TimestepOptions) withDefaults() TimestepOptions {
+	if o.CatchUp == "" {
+		o.CatchUp = CatchUpBurst
+	}
+	if o.MaxCatchUpTicks < 1 {
+		o.MaxCatchUpTicks = defaultMaxCatchUpTicks
+	}
+	return o
+}
+
+func (r *Room) processDueTicks(nextTick time.Time) time.Time {
+	step := time.Second / time.Duration(r.fps)
+	now := r.clock.Now()
+	dueTicks := 1 + int(now.Sub(nextTick)/step)
+	if dueTicks < 1 {
+		dueTicks = 1
+	}
+	ticksToProcess := 1
+	delta := step * time.Duration(dueTicks)
+	if r.timestep.CatchUp == CatchUpBurst {
+		ticksToProcess = dueTicks
+		delta = step
+		if ticksToProcess > r.timestep.MaxCatchUpTicks {
+			r.log(LogLevelWarn, "skipping ticks to catch up", LogField{"skipped_ticks", dueTicks - r.timestep.MaxCatchUpTicks})
+			ticksToProcess = r.timestep.MaxCatchUpTicks
+		}
+	}
+	for i := 0; i < ticksToProcess; i++ {
+		scheduled := nextTick.Add(step * time.Duration(i))
+		var overrun time.Duration
+		if late := r.clock.Now().Sub(scheduled); late > 0 {
+			overrun = late
+		}
+		tickStart := r.clock.Now()
+		r.process(TickInfo{Delta: delta, Overrun: overrun})
+		if duration := r.clock.Now().Sub(tickStart); duration > step {
+			r.log(LogLevelWarn, "tick exceeded time step", LogField{"duration", duration}, LogField{"step", step})
+		}
 	}
-	go r.run()
-}`
\ No newline at end of file
+	return nextTick.Add(step * time.Duration(dueTicks))
+}// processDueTicks is called whenever the tick timer fires and
+// returns the time at which the next tick is due
+
+
+`
\ No newline at end of file
@@ -5,6 +5,8 @@ import (
 
 	"github.com/jobergner/backent-cli/ast"
 	. "github.com/jobergner/backent-cli/factoryutils"
+
+	. "github.com/dave/jennifer/jen"
 )
 
 func anyNameByField(f ast.Field) string {
@@ -21,22 +23,55 @@ func anyNameByField(f ast.Field) string {
 	return name
 }
 
+// equalBasicValues compares two values of a basic type,
+// bytes and time values can't be compared with "=="
+func equalBasicValues(valueType *ast.ConfigType, a, b *Statement) *Statement {
+	switch valueType.Name {
+	case "[]byte":
+		return Id("bytes").Dot("Equal").Call(a, b)
+	case "time.Time":
+		return a.Dot("Equal").Call(b)
+	}
+	return a.Op("==").Add(b)
+}
+
+// copyBasicValue copies bytes values so the
+// state can't be modified from outside the engine
+func copyBasicValue(valueType *ast.ConfigType, value *Statement) *Statement {
+	if valueType.Name == "[]byte" {
+		return Append(Index().Byte().Call(Nil()), value.Op("..."))
+	}
+	return value
+}
+
+// isNullData checks whether the data passed to
+// UnmarshalJSON is null, which leaves the value untouched
+func isNullData() *Statement {
+	return Id("string").Call(Id("data")).Op("==").Lit("null")
+}
+
 type EngineFactory struct {
 	config *ast.AST
 	buf    *bytes.Buffer
+	// ordered makes the engine return elements sorted by ID
+	// and marshal trees with sorted keys
+	ordered bool
 }
 
 // WriteEngine writes source code for a given StateConfig
-func WriteEngine(buf *bytes.Buffer, stateConfigData map[interface{}]interface{}) {
-	config := ast.Parse(stateConfigData, map[interface{}]interface{}{}, map[interface{}]interface{}{})
+func WriteEngine(buf *bytes.Buffer, stateConfigData, indexesConfigData map[interface{}]interface{}, ordered bool) {
+	config := ast.Parse(stateConfigData, map[interface{}]interface{}{}, map[interface{}]interface{}{}, map[interface{}]interface{}{}, indexesConfigData)
 	s := newStateFactory(config).
+		withOrdering(ordered).
 		writePackageName(). // to be able to format the code without errors
 		writeAdders().
+		writeAdopters().
 		writeAny().
 		writeAnyRefs().
 		writeAssembleTree().
 		writeAssembleTreeElement().
 		writeAssembleTreeReference().
+		writeClone().
 		writeCreators().
 		writeDeleters().
 		writeGetters().
@@ -44,6 +79,19 @@ func WriteEngine(buf *bytes.Buffer, stateConfigData map[interface{}]interface{})
 		writeAllIDsMethod().
 		writeMergeIDs().
 		writeIdentifiers().
+		writeIndex().
+		writeQueries().
+		writeListeners().
+		writeEmitChanges().
+		writeAnyElement().
+		writeElementByPath().
+		writeErrors().
+		writeDuration().
+		writeBigID().
+		writeOrdering().
+		writeMovers().
+		writeSets().
+		writeOptionals().
 		writePathSegments().
 		writePath().
 		writeReference().
@@ -74,6 +122,11 @@ func WriteEngine(buf *bytes.Buffer, stateConfigData map[interface{}]interface{})
 	buf.WriteString(TrimPackageName(s.buf.String()))
 }
 
+func (s *EngineFactory) withOrdering(ordered bool) *EngineFactory {
+	s.ordered = ordered
+	return s
+}
+
 func (s *EngineFactory) writePackageName() *EngineFactory {
 	s.buf.WriteString("package state\n")
 	return s
@@ -5,6 +5,7 @@ package enginefactory
 const _AddPlayer_zone_func string = `func (_zone zone) AddPlayer() player {
 	zone := _zone.zone.engine.Zone(_zone.zone.ID)
 	if zone.zone.OperationKind == OperationKindDelete {
+		zone.zone.engine.recordError("AddPlayer", ElementKindZone, int(_zone.zone.ID), ErrElementDoesNotExist)
 		return player{player: playerCore{OperationKind: OperationKindDelete, engine: zone.zone.engine}}
 	}
 	player := zone.zone.engine.createPlayer(zone.zone.path.players(), true)
@@ -17,6 +18,7 @@ const _AddPlayer_zone_func string = `func (_zone zone) AddPlayer() player {
 const _AddItem_zone_func string = `func (_zone zone) AddItem() zoneItem {
 	zone := _zone.zone.engine.Zone(_zone.zone.ID)
 	if zone.zone.OperationKind == OperationKindDelete {
+		zone.zone.engine.recordError("AddItem", ElementKindZone, int(_zone.zone.ID), ErrElementDoesNotExist)
 		return zoneItem{zoneItem: zoneItemCore{OperationKind: OperationKindDelete, engine: zone.zone.engine}}
 	}
 	zoneItem := zone.zone.engine.createZoneItem(zone.zone.path.items(), true)
@@ -29,6 +31,7 @@ const _AddItem_zone_func string = `func (_zone zone) AddItem() zoneItem {
 const _AddInteractablePlayer_zone_func string = `func (_zone zone) AddInteractablePlayer() player {
 	zone := _zone.zone.engine.Zone(_zone.zone.ID)
 	if zone.zone.OperationKind == OperationKindDelete {
+		zone.zone.engine.recordError("AddInteractablePlayer", ElementKindZone, int(_zone.zone.ID), ErrElementDoesNotExist)
 		return player{player: playerCore{OperationKind: OperationKindDelete, engine: zone.zone.engine}}
 	}
 	player := zone.zone.engine.createPlayer(zone.zone.path.interactables(), true)
@@ -43,6 +46,7 @@ const _AddInteractablePlayer_zone_func string = `func (_zone zone) AddInteractab
 const _AddInteractableZoneItem_zone_func string = `func (_zone zone) AddInteractableZoneItem() zoneItem {
 	zone := _zone.zone.engine.Zone(_zone.zone.ID)
 	if zone.zone.OperationKind == OperationKindDelete {
+		zone.zone.engine.recordError("AddInteractableZoneItem", ElementKindZone, int(_zone.zone.ID), ErrElementDoesNotExist)
 		return zoneItem{zoneItem: zoneItemCore{OperationKind: OperationKindDelete, engine: zone.zone.engine}}
 	}
 	zoneItem := zone.zone.engine.createZoneItem(zone.zone.path.interactables(), true)
@@ -57,6 +61,7 @@ const _AddInteractableZoneItem_zone_func string = `func (_zone zone) AddInteract
 const _AddInteractableItem_zone_func string = `func (_zone zone) AddInteractableItem() item {
 	zone := _zone.zone.engine.Zone(_zone.zone.ID)
 	if zone.zone.OperationKind == OperationKindDelete {
+		zone.zone.engine.recordError("AddInteractableItem", ElementKindZone, int(_zone.zone.ID), ErrElementDoesNotExist)
 		return item{item: itemCore{OperationKind: OperationKindDelete, engine: zone.zone.engine}}
 	}
 	item := zone.zone.engine.createItem(zone.zone.path.interactables(), true)
@@ -71,9 +76,20 @@ const _AddInteractableItem_zone_func string = `func (_zone zone) AddInteractable
 const _AddTags_zone_func string = `func (_zone zone) AddTags(tags ...string) {
 	zone := _zone.zone.engine.Zone(_zone.zone.ID)
 	if zone.zone.OperationKind == OperationKindDelete {
+		zone.zone.engine.recordError("AddTags", ElementKindZone, int(_zone.zone.ID), ErrElementDoesNotExist)
+		return
+	}
+	var wereElementsAltered bool
+	for _, tag := range tags {
+		if zone.zone.indexOfTag(tag) != -1 {
+			continue
+		}
+		zone.zone.Tags = append(zone.zone.Tags, tag)
+		wereElementsAltered = true
+	}
+	if !wereElementsAltered {
 		return
 	}
-	zone.zone.Tags = append(zone.zone.Tags, tags...)
 	zone.zone.OperationKind = OperationKindUpdate
 	zone.zone.engine.Patch.Zone[zone.zone.ID] = zone.zone
 }`
@@ -81,6 +97,7 @@ const _AddTags_zone_func string = `func (_zone zone) AddTags(tags ...string) {
 const _AddItem_player_func string = `func (_player player) AddItem() item {
 	player := _player.player.engine.Player(_player.player.ID)
 	if player.player.OperationKind == OperationKindDelete {
+		player.player.engine.recordError("AddItem", ElementKindPlayer, int(_player.player.ID), ErrElementDoesNotExist)
 		return item{item: itemCore{OperationKind: OperationKindDelete, engine: player.player.engine}}
 	}
 	item := player.player.engine.createItem(player.player.path.items(), true)
@@ -93,9 +110,14 @@ const _AddItem_player_func string = `func (_player player) AddItem() item {
 const _AddGuildMember_player_func string = `func (_player player) AddGuildMember(playerID PlayerID) {
 	player := _player.player.engine.Player(_player.player.ID)
 	if player.player.OperationKind == OperationKindDelete {
+		player.player.engine.recordError("AddGuildMember", ElementKindPlayer, int(_player.player.ID), ErrElementDoesNotExist)
 		return
 	}
 	if player.player.engine.Player(playerID).player.OperationKind == OperationKindDelete {
+		player.player.engine.recordError("AddGuildMember", ElementKindPlayer, int(playerID), ErrElementDoesNotExist)
+		return
+	}
+	if player.player.indexOfGuildMember(playerID) != -1 {
 		return
 	}
 	ref := player.player.engine.createPlayerGuildMemberRef(playerID, player.player.ID)
@@ -107,9 +129,11 @@ const _AddGuildMember_player_func string = `func (_player player) AddGuildMember
 const _AddTargetedByPlayer_player_func string = `func (_player player) AddTargetedByPlayer(playerID PlayerID) {
 	player := _player.player.engine.Player(_player.player.ID)
 	if player.player.OperationKind == OperationKindDelete {
+		player.player.engine.recordError("AddTargetedByPlayer", ElementKindPlayer, int(_player.player.ID), ErrElementDoesNotExist)
 		return
 	}
 	if player.player.engine.Player(playerID).player.OperationKind == OperationKindDelete {
+		player.player.engine.recordError("AddTargetedByPlayer", ElementKindPlayer, int(playerID), ErrElementDoesNotExist)
 		return
 	}
 	anyContainer := player.player.engine.createAnyOfPlayer_ZoneItem(false, nil).anyOfPlayer_ZoneItem
@@ -123,9 +147,11 @@ const _AddTargetedByPlayer_player_func string = `func (_player player) AddTarget
 const _AddTargetedByZoneItem_player_func string = `func (_player player) AddTargetedByZoneItem(zoneItemID ZoneItemID) {
 	player := _player.player.engine.Player(_player.player.ID)
 	if player.player.OperationKind == OperationKindDelete {
+		player.player.engine.recordError("AddTargetedByZoneItem", ElementKindPlayer, int(_player.player.ID), ErrElementDoesNotExist)
 		return
 	}
 	if player.player.engine.ZoneItem(zoneItemID).zoneItem.OperationKind == OperationKindDelete {
+		player.player.engine.recordError("AddTargetedByZoneItem", ElementKindZoneItem, int(zoneItemID), ErrElementDoesNotExist)
 		return
 	}
 	anyContainer := player.player.engine.createAnyOfPlayer_ZoneItem(false, nil).anyOfPlayer_ZoneItem
@@ -139,9 +165,11 @@ const _AddTargetedByZoneItem_player_func string = `func (_player player) AddTarg
 const _AddEquipmentSet_player_func string = `func (_player player) AddEquipmentSet(equipmentSetID EquipmentSetID) {
 	player := _player.player.engine.Player(_player.player.ID)
 	if player.player.OperationKind == OperationKindDelete {
+		player.player.engine.recordError("AddEquipmentSet", ElementKindPlayer, int(_player.player.ID), ErrElementDoesNotExist)
 		return
 	}
 	if player.player.engine.EquipmentSet(equipmentSetID).equipmentSet.OperationKind == OperationKindDelete {
+		player.player.engine.recordError("AddEquipmentSet", ElementKindEquipmentSet, int(equipmentSetID), ErrElementDoesNotExist)
 		return
 	}
 	ref := player.player.engine.createPlayerEquipmentSetRef(equipmentSetID, player.player.ID)
@@ -153,9 +181,11 @@ const _AddEquipmentSet_player_func string = `func (_player player) AddEquipmentS
 const _AddEquipment_equipmentSet_func string = `func (_equipmentSet equipmentSet) AddEquipment(itemID ItemID) {
 	equipmentSet := _equipmentSet.equipmentSet.engine.EquipmentSet(_equipmentSet.equipmentSet.ID)
 	if equipmentSet.equipmentSet.OperationKind == OperationKindDelete {
+		equipmentSet.equipmentSet.engine.recordError("AddEquipment", ElementKindEquipmentSet, int(_equipmentSet.equipmentSet.ID), ErrElementDoesNotExist)
 		return
 	}
 	if equipmentSet.equipmentSet.engine.Item(itemID).item.OperationKind == OperationKindDelete {
+		equipmentSet.equipmentSet.engine.recordError("AddEquipment", ElementKindItem, int(itemID), ErrElementDoesNotExist)
 		return
 	}
 	ref := equipmentSet.equipmentSet.engine.createEquipmentSetEquipmentRef(itemID, equipmentSet.equipmentSet.ID)
@@ -164,6 +194,457 @@ const _AddEquipment_equipmentSet_func string = `func (_equipmentSet equipmentSet
 	equipmentSet.equipmentSet.engine.Patch.EquipmentSet[equipmentSet.equipmentSet.ID] = equipmentSet.equipmentSet
 }`
 
+const setGearScorePath_Engine_func string = `func (engine *Engine) setGearScorePath(gearScoreID GearScoreID, p path, extendWithID bool) {
+	gearScore := engine.GearScore(gearScoreID).gearScore
+	gearScore.HasParent = len(p) > 1
+	gearScore.path = p
+	if extendWithID {
+		gearScore.path = gearScore.path.id(int(gearScore.ID))
+	}
+	gearScore.Path = gearScore.path.toJSONPath()
+	gearScore.OperationKind = OperationKindUpdate
+	engine.Patch.GearScore[gearScore.ID] = gearScore
+}`
+
+const detachGearScore_Engine_func string = `func (engine *Engine) detachGearScore(gearScore gearScoreCore) bool {
+	return !gearScore.HasParent
+}`
+
+const setItemPath_Engine_func string = `func (engine *Engine) setItemPath(itemID ItemID, p path, extendWithID bool) {
+	item := engine.Item(itemID).item
+	item.HasParent = len(p) > 1
+	item.path = p
+	if extendWithID {
+		item.path = item.path.id(int(item.ID))
+	}
+	item.Path = item.path.toJSONPath()
+	item.OperationKind = OperationKindUpdate
+	engine.Patch.Item[item.ID] = item
+	engine.setGearScorePath(item.GearScore, item.path.gearScore(), false)
+	engine.setAnyOfPlayer_PositionPath(item.Origin, item.path.origin(), false)
+}`
+
+const detachItem_Engine_func string = `func (engine *Engine) detachItem(item itemCore) bool {
+	if !item.HasParent {
+		return true
+	}
+	if item.path[len(item.path)-1] < 0 {
+		return false
+	}
+	parentElement, ok := engine.ElementByPath(item.path[:len(item.path)-2].toJSONPath())
+	if !ok {
+		return false
+	}
+	switch {
+	case parentElement.Kind() == ElementKindPlayer && item.path[len(item.path)-2] == itemsIdentifier:
+		parent := parentElement.Player().player
+		var items []ItemID
+		for _, itemID := range parent.Items {
+			if itemID != item.ID {
+				items = append(items, itemID)
+			}
+		}
+		parent.Items = items
+		parent.OperationKind = OperationKindUpdate
+		engine.Patch.Player[parent.ID] = parent
+		return true
+	case parentElement.Kind() == ElementKindZone && item.path[len(item.path)-2] == interactablesIdentifier:
+		return false
+	}
+	return false
+}`
+
+const wasItemDetached_Engine_func string = `func (engine *Engine) wasItemDetached(itemID ItemID, slicePath path) bool {
+	item, ok := engine.Patch.Item[itemID]
+	return ok && item.OperationKind != OperationKindDelete && !item.path.equals(slicePath.id(int(item.ID)))
+}`
+
+const _AdoptGearScore_item_func string = `func (_item item) AdoptGearScore(gearScoreID GearScoreID) gearScore {
+	item := _item.item.engine.Item(_item.item.ID)
+	child := item.item.engine.GearScore(gearScoreID)
+	if item.item.OperationKind == OperationKindDelete {
+		item.item.engine.recordError("AdoptGearScore", ElementKindItem, int(_item.item.ID), ErrElementDoesNotExist)
+		return child
+	}
+	if child.gearScore.OperationKind == OperationKindDelete {
+		item.item.engine.recordError("AdoptGearScore", ElementKindGearScore, int(gearScoreID), ErrElementDoesNotExist)
+		return child
+	}
+	if item.item.path.isWithin(child.gearScore.path) {
+		item.item.engine.recordError("AdoptGearScore", ElementKindGearScore, int(gearScoreID), ErrElementIsAncestor)
+		return child
+	}
+	if !item.item.engine.detachGearScore(child.gearScore) {
+		item.item.engine.recordError("AdoptGearScore", ElementKindGearScore, int(gearScoreID), ErrElementNotDetachable)
+		return child
+	}
+	item = item.item.engine.Item(item.item.ID)
+	item.item.engine.setGearScorePath(item.item.GearScore, newPath(gearScoreIdentifier), true)
+	item.item.engine.deleteGearScore(item.item.GearScore)
+	item.item.engine.setGearScorePath(child.gearScore.ID, item.item.path.gearScore(), false)
+	item.item.GearScore = child.gearScore.ID
+	item.item.OperationKind = OperationKindUpdate
+	item.item.engine.Patch.Item[item.item.ID] = item.item
+	return item.item.engine.GearScore(gearScoreID)
+}`
+
+const setPlayerPath_Engine_func string = `func (engine *Engine) setPlayerPath(playerID PlayerID, p path, extendWithID bool) {
+	player := engine.Player(playerID).player
+	player.HasParent = len(p) > 1
+	player.path = p
+	if extendWithID {
+		player.path = player.path.id(int(player.ID))
+	}
+	player.Path = player.path.toJSONPath()
+	player.OperationKind = OperationKindUpdate
+	engine.Patch.Player[player.ID] = player
+	engine.setGearScorePath(player.GearScore, player.path.gearScore(), false)
+	for _, itemID := range player.Items {
+		engine.setItemPath(itemID, player.path.items(), true)
+	}
+	engine.setPositionPath(player.Position, player.path.position(), false)
+}`
+
+const detachPlayer_Engine_func string = `func (engine *Engine) detachPlayer(player playerCore) bool {
+	if !player.HasParent {
+		return true
+	}
+	if player.path[len(player.path)-1] < 0 {
+		return false
+	}
+	parentElement, ok := engine.ElementByPath(player.path[:len(player.path)-2].toJSONPath())
+	if !ok {
+		return false
+	}
+	switch {
+	case parentElement.Kind() == ElementKindZone && player.path[len(player.path)-2] == playersIdentifier:
+		parent := parentElement.Zone().zone
+		var players []PlayerID
+		for _, playerID := range parent.Players {
+			if playerID != player.ID {
+				players = append(players, playerID)
+			}
+		}
+		parent.Players = players
+		parent.OperationKind = OperationKindUpdate
+		engine.Patch.Zone[parent.ID] = parent
+		return true
+	case parentElement.Kind() == ElementKindZone && player.path[len(player.path)-2] == interactablesIdentifier:
+		return false
+	}
+	return false
+}`
+
+const wasPlayerDetached_Engine_func string = `func (engine *Engine) wasPlayerDetached(playerID PlayerID, slicePath path) bool {
+	player, ok := engine.Patch.Player[playerID]
+	return ok && player.OperationKind != OperationKindDelete && !player.path.equals(slicePath.id(int(player.ID)))
+}`
+
+const _AdoptGearScore_player_func string = `func (_player player) AdoptGearScore(gearScoreID GearScoreID) gearScore {
+	player := _player.player.engine.Player(_player.player.ID)
+	child := player.player.engine.GearScore(gearScoreID)
+	if player.player.OperationKind == OperationKindDelete {
+		player.player.engine.recordError("AdoptGearScore", ElementKindPlayer, int(_player.player.ID), ErrElementDoesNotExist)
+		return child
+	}
+	if child.gearScore.OperationKind == OperationKindDelete {
+		player.player.engine.recordError("AdoptGearScore", ElementKindGearScore, int(gearScoreID), ErrElementDoesNotExist)
+		return child
+	}
+	if player.player.path.isWithin(child.gearScore.path) {
+		player.player.engine.recordError("AdoptGearScore", ElementKindGearScore, int(gearScoreID), ErrElementIsAncestor)
+		return child
+	}
+	if !player.player.engine.detachGearScore(child.gearScore) {
+		player.player.engine.recordError("AdoptGearScore", ElementKindGearScore, int(gearScoreID), ErrElementNotDetachable)
+		return child
+	}
+	player = player.player.engine.Player(player.player.ID)
+	player.player.engine.setGearScorePath(player.player.GearScore, newPath(gearScoreIdentifier), true)
+	player.player.engine.deleteGearScore(player.player.GearScore)
+	player.player.engine.setGearScorePath(child.gearScore.ID, player.player.path.gearScore(), false)
+	player.player.GearScore = child.gearScore.ID
+	player.player.OperationKind = OperationKindUpdate
+	player.player.engine.Patch.Player[player.player.ID] = player.player
+	return player.player.engine.GearScore(gearScoreID)
+}`
+
+const _AdoptItem_player_func string = `func (_player player) AdoptItem(itemID ItemID) item {
+	player := _player.player.engine.Player(_player.player.ID)
+	child := player.player.engine.Item(itemID)
+	if player.player.OperationKind == OperationKindDelete {
+		player.player.engine.recordError("AdoptItem", ElementKindPlayer, int(_player.player.ID), ErrElementDoesNotExist)
+		return child
+	}
+	if child.item.OperationKind == OperationKindDelete {
+		player.player.engine.recordError("AdoptItem", ElementKindItem, int(itemID), ErrElementDoesNotExist)
+		return child
+	}
+	if player.player.path.isWithin(child.item.path) {
+		player.player.engine.recordError("AdoptItem", ElementKindItem, int(itemID), ErrElementIsAncestor)
+		return child
+	}
+	if !player.player.engine.detachItem(child.item) {
+		player.player.engine.recordError("AdoptItem", ElementKindItem, int(itemID), ErrElementNotDetachable)
+		return child
+	}
+	player = player.player.engine.Player(player.player.ID)
+	player.player.engine.setItemPath(child.item.ID, player.player.path.items(), true)
+	player.player.Items = append(player.player.Items, child.item.ID)
+	player.player.OperationKind = OperationKindUpdate
+	player.player.engine.Patch.Player[player.player.ID] = player.player
+	return player.player.engine.Item(itemID)
+}`
+
+const _AdoptPosition_player_func string = `func (_player player) AdoptPosition(positionID PositionID) position {
+	player := _player.player.engine.Player(_player.player.ID)
+	child := player.player.engine.Position(positionID)
+	if player.player.OperationKind == OperationKindDelete {
+		player.player.engine.recordError("AdoptPosition", ElementKindPlayer, int(_player.player.ID), ErrElementDoesNotExist)
+		return child
+	}
+	if child.position.OperationKind == OperationKindDelete {
+		player.player.engine.recordError("AdoptPosition", ElementKindPosition, int(positionID), ErrElementDoesNotExist)
+		return child
+	}
+	if player.player.path.isWithin(child.position.path) {
+		player.player.engine.recordError("AdoptPosition", ElementKindPosition, int(positionID), ErrElementIsAncestor)
+		return child
+	}
+	if !player.player.engine.detachPosition(child.position) {
+		player.player.engine.recordError("AdoptPosition", ElementKindPosition, int(positionID), ErrElementNotDetachable)
+		return child
+	}
+	player = player.player.engine.Player(player.player.ID)
+	player.player.engine.setPositionPath(player.player.Position, newPath(positionIdentifier), true)
+	player.player.engine.deletePosition(player.player.Position)
+	player.player.engine.setPositionPath(child.position.ID, player.player.path.position(), false)
+	player.player.Position = child.position.ID
+	player.player.OperationKind = OperationKindUpdate
+	player.player.engine.Patch.Player[player.player.ID] = player.player
+	return player.player.engine.Position(positionID)
+}`
+
+const setPositionPath_Engine_func string = `func (engine *Engine) setPositionPath(positionID PositionID, p path, extendWithID bool) {
+	position := engine.Position(positionID).position
+	position.HasParent = len(p) > 1
+	position.path = p
+	if extendWithID {
+		position.path = position.path.id(int(position.ID))
+	}
+	position.Path = position.path.toJSONPath()
+	position.OperationKind = OperationKindUpdate
+	engine.Patch.Position[position.ID] = position
+}`
+
+const detachPosition_Engine_func string = `func (engine *Engine) detachPosition(position positionCore) bool {
+	return !position.HasParent
+}`
+
+const _AdoptBoss_zone_func string = `func (_zone zone) AdoptBoss(playerID PlayerID) player {
+	zone := _zone.zone.engine.Zone(_zone.zone.ID)
+	child := zone.zone.engine.Player(playerID)
+	if zone.zone.OperationKind == OperationKindDelete {
+		zone.zone.engine.recordError("AdoptBoss", ElementKindZone, int(_zone.zone.ID), ErrElementDoesNotExist)
+		return child
+	}
+	if child.player.OperationKind == OperationKindDelete {
+		zone.zone.engine.recordError("AdoptBoss", ElementKindPlayer, int(playerID), ErrElementDoesNotExist)
+		return child
+	}
+	if zone.zone.path.isWithin(child.player.path) {
+		zone.zone.engine.recordError("AdoptBoss", ElementKindPlayer, int(playerID), ErrElementIsAncestor)
+		return child
+	}
+	if !zone.zone.engine.detachPlayer(child.player) {
+		zone.zone.engine.recordError("AdoptBoss", ElementKindPlayer, int(playerID), ErrElementNotDetachable)
+		return child
+	}
+	zone = zone.zone.engine.Zone(zone.zone.ID)
+	if zone.zone.Boss != 0 {
+		zone.zone.engine.setPlayerPath(zone.zone.Boss, newPath(playerIdentifier), true)
+		zone.zone.engine.deletePlayer(zone.zone.Boss)
+	}
+	zone.zone.engine.setPlayerPath(child.player.ID, zone.zone.path.boss(), false)
+	zone.zone.Boss = child.player.ID
+	zone.zone.OperationKind = OperationKindUpdate
+	zone.zone.engine.Patch.Zone[zone.zone.ID] = zone.zone
+	return zone.zone.engine.Player(playerID)
+}`
+
+const _AdoptItem_zone_func string = `func (_zone zone) AdoptItem(zoneItemID ZoneItemID) zoneItem {
+	zone := _zone.zone.engine.Zone(_zone.zone.ID)
+	child := zone.zone.engine.ZoneItem(zoneItemID)
+	if zone.zone.OperationKind == OperationKindDelete {
+		zone.zone.engine.recordError("AdoptItem", ElementKindZone, int(_zone.zone.ID), ErrElementDoesNotExist)
+		return child
+	}
+	if child.zoneItem.OperationKind == OperationKindDelete {
+		zone.zone.engine.recordError("AdoptItem", ElementKindZoneItem, int(zoneItemID), ErrElementDoesNotExist)
+		return child
+	}
+	if zone.zone.path.isWithin(child.zoneItem.path) {
+		zone.zone.engine.recordError("AdoptItem", ElementKindZoneItem, int(zoneItemID), ErrElementIsAncestor)
+		return child
+	}
+	if !zone.zone.engine.detachZoneItem(child.zoneItem) {
+		zone.zone.engine.recordError("AdoptItem", ElementKindZoneItem, int(zoneItemID), ErrElementNotDetachable)
+		return child
+	}
+	zone = zone.zone.engine.Zone(zone.zone.ID)
+	zone.zone.engine.setZoneItemPath(child.zoneItem.ID, zone.zone.path.items(), true)
+	zone.zone.Items = append(zone.zone.Items, child.zoneItem.ID)
+	zone.zone.OperationKind = OperationKindUpdate
+	zone.zone.engine.Patch.Zone[zone.zone.ID] = zone.zone
+	return zone.zone.engine.ZoneItem(zoneItemID)
+}`
+
+const _AdoptPlayer_zone_func string = `func (_zone zone) AdoptPlayer(playerID PlayerID) player {
+	zone := _zone.zone.engine.Zone(_zone.zone.ID)
+	child := zone.zone.engine.Player(playerID)
+	if zone.zone.OperationKind == OperationKindDelete {
+		zone.zone.engine.recordError("AdoptPlayer", ElementKindZone, int(_zone.zone.ID), ErrElementDoesNotExist)
+		return child
+	}
+	if child.player.OperationKind == OperationKindDelete {
+		zone.zone.engine.recordError("AdoptPlayer", ElementKindPlayer, int(playerID), ErrElementDoesNotExist)
+		return child
+	}
+	if zone.zone.path.isWithin(child.player.path) {
+		zone.zone.engine.recordError("AdoptPlayer", ElementKindPlayer, int(playerID), ErrElementIsAncestor)
+		return child
+	}
+	if !zone.zone.engine.detachPlayer(child.player) {
+		zone.zone.engine.recordError("AdoptPlayer", ElementKindPlayer, int(playerID), ErrElementNotDetachable)
+		return child
+	}
+	zone = zone.zone.engine.Zone(zone.zone.ID)
+	zone.zone.engine.setPlayerPath(child.player.ID, zone.zone.path.players(), true)
+	zone.zone.Players = append(zone.zone.Players, child.player.ID)
+	zone.zone.OperationKind = OperationKindUpdate
+	zone.zone.engine.Patch.Zone[zone.zone.ID] = zone.zone
+	return zone.zone.engine.Player(playerID)
+}`
+
+const setZoneItemPath_Engine_func string = `func (engine *Engine) setZoneItemPath(zoneItemID ZoneItemID, p path, extendWithID bool) {
+	zoneItem := engine.ZoneItem(zoneItemID).zoneItem
+	zoneItem.HasParent = len(p) > 1
+	zoneItem.path = p
+	if extendWithID {
+		zoneItem.path = zoneItem.path.id(int(zoneItem.ID))
+	}
+	zoneItem.Path = zoneItem.path.toJSONPath()
+	zoneItem.OperationKind = OperationKindUpdate
+	engine.Patch.ZoneItem[zoneItem.ID] = zoneItem
+	engine.setItemPath(zoneItem.Item, zoneItem.path.item(), false)
+	engine.setPositionPath(zoneItem.Position, zoneItem.path.position(), false)
+}`
+
+const detachZoneItem_Engine_func string = `func (engine *Engine) detachZoneItem(zoneItem zoneItemCore) bool {
+	if !zoneItem.HasParent {
+		return true
+	}
+	if zoneItem.path[len(zoneItem.path)-1] < 0 {
+		return false
+	}
+	parentElement, ok := engine.ElementByPath(zoneItem.path[:len(zoneItem.path)-2].toJSONPath())
+	if !ok {
+		return false
+	}
+	switch {
+	case parentElement.Kind() == ElementKindZone && zoneItem.path[len(zoneItem.path)-2] == itemsIdentifier:
+		parent := parentElement.Zone().zone
+		var items []ZoneItemID
+		for _, zoneItemID := range parent.Items {
+			if zoneItemID != zoneItem.ID {
+				items = append(items, zoneItemID)
+			}
+		}
+		parent.Items = items
+		parent.OperationKind = OperationKindUpdate
+		engine.Patch.Zone[parent.ID] = parent
+		return true
+	case parentElement.Kind() == ElementKindZone && zoneItem.path[len(zoneItem.path)-2] == interactablesIdentifier:
+		return false
+	}
+	return false
+}`
+
+const wasZoneItemDetached_Engine_func string = `func (engine *Engine) wasZoneItemDetached(zoneItemID ZoneItemID, slicePath path) bool {
+	zoneItem, ok := engine.Patch.ZoneItem[zoneItemID]
+	return ok && zoneItem.OperationKind != OperationKindDelete && !zoneItem.path.equals(slicePath.id(int(zoneItem.ID)))
+}`
+
+const _AdoptItem_zoneItem_func string = `func (_zoneItem zoneItem) AdoptItem(itemID ItemID) item {
+	zoneItem := _zoneItem.zoneItem.engine.ZoneItem(_zoneItem.zoneItem.ID)
+	child := zoneItem.zoneItem.engine.Item(itemID)
+	if zoneItem.zoneItem.OperationKind == OperationKindDelete {
+		zoneItem.zoneItem.engine.recordError("AdoptItem", ElementKindZoneItem, int(_zoneItem.zoneItem.ID), ErrElementDoesNotExist)
+		return child
+	}
+	if child.item.OperationKind == OperationKindDelete {
+		zoneItem.zoneItem.engine.recordError("AdoptItem", ElementKindItem, int(itemID), ErrElementDoesNotExist)
+		return child
+	}
+	if zoneItem.zoneItem.path.isWithin(child.item.path) {
+		zoneItem.zoneItem.engine.recordError("AdoptItem", ElementKindItem, int(itemID), ErrElementIsAncestor)
+		return child
+	}
+	if !zoneItem.zoneItem.engine.detachItem(child.item) {
+		zoneItem.zoneItem.engine.recordError("AdoptItem", ElementKindItem, int(itemID), ErrElementNotDetachable)
+		return child
+	}
+	zoneItem = zoneItem.zoneItem.engine.ZoneItem(zoneItem.zoneItem.ID)
+	zoneItem.zoneItem.engine.setItemPath(zoneItem.zoneItem.Item, newPath(itemIdentifier), true)
+	zoneItem.zoneItem.engine.deleteItem(zoneItem.zoneItem.Item)
+	zoneItem.zoneItem.engine.setItemPath(child.item.ID, zoneItem.zoneItem.path.item(), false)
+	zoneItem.zoneItem.Item = child.item.ID
+	zoneItem.zoneItem.OperationKind = OperationKindUpdate
+	zoneItem.zoneItem.engine.Patch.ZoneItem[zoneItem.zoneItem.ID] = zoneItem.zoneItem
+	return zoneItem.zoneItem.engine.Item(itemID)
+}`
+
+const _AdoptPosition_zoneItem_func string = `func (_zoneItem zoneItem) AdoptPosition(positionID PositionID) position {
+	zoneItem := _zoneItem.zoneItem.engine.ZoneItem(_zoneItem.zoneItem.ID)
+	child := zoneItem.zoneItem.engine.Position(positionID)
+	if zoneItem.zoneItem.OperationKind == OperationKindDelete {
+		zoneItem.zoneItem.engine.recordError("AdoptPosition", ElementKindZoneItem, int(_zoneItem.zoneItem.ID), ErrElementDoesNotExist)
+		return child
+	}
+	if child.position.OperationKind == OperationKindDelete {
+		zoneItem.zoneItem.engine.recordError("AdoptPosition", ElementKindPosition, int(positionID), ErrElementDoesNotExist)
+		return child
+	}
+	if zoneItem.zoneItem.path.isWithin(child.position.path) {
+		zoneItem.zoneItem.engine.recordError("AdoptPosition", ElementKindPosition, int(positionID), ErrElementIsAncestor)
+		return child
+	}
+	if !zoneItem.zoneItem.engine.detachPosition(child.position) {
+		zoneItem.zoneItem.engine.recordError("AdoptPosition", ElementKindPosition, int(positionID), ErrElementNotDetachable)
+		return child
+	}
+	zoneItem = zoneItem.zoneItem.engine.ZoneItem(zoneItem.zoneItem.ID)
+	zoneItem.zoneItem.engine.setPositionPath(zoneItem.zoneItem.Position, newPath(positionIdentifier), true)
+	zoneItem.zoneItem.engine.deletePosition(zoneItem.zoneItem.Position)
+	zoneItem.zoneItem.engine.setPositionPath(child.position.ID, zoneItem.zoneItem.path.position(), false)
+	zoneItem.zoneItem.Position = child.position.ID
+	zoneItem.zoneItem.OperationKind = OperationKindUpdate
+	zoneItem.zoneItem.engine.Patch.ZoneItem[zoneItem.zoneItem.ID] = zoneItem.zoneItem
+	return zoneItem.zoneItem.engine.Position(positionID)
+}`
+
+const setAnyOfPlayer_PositionPath_Engine_func string = `func (engine *Engine) setAnyOfPlayer_PositionPath(anyOfPlayer_PositionID AnyOfPlayer_PositionID, childElementPath path, extendWithID bool) {
+	any := engine.anyOfPlayer_Position(anyOfPlayer_PositionID).anyOfPlayer_Position
+	any.ChildElementPath = childElementPath
+	engine.Patch.AnyOfPlayer_Position[any.ID] = any
+	switch any.ElementKind {
+	case ElementKindPlayer:
+		engine.setPlayerPath(any.Player, childElementPath, extendWithID)
+	case ElementKindPosition:
+		engine.setPositionPath(any.Position, childElementPath, extendWithID)
+	}
+}`
+
 const anyOfPlayer_PositionRef_type string = `type anyOfPlayer_PositionRef struct {
 	anyOfPlayer_PositionWrapper	anyOfPlayer_Position
 	anyOfPlayer_Position		anyOfPlayer_PositionCore
@@ -526,8 +1007,15 @@ const assembleEquipmentSet_Engine_func string = `func (engine *Engine) assembleE
 			equipmentSet.Equipment[treeEquipmentSetEquipmentRef.ElementID] = treeEquipmentSetEquipmentRef
 		}
 	}
+	if len(equipmentSetData.Equipment) > 1 && (config.forceInclude || wereEquipmentSetEquipmentRefIDsReordered(engine.State.EquipmentSet[equipmentSetData.ID].Equipment, engine.Patch.EquipmentSet[equipmentSetData.ID].Equipment)) {
+		equipmentSet.EquipmentOrder = equipmentSetData.equipmentKeys()
+	}
 	equipmentSet.ID = equipmentSetData.ID
 	equipmentSet.OperationKind = equipmentSetData.OperationKind
+	equipmentSet.Cooldown = equipmentSetData.Cooldown
+	equipmentSet.CreatedAt = equipmentSetData.CreatedAt
+	equipmentSet.ExternalID = equipmentSetData.ExternalID
+	equipmentSet.Icon = equipmentSetData.Icon
 	equipmentSet.Name = equipmentSetData.Name
 	if config.forceInclude {
 		engine.forceIncludeAssembleCache.equipmentSet[equipmentSet.ID] = equipmentSetCacheElement{hasUpdated: hasUpdated, equipmentSet: equipmentSet}
@@ -668,6 +1156,9 @@ const assemblePlayer_Engine_func string = `func (engine *Engine) assemblePlayer(
 			player.EquipmentSets[treePlayerEquipmentSetRef.ElementID] = treePlayerEquipmentSetRef
 		}
 	}
+	if len(playerData.EquipmentSets) > 1 && (config.forceInclude || werePlayerEquipmentSetRefIDsReordered(engine.State.Player[playerData.ID].EquipmentSets, engine.Patch.Player[playerData.ID].EquipmentSets)) {
+		player.EquipmentSetsOrder = playerData.equipmentSetsKeys()
+	}
 	if treeGearScore, include, childHasUpdated := engine.assembleGearScore(playerData.GearScore, check, config); include {
 		if childHasUpdated {
 			hasUpdated = true
@@ -685,7 +1176,20 @@ const assemblePlayer_Engine_func string = `func (engine *Engine) assemblePlayer(
 			player.GuildMembers[treePlayerGuildMemberRef.ElementID] = treePlayerGuildMemberRef
 		}
 	}
+	if len(playerData.GuildMembers) > 1 && (config.forceInclude || werePlayerGuildMemberRefIDsReordered(engine.State.Player[playerData.ID].GuildMembers, engine.Patch.Player[playerData.ID].GuildMembers)) {
+		player.GuildMembersOrder = playerData.guildMembersKeys()
+	}
 	for _, itemID := range mergeItemIDs(engine.State.Player[playerData.ID].Items, engine.Patch.Player[playerData.ID].Items) {
+		if engine.wasItemDetached(itemID, playerData.path.items()) {
+			if !config.forceInclude {
+				if player.Items == nil {
+					player.Items = make(map[ItemID]Item)
+				}
+				player.Items[itemID] = Item{ID: itemID, OperationKind: OperationKindDelete}
+				hasUpdated = true
+			}
+			continue
+		}
 		if treeItem, include, childHasUpdated := engine.assembleItem(itemID, check, config); include {
 			if childHasUpdated {
 				hasUpdated = true
@@ -696,6 +1200,9 @@ const assemblePlayer_Engine_func string = `func (engine *Engine) assemblePlayer(
 			player.Items[treeItem.ID] = treeItem
 		}
 	}
+	if len(playerData.Items) > 1 && (config.forceInclude || wereItemIDsReordered(engine.State.Player[playerData.ID].Items, engine.Patch.Player[playerData.ID].Items)) {
+		player.ItemsOrder = playerData.itemsKeys()
+	}
 	if treePosition, include, childHasUpdated := engine.assemblePosition(playerData.Position, check, config); include {
 		if childHasUpdated {
 			hasUpdated = true
@@ -719,6 +1226,9 @@ const assemblePlayer_Engine_func string = `func (engine *Engine) assemblePlayer(
 			player.TargetedBy[treePlayerTargetedByRef.ElementID] = treePlayerTargetedByRef
 		}
 	}
+	if len(playerData.TargetedBy) > 1 && (config.forceInclude || werePlayerTargetedByRefIDsReordered(engine.State.Player[playerData.ID].TargetedBy, engine.Patch.Player[playerData.ID].TargetedBy)) {
+		player.TargetedByOrder = playerData.targetedByKeys()
+	}
 	player.ID = playerData.ID
 	player.OperationKind = playerData.OperationKind
 	if config.forceInclude {
@@ -748,6 +1258,18 @@ const assembleZone_Engine_func string = `func (engine *Engine) assembleZone(zone
 		return cachedZone.zone, cachedZone.hasUpdated || config.forceInclude, cachedZone.hasUpdated
 	}
 	var zone Zone
+	bossID := zoneData.Boss
+	if bossID == 0 && !config.forceInclude {
+		bossID = engine.State.Zone[zoneData.ID].Boss
+	}
+	if bossID != 0 {
+		if treePlayer, include, childHasUpdated := engine.assemblePlayer(bossID, check, config); include {
+			if childHasUpdated {
+				hasUpdated = true
+			}
+			zone.Boss = &treePlayer
+		}
+	}
 	for _, anyOfItem_Player_ZoneItemID := range mergeAnyOfItem_Player_ZoneItemIDs(engine.State.Zone[zoneData.ID].Interactables, engine.Patch.Zone[zoneData.ID].Interactables) {
 		anyOfItem_Player_ZoneItemContainer := engine.anyOfItem_Player_ZoneItem(anyOfItem_Player_ZoneItemID).anyOfItem_Player_ZoneItem
 		if anyOfItem_Player_ZoneItemContainer.ElementKind == ElementKindItem {
@@ -785,7 +1307,20 @@ const assembleZone_Engine_func string = `func (engine *Engine) assembleZone(zone
 			}
 		}
 	}
+	if len(zoneData.Interactables) > 1 && (config.forceInclude || wereAnyOfItem_Player_ZoneItemIDsReordered(engine.State.Zone[zoneData.ID].Interactables, engine.Patch.Zone[zoneData.ID].Interactables)) {
+		zone.InteractablesOrder = zoneData.interactablesKeys()
+	}
 	for _, zoneItemID := range mergeZoneItemIDs(engine.State.Zone[zoneData.ID].Items, engine.Patch.Zone[zoneData.ID].Items) {
+		if engine.wasZoneItemDetached(zoneItemID, zoneData.path.items()) {
+			if !config.forceInclude {
+				if zone.Items == nil {
+					zone.Items = make(map[ZoneItemID]ZoneItem)
+				}
+				zone.Items[zoneItemID] = ZoneItem{ID: zoneItemID, OperationKind: OperationKindDelete}
+				hasUpdated = true
+			}
+			continue
+		}
 		if treeZoneItem, include, childHasUpdated := engine.assembleZoneItem(zoneItemID, check, config); include {
 			if childHasUpdated {
 				hasUpdated = true
@@ -796,7 +1331,20 @@ const assembleZone_Engine_func string = `func (engine *Engine) assembleZone(zone
 			zone.Items[treeZoneItem.ID] = treeZoneItem
 		}
 	}
+	if len(zoneData.Items) > 1 && (config.forceInclude || wereZoneItemIDsReordered(engine.State.Zone[zoneData.ID].Items, engine.Patch.Zone[zoneData.ID].Items)) {
+		zone.ItemsOrder = zoneData.itemsKeys()
+	}
 	for _, playerID := range mergePlayerIDs(engine.State.Zone[zoneData.ID].Players, engine.Patch.Zone[zoneData.ID].Players) {
+		if engine.wasPlayerDetached(playerID, zoneData.path.players()) {
+			if !config.forceInclude {
+				if zone.Players == nil {
+					zone.Players = make(map[PlayerID]Player)
+				}
+				zone.Players[playerID] = Player{ID: playerID, OperationKind: OperationKindDelete}
+				hasUpdated = true
+			}
+			continue
+		}
 		if treePlayer, include, childHasUpdated := engine.assemblePlayer(playerID, check, config); include {
 			if childHasUpdated {
 				hasUpdated = true
@@ -807,6 +1355,9 @@ const assembleZone_Engine_func string = `func (engine *Engine) assembleZone(zone
 			zone.Players[treePlayer.ID] = treePlayer
 		}
 	}
+	if len(zoneData.Players) > 1 && (config.forceInclude || werePlayerIDsReordered(engine.State.Zone[zoneData.ID].Players, engine.Patch.Zone[zoneData.ID].Players)) {
+		zone.PlayersOrder = zoneData.playersKeys()
+	}
 	zone.ID = zoneData.ID
 	zone.OperationKind = zoneData.OperationKind
 	zone.Tags = zoneData.Tags
@@ -1374,6 +1925,12 @@ const assembleTree_Engine_func string = `func (engine *Engine) assembleTree(asse
 	for _, gearScoreData := range engine.State.GearScore {
 		if !gearScoreData.HasParent {
 			if _, ok := engine.Tree.GearScore[gearScoreData.ID]; !ok {
+				if engine.Patch.GearScore[gearScoreData.ID].HasParent {
+					if !assembleEntireTree {
+						engine.Tree.GearScore[gearScoreData.ID] = GearScore{ID: gearScoreData.ID, OperationKind: OperationKindDelete}
+					}
+					continue
+				}
 				gearScore, include, _ := engine.assembleGearScore(gearScoreData.ID, nil, config)
 				if include {
 					engine.Tree.GearScore[gearScoreData.ID] = gearScore
@@ -1384,6 +1941,12 @@ const assembleTree_Engine_func string = `func (engine *Engine) assembleTree(asse
 	for _, itemData := range engine.State.Item {
 		if !itemData.HasParent {
 			if _, ok := engine.Tree.Item[itemData.ID]; !ok {
+				if engine.Patch.Item[itemData.ID].HasParent {
+					if !assembleEntireTree {
+						engine.Tree.Item[itemData.ID] = Item{ID: itemData.ID, OperationKind: OperationKindDelete}
+					}
+					continue
+				}
 				item, include, _ := engine.assembleItem(itemData.ID, nil, config)
 				if include {
 					engine.Tree.Item[itemData.ID] = item
@@ -1394,6 +1957,12 @@ const assembleTree_Engine_func string = `func (engine *Engine) assembleTree(asse
 	for _, playerData := range engine.State.Player {
 		if !playerData.HasParent {
 			if _, ok := engine.Tree.Player[playerData.ID]; !ok {
+				if engine.Patch.Player[playerData.ID].HasParent {
+					if !assembleEntireTree {
+						engine.Tree.Player[playerData.ID] = Player{ID: playerData.ID, OperationKind: OperationKindDelete}
+					}
+					continue
+				}
 				player, include, _ := engine.assemblePlayer(playerData.ID, nil, config)
 				if include {
 					engine.Tree.Player[playerData.ID] = player
@@ -1404,6 +1973,12 @@ const assembleTree_Engine_func string = `func (engine *Engine) assembleTree(asse
 	for _, positionData := range engine.State.Position {
 		if !positionData.HasParent {
 			if _, ok := engine.Tree.Position[positionData.ID]; !ok {
+				if engine.Patch.Position[positionData.ID].HasParent {
+					if !assembleEntireTree {
+						engine.Tree.Position[positionData.ID] = Position{ID: positionData.ID, OperationKind: OperationKindDelete}
+					}
+					continue
+				}
 				position, include, _ := engine.assemblePosition(positionData.ID, nil, config)
 				if include {
 					engine.Tree.Position[positionData.ID] = position
@@ -1424,6 +1999,12 @@ const assembleTree_Engine_func string = `func (engine *Engine) assembleTree(asse
 	for _, zoneItemData := range engine.State.ZoneItem {
 		if !zoneItemData.HasParent {
 			if _, ok := engine.Tree.ZoneItem[zoneItemData.ID]; !ok {
+				if engine.Patch.ZoneItem[zoneItemData.ID].HasParent {
+					if !assembleEntireTree {
+						engine.Tree.ZoneItem[zoneItemData.ID] = ZoneItem{ID: zoneItemData.ID, OperationKind: OperationKindDelete}
+					}
+					continue
+				}
 				zoneItem, include, _ := engine.assembleZoneItem(zoneItemData.ID, nil, config)
 				if include {
 					engine.Tree.ZoneItem[zoneItemData.ID] = zoneItem
@@ -1434,87 +2015,286 @@ const assembleTree_Engine_func string = `func (engine *Engine) assembleTree(asse
 	return engine.Tree
 }`
 
-const _CreateEquipmentSet_Engine_func string = `func (engine *Engine) CreateEquipmentSet() equipmentSet {
-	return engine.createEquipmentSet(newPath(equipmentSetIdentifier), true)
-}`
+const big_id_go_import string = `import (
+	"strconv"
+)`
 
-const createEquipmentSet_Engine_func string = `func (engine *Engine) createEquipmentSet(p path, extendWithID bool) equipmentSet {
-	var element equipmentSetCore
-	element.engine = engine
-	element.ID = EquipmentSetID(engine.GenerateID())
-	element.OperationKind = OperationKindUpdate
-	element.HasParent = len(p) > 1
-	element.path = p
-	if extendWithID {
-		element.path = element.path.id(int(element.ID))
-	}
-	element.Path = element.path.toJSONPath()
-	engine.Patch.EquipmentSet[element.ID] = element
-	return equipmentSet{equipmentSet: element}
+const _BigID_type string = `type BigID uint64`
+
+const _MarshalJSON_BigID_func string = `func (id BigID) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(strconv.FormatUint(uint64(id), 10))), nil
 }`
 
-const _CreateGearScore_Engine_func string = `func (engine *Engine) CreateGearScore() gearScore {
-	return engine.createGearScore(newPath(gearScoreIdentifier), true)
+const _UnmarshalJSON_BigID_func string = `func (id *BigID) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	unquoted, err := strconv.Unquote(string(data))
+	if err != nil {
+		return err
+	}
+	value, err := strconv.ParseUint(unquoted, 10, 64)
+	if err != nil {
+		return err
+	}
+	*id = BigID(value)
+	return nil
 }`
 
-const createGearScore_Engine_func string = `func (engine *Engine) createGearScore(p path, extendWithID bool) gearScore {
-	var element gearScoreCore
-	element.engine = engine
-	element.ID = GearScoreID(engine.GenerateID())
-	element.OperationKind = OperationKindUpdate
-	element.HasParent = len(p) > 1
-	element.path = p
-	if extendWithID {
-		element.path = element.path.id(int(element.ID))
+const _Clone_equipmentSet_func string = `func (_equipmentSet equipmentSet) Clone() equipmentSet {
+	equipmentSet := _equipmentSet.equipmentSet.engine.EquipmentSet(_equipmentSet.equipmentSet.ID)
+	if equipmentSet.equipmentSet.OperationKind == OperationKindDelete {
+		equipmentSet.equipmentSet.engine.recordError("Clone", ElementKindEquipmentSet, int(_equipmentSet.equipmentSet.ID), ErrElementDoesNotExist)
+		return equipmentSet
 	}
-	element.Path = element.path.toJSONPath()
-	engine.Patch.GearScore[element.ID] = element
-	return gearScore{gearScore: element}
+	clone := equipmentSet.equipmentSet.engine.CreateEquipmentSet()
+	equipmentSet.equipmentSet.engine.copyEquipmentSet(clone, equipmentSet)
+	return clone
 }`
 
-const _CreatePosition_Engine_func string = `func (engine *Engine) CreatePosition() position {
-	return engine.createPosition(newPath(positionIdentifier), true)
+const copyEquipmentSet_Engine_func string = `func (engine *Engine) copyEquipmentSet(dst, src equipmentSet) {
+	dst.SetCooldown(src.Cooldown())
+	dst.SetCreatedAt(src.CreatedAt())
+	for _, equipment := range src.Equipment() {
+		dst.AddEquipment(equipment.ID())
+	}
+	dst.SetExternalID(src.ExternalID())
+	dst.SetIcon(src.Icon())
+	dst.SetName(src.Name())
 }`
 
-const createPosition_Engine_func string = `func (engine *Engine) createPosition(p path, extendWithID bool) position {
-	var element positionCore
-	element.engine = engine
-	element.ID = PositionID(engine.GenerateID())
-	element.OperationKind = OperationKindUpdate
-	element.HasParent = len(p) > 1
-	element.path = p
-	if extendWithID {
-		element.path = element.path.id(int(element.ID))
+const _Clone_gearScore_func string = `func (_gearScore gearScore) Clone() gearScore {
+	gearScore := _gearScore.gearScore.engine.GearScore(_gearScore.gearScore.ID)
+	if gearScore.gearScore.OperationKind == OperationKindDelete {
+		gearScore.gearScore.engine.recordError("Clone", ElementKindGearScore, int(_gearScore.gearScore.ID), ErrElementDoesNotExist)
+		return gearScore
 	}
-	element.Path = element.path.toJSONPath()
-	engine.Patch.Position[element.ID] = element
-	return position{position: element}
+	clone := gearScore.gearScore.engine.CreateGearScore()
+	gearScore.gearScore.engine.copyGearScore(clone, gearScore)
+	return clone
 }`
 
-const _CreateItem_Engine_func string = `func (engine *Engine) CreateItem() item {
-	return engine.createItem(newPath(itemIdentifier), true)
+const copyGearScore_Engine_func string = `func (engine *Engine) copyGearScore(dst, src gearScore) {
+	dst.SetLevel(src.Level())
+	dst.SetScore(src.Score())
 }`
 
-const createItem_Engine_func string = `func (engine *Engine) createItem(p path, extendWithID bool) item {
-	var element itemCore
-	element.engine = engine
-	element.ID = ItemID(engine.GenerateID())
-	elementGearScore := engine.createGearScore(p.gearScore(), false)
-	element.GearScore = elementGearScore.gearScore.ID
-	elementOrigin := engine.createAnyOfPlayer_Position(true, p.origin())
-	element.Origin = elementOrigin.anyOfPlayer_Position.ID
-	element.OperationKind = OperationKindUpdate
-	element.HasParent = len(p) > 1
-	element.path = p
-	if extendWithID {
-		element.path = element.path.id(int(element.ID))
+const _Clone_item_func string = `func (_item item) Clone() item {
+	item := _item.item.engine.Item(_item.item.ID)
+	if item.item.OperationKind == OperationKindDelete {
+		item.item.engine.recordError("Clone", ElementKindItem, int(_item.item.ID), ErrElementDoesNotExist)
+		return item
 	}
-	element.Path = element.path.toJSONPath()
-	engine.Patch.Item[element.ID] = element
-	return item{item: element}
+	clone := item.item.engine.CreateItem()
+	item.item.engine.copyItem(clone, item)
+	return clone
 }`
 
-const _CreateZoneItem_Engine_func string = `func (engine *Engine) CreateZoneItem() zoneItem {
+const copyItem_Engine_func string = `func (engine *Engine) copyItem(dst, src item) {
+	if boundTo, ok := src.BoundTo(); ok {
+		dst.SetBoundTo(boundTo.ID())
+	}
+	engine.copyGearScore(dst.GearScore(), src.GearScore())
+	dst.SetName(src.Name())
+	origin := src.Origin()
+	switch origin.Kind() {
+	case ElementKindPlayer:
+		engine.copyPlayer(dst.Origin().SetPlayer(), origin.Player())
+	case ElementKindPosition:
+		engine.copyPosition(dst.Origin().SetPosition(), origin.Position())
+	}
+}`
+
+const _Clone_player_func string = `func (_player player) Clone() player {
+	player := _player.player.engine.Player(_player.player.ID)
+	if player.player.OperationKind == OperationKindDelete {
+		player.player.engine.recordError("Clone", ElementKindPlayer, int(_player.player.ID), ErrElementDoesNotExist)
+		return player
+	}
+	clone := player.player.engine.CreatePlayer()
+	player.player.engine.copyPlayer(clone, player)
+	return clone
+}`
+
+const copyPlayer_Engine_func string = `func (engine *Engine) copyPlayer(dst, src player) {
+	for _, equipmentSet := range src.EquipmentSets() {
+		dst.AddEquipmentSet(equipmentSet.ID())
+	}
+	engine.copyGearScore(dst.GearScore(), src.GearScore())
+	for _, guildMember := range src.GuildMembers() {
+		dst.AddGuildMember(guildMember.ID())
+	}
+	for _, item := range src.Items() {
+		engine.copyItem(dst.AddItem(), item)
+	}
+	engine.copyPosition(dst.Position(), src.Position())
+	if target, ok := src.Target(); ok {
+		switch target.Get().Kind() {
+		case ElementKindPlayer:
+			dst.SetTargetPlayer(target.Get().Player().ID())
+		case ElementKindZoneItem:
+			dst.SetTargetZoneItem(target.Get().ZoneItem().ID())
+		}
+	}
+	for _, targetedBy := range src.TargetedBy() {
+		switch targetedBy.Get().Kind() {
+		case ElementKindPlayer:
+			dst.AddTargetedByPlayer(targetedBy.Get().Player().ID())
+		case ElementKindZoneItem:
+			dst.AddTargetedByZoneItem(targetedBy.Get().ZoneItem().ID())
+		}
+	}
+}`
+
+const _Clone_position_func string = `func (_position position) Clone() position {
+	position := _position.position.engine.Position(_position.position.ID)
+	if position.position.OperationKind == OperationKindDelete {
+		position.position.engine.recordError("Clone", ElementKindPosition, int(_position.position.ID), ErrElementDoesNotExist)
+		return position
+	}
+	clone := position.position.engine.CreatePosition()
+	position.position.engine.copyPosition(clone, position)
+	return clone
+}`
+
+const copyPosition_Engine_func string = `func (engine *Engine) copyPosition(dst, src position) {
+	dst.SetX(src.X())
+	dst.SetY(src.Y())
+}`
+
+const _Clone_zone_func string = `func (_zone zone) Clone() zone {
+	zone := _zone.zone.engine.Zone(_zone.zone.ID)
+	if zone.zone.OperationKind == OperationKindDelete {
+		zone.zone.engine.recordError("Clone", ElementKindZone, int(_zone.zone.ID), ErrElementDoesNotExist)
+		return zone
+	}
+	clone := zone.zone.engine.CreateZone()
+	zone.zone.engine.copyZone(clone, zone)
+	return clone
+}`
+
+const copyZone_Engine_func string = `func (engine *Engine) copyZone(dst, src zone) {
+	if boss, ok := src.Boss(); ok {
+		engine.copyPlayer(dst.SetBoss(), boss)
+	}
+	for _, interactable := range src.Interactables() {
+		switch interactable.Kind() {
+		case ElementKindItem:
+			engine.copyItem(dst.AddInteractableItem(), interactable.Item())
+		case ElementKindPlayer:
+			engine.copyPlayer(dst.AddInteractablePlayer(), interactable.Player())
+		case ElementKindZoneItem:
+			engine.copyZoneItem(dst.AddInteractableZoneItem(), interactable.ZoneItem())
+		}
+	}
+	for _, item := range src.Items() {
+		engine.copyZoneItem(dst.AddItem(), item)
+	}
+	for _, player := range src.Players() {
+		engine.copyPlayer(dst.AddPlayer(), player)
+	}
+	dst.AddTags(src.Tags()...)
+}`
+
+const _Clone_zoneItem_func string = `func (_zoneItem zoneItem) Clone() zoneItem {
+	zoneItem := _zoneItem.zoneItem.engine.ZoneItem(_zoneItem.zoneItem.ID)
+	if zoneItem.zoneItem.OperationKind == OperationKindDelete {
+		zoneItem.zoneItem.engine.recordError("Clone", ElementKindZoneItem, int(_zoneItem.zoneItem.ID), ErrElementDoesNotExist)
+		return zoneItem
+	}
+	clone := zoneItem.zoneItem.engine.CreateZoneItem()
+	zoneItem.zoneItem.engine.copyZoneItem(clone, zoneItem)
+	return clone
+}`
+
+const copyZoneItem_Engine_func string = `func (engine *Engine) copyZoneItem(dst, src zoneItem) {
+	engine.copyItem(dst.Item(), src.Item())
+	engine.copyPosition(dst.Position(), src.Position())
+}`
+
+const _CreateEquipmentSet_Engine_func string = `func (engine *Engine) CreateEquipmentSet() equipmentSet {
+	return engine.createEquipmentSet(newPath(equipmentSetIdentifier), true)
+}`
+
+const createEquipmentSet_Engine_func string = `func (engine *Engine) createEquipmentSet(p path, extendWithID bool) equipmentSet {
+	var element equipmentSetCore
+	element.engine = engine
+	element.ID = EquipmentSetID(engine.GenerateID())
+	element.HasParent = len(p) > 1
+	element.path = p
+	if extendWithID {
+		element.path = element.path.id(int(element.ID))
+	}
+	element.Path = element.path.toJSONPath()
+	element.OperationKind = OperationKindUpdate
+	engine.Patch.EquipmentSet[element.ID] = element
+	return equipmentSet{equipmentSet: element}
+}`
+
+const _CreateGearScore_Engine_func string = `func (engine *Engine) CreateGearScore() gearScore {
+	return engine.createGearScore(newPath(gearScoreIdentifier), true)
+}`
+
+const createGearScore_Engine_func string = `func (engine *Engine) createGearScore(p path, extendWithID bool) gearScore {
+	var element gearScoreCore
+	element.engine = engine
+	element.ID = GearScoreID(engine.GenerateID())
+	element.HasParent = len(p) > 1
+	element.path = p
+	if extendWithID {
+		element.path = element.path.id(int(element.ID))
+	}
+	element.Path = element.path.toJSONPath()
+	element.OperationKind = OperationKindUpdate
+	engine.Patch.GearScore[element.ID] = element
+	engine.indexGearScoreScore(element.ID, element.Score)
+	return gearScore{gearScore: element}
+}`
+
+const _CreatePosition_Engine_func string = `func (engine *Engine) CreatePosition() position {
+	return engine.createPosition(newPath(positionIdentifier), true)
+}`
+
+const createPosition_Engine_func string = `func (engine *Engine) createPosition(p path, extendWithID bool) position {
+	var element positionCore
+	element.engine = engine
+	element.ID = PositionID(engine.GenerateID())
+	element.HasParent = len(p) > 1
+	element.path = p
+	if extendWithID {
+		element.path = element.path.id(int(element.ID))
+	}
+	element.Path = element.path.toJSONPath()
+	element.OperationKind = OperationKindUpdate
+	engine.Patch.Position[element.ID] = element
+	return position{position: element}
+}`
+
+const _CreateItem_Engine_func string = `func (engine *Engine) CreateItem() item {
+	return engine.createItem(newPath(itemIdentifier), true)
+}`
+
+const createItem_Engine_func string = `func (engine *Engine) createItem(p path, extendWithID bool) item {
+	var element itemCore
+	element.engine = engine
+	element.ID = ItemID(engine.GenerateID())
+	element.HasParent = len(p) > 1
+	element.path = p
+	if extendWithID {
+		element.path = element.path.id(int(element.ID))
+	}
+	element.Path = element.path.toJSONPath()
+	elementGearScore := engine.createGearScore(element.path.gearScore(), false)
+	element.GearScore = elementGearScore.gearScore.ID
+	elementOrigin := engine.createAnyOfPlayer_Position(true, element.path.origin())
+	element.Origin = elementOrigin.anyOfPlayer_Position.ID
+	element.OperationKind = OperationKindUpdate
+	engine.Patch.Item[element.ID] = element
+	engine.indexItemName(element.ID, element.Name)
+	return item{item: element}
+}`
+
+const _CreateZoneItem_Engine_func string = `func (engine *Engine) CreateZoneItem() zoneItem {
 	return engine.createZoneItem(newPath(zoneItemIdentifier), true)
 }`
 
@@ -1522,17 +2302,17 @@ const createZoneItem_Engine_func string = `func (engine *Engine) createZoneItem(
 	var element zoneItemCore
 	element.engine = engine
 	element.ID = ZoneItemID(engine.GenerateID())
-	elementItem := engine.createItem(p.item(), false)
-	element.Item = elementItem.item.ID
-	elementPosition := engine.createPosition(p.position(), false)
-	element.Position = elementPosition.position.ID
-	element.OperationKind = OperationKindUpdate
 	element.HasParent = len(p) > 1
 	element.path = p
 	if extendWithID {
 		element.path = element.path.id(int(element.ID))
 	}
 	element.Path = element.path.toJSONPath()
+	elementItem := engine.createItem(element.path.item(), false)
+	element.Item = elementItem.item.ID
+	elementPosition := engine.createPosition(element.path.position(), false)
+	element.Position = elementPosition.position.ID
+	element.OperationKind = OperationKindUpdate
 	engine.Patch.ZoneItem[element.ID] = element
 	return zoneItem{zoneItem: element}
 }`
@@ -1545,17 +2325,17 @@ const createPlayer_Engine_func string = `func (engine *Engine) createPlayer(p pa
 	var element playerCore
 	element.engine = engine
 	element.ID = PlayerID(engine.GenerateID())
-	elementGearScore := engine.createGearScore(p.gearScore(), false)
-	element.GearScore = elementGearScore.gearScore.ID
-	elementPosition := engine.createPosition(p.position(), false)
-	element.Position = elementPosition.position.ID
-	element.OperationKind = OperationKindUpdate
 	element.HasParent = len(p) > 1
 	element.path = p
 	if extendWithID {
 		element.path = element.path.id(int(element.ID))
 	}
 	element.Path = element.path.toJSONPath()
+	elementGearScore := engine.createGearScore(element.path.gearScore(), false)
+	element.GearScore = elementGearScore.gearScore.ID
+	elementPosition := engine.createPosition(element.path.position(), false)
+	element.Position = elementPosition.position.ID
+	element.OperationKind = OperationKindUpdate
 	engine.Patch.Player[element.ID] = element
 	return player{player: element}
 }`
@@ -1568,13 +2348,13 @@ const createZone_Engine_func string = `func (engine *Engine) createZone(p path,
 	var element zoneCore
 	element.engine = engine
 	element.ID = ZoneID(engine.GenerateID())
-	element.OperationKind = OperationKindUpdate
 	element.HasParent = len(p) > 1
 	element.path = p
 	if extendWithID {
 		element.path = element.path.id(int(element.ID))
 	}
 	element.Path = element.path.toJSONPath()
+	element.OperationKind = OperationKindUpdate
 	engine.Patch.Zone[element.ID] = element
 	return zone{zone: element}
 }`
@@ -1692,7 +2472,12 @@ const createAnyOfItem_Player_ZoneItem_Engine_func string = `func (engine *Engine
 
 const _DeletePlayer_Engine_func string = `func (engine *Engine) DeletePlayer(playerID PlayerID) {
 	player := engine.Player(playerID).player
+	if player.OperationKind == OperationKindDelete {
+		engine.recordError("DeletePlayer", ElementKindPlayer, int(playerID), ErrElementDoesNotExist)
+		return
+	}
 	if player.HasParent {
+		engine.recordError("DeletePlayer", ElementKindPlayer, int(playerID), ErrElementHasParent)
 		return
 	}
 	engine.deletePlayer(playerID)
@@ -1729,7 +2514,12 @@ const deletePlayer_Engine_func string = `func (engine *Engine) deletePlayer(play
 
 const _DeleteGearScore_Engine_func string = `func (engine *Engine) DeleteGearScore(gearScoreID GearScoreID) {
 	gearScore := engine.GearScore(gearScoreID).gearScore
+	if gearScore.OperationKind == OperationKindDelete {
+		engine.recordError("DeleteGearScore", ElementKindGearScore, int(gearScoreID), ErrElementDoesNotExist)
+		return
+	}
 	if gearScore.HasParent {
+		engine.recordError("DeleteGearScore", ElementKindGearScore, int(gearScoreID), ErrElementHasParent)
 		return
 	}
 	engine.deleteGearScore(gearScoreID)
@@ -1737,6 +2527,7 @@ const _DeleteGearScore_Engine_func string = `func (engine *Engine) DeleteGearSco
 
 const deleteGearScore_Engine_func string = `func (engine *Engine) deleteGearScore(gearScoreID GearScoreID) {
 	gearScore := engine.GearScore(gearScoreID).gearScore
+	engine.unindexGearScoreScore(gearScoreID, gearScore.Score)
 	if _, ok := engine.State.GearScore[gearScoreID]; ok {
 		gearScore.OperationKind = OperationKindDelete
 		engine.Patch.GearScore[gearScore.ID] = gearScore
@@ -1747,7 +2538,12 @@ const deleteGearScore_Engine_func string = `func (engine *Engine) deleteGearScor
 
 const _DeletePosition_Engine_func string = `func (engine *Engine) DeletePosition(positionID PositionID) {
 	position := engine.Position(positionID).position
+	if position.OperationKind == OperationKindDelete {
+		engine.recordError("DeletePosition", ElementKindPosition, int(positionID), ErrElementDoesNotExist)
+		return
+	}
 	if position.HasParent {
+		engine.recordError("DeletePosition", ElementKindPosition, int(positionID), ErrElementHasParent)
 		return
 	}
 	engine.deletePosition(positionID)
@@ -1765,7 +2561,12 @@ const deletePosition_Engine_func string = `func (engine *Engine) deletePosition(
 
 const _DeleteItem_Engine_func string = `func (engine *Engine) DeleteItem(itemID ItemID) {
 	item := engine.Item(itemID).item
+	if item.OperationKind == OperationKindDelete {
+		engine.recordError("DeleteItem", ElementKindItem, int(itemID), ErrElementDoesNotExist)
+		return
+	}
 	if item.HasParent {
+		engine.recordError("DeleteItem", ElementKindItem, int(itemID), ErrElementHasParent)
 		return
 	}
 	engine.deleteItem(itemID)
@@ -1773,6 +2574,7 @@ const _DeleteItem_Engine_func string = `func (engine *Engine) DeleteItem(itemID
 
 const deleteItem_Engine_func string = `func (engine *Engine) deleteItem(itemID ItemID) {
 	item := engine.Item(itemID).item
+	engine.unindexItemName(itemID, item.Name)
 	engine.dereferenceEquipmentSetEquipmentRefs(itemID)
 	engine.deleteItemBoundToRef(item.BoundTo)
 	engine.deleteGearScore(item.GearScore)
@@ -1787,7 +2589,12 @@ const deleteItem_Engine_func string = `func (engine *Engine) deleteItem(itemID I
 
 const _DeleteZoneItem_Engine_func string = `func (engine *Engine) DeleteZoneItem(zoneItemID ZoneItemID) {
 	zoneItem := engine.ZoneItem(zoneItemID).zoneItem
+	if zoneItem.OperationKind == OperationKindDelete {
+		engine.recordError("DeleteZoneItem", ElementKindZoneItem, int(zoneItemID), ErrElementDoesNotExist)
+		return
+	}
 	if zoneItem.HasParent {
+		engine.recordError("DeleteZoneItem", ElementKindZoneItem, int(zoneItemID), ErrElementHasParent)
 		return
 	}
 	engine.deleteZoneItem(zoneItemID)
@@ -1808,11 +2615,19 @@ const deleteZoneItem_Engine_func string = `func (engine *Engine) deleteZoneItem(
 }`
 
 const _DeleteZone_Engine_func string = `func (engine *Engine) DeleteZone(zoneID ZoneID) {
+	zone := engine.Zone(zoneID).zone
+	if zone.OperationKind == OperationKindDelete {
+		engine.recordError("DeleteZone", ElementKindZone, int(zoneID), ErrElementDoesNotExist)
+		return
+	}
 	engine.deleteZone(zoneID)
 }`
 
 const deleteZone_Engine_func string = `func (engine *Engine) deleteZone(zoneID ZoneID) {
 	zone := engine.Zone(zoneID).zone
+	if zone.Boss != 0 {
+		engine.deletePlayer(zone.Boss)
+	}
 	for _, interactableID := range zone.Interactables {
 		engine.deleteAnyOfItem_Player_ZoneItem(interactableID, true)
 	}
@@ -1831,6 +2646,11 @@ const deleteZone_Engine_func string = `func (engine *Engine) deleteZone(zoneID Z
 }`
 
 const _DeleteEquipmentSet_Engine_func string = `func (engine *Engine) DeleteEquipmentSet(equipmentSetID EquipmentSetID) {
+	equipmentSet := engine.EquipmentSet(equipmentSetID).equipmentSet
+	if equipmentSet.OperationKind == OperationKindDelete {
+		engine.recordError("DeleteEquipmentSet", ElementKindEquipmentSet, int(equipmentSetID), ErrElementDoesNotExist)
+		return
+	}
 	engine.deleteEquipmentSet(equipmentSetID)
 }`
 
@@ -1949,6 +2769,219 @@ const deleteAnyOfItem_Player_ZoneItem_Engine_func string = `func (engine *Engine
 	}
 }`
 
+const duration_go_import string = `import (
+	"strconv"
+	"time"
+)`
+
+const _Duration_type string = `type Duration time.Duration`
+
+const _MarshalJSON_Duration_func string = `func (d Duration) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.FormatInt(time.Duration(d).Milliseconds(), 10)), nil
+}`
+
+const _UnmarshalJSON_Duration_func string = `func (d *Duration) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	milliseconds, err := strconv.ParseFloat(string(data), 64)
+	if err != nil {
+		return err
+	}
+	*d = Duration(milliseconds * float64(time.Millisecond))
+	return nil
+}`
+
+const element_by_path_go_import string = `import (
+	"strconv"
+	"strings"
+)`
+
+const _AnyElement_type string = `type AnyElement struct {
+	kind	ElementKind
+	id	int
+	engine	*Engine
+}`
+
+const _Kind_AnyElement_func string = `func (element AnyElement) Kind() ElementKind {
+	return element.kind
+}`
+
+const _EquipmentSet_AnyElement_func string = `func (element AnyElement) EquipmentSet() equipmentSet {
+	return element.engine.EquipmentSet(EquipmentSetID(element.id))
+}`
+
+const _GearScore_AnyElement_func string = `func (element AnyElement) GearScore() gearScore {
+	return element.engine.GearScore(GearScoreID(element.id))
+}`
+
+const _Item_AnyElement_func string = `func (element AnyElement) Item() item {
+	return element.engine.Item(ItemID(element.id))
+}`
+
+const _Player_AnyElement_func string = `func (element AnyElement) Player() player {
+	return element.engine.Player(PlayerID(element.id))
+}`
+
+const _Position_AnyElement_func string = `func (element AnyElement) Position() position {
+	return element.engine.Position(PositionID(element.id))
+}`
+
+const _Zone_AnyElement_func string = `func (element AnyElement) Zone() zone {
+	return element.engine.Zone(ZoneID(element.id))
+}`
+
+const _ZoneItem_AnyElement_func string = `func (element AnyElement) ZoneItem() zoneItem {
+	return element.engine.ZoneItem(ZoneItemID(element.id))
+}`
+
+const path_AnyElement_func string = `func (element AnyElement) path() string {
+	switch element.kind {
+	case ElementKindEquipmentSet:
+		return element.EquipmentSet().Path()
+	case ElementKindGearScore:
+		return element.GearScore().Path()
+	case ElementKindItem:
+		return element.Item().Path()
+	case ElementKindPlayer:
+		return element.Player().Path()
+	case ElementKindPosition:
+		return element.Position().Path()
+	case ElementKindZone:
+		return element.Zone().Path()
+	case ElementKindZoneItem:
+		return element.ZoneItem().Path()
+	}
+	return ""
+}`
+
+const elementByID_Engine_func string = `func (engine *Engine) elementByID(id int) (AnyElement, bool) {
+	if engine.EquipmentSet(EquipmentSetID(id)).equipmentSet.OperationKind != OperationKindDelete {
+		return AnyElement{engine: engine, id: id, kind: ElementKindEquipmentSet}, true
+	}
+	if engine.GearScore(GearScoreID(id)).gearScore.OperationKind != OperationKindDelete {
+		return AnyElement{engine: engine, id: id, kind: ElementKindGearScore}, true
+	}
+	if engine.Item(ItemID(id)).item.OperationKind != OperationKindDelete {
+		return AnyElement{engine: engine, id: id, kind: ElementKindItem}, true
+	}
+	if engine.Player(PlayerID(id)).player.OperationKind != OperationKindDelete {
+		return AnyElement{engine: engine, id: id, kind: ElementKindPlayer}, true
+	}
+	if engine.Position(PositionID(id)).position.OperationKind != OperationKindDelete {
+		return AnyElement{engine: engine, id: id, kind: ElementKindPosition}, true
+	}
+	if engine.Zone(ZoneID(id)).zone.OperationKind != OperationKindDelete {
+		return AnyElement{engine: engine, id: id, kind: ElementKindZone}, true
+	}
+	if engine.ZoneItem(ZoneItemID(id)).zoneItem.OperationKind != OperationKindDelete {
+		return AnyElement{engine: engine, id: id, kind: ElementKindZoneItem}, true
+	}
+	return AnyElement{}, false
+}`
+
+const child_AnyElement_func string = `func (element AnyElement) child(identifier string) (AnyElement, bool) {
+	switch element.kind {
+	case ElementKindItem:
+		item := element.Item()
+		switch identifier {
+		case "gearScore":
+			return AnyElement{engine: element.engine, id: int(item.GearScore().ID()), kind: ElementKindGearScore}, true
+		case "origin":
+			origin := item.Origin()
+			switch origin.Kind() {
+			case ElementKindPlayer:
+				return AnyElement{engine: element.engine, id: int(origin.Player().ID()), kind: ElementKindPlayer}, true
+			case ElementKindPosition:
+				return AnyElement{engine: element.engine, id: int(origin.Position().ID()), kind: ElementKindPosition}, true
+			}
+		}
+	case ElementKindPlayer:
+		player := element.Player()
+		switch identifier {
+		case "gearScore":
+			return AnyElement{engine: element.engine, id: int(player.GearScore().ID()), kind: ElementKindGearScore}, true
+		case "position":
+			return AnyElement{engine: element.engine, id: int(player.Position().ID()), kind: ElementKindPosition}, true
+		}
+	case ElementKindZone:
+		zone := element.Zone()
+		switch identifier {
+		case "boss":
+			if boss, ok := zone.Boss(); ok {
+				return AnyElement{engine: element.engine, id: int(boss.ID()), kind: ElementKindPlayer}, true
+			}
+		}
+	case ElementKindZoneItem:
+		zoneItem := element.ZoneItem()
+		switch identifier {
+		case "item":
+			return AnyElement{engine: element.engine, id: int(zoneItem.Item().ID()), kind: ElementKindItem}, true
+		case "position":
+			return AnyElement{engine: element.engine, id: int(zoneItem.Position().ID()), kind: ElementKindPosition}, true
+		}
+	}
+	return AnyElement{}, false
+}`
+
+const _ElementByPath_Engine_func string = `func (engine *Engine) ElementByPath(jsonPath string) (AnyElement, bool) {
+	segments := strings.Split(strings.NewReplacer("[", ".", "]", "").Replace(strings.TrimPrefix(jsonPath, "$.")), ".")
+	lastIDIndex := -1
+	var id int
+	for i, segment := range segments {
+		if segmentID, err := strconv.Atoi(segment); err == nil {
+			lastIDIndex = i
+			id = segmentID
+		}
+	}
+	if lastIDIndex == -1 {
+		return AnyElement{}, false
+	}
+	element, ok := engine.elementByID(id)
+	for _, identifier := range segments[lastIDIndex+1:] {
+		if !ok {
+			break
+		}
+		element, ok = element.child(identifier)
+	}
+	if !ok || element.path() != jsonPath {
+		return AnyElement{}, false
+	}
+	return element, true
+}`
+
+const errors_go_import string = `import (
+	"errors"
+	"fmt"
+)`
+
+const _ErrElementDoesNotExist_type string = `var ErrElementDoesNotExist = errors.New("element does not exist")`
+
+const _ErrElementHasParent_type string = `var ErrElementHasParent = errors.New("element has a parent")`
+
+const _ErrElementNotDetachable_type string = `var ErrElementNotDetachable = errors.New("element cannot be detached from its parent")`
+
+const _ErrElementIsAncestor_type string = `var ErrElementIsAncestor = errors.New("element is an ancestor of the adopting element")`
+
+const _ErrElementNotInSlice_type string = `var ErrElementNotInSlice = errors.New("element is not in slice")`
+
+const _EnableStrictMode_Engine_func string = `func (engine *Engine) EnableStrictMode() {
+	engine.strict = true
+}`
+
+const _Errors_Engine_func string = `func (engine *Engine) Errors() []error {
+	return engine.errs
+}`
+
+const recordError_Engine_func string = `func (engine *Engine) recordError(operation string, kind ElementKind, id int, err error) {
+	if !engine.strict {
+		return
+	}
+	engine.errs = append(engine.errs, fmt.Errorf("%s: %s %d: %w", operation, kind, id, err))
+}`
+
+const getters_go_import string = `import "time"`
+
 const _EveryPlayer_Engine_func string = `func (engine *Engine) EveryPlayer() []player {
 	playerIDs := engine.allPlayerIDs()
 	var players []player
@@ -1975,6 +3008,15 @@ const _Player_Engine_func string = `func (engine *Engine) Player(playerID Player
 	return player{player: playerCore{OperationKind: OperationKindDelete, engine: engine}}
 }`
 
+const _PlayerExists_Engine_func string = `func (engine *Engine) PlayerExists(playerID PlayerID) bool {
+	return engine.Player(playerID).player.OperationKind != OperationKindDelete
+}`
+
+const _LookupPlayer_Engine_func string = `func (engine *Engine) LookupPlayer(playerID PlayerID) (player, bool) {
+	player := engine.Player(playerID)
+	return player, player.player.OperationKind != OperationKindDelete
+}`
+
 const _ID_player_func string = `func (_player player) ID() PlayerID {
 	return _player.player.ID
 }`
@@ -2060,6 +3102,15 @@ const _GearScore_Engine_func string = `func (engine *Engine) GearScore(gearScore
 	return gearScore{gearScore: gearScoreCore{OperationKind: OperationKindDelete, engine: engine}}
 }`
 
+const _GearScoreExists_Engine_func string = `func (engine *Engine) GearScoreExists(gearScoreID GearScoreID) bool {
+	return engine.GearScore(gearScoreID).gearScore.OperationKind != OperationKindDelete
+}`
+
+const _LookupGearScore_Engine_func string = `func (engine *Engine) LookupGearScore(gearScoreID GearScoreID) (gearScore, bool) {
+	gearScore := engine.GearScore(gearScoreID)
+	return gearScore, gearScore.gearScore.OperationKind != OperationKindDelete
+}`
+
 const _ID_gearScore_func string = `func (_gearScore gearScore) ID() GearScoreID {
 	return _gearScore.gearScore.ID
 }`
@@ -2104,6 +3155,15 @@ const _Item_Engine_func string = `func (engine *Engine) Item(itemID ItemID) item
 	return item{item: itemCore{OperationKind: OperationKindDelete, engine: engine}}
 }`
 
+const _ItemExists_Engine_func string = `func (engine *Engine) ItemExists(itemID ItemID) bool {
+	return engine.Item(itemID).item.OperationKind != OperationKindDelete
+}`
+
+const _LookupItem_Engine_func string = `func (engine *Engine) LookupItem(itemID ItemID) (item, bool) {
+	item := engine.Item(itemID)
+	return item, item.item.OperationKind != OperationKindDelete
+}`
+
 const _ID_item_func string = `func (_item item) ID() ItemID {
 	return _item.item.ID
 }`
@@ -2158,6 +3218,15 @@ const _Position_Engine_func string = `func (engine *Engine) Position(positionID
 	return position{position: positionCore{OperationKind: OperationKindDelete, engine: engine}}
 }`
 
+const _PositionExists_Engine_func string = `func (engine *Engine) PositionExists(positionID PositionID) bool {
+	return engine.Position(positionID).position.OperationKind != OperationKindDelete
+}`
+
+const _LookupPosition_Engine_func string = `func (engine *Engine) LookupPosition(positionID PositionID) (position, bool) {
+	position := engine.Position(positionID)
+	return position, position.position.OperationKind != OperationKindDelete
+}`
+
 const _ID_position_func string = `func (_position position) ID() PositionID {
 	return _position.position.ID
 }`
@@ -2202,7 +3271,16 @@ const _ZoneItem_Engine_func string = `func (engine *Engine) ZoneItem(zoneItemID
 	return zoneItem{zoneItem: zoneItemCore{OperationKind: OperationKindDelete, engine: engine}}
 }`
 
-const _ID_zoneItem_func string = `func (_zoneItem zoneItem) ID() ZoneItemID {
+const _ZoneItemExists_Engine_func string = `func (engine *Engine) ZoneItemExists(zoneItemID ZoneItemID) bool {
+	return engine.ZoneItem(zoneItemID).zoneItem.OperationKind != OperationKindDelete
+}`
+
+const _LookupZoneItem_Engine_func string = `func (engine *Engine) LookupZoneItem(zoneItemID ZoneItemID) (zoneItem, bool) {
+	zoneItem := engine.ZoneItem(zoneItemID)
+	return zoneItem, zoneItem.zoneItem.OperationKind != OperationKindDelete
+}`
+
+const _ID_zoneItem_func string = `func (_zoneItem zoneItem) ID() ZoneItemID {
 	return _zoneItem.zoneItem.ID
 }`
 
@@ -2243,6 +3321,15 @@ const _Zone_Engine_func string = `func (engine *Engine) Zone(zoneID ZoneID) zone
 	return zone{zone: zoneCore{OperationKind: OperationKindDelete, engine: engine}}
 }`
 
+const _ZoneExists_Engine_func string = `func (engine *Engine) ZoneExists(zoneID ZoneID) bool {
+	return engine.Zone(zoneID).zone.OperationKind != OperationKindDelete
+}`
+
+const _LookupZone_Engine_func string = `func (engine *Engine) LookupZone(zoneID ZoneID) (zone, bool) {
+	zone := engine.Zone(zoneID)
+	return zone, zone.zone.OperationKind != OperationKindDelete
+}`
+
 const _ID_zone_func string = `func (_zone zone) ID() ZoneID {
 	return _zone.zone.ID
 }`
@@ -2251,6 +3338,11 @@ const _Path_zone_func string = `func (_zone zone) Path() string {
 	return _zone.zone.Path
 }`
 
+const _Boss_zone_func string = `func (_zone zone) Boss() (player, bool) {
+	zone := _zone.zone.engine.Zone(_zone.zone.ID)
+	return zone.zone.engine.Player(zone.zone.Boss), zone.zone.Boss != 0
+}`
+
 const _Players_zone_func string = `func (_zone zone) Players() []player {
 	zone := _zone.zone.engine.Zone(_zone.zone.ID)
 	var players []player
@@ -2346,6 +3438,15 @@ const _EquipmentSet_Engine_func string = `func (engine *Engine) EquipmentSet(equ
 	return equipmentSet{equipmentSet: equipmentSetCore{OperationKind: OperationKindDelete, engine: engine}}
 }`
 
+const _EquipmentSetExists_Engine_func string = `func (engine *Engine) EquipmentSetExists(equipmentSetID EquipmentSetID) bool {
+	return engine.EquipmentSet(equipmentSetID).equipmentSet.OperationKind != OperationKindDelete
+}`
+
+const _LookupEquipmentSet_Engine_func string = `func (engine *Engine) LookupEquipmentSet(equipmentSetID EquipmentSetID) (equipmentSet, bool) {
+	equipmentSet := engine.EquipmentSet(equipmentSetID)
+	return equipmentSet, equipmentSet.equipmentSet.OperationKind != OperationKindDelete
+}`
+
 const _ID_equipmentSet_func string = `func (_equipmentSet equipmentSet) ID() EquipmentSetID {
 	return _equipmentSet.equipmentSet.ID
 }`
@@ -2359,6 +3460,26 @@ const _Name_equipmentSet_func string = `func (_equipmentSet equipmentSet) Name()
 	return equipmentSet.equipmentSet.Name
 }`
 
+const _CreatedAt_equipmentSet_func string = `func (_equipmentSet equipmentSet) CreatedAt() time.Time {
+	equipmentSet := _equipmentSet.equipmentSet.engine.EquipmentSet(_equipmentSet.equipmentSet.ID)
+	return equipmentSet.equipmentSet.CreatedAt
+}`
+
+const _Cooldown_equipmentSet_func string = `func (_equipmentSet equipmentSet) Cooldown() Duration {
+	equipmentSet := _equipmentSet.equipmentSet.engine.EquipmentSet(_equipmentSet.equipmentSet.ID)
+	return equipmentSet.equipmentSet.Cooldown
+}`
+
+const _ExternalID_equipmentSet_func string = `func (_equipmentSet equipmentSet) ExternalID() BigID {
+	equipmentSet := _equipmentSet.equipmentSet.engine.EquipmentSet(_equipmentSet.equipmentSet.ID)
+	return equipmentSet.equipmentSet.ExternalID
+}`
+
+const _Icon_equipmentSet_func string = `func (_equipmentSet equipmentSet) Icon() []byte {
+	equipmentSet := _equipmentSet.equipmentSet.engine.EquipmentSet(_equipmentSet.equipmentSet.ID)
+	return append([]byte(nil), equipmentSet.equipmentSet.Icon...)
+}`
+
 const _Equipment_equipmentSet_func string = `func (_equipmentSet equipmentSet) Equipment() []equipmentSetEquipmentRef {
 	equipmentSet := _equipmentSet.equipmentSet.engine.EquipmentSet(_equipmentSet.equipmentSet.ID)
 	var equipment []equipmentSetEquipmentRef
@@ -2511,6 +3632,8 @@ const _Item_anyOfItem_Player_ZoneItem_func string = `func (_anyOfItem_Player_Zon
 	return anyOfItem_Player_ZoneItem.anyOfItem_Player_ZoneItem.engine.Item(anyOfItem_Player_ZoneItem.anyOfItem_Player_ZoneItem.Item)
 }`
 
+const helpers_go_import string = `import "sort"`
+
 const deduplicateZoneItemIDs_func string = `func deduplicateZoneItemIDs(a []ZoneItemID, b []ZoneItemID) []ZoneItemID {
 	check := zoneItemCheckPool.Get().(map[ZoneItemID]bool)
 	for k := range check {
@@ -2768,6 +3891,11 @@ const allEquipmentSetIDs_Engine_func string = `func (engine Engine) allEquipment
 		patchEquipmentSetIDs = append(patchEquipmentSetIDs, equipmentSetID)
 	}
 	dedupedIDs := deduplicateEquipmentSetIDs(stateEquipmentSetIDs, patchEquipmentSetIDs)
+	if engine.ordered {
+		sort.Slice(dedupedIDs, func(i, j int) bool {
+			return dedupedIDs[i] < dedupedIDs[j]
+		})
+	}
 	equipmentSetIDSlicePool.Put(stateEquipmentSetIDs)
 	equipmentSetIDSlicePool.Put(patchEquipmentSetIDs)
 	return dedupedIDs
@@ -2783,6 +3911,11 @@ const allGearScoreIDs_Engine_func string = `func (engine Engine) allGearScoreIDs
 		patchGearScoreIDs = append(patchGearScoreIDs, gearScoreID)
 	}
 	dedupedIDs := deduplicateGearScoreIDs(stateGearScoreIDs, patchGearScoreIDs)
+	if engine.ordered {
+		sort.Slice(dedupedIDs, func(i, j int) bool {
+			return dedupedIDs[i] < dedupedIDs[j]
+		})
+	}
 	gearScoreIDSlicePool.Put(stateGearScoreIDs)
 	gearScoreIDSlicePool.Put(patchGearScoreIDs)
 	return dedupedIDs
@@ -2798,6 +3931,11 @@ const allItemIDs_Engine_func string = `func (engine Engine) allItemIDs() []ItemI
 		patchItemIDs = append(patchItemIDs, itemID)
 	}
 	dedupedIDs := deduplicateItemIDs(stateItemIDs, patchItemIDs)
+	if engine.ordered {
+		sort.Slice(dedupedIDs, func(i, j int) bool {
+			return dedupedIDs[i] < dedupedIDs[j]
+		})
+	}
 	itemIDSlicePool.Put(stateItemIDs)
 	itemIDSlicePool.Put(patchItemIDs)
 	return dedupedIDs
@@ -2813,6 +3951,11 @@ const allPositionIDs_Engine_func string = `func (engine Engine) allPositionIDs()
 		patchPositionIDs = append(patchPositionIDs, positionID)
 	}
 	dedupedIDs := deduplicatePositionIDs(statePositionIDs, patchPositionIDs)
+	if engine.ordered {
+		sort.Slice(dedupedIDs, func(i, j int) bool {
+			return dedupedIDs[i] < dedupedIDs[j]
+		})
+	}
 	positionIDSlicePool.Put(statePositionIDs)
 	positionIDSlicePool.Put(patchPositionIDs)
 	return dedupedIDs
@@ -2828,6 +3971,11 @@ const allZoneIDs_Engine_func string = `func (engine Engine) allZoneIDs() []ZoneI
 		patchZoneIDs = append(patchZoneIDs, zoneID)
 	}
 	dedupedIDs := deduplicateZoneIDs(stateZoneIDs, patchZoneIDs)
+	if engine.ordered {
+		sort.Slice(dedupedIDs, func(i, j int) bool {
+			return dedupedIDs[i] < dedupedIDs[j]
+		})
+	}
 	zoneIDSlicePool.Put(stateZoneIDs)
 	zoneIDSlicePool.Put(patchZoneIDs)
 	return dedupedIDs
@@ -2843,6 +3991,11 @@ const allZoneItemIDs_Engine_func string = `func (engine Engine) allZoneItemIDs()
 		patchZoneItemIDs = append(patchZoneItemIDs, zoneItemID)
 	}
 	dedupedIDs := deduplicateZoneItemIDs(stateZoneItemIDs, patchZoneItemIDs)
+	if engine.ordered {
+		sort.Slice(dedupedIDs, func(i, j int) bool {
+			return dedupedIDs[i] < dedupedIDs[j]
+		})
+	}
 	zoneItemIDSlicePool.Put(stateZoneItemIDs)
 	zoneItemIDSlicePool.Put(patchZoneItemIDs)
 	return dedupedIDs
@@ -2858,6 +4011,11 @@ const allPlayerIDs_Engine_func string = `func (engine Engine) allPlayerIDs() []P
 		patchPlayerIDs = append(patchPlayerIDs, playerID)
 	}
 	dedupedIDs := deduplicatePlayerIDs(statePlayerIDs, patchPlayerIDs)
+	if engine.ordered {
+		sort.Slice(dedupedIDs, func(i, j int) bool {
+			return dedupedIDs[i] < dedupedIDs[j]
+		})
+	}
 	playerIDSlicePool.Put(statePlayerIDs)
 	playerIDSlicePool.Put(patchPlayerIDs)
 	return dedupedIDs
@@ -2873,6 +4031,11 @@ const allPlayerTargetedByRefIDs_Engine_func string = `func (engine Engine) allPl
 		patchPlayerTargetedByRefIDs = append(patchPlayerTargetedByRefIDs, playerTargetedByRefID)
 	}
 	dedupedIDs := deduplicatePlayerTargetedByRefIDs(statePlayerTargetedByRefIDs, patchPlayerTargetedByRefIDs)
+	if engine.ordered {
+		sort.Slice(dedupedIDs, func(i, j int) bool {
+			return dedupedIDs[i] < dedupedIDs[j]
+		})
+	}
 	playerTargetedByRefIDSlicePool.Put(statePlayerTargetedByRefIDs)
 	playerTargetedByRefIDSlicePool.Put(patchPlayerTargetedByRefIDs)
 	return dedupedIDs
@@ -2888,6 +4051,11 @@ const allPlayerTargetRefIDs_Engine_func string = `func (engine Engine) allPlayer
 		patchPlayerTargetRefIDs = append(patchPlayerTargetRefIDs, playerTargetRefID)
 	}
 	dedupedIDs := deduplicatePlayerTargetRefIDs(statePlayerTargetRefIDs, patchPlayerTargetRefIDs)
+	if engine.ordered {
+		sort.Slice(dedupedIDs, func(i, j int) bool {
+			return dedupedIDs[i] < dedupedIDs[j]
+		})
+	}
 	playerTargetRefIDSlicePool.Put(statePlayerTargetRefIDs)
 	playerTargetRefIDSlicePool.Put(patchPlayerTargetRefIDs)
 	return dedupedIDs
@@ -2903,6 +4071,11 @@ const allItemBoundToRefIDs_Engine_func string = `func (engine Engine) allItemBou
 		patchItemBoundToRefIDs = append(patchItemBoundToRefIDs, itemBoundToRefID)
 	}
 	dedupedIDs := deduplicateItemBoundToRefIDs(stateItemBoundToRefIDs, patchItemBoundToRefIDs)
+	if engine.ordered {
+		sort.Slice(dedupedIDs, func(i, j int) bool {
+			return dedupedIDs[i] < dedupedIDs[j]
+		})
+	}
 	itemBoundToRefIDSlicePool.Put(stateItemBoundToRefIDs)
 	itemBoundToRefIDSlicePool.Put(patchItemBoundToRefIDs)
 	return dedupedIDs
@@ -2918,6 +4091,11 @@ const allPlayerGuildMemberRefIDs_Engine_func string = `func (engine Engine) allP
 		patchPlayerGuildMemberRefIDs = append(patchPlayerGuildMemberRefIDs, playerGuildMemberRefID)
 	}
 	dedupedIDs := deduplicatePlayerGuildMemberRefIDs(statePlayerGuildMemberRefIDs, patchPlayerGuildMemberRefIDs)
+	if engine.ordered {
+		sort.Slice(dedupedIDs, func(i, j int) bool {
+			return dedupedIDs[i] < dedupedIDs[j]
+		})
+	}
 	playerGuildMemberRefIDSlicePool.Put(statePlayerGuildMemberRefIDs)
 	playerGuildMemberRefIDSlicePool.Put(patchPlayerGuildMemberRefIDs)
 	return dedupedIDs
@@ -2933,6 +4111,11 @@ const allPlayerEquipmentSetRefIDs_Engine_func string = `func (engine Engine) all
 		patchPlayerEquipmentSetRefIDs = append(patchPlayerEquipmentSetRefIDs, playerEquipmentSetRefID)
 	}
 	dedupedIDs := deduplicatePlayerEquipmentSetRefIDs(statePlayerEquipmentSetRefIDs, patchPlayerEquipmentSetRefIDs)
+	if engine.ordered {
+		sort.Slice(dedupedIDs, func(i, j int) bool {
+			return dedupedIDs[i] < dedupedIDs[j]
+		})
+	}
 	playerEquipmentSetRefIDSlicePool.Put(statePlayerEquipmentSetRefIDs)
 	playerEquipmentSetRefIDSlicePool.Put(patchPlayerEquipmentSetRefIDs)
 	return dedupedIDs
@@ -2948,6 +4131,11 @@ const allEquipmentSetEquipmentRefIDs_Engine_func string = `func (engine Engine)
 		patchEquipmentSetEquipmentRefIDs = append(patchEquipmentSetEquipmentRefIDs, equipmentSetEquipmentRefID)
 	}
 	dedupedIDs := deduplicateEquipmentSetEquipmentRefIDs(stateEquipmentSetEquipmentRefIDs, patchEquipmentSetEquipmentRefIDs)
+	if engine.ordered {
+		sort.Slice(dedupedIDs, func(i, j int) bool {
+			return dedupedIDs[i] < dedupedIDs[j]
+		})
+	}
 	equipmentSetEquipmentRefIDSlicePool.Put(stateEquipmentSetEquipmentRefIDs)
 	equipmentSetEquipmentRefIDSlicePool.Put(patchEquipmentSetEquipmentRefIDs)
 	return dedupedIDs
@@ -2969,6 +4157,40 @@ const mergeGearScoreIDs_func string = `func mergeGearScoreIDs(currentIDs, nextID
 	return ids
 }`
 
+const wereGearScoreIDsReordered_func string = `func wereGearScoreIDsReordered(currentIDs, nextIDs []GearScoreID) bool {
+	var j int
+	for _, currentID := range currentIDs {
+		if len(nextIDs) <= j || currentID != nextIDs[j] {
+			continue
+		}
+		j += 1
+	}
+	if len(nextIDs[j:]) > 1 {
+		return true
+	}
+	for _, nextID := range nextIDs[j:] {
+		for _, currentID := range currentIDs {
+			if nextID == currentID {
+				return true
+			}
+		}
+	}
+	return false
+}`
+
+const moveGearScoreID_func string = `func moveGearScoreID(ids []GearScoreID, from, to int) []GearScoreID {
+	if to < 0 {
+		to = 0
+	}
+	if to > len(ids)-1 {
+		to = len(ids) - 1
+	}
+	movedIDs := make([]GearScoreID, 0, len(ids))
+	movedIDs = append(movedIDs, ids[:from]...)
+	movedIDs = append(movedIDs, ids[from+1:]...)
+	return append(movedIDs[:to], append([]GearScoreID{ids[from]}, movedIDs[to:]...)...)
+}`
+
 const mergeItemIDs_func string = `func mergeItemIDs(currentIDs, nextIDs []ItemID) []ItemID {
 	ids := make([]ItemID, len(currentIDs))
 	copy(ids, currentIDs)
@@ -2985,6 +4207,40 @@ const mergeItemIDs_func string = `func mergeItemIDs(currentIDs, nextIDs []ItemID
 	return ids
 }`
 
+const wereItemIDsReordered_func string = `func wereItemIDsReordered(currentIDs, nextIDs []ItemID) bool {
+	var j int
+	for _, currentID := range currentIDs {
+		if len(nextIDs) <= j || currentID != nextIDs[j] {
+			continue
+		}
+		j += 1
+	}
+	if len(nextIDs[j:]) > 1 {
+		return true
+	}
+	for _, nextID := range nextIDs[j:] {
+		for _, currentID := range currentIDs {
+			if nextID == currentID {
+				return true
+			}
+		}
+	}
+	return false
+}`
+
+const moveItemID_func string = `func moveItemID(ids []ItemID, from, to int) []ItemID {
+	if to < 0 {
+		to = 0
+	}
+	if to > len(ids)-1 {
+		to = len(ids) - 1
+	}
+	movedIDs := make([]ItemID, 0, len(ids))
+	movedIDs = append(movedIDs, ids[:from]...)
+	movedIDs = append(movedIDs, ids[from+1:]...)
+	return append(movedIDs[:to], append([]ItemID{ids[from]}, movedIDs[to:]...)...)
+}`
+
 const mergePlayerIDs_func string = `func mergePlayerIDs(currentIDs, nextIDs []PlayerID) []PlayerID {
 	ids := make([]PlayerID, len(currentIDs))
 	copy(ids, currentIDs)
@@ -3001,6 +4257,40 @@ const mergePlayerIDs_func string = `func mergePlayerIDs(currentIDs, nextIDs []Pl
 	return ids
 }`
 
+const werePlayerIDsReordered_func string = `func werePlayerIDsReordered(currentIDs, nextIDs []PlayerID) bool {
+	var j int
+	for _, currentID := range currentIDs {
+		if len(nextIDs) <= j || currentID != nextIDs[j] {
+			continue
+		}
+		j += 1
+	}
+	if len(nextIDs[j:]) > 1 {
+		return true
+	}
+	for _, nextID := range nextIDs[j:] {
+		for _, currentID := range currentIDs {
+			if nextID == currentID {
+				return true
+			}
+		}
+	}
+	return false
+}`
+
+const movePlayerID_func string = `func movePlayerID(ids []PlayerID, from, to int) []PlayerID {
+	if to < 0 {
+		to = 0
+	}
+	if to > len(ids)-1 {
+		to = len(ids) - 1
+	}
+	movedIDs := make([]PlayerID, 0, len(ids))
+	movedIDs = append(movedIDs, ids[:from]...)
+	movedIDs = append(movedIDs, ids[from+1:]...)
+	return append(movedIDs[:to], append([]PlayerID{ids[from]}, movedIDs[to:]...)...)
+}`
+
 const mergePositionIDs_func string = `func mergePositionIDs(currentIDs, nextIDs []PositionID) []PositionID {
 	ids := make([]PositionID, len(currentIDs))
 	copy(ids, currentIDs)
@@ -3017,6 +4307,40 @@ const mergePositionIDs_func string = `func mergePositionIDs(currentIDs, nextIDs
 	return ids
 }`
 
+const werePositionIDsReordered_func string = `func werePositionIDsReordered(currentIDs, nextIDs []PositionID) bool {
+	var j int
+	for _, currentID := range currentIDs {
+		if len(nextIDs) <= j || currentID != nextIDs[j] {
+			continue
+		}
+		j += 1
+	}
+	if len(nextIDs[j:]) > 1 {
+		return true
+	}
+	for _, nextID := range nextIDs[j:] {
+		for _, currentID := range currentIDs {
+			if nextID == currentID {
+				return true
+			}
+		}
+	}
+	return false
+}`
+
+const movePositionID_func string = `func movePositionID(ids []PositionID, from, to int) []PositionID {
+	if to < 0 {
+		to = 0
+	}
+	if to > len(ids)-1 {
+		to = len(ids) - 1
+	}
+	movedIDs := make([]PositionID, 0, len(ids))
+	movedIDs = append(movedIDs, ids[:from]...)
+	movedIDs = append(movedIDs, ids[from+1:]...)
+	return append(movedIDs[:to], append([]PositionID{ids[from]}, movedIDs[to:]...)...)
+}`
+
 const mergeZoneIDs_func string = `func mergeZoneIDs(currentIDs, nextIDs []ZoneID) []ZoneID {
 	ids := make([]ZoneID, len(currentIDs))
 	copy(ids, currentIDs)
@@ -3033,6 +4357,40 @@ const mergeZoneIDs_func string = `func mergeZoneIDs(currentIDs, nextIDs []ZoneID
 	return ids
 }`
 
+const wereZoneIDsReordered_func string = `func wereZoneIDsReordered(currentIDs, nextIDs []ZoneID) bool {
+	var j int
+	for _, currentID := range currentIDs {
+		if len(nextIDs) <= j || currentID != nextIDs[j] {
+			continue
+		}
+		j += 1
+	}
+	if len(nextIDs[j:]) > 1 {
+		return true
+	}
+	for _, nextID := range nextIDs[j:] {
+		for _, currentID := range currentIDs {
+			if nextID == currentID {
+				return true
+			}
+		}
+	}
+	return false
+}`
+
+const moveZoneID_func string = `func moveZoneID(ids []ZoneID, from, to int) []ZoneID {
+	if to < 0 {
+		to = 0
+	}
+	if to > len(ids)-1 {
+		to = len(ids) - 1
+	}
+	movedIDs := make([]ZoneID, 0, len(ids))
+	movedIDs = append(movedIDs, ids[:from]...)
+	movedIDs = append(movedIDs, ids[from+1:]...)
+	return append(movedIDs[:to], append([]ZoneID{ids[from]}, movedIDs[to:]...)...)
+}`
+
 const mergeZoneItemIDs_func string = `func mergeZoneItemIDs(currentIDs, nextIDs []ZoneItemID) []ZoneItemID {
 	ids := make([]ZoneItemID, len(currentIDs))
 	copy(ids, currentIDs)
@@ -3049,6 +4407,40 @@ const mergeZoneItemIDs_func string = `func mergeZoneItemIDs(currentIDs, nextIDs
 	return ids
 }`
 
+const wereZoneItemIDsReordered_func string = `func wereZoneItemIDsReordered(currentIDs, nextIDs []ZoneItemID) bool {
+	var j int
+	for _, currentID := range currentIDs {
+		if len(nextIDs) <= j || currentID != nextIDs[j] {
+			continue
+		}
+		j += 1
+	}
+	if len(nextIDs[j:]) > 1 {
+		return true
+	}
+	for _, nextID := range nextIDs[j:] {
+		for _, currentID := range currentIDs {
+			if nextID == currentID {
+				return true
+			}
+		}
+	}
+	return false
+}`
+
+const moveZoneItemID_func string = `func moveZoneItemID(ids []ZoneItemID, from, to int) []ZoneItemID {
+	if to < 0 {
+		to = 0
+	}
+	if to > len(ids)-1 {
+		to = len(ids) - 1
+	}
+	movedIDs := make([]ZoneItemID, 0, len(ids))
+	movedIDs = append(movedIDs, ids[:from]...)
+	movedIDs = append(movedIDs, ids[from+1:]...)
+	return append(movedIDs[:to], append([]ZoneItemID{ids[from]}, movedIDs[to:]...)...)
+}`
+
 const mergeEquipmentSetIDs_func string = `func mergeEquipmentSetIDs(currentIDs, nextIDs []EquipmentSetID) []EquipmentSetID {
 	ids := make([]EquipmentSetID, len(currentIDs))
 	copy(ids, currentIDs)
@@ -3065,6 +4457,40 @@ const mergeEquipmentSetIDs_func string = `func mergeEquipmentSetIDs(currentIDs,
 	return ids
 }`
 
+const wereEquipmentSetIDsReordered_func string = `func wereEquipmentSetIDsReordered(currentIDs, nextIDs []EquipmentSetID) bool {
+	var j int
+	for _, currentID := range currentIDs {
+		if len(nextIDs) <= j || currentID != nextIDs[j] {
+			continue
+		}
+		j += 1
+	}
+	if len(nextIDs[j:]) > 1 {
+		return true
+	}
+	for _, nextID := range nextIDs[j:] {
+		for _, currentID := range currentIDs {
+			if nextID == currentID {
+				return true
+			}
+		}
+	}
+	return false
+}`
+
+const moveEquipmentSetID_func string = `func moveEquipmentSetID(ids []EquipmentSetID, from, to int) []EquipmentSetID {
+	if to < 0 {
+		to = 0
+	}
+	if to > len(ids)-1 {
+		to = len(ids) - 1
+	}
+	movedIDs := make([]EquipmentSetID, 0, len(ids))
+	movedIDs = append(movedIDs, ids[:from]...)
+	movedIDs = append(movedIDs, ids[from+1:]...)
+	return append(movedIDs[:to], append([]EquipmentSetID{ids[from]}, movedIDs[to:]...)...)
+}`
+
 const mergeItemBoundToRefIDs_func string = `func mergeItemBoundToRefIDs(currentIDs, nextIDs []ItemBoundToRefID) []ItemBoundToRefID {
 	ids := make([]ItemBoundToRefID, len(currentIDs))
 	copy(ids, currentIDs)
@@ -3081,6 +4507,40 @@ const mergeItemBoundToRefIDs_func string = `func mergeItemBoundToRefIDs(currentI
 	return ids
 }`
 
+const wereItemBoundToRefIDsReordered_func string = `func wereItemBoundToRefIDsReordered(currentIDs, nextIDs []ItemBoundToRefID) bool {
+	var j int
+	for _, currentID := range currentIDs {
+		if len(nextIDs) <= j || currentID != nextIDs[j] {
+			continue
+		}
+		j += 1
+	}
+	if len(nextIDs[j:]) > 1 {
+		return true
+	}
+	for _, nextID := range nextIDs[j:] {
+		for _, currentID := range currentIDs {
+			if nextID == currentID {
+				return true
+			}
+		}
+	}
+	return false
+}`
+
+const moveItemBoundToRefID_func string = `func moveItemBoundToRefID(ids []ItemBoundToRefID, from, to int) []ItemBoundToRefID {
+	if to < 0 {
+		to = 0
+	}
+	if to > len(ids)-1 {
+		to = len(ids) - 1
+	}
+	movedIDs := make([]ItemBoundToRefID, 0, len(ids))
+	movedIDs = append(movedIDs, ids[:from]...)
+	movedIDs = append(movedIDs, ids[from+1:]...)
+	return append(movedIDs[:to], append([]ItemBoundToRefID{ids[from]}, movedIDs[to:]...)...)
+}`
+
 const mergeEquipmentSetEquipmentRefIDs_func string = `func mergeEquipmentSetEquipmentRefIDs(currentIDs, nextIDs []EquipmentSetEquipmentRefID) []EquipmentSetEquipmentRefID {
 	ids := make([]EquipmentSetEquipmentRefID, len(currentIDs))
 	copy(ids, currentIDs)
@@ -3097,6 +4557,40 @@ const mergeEquipmentSetEquipmentRefIDs_func string = `func mergeEquipmentSetEqui
 	return ids
 }`
 
+const wereEquipmentSetEquipmentRefIDsReordered_func string = `func wereEquipmentSetEquipmentRefIDsReordered(currentIDs, nextIDs []EquipmentSetEquipmentRefID) bool {
+	var j int
+	for _, currentID := range currentIDs {
+		if len(nextIDs) <= j || currentID != nextIDs[j] {
+			continue
+		}
+		j += 1
+	}
+	if len(nextIDs[j:]) > 1 {
+		return true
+	}
+	for _, nextID := range nextIDs[j:] {
+		for _, currentID := range currentIDs {
+			if nextID == currentID {
+				return true
+			}
+		}
+	}
+	return false
+}`
+
+const moveEquipmentSetEquipmentRefID_func string = `func moveEquipmentSetEquipmentRefID(ids []EquipmentSetEquipmentRefID, from, to int) []EquipmentSetEquipmentRefID {
+	if to < 0 {
+		to = 0
+	}
+	if to > len(ids)-1 {
+		to = len(ids) - 1
+	}
+	movedIDs := make([]EquipmentSetEquipmentRefID, 0, len(ids))
+	movedIDs = append(movedIDs, ids[:from]...)
+	movedIDs = append(movedIDs, ids[from+1:]...)
+	return append(movedIDs[:to], append([]EquipmentSetEquipmentRefID{ids[from]}, movedIDs[to:]...)...)
+}`
+
 const mergePlayerGuildMemberRefIDs_func string = `func mergePlayerGuildMemberRefIDs(currentIDs, nextIDs []PlayerGuildMemberRefID) []PlayerGuildMemberRefID {
 	ids := make([]PlayerGuildMemberRefID, len(currentIDs))
 	copy(ids, currentIDs)
@@ -3113,9 +4607,7 @@ const mergePlayerGuildMemberRefIDs_func string = `func mergePlayerGuildMemberRef
 	return ids
 }`
 
-const mergePlayerTargetedByRefIDs_func string = `func mergePlayerTargetedByRefIDs(currentIDs, nextIDs []PlayerTargetedByRefID) []PlayerTargetedByRefID {
-	ids := make([]PlayerTargetedByRefID, len(currentIDs))
-	copy(ids, currentIDs)
+const werePlayerGuildMemberRefIDsReordered_func string = `func werePlayerGuildMemberRefIDsReordered(currentIDs, nextIDs []PlayerGuildMemberRefID) bool {
 	var j int
 	for _, currentID := range currentIDs {
 		if len(nextIDs) <= j || currentID != nextIDs[j] {
@@ -3123,14 +4615,34 @@ const mergePlayerTargetedByRefIDs_func string = `func mergePlayerTargetedByRefID
 		}
 		j += 1
 	}
+	if len(nextIDs[j:]) > 1 {
+		return true
+	}
 	for _, nextID := range nextIDs[j:] {
-		ids = append(ids, nextID)
+		for _, currentID := range currentIDs {
+			if nextID == currentID {
+				return true
+			}
+		}
 	}
-	return ids
+	return false
 }`
 
-const mergePlayerTargetRefIDs_func string = `func mergePlayerTargetRefIDs(currentIDs, nextIDs []PlayerTargetRefID) []PlayerTargetRefID {
-	ids := make([]PlayerTargetRefID, len(currentIDs))
+const movePlayerGuildMemberRefID_func string = `func movePlayerGuildMemberRefID(ids []PlayerGuildMemberRefID, from, to int) []PlayerGuildMemberRefID {
+	if to < 0 {
+		to = 0
+	}
+	if to > len(ids)-1 {
+		to = len(ids) - 1
+	}
+	movedIDs := make([]PlayerGuildMemberRefID, 0, len(ids))
+	movedIDs = append(movedIDs, ids[:from]...)
+	movedIDs = append(movedIDs, ids[from+1:]...)
+	return append(movedIDs[:to], append([]PlayerGuildMemberRefID{ids[from]}, movedIDs[to:]...)...)
+}`
+
+const mergePlayerTargetedByRefIDs_func string = `func mergePlayerTargetedByRefIDs(currentIDs, nextIDs []PlayerTargetedByRefID) []PlayerTargetedByRefID {
+	ids := make([]PlayerTargetedByRefID, len(currentIDs))
 	copy(ids, currentIDs)
 	var j int
 	for _, currentID := range currentIDs {
@@ -3145,9 +4657,7 @@ const mergePlayerTargetRefIDs_func string = `func mergePlayerTargetRefIDs(curren
 	return ids
 }`
 
-const mergePlayerEquipmentSetRefIDs_func string = `func mergePlayerEquipmentSetRefIDs(currentIDs, nextIDs []PlayerEquipmentSetRefID) []PlayerEquipmentSetRefID {
-	ids := make([]PlayerEquipmentSetRefID, len(currentIDs))
-	copy(ids, currentIDs)
+const werePlayerTargetedByRefIDsReordered_func string = `func werePlayerTargetedByRefIDsReordered(currentIDs, nextIDs []PlayerTargetedByRefID) bool {
 	var j int
 	for _, currentID := range currentIDs {
 		if len(nextIDs) <= j || currentID != nextIDs[j] {
@@ -3155,14 +4665,34 @@ const mergePlayerEquipmentSetRefIDs_func string = `func mergePlayerEquipmentSetR
 		}
 		j += 1
 	}
+	if len(nextIDs[j:]) > 1 {
+		return true
+	}
 	for _, nextID := range nextIDs[j:] {
-		ids = append(ids, nextID)
+		for _, currentID := range currentIDs {
+			if nextID == currentID {
+				return true
+			}
+		}
 	}
-	return ids
+	return false
 }`
 
-const mergeAnyOfPlayer_ZoneItemIDs_func string = `func mergeAnyOfPlayer_ZoneItemIDs(currentIDs, nextIDs []AnyOfPlayer_ZoneItemID) []AnyOfPlayer_ZoneItemID {
-	ids := make([]AnyOfPlayer_ZoneItemID, len(currentIDs))
+const movePlayerTargetedByRefID_func string = `func movePlayerTargetedByRefID(ids []PlayerTargetedByRefID, from, to int) []PlayerTargetedByRefID {
+	if to < 0 {
+		to = 0
+	}
+	if to > len(ids)-1 {
+		to = len(ids) - 1
+	}
+	movedIDs := make([]PlayerTargetedByRefID, 0, len(ids))
+	movedIDs = append(movedIDs, ids[:from]...)
+	movedIDs = append(movedIDs, ids[from+1:]...)
+	return append(movedIDs[:to], append([]PlayerTargetedByRefID{ids[from]}, movedIDs[to:]...)...)
+}`
+
+const mergePlayerTargetRefIDs_func string = `func mergePlayerTargetRefIDs(currentIDs, nextIDs []PlayerTargetRefID) []PlayerTargetRefID {
+	ids := make([]PlayerTargetRefID, len(currentIDs))
 	copy(ids, currentIDs)
 	var j int
 	for _, currentID := range currentIDs {
@@ -3177,9 +4707,7 @@ const mergeAnyOfPlayer_ZoneItemIDs_func string = `func mergeAnyOfPlayer_ZoneItem
 	return ids
 }`
 
-const mergeAnyOfPlayer_PositionIDs_func string = `func mergeAnyOfPlayer_PositionIDs(currentIDs, nextIDs []AnyOfPlayer_PositionID) []AnyOfPlayer_PositionID {
-	ids := make([]AnyOfPlayer_PositionID, len(currentIDs))
-	copy(ids, currentIDs)
+const werePlayerTargetRefIDsReordered_func string = `func werePlayerTargetRefIDsReordered(currentIDs, nextIDs []PlayerTargetRefID) bool {
 	var j int
 	for _, currentID := range currentIDs {
 		if len(nextIDs) <= j || currentID != nextIDs[j] {
@@ -3187,14 +4715,34 @@ const mergeAnyOfPlayer_PositionIDs_func string = `func mergeAnyOfPlayer_Position
 		}
 		j += 1
 	}
+	if len(nextIDs[j:]) > 1 {
+		return true
+	}
 	for _, nextID := range nextIDs[j:] {
-		ids = append(ids, nextID)
+		for _, currentID := range currentIDs {
+			if nextID == currentID {
+				return true
+			}
+		}
 	}
-	return ids
+	return false
 }`
 
-const mergeAnyOfItem_Player_ZoneItemIDs_func string = `func mergeAnyOfItem_Player_ZoneItemIDs(currentIDs, nextIDs []AnyOfItem_Player_ZoneItemID) []AnyOfItem_Player_ZoneItemID {
-	ids := make([]AnyOfItem_Player_ZoneItemID, len(currentIDs))
+const movePlayerTargetRefID_func string = `func movePlayerTargetRefID(ids []PlayerTargetRefID, from, to int) []PlayerTargetRefID {
+	if to < 0 {
+		to = 0
+	}
+	if to > len(ids)-1 {
+		to = len(ids) - 1
+	}
+	movedIDs := make([]PlayerTargetRefID, 0, len(ids))
+	movedIDs = append(movedIDs, ids[:from]...)
+	movedIDs = append(movedIDs, ids[from+1:]...)
+	return append(movedIDs[:to], append([]PlayerTargetRefID{ids[from]}, movedIDs[to:]...)...)
+}`
+
+const mergePlayerEquipmentSetRefIDs_func string = `func mergePlayerEquipmentSetRefIDs(currentIDs, nextIDs []PlayerEquipmentSetRefID) []PlayerEquipmentSetRefID {
+	ids := make([]PlayerEquipmentSetRefID, len(currentIDs))
 	copy(ids, currentIDs)
 	var j int
 	for _, currentID := range currentIDs {
@@ -3209,10 +4757,1675 @@ const mergeAnyOfItem_Player_ZoneItemIDs_func string = `func mergeAnyOfItem_Playe
 	return ids
 }`
 
-const path_go_import string = `import "strconv"`
-
-const equipmentSetIdentifier_type string = `const (
-	equipmentSetIdentifier	int	= -1
+const werePlayerEquipmentSetRefIDsReordered_func string = `func werePlayerEquipmentSetRefIDsReordered(currentIDs, nextIDs []PlayerEquipmentSetRefID) bool {
+	var j int
+	for _, currentID := range currentIDs {
+		if len(nextIDs) <= j || currentID != nextIDs[j] {
+			continue
+		}
+		j += 1
+	}
+	if len(nextIDs[j:]) > 1 {
+		return true
+	}
+	for _, nextID := range nextIDs[j:] {
+		for _, currentID := range currentIDs {
+			if nextID == currentID {
+				return true
+			}
+		}
+	}
+	return false
+}`
+
+const movePlayerEquipmentSetRefID_func string = `func movePlayerEquipmentSetRefID(ids []PlayerEquipmentSetRefID, from, to int) []PlayerEquipmentSetRefID {
+	if to < 0 {
+		to = 0
+	}
+	if to > len(ids)-1 {
+		to = len(ids) - 1
+	}
+	movedIDs := make([]PlayerEquipmentSetRefID, 0, len(ids))
+	movedIDs = append(movedIDs, ids[:from]...)
+	movedIDs = append(movedIDs, ids[from+1:]...)
+	return append(movedIDs[:to], append([]PlayerEquipmentSetRefID{ids[from]}, movedIDs[to:]...)...)
+}`
+
+const mergeAnyOfPlayer_ZoneItemIDs_func string = `func mergeAnyOfPlayer_ZoneItemIDs(currentIDs, nextIDs []AnyOfPlayer_ZoneItemID) []AnyOfPlayer_ZoneItemID {
+	ids := make([]AnyOfPlayer_ZoneItemID, len(currentIDs))
+	copy(ids, currentIDs)
+	var j int
+	for _, currentID := range currentIDs {
+		if len(nextIDs) <= j || currentID != nextIDs[j] {
+			continue
+		}
+		j += 1
+	}
+	for _, nextID := range nextIDs[j:] {
+		ids = append(ids, nextID)
+	}
+	return ids
+}`
+
+const wereAnyOfPlayer_ZoneItemIDsReordered_func string = `func wereAnyOfPlayer_ZoneItemIDsReordered(currentIDs, nextIDs []AnyOfPlayer_ZoneItemID) bool {
+	var j int
+	for _, currentID := range currentIDs {
+		if len(nextIDs) <= j || currentID != nextIDs[j] {
+			continue
+		}
+		j += 1
+	}
+	if len(nextIDs[j:]) > 1 {
+		return true
+	}
+	for _, nextID := range nextIDs[j:] {
+		for _, currentID := range currentIDs {
+			if nextID == currentID {
+				return true
+			}
+		}
+	}
+	return false
+}`
+
+const moveAnyOfPlayer_ZoneItemID_func string = `func moveAnyOfPlayer_ZoneItemID(ids []AnyOfPlayer_ZoneItemID, from, to int) []AnyOfPlayer_ZoneItemID {
+	if to < 0 {
+		to = 0
+	}
+	if to > len(ids)-1 {
+		to = len(ids) - 1
+	}
+	movedIDs := make([]AnyOfPlayer_ZoneItemID, 0, len(ids))
+	movedIDs = append(movedIDs, ids[:from]...)
+	movedIDs = append(movedIDs, ids[from+1:]...)
+	return append(movedIDs[:to], append([]AnyOfPlayer_ZoneItemID{ids[from]}, movedIDs[to:]...)...)
+}`
+
+const mergeAnyOfPlayer_PositionIDs_func string = `func mergeAnyOfPlayer_PositionIDs(currentIDs, nextIDs []AnyOfPlayer_PositionID) []AnyOfPlayer_PositionID {
+	ids := make([]AnyOfPlayer_PositionID, len(currentIDs))
+	copy(ids, currentIDs)
+	var j int
+	for _, currentID := range currentIDs {
+		if len(nextIDs) <= j || currentID != nextIDs[j] {
+			continue
+		}
+		j += 1
+	}
+	for _, nextID := range nextIDs[j:] {
+		ids = append(ids, nextID)
+	}
+	return ids
+}`
+
+const wereAnyOfPlayer_PositionIDsReordered_func string = `func wereAnyOfPlayer_PositionIDsReordered(currentIDs, nextIDs []AnyOfPlayer_PositionID) bool {
+	var j int
+	for _, currentID := range currentIDs {
+		if len(nextIDs) <= j || currentID != nextIDs[j] {
+			continue
+		}
+		j += 1
+	}
+	if len(nextIDs[j:]) > 1 {
+		return true
+	}
+	for _, nextID := range nextIDs[j:] {
+		for _, currentID := range currentIDs {
+			if nextID == currentID {
+				return true
+			}
+		}
+	}
+	return false
+}`
+
+const moveAnyOfPlayer_PositionID_func string = `func moveAnyOfPlayer_PositionID(ids []AnyOfPlayer_PositionID, from, to int) []AnyOfPlayer_PositionID {
+	if to < 0 {
+		to = 0
+	}
+	if to > len(ids)-1 {
+		to = len(ids) - 1
+	}
+	movedIDs := make([]AnyOfPlayer_PositionID, 0, len(ids))
+	movedIDs = append(movedIDs, ids[:from]...)
+	movedIDs = append(movedIDs, ids[from+1:]...)
+	return append(movedIDs[:to], append([]AnyOfPlayer_PositionID{ids[from]}, movedIDs[to:]...)...)
+}`
+
+const mergeAnyOfItem_Player_ZoneItemIDs_func string = `func mergeAnyOfItem_Player_ZoneItemIDs(currentIDs, nextIDs []AnyOfItem_Player_ZoneItemID) []AnyOfItem_Player_ZoneItemID {
+	ids := make([]AnyOfItem_Player_ZoneItemID, len(currentIDs))
+	copy(ids, currentIDs)
+	var j int
+	for _, currentID := range currentIDs {
+		if len(nextIDs) <= j || currentID != nextIDs[j] {
+			continue
+		}
+		j += 1
+	}
+	for _, nextID := range nextIDs[j:] {
+		ids = append(ids, nextID)
+	}
+	return ids
+}`
+
+const wereAnyOfItem_Player_ZoneItemIDsReordered_func string = `func wereAnyOfItem_Player_ZoneItemIDsReordered(currentIDs, nextIDs []AnyOfItem_Player_ZoneItemID) bool {
+	var j int
+	for _, currentID := range currentIDs {
+		if len(nextIDs) <= j || currentID != nextIDs[j] {
+			continue
+		}
+		j += 1
+	}
+	if len(nextIDs[j:]) > 1 {
+		return true
+	}
+	for _, nextID := range nextIDs[j:] {
+		for _, currentID := range currentIDs {
+			if nextID == currentID {
+				return true
+			}
+		}
+	}
+	return false
+}`
+
+const moveAnyOfItem_Player_ZoneItemID_func string = `func moveAnyOfItem_Player_ZoneItemID(ids []AnyOfItem_Player_ZoneItemID, from, to int) []AnyOfItem_Player_ZoneItemID {
+	if to < 0 {
+		to = 0
+	}
+	if to > len(ids)-1 {
+		to = len(ids) - 1
+	}
+	movedIDs := make([]AnyOfItem_Player_ZoneItemID, 0, len(ids))
+	movedIDs = append(movedIDs, ids[:from]...)
+	movedIDs = append(movedIDs, ids[from+1:]...)
+	return append(movedIDs[:to], append([]AnyOfItem_Player_ZoneItemID{ids[from]}, movedIDs[to:]...)...)
+}`
+
+const listeners_go_import string = `import "sort"`
+
+const listeners_type string = `type listeners struct {
+	registered		bool
+	emitted			bool
+	equipmentSetCreated	[]func(equipmentSet)
+	equipmentSetUpdated	[]func(old, new equipmentSet)
+	equipmentSetDeleted	[]func(equipmentSet)
+	gearScoreCreated	[]func(gearScore)
+	gearScoreUpdated	[]func(old, new gearScore)
+	gearScoreDeleted	[]func(gearScore)
+	itemCreated		[]func(item)
+	itemUpdated		[]func(old, new item)
+	itemDeleted		[]func(item)
+	playerCreated		[]func(player)
+	playerUpdated		[]func(old, new player)
+	playerDeleted		[]func(player)
+	positionCreated		[]func(position)
+	positionUpdated		[]func(old, new position)
+	positionDeleted		[]func(position)
+	zoneCreated		[]func(zone)
+	zoneUpdated		[]func(old, new zone)
+	zoneDeleted		[]func(zone)
+	zoneItemCreated		[]func(zoneItem)
+	zoneItemUpdated		[]func(old, new zoneItem)
+	zoneItemDeleted		[]func(zoneItem)
+}`
+
+const _OnEquipmentSetCreated_Engine_func string = `func (engine *Engine) OnEquipmentSetCreated(listener func(equipmentSet)) {
+	engine.listeners.registered = true
+	engine.listeners.equipmentSetCreated = append(engine.listeners.equipmentSetCreated, listener)
+}`
+
+const _OnEquipmentSetUpdated_Engine_func string = `func (engine *Engine) OnEquipmentSetUpdated(listener func(old, new equipmentSet)) {
+	engine.listeners.registered = true
+	engine.listeners.equipmentSetUpdated = append(engine.listeners.equipmentSetUpdated, listener)
+}`
+
+const _OnEquipmentSetDeleted_Engine_func string = `func (engine *Engine) OnEquipmentSetDeleted(listener func(equipmentSet)) {
+	engine.listeners.registered = true
+	engine.listeners.equipmentSetDeleted = append(engine.listeners.equipmentSetDeleted, listener)
+}`
+
+const _OnGearScoreCreated_Engine_func string = `func (engine *Engine) OnGearScoreCreated(listener func(gearScore)) {
+	engine.listeners.registered = true
+	engine.listeners.gearScoreCreated = append(engine.listeners.gearScoreCreated, listener)
+}`
+
+const _OnGearScoreUpdated_Engine_func string = `func (engine *Engine) OnGearScoreUpdated(listener func(old, new gearScore)) {
+	engine.listeners.registered = true
+	engine.listeners.gearScoreUpdated = append(engine.listeners.gearScoreUpdated, listener)
+}`
+
+const _OnGearScoreDeleted_Engine_func string = `func (engine *Engine) OnGearScoreDeleted(listener func(gearScore)) {
+	engine.listeners.registered = true
+	engine.listeners.gearScoreDeleted = append(engine.listeners.gearScoreDeleted, listener)
+}`
+
+const _OnItemCreated_Engine_func string = `func (engine *Engine) OnItemCreated(listener func(item)) {
+	engine.listeners.registered = true
+	engine.listeners.itemCreated = append(engine.listeners.itemCreated, listener)
+}`
+
+const _OnItemUpdated_Engine_func string = `func (engine *Engine) OnItemUpdated(listener func(old, new item)) {
+	engine.listeners.registered = true
+	engine.listeners.itemUpdated = append(engine.listeners.itemUpdated, listener)
+}`
+
+const _OnItemDeleted_Engine_func string = `func (engine *Engine) OnItemDeleted(listener func(item)) {
+	engine.listeners.registered = true
+	engine.listeners.itemDeleted = append(engine.listeners.itemDeleted, listener)
+}`
+
+const _OnPlayerCreated_Engine_func string = `func (engine *Engine) OnPlayerCreated(listener func(player)) {
+	engine.listeners.registered = true
+	engine.listeners.playerCreated = append(engine.listeners.playerCreated, listener)
+}`
+
+const _OnPlayerUpdated_Engine_func string = `func (engine *Engine) OnPlayerUpdated(listener func(old, new player)) {
+	engine.listeners.registered = true
+	engine.listeners.playerUpdated = append(engine.listeners.playerUpdated, listener)
+}`
+
+const _OnPlayerDeleted_Engine_func string = `func (engine *Engine) OnPlayerDeleted(listener func(player)) {
+	engine.listeners.registered = true
+	engine.listeners.playerDeleted = append(engine.listeners.playerDeleted, listener)
+}`
+
+const _OnPositionCreated_Engine_func string = `func (engine *Engine) OnPositionCreated(listener func(position)) {
+	engine.listeners.registered = true
+	engine.listeners.positionCreated = append(engine.listeners.positionCreated, listener)
+}`
+
+const _OnPositionUpdated_Engine_func string = `func (engine *Engine) OnPositionUpdated(listener func(old, new position)) {
+	engine.listeners.registered = true
+	engine.listeners.positionUpdated = append(engine.listeners.positionUpdated, listener)
+}`
+
+const _OnPositionDeleted_Engine_func string = `func (engine *Engine) OnPositionDeleted(listener func(position)) {
+	engine.listeners.registered = true
+	engine.listeners.positionDeleted = append(engine.listeners.positionDeleted, listener)
+}`
+
+const _OnZoneCreated_Engine_func string = `func (engine *Engine) OnZoneCreated(listener func(zone)) {
+	engine.listeners.registered = true
+	engine.listeners.zoneCreated = append(engine.listeners.zoneCreated, listener)
+}`
+
+const _OnZoneUpdated_Engine_func string = `func (engine *Engine) OnZoneUpdated(listener func(old, new zone)) {
+	engine.listeners.registered = true
+	engine.listeners.zoneUpdated = append(engine.listeners.zoneUpdated, listener)
+}`
+
+const _OnZoneDeleted_Engine_func string = `func (engine *Engine) OnZoneDeleted(listener func(zone)) {
+	engine.listeners.registered = true
+	engine.listeners.zoneDeleted = append(engine.listeners.zoneDeleted, listener)
+}`
+
+const _OnZoneItemCreated_Engine_func string = `func (engine *Engine) OnZoneItemCreated(listener func(zoneItem)) {
+	engine.listeners.registered = true
+	engine.listeners.zoneItemCreated = append(engine.listeners.zoneItemCreated, listener)
+}`
+
+const _OnZoneItemUpdated_Engine_func string = `func (engine *Engine) OnZoneItemUpdated(listener func(old, new zoneItem)) {
+	engine.listeners.registered = true
+	engine.listeners.zoneItemUpdated = append(engine.listeners.zoneItemUpdated, listener)
+}`
+
+const _OnZoneItemDeleted_Engine_func string = `func (engine *Engine) OnZoneItemDeleted(listener func(zoneItem)) {
+	engine.listeners.registered = true
+	engine.listeners.zoneItemDeleted = append(engine.listeners.zoneItemDeleted, listener)
+}`
+
+const emitChanges_Engine_func string = `func (engine *Engine) emitChanges() {
+	if engine.listeners.emitted || !engine.listeners.registered {
+		return
+	}
+	engine.listeners.emitted = true
+	previous := &Engine{Patch: newState(), State: engine.State}
+	var emits []func()
+	emits = engine.collectEquipmentSetChanges(emits, previous)
+	emits = engine.collectGearScoreChanges(emits, previous)
+	emits = engine.collectItemChanges(emits, previous)
+	emits = engine.collectPlayerChanges(emits, previous)
+	emits = engine.collectPositionChanges(emits, previous)
+	emits = engine.collectZoneChanges(emits, previous)
+	emits = engine.collectZoneItemChanges(emits, previous)
+	for _, emit := range emits {
+		emit()
+	}
+}`
+
+const collectEquipmentSetChanges_Engine_func string = `func (engine *Engine) collectEquipmentSetChanges(emits []func(), previous *Engine) []func() {
+	equipmentSetIDs := make([]EquipmentSetID, 0, len(engine.Patch.EquipmentSet))
+	for equipmentSetID := range engine.Patch.EquipmentSet {
+		equipmentSetIDs = append(equipmentSetIDs, equipmentSetID)
+	}
+	sort.Slice(equipmentSetIDs, func(i, j int) bool {
+		return equipmentSetIDs[i] < equipmentSetIDs[j]
+	})
+	for _, equipmentSetID := range equipmentSetIDs {
+		equipmentSetData := engine.Patch.EquipmentSet[equipmentSetID]
+		current := equipmentSet{equipmentSet: equipmentSetData}
+		oldData, existed := engine.State.EquipmentSet[equipmentSetData.ID]
+		if equipmentSetData.OperationKind == OperationKindDelete {
+			for _, listener := range engine.listeners.equipmentSetDeleted {
+				listener := listener
+				emits = append(emits, func() {
+					listener(current)
+				})
+			}
+			continue
+		}
+		if !existed {
+			for _, listener := range engine.listeners.equipmentSetCreated {
+				listener := listener
+				emits = append(emits, func() {
+					listener(current)
+				})
+			}
+			continue
+		}
+		if equipmentSetData.OperationKind == OperationKindUpdate {
+			oldData.engine = previous
+			old := equipmentSet{equipmentSet: oldData}
+			for _, listener := range engine.listeners.equipmentSetUpdated {
+				listener := listener
+				emits = append(emits, func() {
+					listener(old, current)
+				})
+			}
+		}
+	}
+	return emits
+}`
+
+const collectGearScoreChanges_Engine_func string = `func (engine *Engine) collectGearScoreChanges(emits []func(), previous *Engine) []func() {
+	gearScoreIDs := make([]GearScoreID, 0, len(engine.Patch.GearScore))
+	for gearScoreID := range engine.Patch.GearScore {
+		gearScoreIDs = append(gearScoreIDs, gearScoreID)
+	}
+	sort.Slice(gearScoreIDs, func(i, j int) bool {
+		return gearScoreIDs[i] < gearScoreIDs[j]
+	})
+	for _, gearScoreID := range gearScoreIDs {
+		gearScoreData := engine.Patch.GearScore[gearScoreID]
+		current := gearScore{gearScore: gearScoreData}
+		oldData, existed := engine.State.GearScore[gearScoreData.ID]
+		if gearScoreData.OperationKind == OperationKindDelete {
+			for _, listener := range engine.listeners.gearScoreDeleted {
+				listener := listener
+				emits = append(emits, func() {
+					listener(current)
+				})
+			}
+			continue
+		}
+		if !existed {
+			for _, listener := range engine.listeners.gearScoreCreated {
+				listener := listener
+				emits = append(emits, func() {
+					listener(current)
+				})
+			}
+			continue
+		}
+		if gearScoreData.OperationKind == OperationKindUpdate {
+			oldData.engine = previous
+			old := gearScore{gearScore: oldData}
+			for _, listener := range engine.listeners.gearScoreUpdated {
+				listener := listener
+				emits = append(emits, func() {
+					listener(old, current)
+				})
+			}
+		}
+	}
+	return emits
+}`
+
+const collectItemChanges_Engine_func string = `func (engine *Engine) collectItemChanges(emits []func(), previous *Engine) []func() {
+	itemIDs := make([]ItemID, 0, len(engine.Patch.Item))
+	for itemID := range engine.Patch.Item {
+		itemIDs = append(itemIDs, itemID)
+	}
+	sort.Slice(itemIDs, func(i, j int) bool {
+		return itemIDs[i] < itemIDs[j]
+	})
+	for _, itemID := range itemIDs {
+		itemData := engine.Patch.Item[itemID]
+		current := item{item: itemData}
+		oldData, existed := engine.State.Item[itemData.ID]
+		if itemData.OperationKind == OperationKindDelete {
+			for _, listener := range engine.listeners.itemDeleted {
+				listener := listener
+				emits = append(emits, func() {
+					listener(current)
+				})
+			}
+			continue
+		}
+		if !existed {
+			for _, listener := range engine.listeners.itemCreated {
+				listener := listener
+				emits = append(emits, func() {
+					listener(current)
+				})
+			}
+			continue
+		}
+		if itemData.OperationKind == OperationKindUpdate {
+			oldData.engine = previous
+			old := item{item: oldData}
+			for _, listener := range engine.listeners.itemUpdated {
+				listener := listener
+				emits = append(emits, func() {
+					listener(old, current)
+				})
+			}
+		}
+	}
+	return emits
+}`
+
+const collectPlayerChanges_Engine_func string = `func (engine *Engine) collectPlayerChanges(emits []func(), previous *Engine) []func() {
+	playerIDs := make([]PlayerID, 0, len(engine.Patch.Player))
+	for playerID := range engine.Patch.Player {
+		playerIDs = append(playerIDs, playerID)
+	}
+	sort.Slice(playerIDs, func(i, j int) bool {
+		return playerIDs[i] < playerIDs[j]
+	})
+	for _, playerID := range playerIDs {
+		playerData := engine.Patch.Player[playerID]
+		current := player{player: playerData}
+		oldData, existed := engine.State.Player[playerData.ID]
+		if playerData.OperationKind == OperationKindDelete {
+			for _, listener := range engine.listeners.playerDeleted {
+				listener := listener
+				emits = append(emits, func() {
+					listener(current)
+				})
+			}
+			continue
+		}
+		if !existed {
+			for _, listener := range engine.listeners.playerCreated {
+				listener := listener
+				emits = append(emits, func() {
+					listener(current)
+				})
+			}
+			continue
+		}
+		if playerData.OperationKind == OperationKindUpdate {
+			oldData.engine = previous
+			old := player{player: oldData}
+			for _, listener := range engine.listeners.playerUpdated {
+				listener := listener
+				emits = append(emits, func() {
+					listener(old, current)
+				})
+			}
+		}
+	}
+	return emits
+}`
+
+const collectPositionChanges_Engine_func string = `func (engine *Engine) collectPositionChanges(emits []func(), previous *Engine) []func() {
+	positionIDs := make([]PositionID, 0, len(engine.Patch.Position))
+	for positionID := range engine.Patch.Position {
+		positionIDs = append(positionIDs, positionID)
+	}
+	sort.Slice(positionIDs, func(i, j int) bool {
+		return positionIDs[i] < positionIDs[j]
+	})
+	for _, positionID := range positionIDs {
+		positionData := engine.Patch.Position[positionID]
+		current := position{position: positionData}
+		oldData, existed := engine.State.Position[positionData.ID]
+		if positionData.OperationKind == OperationKindDelete {
+			for _, listener := range engine.listeners.positionDeleted {
+				listener := listener
+				emits = append(emits, func() {
+					listener(current)
+				})
+			}
+			continue
+		}
+		if !existed {
+			for _, listener := range engine.listeners.positionCreated {
+				listener := listener
+				emits = append(emits, func() {
+					listener(current)
+				})
+			}
+			continue
+		}
+		if positionData.OperationKind == OperationKindUpdate {
+			oldData.engine = previous
+			old := position{position: oldData}
+			for _, listener := range engine.listeners.positionUpdated {
+				listener := listener
+				emits = append(emits, func() {
+					listener(old, current)
+				})
+			}
+		}
+	}
+	return emits
+}`
+
+const collectZoneChanges_Engine_func string = `func (engine *Engine) collectZoneChanges(emits []func(), previous *Engine) []func() {
+	zoneIDs := make([]ZoneID, 0, len(engine.Patch.Zone))
+	for zoneID := range engine.Patch.Zone {
+		zoneIDs = append(zoneIDs, zoneID)
+	}
+	sort.Slice(zoneIDs, func(i, j int) bool {
+		return zoneIDs[i] < zoneIDs[j]
+	})
+	for _, zoneID := range zoneIDs {
+		zoneData := engine.Patch.Zone[zoneID]
+		current := zone{zone: zoneData}
+		oldData, existed := engine.State.Zone[zoneData.ID]
+		if zoneData.OperationKind == OperationKindDelete {
+			for _, listener := range engine.listeners.zoneDeleted {
+				listener := listener
+				emits = append(emits, func() {
+					listener(current)
+				})
+			}
+			continue
+		}
+		if !existed {
+			for _, listener := range engine.listeners.zoneCreated {
+				listener := listener
+				emits = append(emits, func() {
+					listener(current)
+				})
+			}
+			continue
+		}
+		if zoneData.OperationKind == OperationKindUpdate {
+			oldData.engine = previous
+			old := zone{zone: oldData}
+			for _, listener := range engine.listeners.zoneUpdated {
+				listener := listener
+				emits = append(emits, func() {
+					listener(old, current)
+				})
+			}
+		}
+	}
+	return emits
+}`
+
+const collectZoneItemChanges_Engine_func string = `func (engine *Engine) collectZoneItemChanges(emits []func(), previous *Engine) []func() {
+	zoneItemIDs := make([]ZoneItemID, 0, len(engine.Patch.ZoneItem))
+	for zoneItemID := range engine.Patch.ZoneItem {
+		zoneItemIDs = append(zoneItemIDs, zoneItemID)
+	}
+	sort.Slice(zoneItemIDs, func(i, j int) bool {
+		return zoneItemIDs[i] < zoneItemIDs[j]
+	})
+	for _, zoneItemID := range zoneItemIDs {
+		zoneItemData := engine.Patch.ZoneItem[zoneItemID]
+		current := zoneItem{zoneItem: zoneItemData}
+		oldData, existed := engine.State.ZoneItem[zoneItemData.ID]
+		if zoneItemData.OperationKind == OperationKindDelete {
+			for _, listener := range engine.listeners.zoneItemDeleted {
+				listener := listener
+				emits = append(emits, func() {
+					listener(current)
+				})
+			}
+			continue
+		}
+		if !existed {
+			for _, listener := range engine.listeners.zoneItemCreated {
+				listener := listener
+				emits = append(emits, func() {
+					listener(current)
+				})
+			}
+			continue
+		}
+		if zoneItemData.OperationKind == OperationKindUpdate {
+			oldData.engine = previous
+			old := zoneItem{zoneItem: oldData}
+			for _, listener := range engine.listeners.zoneItemUpdated {
+				listener := listener
+				emits = append(emits, func() {
+					listener(old, current)
+				})
+			}
+		}
+	}
+	return emits
+}`
+
+const childID_anyOfPlayer_PositionCore_func string = `func (_any anyOfPlayer_PositionCore) childID() int {
+	switch _any.ElementKind {
+	case ElementKindPlayer:
+		return int(_any.Player)
+	case ElementKindPosition:
+		return int(_any.Position)
+	}
+	return 0
+}`
+
+const childID_anyOfPlayer_ZoneItemCore_func string = `func (_any anyOfPlayer_ZoneItemCore) childID() int {
+	switch _any.ElementKind {
+	case ElementKindPlayer:
+		return int(_any.Player)
+	case ElementKindZoneItem:
+		return int(_any.ZoneItem)
+	}
+	return 0
+}`
+
+const childID_anyOfItem_Player_ZoneItemCore_func string = `func (_any anyOfItem_Player_ZoneItemCore) childID() int {
+	switch _any.ElementKind {
+	case ElementKindItem:
+		return int(_any.Item)
+	case ElementKindPlayer:
+		return int(_any.Player)
+	case ElementKindZoneItem:
+		return int(_any.ZoneItem)
+	}
+	return 0
+}`
+
+const equipmentKeys_equipmentSetCore_func string = `func (_equipmentSet equipmentSetCore) equipmentKeys() []ItemID {
+	keys := make([]ItemID, 0, len(_equipmentSet.Equipment))
+	for _, id := range _equipmentSet.Equipment {
+		keys = append(keys, _equipmentSet.engine.equipmentSetEquipmentRef(id).equipmentSetEquipmentRef.ReferencedElementID)
+	}
+	return keys
+}`
+
+const indexOfEquipment_equipmentSetCore_func string = `func (_equipmentSet equipmentSetCore) indexOfEquipment(itemID ItemID) int {
+	for i, key := range _equipmentSet.equipmentKeys() {
+		if key == itemID {
+			return i
+		}
+	}
+	return -1
+}`
+
+const _InsertEquipmentAt_equipmentSet_func string = `func (_equipmentSet equipmentSet) InsertEquipmentAt(index int, itemID ItemID) {
+	length := len(_equipmentSet.equipmentSet.engine.EquipmentSet(_equipmentSet.equipmentSet.ID).equipmentSet.Equipment)
+	_equipmentSet.AddEquipment(itemID)
+	equipmentSet := _equipmentSet.equipmentSet.engine.EquipmentSet(_equipmentSet.equipmentSet.ID)
+	if len(equipmentSet.equipmentSet.Equipment) == length {
+		return
+	}
+	equipmentSet.equipmentSet.Equipment = moveEquipmentSetEquipmentRefID(equipmentSet.equipmentSet.Equipment, length, index)
+	equipmentSet.equipmentSet.engine.Patch.EquipmentSet[equipmentSet.equipmentSet.ID] = equipmentSet.equipmentSet
+}`
+
+const _MoveEquipment_equipmentSet_func string = `func (_equipmentSet equipmentSet) MoveEquipment(itemID ItemID, index int) equipmentSet {
+	equipmentSet := _equipmentSet.equipmentSet.engine.EquipmentSet(_equipmentSet.equipmentSet.ID)
+	if equipmentSet.equipmentSet.OperationKind == OperationKindDelete {
+		equipmentSet.equipmentSet.engine.recordError("MoveEquipment", ElementKindEquipmentSet, int(_equipmentSet.equipmentSet.ID), ErrElementDoesNotExist)
+		return equipmentSet
+	}
+	from := equipmentSet.equipmentSet.indexOfEquipment(itemID)
+	if from == -1 {
+		equipmentSet.equipmentSet.engine.recordError("MoveEquipment", ElementKindEquipmentSet, int(_equipmentSet.equipmentSet.ID), ErrElementNotInSlice)
+		return equipmentSet
+	}
+	equipmentSet.equipmentSet.Equipment = moveEquipmentSetEquipmentRefID(equipmentSet.equipmentSet.Equipment, from, index)
+	equipmentSet.equipmentSet.OperationKind = OperationKindUpdate
+	equipmentSet.equipmentSet.engine.Patch.EquipmentSet[equipmentSet.equipmentSet.ID] = equipmentSet.equipmentSet
+	return equipmentSet
+}`
+
+const _SwapEquipment_equipmentSet_func string = `func (_equipmentSet equipmentSet) SwapEquipment(a, b ItemID) equipmentSet {
+	equipmentSet := _equipmentSet.equipmentSet.engine.EquipmentSet(_equipmentSet.equipmentSet.ID)
+	if equipmentSet.equipmentSet.OperationKind == OperationKindDelete {
+		equipmentSet.equipmentSet.engine.recordError("SwapEquipment", ElementKindEquipmentSet, int(_equipmentSet.equipmentSet.ID), ErrElementDoesNotExist)
+		return equipmentSet
+	}
+	i, j := equipmentSet.equipmentSet.indexOfEquipment(a), equipmentSet.equipmentSet.indexOfEquipment(b)
+	if i == -1 || j == -1 {
+		equipmentSet.equipmentSet.engine.recordError("SwapEquipment", ElementKindEquipmentSet, int(_equipmentSet.equipmentSet.ID), ErrElementNotInSlice)
+		return equipmentSet
+	}
+	ids := make([]EquipmentSetEquipmentRefID, len(equipmentSet.equipmentSet.Equipment))
+	copy(ids, equipmentSet.equipmentSet.Equipment)
+	ids[i], ids[j] = ids[j], ids[i]
+	equipmentSet.equipmentSet.Equipment = ids
+	equipmentSet.equipmentSet.OperationKind = OperationKindUpdate
+	equipmentSet.equipmentSet.engine.Patch.EquipmentSet[equipmentSet.equipmentSet.ID] = equipmentSet.equipmentSet
+	return equipmentSet
+}`
+
+const equipmentSetsKeys_playerCore_func string = `func (_player playerCore) equipmentSetsKeys() []EquipmentSetID {
+	keys := make([]EquipmentSetID, 0, len(_player.EquipmentSets))
+	for _, id := range _player.EquipmentSets {
+		keys = append(keys, _player.engine.playerEquipmentSetRef(id).playerEquipmentSetRef.ReferencedElementID)
+	}
+	return keys
+}`
+
+const indexOfEquipmentSet_playerCore_func string = `func (_player playerCore) indexOfEquipmentSet(equipmentSetID EquipmentSetID) int {
+	for i, key := range _player.equipmentSetsKeys() {
+		if key == equipmentSetID {
+			return i
+		}
+	}
+	return -1
+}`
+
+const _InsertEquipmentSetAt_player_func string = `func (_player player) InsertEquipmentSetAt(index int, equipmentSetID EquipmentSetID) {
+	length := len(_player.player.engine.Player(_player.player.ID).player.EquipmentSets)
+	_player.AddEquipmentSet(equipmentSetID)
+	player := _player.player.engine.Player(_player.player.ID)
+	if len(player.player.EquipmentSets) == length {
+		return
+	}
+	player.player.EquipmentSets = movePlayerEquipmentSetRefID(player.player.EquipmentSets, length, index)
+	player.player.engine.Patch.Player[player.player.ID] = player.player
+}`
+
+const _MoveEquipmentSet_player_func string = `func (_player player) MoveEquipmentSet(equipmentSetID EquipmentSetID, index int) player {
+	player := _player.player.engine.Player(_player.player.ID)
+	if player.player.OperationKind == OperationKindDelete {
+		player.player.engine.recordError("MoveEquipmentSet", ElementKindPlayer, int(_player.player.ID), ErrElementDoesNotExist)
+		return player
+	}
+	from := player.player.indexOfEquipmentSet(equipmentSetID)
+	if from == -1 {
+		player.player.engine.recordError("MoveEquipmentSet", ElementKindPlayer, int(_player.player.ID), ErrElementNotInSlice)
+		return player
+	}
+	player.player.EquipmentSets = movePlayerEquipmentSetRefID(player.player.EquipmentSets, from, index)
+	player.player.OperationKind = OperationKindUpdate
+	player.player.engine.Patch.Player[player.player.ID] = player.player
+	return player
+}`
+
+const _SwapEquipmentSets_player_func string = `func (_player player) SwapEquipmentSets(a, b EquipmentSetID) player {
+	player := _player.player.engine.Player(_player.player.ID)
+	if player.player.OperationKind == OperationKindDelete {
+		player.player.engine.recordError("SwapEquipmentSets", ElementKindPlayer, int(_player.player.ID), ErrElementDoesNotExist)
+		return player
+	}
+	i, j := player.player.indexOfEquipmentSet(a), player.player.indexOfEquipmentSet(b)
+	if i == -1 || j == -1 {
+		player.player.engine.recordError("SwapEquipmentSets", ElementKindPlayer, int(_player.player.ID), ErrElementNotInSlice)
+		return player
+	}
+	ids := make([]PlayerEquipmentSetRefID, len(player.player.EquipmentSets))
+	copy(ids, player.player.EquipmentSets)
+	ids[i], ids[j] = ids[j], ids[i]
+	player.player.EquipmentSets = ids
+	player.player.OperationKind = OperationKindUpdate
+	player.player.engine.Patch.Player[player.player.ID] = player.player
+	return player
+}`
+
+const guildMembersKeys_playerCore_func string = `func (_player playerCore) guildMembersKeys() []PlayerID {
+	keys := make([]PlayerID, 0, len(_player.GuildMembers))
+	for _, id := range _player.GuildMembers {
+		keys = append(keys, _player.engine.playerGuildMemberRef(id).playerGuildMemberRef.ReferencedElementID)
+	}
+	return keys
+}`
+
+const indexOfGuildMember_playerCore_func string = `func (_player playerCore) indexOfGuildMember(playerID PlayerID) int {
+	for i, key := range _player.guildMembersKeys() {
+		if key == playerID {
+			return i
+		}
+	}
+	return -1
+}`
+
+const _InsertGuildMemberAt_player_func string = `func (_player player) InsertGuildMemberAt(index int, playerID PlayerID) {
+	length := len(_player.player.engine.Player(_player.player.ID).player.GuildMembers)
+	_player.AddGuildMember(playerID)
+	player := _player.player.engine.Player(_player.player.ID)
+	if len(player.player.GuildMembers) == length {
+		return
+	}
+	player.player.GuildMembers = movePlayerGuildMemberRefID(player.player.GuildMembers, length, index)
+	player.player.engine.Patch.Player[player.player.ID] = player.player
+}`
+
+const _MoveGuildMember_player_func string = `func (_player player) MoveGuildMember(playerID PlayerID, index int) player {
+	player := _player.player.engine.Player(_player.player.ID)
+	if player.player.OperationKind == OperationKindDelete {
+		player.player.engine.recordError("MoveGuildMember", ElementKindPlayer, int(_player.player.ID), ErrElementDoesNotExist)
+		return player
+	}
+	from := player.player.indexOfGuildMember(playerID)
+	if from == -1 {
+		player.player.engine.recordError("MoveGuildMember", ElementKindPlayer, int(_player.player.ID), ErrElementNotInSlice)
+		return player
+	}
+	player.player.GuildMembers = movePlayerGuildMemberRefID(player.player.GuildMembers, from, index)
+	player.player.OperationKind = OperationKindUpdate
+	player.player.engine.Patch.Player[player.player.ID] = player.player
+	return player
+}`
+
+const _SwapGuildMembers_player_func string = `func (_player player) SwapGuildMembers(a, b PlayerID) player {
+	player := _player.player.engine.Player(_player.player.ID)
+	if player.player.OperationKind == OperationKindDelete {
+		player.player.engine.recordError("SwapGuildMembers", ElementKindPlayer, int(_player.player.ID), ErrElementDoesNotExist)
+		return player
+	}
+	i, j := player.player.indexOfGuildMember(a), player.player.indexOfGuildMember(b)
+	if i == -1 || j == -1 {
+		player.player.engine.recordError("SwapGuildMembers", ElementKindPlayer, int(_player.player.ID), ErrElementNotInSlice)
+		return player
+	}
+	ids := make([]PlayerGuildMemberRefID, len(player.player.GuildMembers))
+	copy(ids, player.player.GuildMembers)
+	ids[i], ids[j] = ids[j], ids[i]
+	player.player.GuildMembers = ids
+	player.player.OperationKind = OperationKindUpdate
+	player.player.engine.Patch.Player[player.player.ID] = player.player
+	return player
+}`
+
+const itemsKeys_playerCore_func string = `func (_player playerCore) itemsKeys() []ItemID {
+	return _player.Items
+}`
+
+const indexOfItem_playerCore_func string = `func (_player playerCore) indexOfItem(itemID ItemID) int {
+	for i, key := range _player.itemsKeys() {
+		if key == itemID {
+			return i
+		}
+	}
+	return -1
+}`
+
+const _InsertItemAt_player_func string = `func (_player player) InsertItemAt(index int) item {
+	length := len(_player.player.engine.Player(_player.player.ID).player.Items)
+	item := _player.AddItem()
+	player := _player.player.engine.Player(_player.player.ID)
+	if len(player.player.Items) == length {
+		return item
+	}
+	player.player.Items = moveItemID(player.player.Items, length, index)
+	player.player.engine.Patch.Player[player.player.ID] = player.player
+	return item
+}`
+
+const _MoveItem_player_func string = `func (_player player) MoveItem(itemID ItemID, index int) player {
+	player := _player.player.engine.Player(_player.player.ID)
+	if player.player.OperationKind == OperationKindDelete {
+		player.player.engine.recordError("MoveItem", ElementKindPlayer, int(_player.player.ID), ErrElementDoesNotExist)
+		return player
+	}
+	from := player.player.indexOfItem(itemID)
+	if from == -1 {
+		player.player.engine.recordError("MoveItem", ElementKindPlayer, int(_player.player.ID), ErrElementNotInSlice)
+		return player
+	}
+	player.player.Items = moveItemID(player.player.Items, from, index)
+	player.player.OperationKind = OperationKindUpdate
+	player.player.engine.Patch.Player[player.player.ID] = player.player
+	return player
+}`
+
+const _SwapItems_player_func string = `func (_player player) SwapItems(a, b ItemID) player {
+	player := _player.player.engine.Player(_player.player.ID)
+	if player.player.OperationKind == OperationKindDelete {
+		player.player.engine.recordError("SwapItems", ElementKindPlayer, int(_player.player.ID), ErrElementDoesNotExist)
+		return player
+	}
+	i, j := player.player.indexOfItem(a), player.player.indexOfItem(b)
+	if i == -1 || j == -1 {
+		player.player.engine.recordError("SwapItems", ElementKindPlayer, int(_player.player.ID), ErrElementNotInSlice)
+		return player
+	}
+	ids := make([]ItemID, len(player.player.Items))
+	copy(ids, player.player.Items)
+	ids[i], ids[j] = ids[j], ids[i]
+	player.player.Items = ids
+	player.player.OperationKind = OperationKindUpdate
+	player.player.engine.Patch.Player[player.player.ID] = player.player
+	return player
+}`
+
+const targetedByKeys_playerCore_func string = `func (_player playerCore) targetedByKeys() []int {
+	keys := make([]int, 0, len(_player.TargetedBy))
+	for _, id := range _player.TargetedBy {
+		keys = append(keys, _player.engine.anyOfPlayer_ZoneItem(_player.engine.playerTargetedByRef(id).playerTargetedByRef.ReferencedElementID).anyOfPlayer_ZoneItem.childID())
+	}
+	return keys
+}`
+
+const indexOfTargetedBy_playerCore_func string = `func (_player playerCore) indexOfTargetedBy(targetedByID int) int {
+	for i, key := range _player.targetedByKeys() {
+		if key == targetedByID {
+			return i
+		}
+	}
+	return -1
+}`
+
+const _InsertTargetedByPlayerAt_player_func string = `func (_player player) InsertTargetedByPlayerAt(index int, playerID PlayerID) {
+	length := len(_player.player.engine.Player(_player.player.ID).player.TargetedBy)
+	_player.AddTargetedByPlayer(playerID)
+	player := _player.player.engine.Player(_player.player.ID)
+	if len(player.player.TargetedBy) == length {
+		return
+	}
+	player.player.TargetedBy = movePlayerTargetedByRefID(player.player.TargetedBy, length, index)
+	player.player.engine.Patch.Player[player.player.ID] = player.player
+}`
+
+const _InsertTargetedByZoneItemAt_player_func string = `func (_player player) InsertTargetedByZoneItemAt(index int, zoneItemID ZoneItemID) {
+	length := len(_player.player.engine.Player(_player.player.ID).player.TargetedBy)
+	_player.AddTargetedByZoneItem(zoneItemID)
+	player := _player.player.engine.Player(_player.player.ID)
+	if len(player.player.TargetedBy) == length {
+		return
+	}
+	player.player.TargetedBy = movePlayerTargetedByRefID(player.player.TargetedBy, length, index)
+	player.player.engine.Patch.Player[player.player.ID] = player.player
+}`
+
+const _MoveTargetedBy_player_func string = `func (_player player) MoveTargetedBy(targetedByID int, index int) player {
+	player := _player.player.engine.Player(_player.player.ID)
+	if player.player.OperationKind == OperationKindDelete {
+		player.player.engine.recordError("MoveTargetedBy", ElementKindPlayer, int(_player.player.ID), ErrElementDoesNotExist)
+		return player
+	}
+	from := player.player.indexOfTargetedBy(targetedByID)
+	if from == -1 {
+		player.player.engine.recordError("MoveTargetedBy", ElementKindPlayer, int(_player.player.ID), ErrElementNotInSlice)
+		return player
+	}
+	player.player.TargetedBy = movePlayerTargetedByRefID(player.player.TargetedBy, from, index)
+	player.player.OperationKind = OperationKindUpdate
+	player.player.engine.Patch.Player[player.player.ID] = player.player
+	return player
+}`
+
+const _SwapTargetedBy_player_func string = `func (_player player) SwapTargetedBy(a, b int) player {
+	player := _player.player.engine.Player(_player.player.ID)
+	if player.player.OperationKind == OperationKindDelete {
+		player.player.engine.recordError("SwapTargetedBy", ElementKindPlayer, int(_player.player.ID), ErrElementDoesNotExist)
+		return player
+	}
+	i, j := player.player.indexOfTargetedBy(a), player.player.indexOfTargetedBy(b)
+	if i == -1 || j == -1 {
+		player.player.engine.recordError("SwapTargetedBy", ElementKindPlayer, int(_player.player.ID), ErrElementNotInSlice)
+		return player
+	}
+	ids := make([]PlayerTargetedByRefID, len(player.player.TargetedBy))
+	copy(ids, player.player.TargetedBy)
+	ids[i], ids[j] = ids[j], ids[i]
+	player.player.TargetedBy = ids
+	player.player.OperationKind = OperationKindUpdate
+	player.player.engine.Patch.Player[player.player.ID] = player.player
+	return player
+}`
+
+const interactablesKeys_zoneCore_func string = `func (_zone zoneCore) interactablesKeys() []int {
+	keys := make([]int, 0, len(_zone.Interactables))
+	for _, id := range _zone.Interactables {
+		keys = append(keys, _zone.engine.anyOfItem_Player_ZoneItem(id).anyOfItem_Player_ZoneItem.childID())
+	}
+	return keys
+}`
+
+const indexOfInteractable_zoneCore_func string = `func (_zone zoneCore) indexOfInteractable(interactableID int) int {
+	for i, key := range _zone.interactablesKeys() {
+		if key == interactableID {
+			return i
+		}
+	}
+	return -1
+}`
+
+const _InsertInteractableItemAt_zone_func string = `func (_zone zone) InsertInteractableItemAt(index int) item {
+	length := len(_zone.zone.engine.Zone(_zone.zone.ID).zone.Interactables)
+	item := _zone.AddInteractableItem()
+	zone := _zone.zone.engine.Zone(_zone.zone.ID)
+	if len(zone.zone.Interactables) == length {
+		return item
+	}
+	zone.zone.Interactables = moveAnyOfItem_Player_ZoneItemID(zone.zone.Interactables, length, index)
+	zone.zone.engine.Patch.Zone[zone.zone.ID] = zone.zone
+	return item
+}`
+
+const _InsertInteractablePlayerAt_zone_func string = `func (_zone zone) InsertInteractablePlayerAt(index int) player {
+	length := len(_zone.zone.engine.Zone(_zone.zone.ID).zone.Interactables)
+	player := _zone.AddInteractablePlayer()
+	zone := _zone.zone.engine.Zone(_zone.zone.ID)
+	if len(zone.zone.Interactables) == length {
+		return player
+	}
+	zone.zone.Interactables = moveAnyOfItem_Player_ZoneItemID(zone.zone.Interactables, length, index)
+	zone.zone.engine.Patch.Zone[zone.zone.ID] = zone.zone
+	return player
+}`
+
+const _InsertInteractableZoneItemAt_zone_func string = `func (_zone zone) InsertInteractableZoneItemAt(index int) zoneItem {
+	length := len(_zone.zone.engine.Zone(_zone.zone.ID).zone.Interactables)
+	zoneItem := _zone.AddInteractableZoneItem()
+	zone := _zone.zone.engine.Zone(_zone.zone.ID)
+	if len(zone.zone.Interactables) == length {
+		return zoneItem
+	}
+	zone.zone.Interactables = moveAnyOfItem_Player_ZoneItemID(zone.zone.Interactables, length, index)
+	zone.zone.engine.Patch.Zone[zone.zone.ID] = zone.zone
+	return zoneItem
+}`
+
+const _MoveInteractable_zone_func string = `func (_zone zone) MoveInteractable(interactableID int, index int) zone {
+	zone := _zone.zone.engine.Zone(_zone.zone.ID)
+	if zone.zone.OperationKind == OperationKindDelete {
+		zone.zone.engine.recordError("MoveInteractable", ElementKindZone, int(_zone.zone.ID), ErrElementDoesNotExist)
+		return zone
+	}
+	from := zone.zone.indexOfInteractable(interactableID)
+	if from == -1 {
+		zone.zone.engine.recordError("MoveInteractable", ElementKindZone, int(_zone.zone.ID), ErrElementNotInSlice)
+		return zone
+	}
+	zone.zone.Interactables = moveAnyOfItem_Player_ZoneItemID(zone.zone.Interactables, from, index)
+	zone.zone.OperationKind = OperationKindUpdate
+	zone.zone.engine.Patch.Zone[zone.zone.ID] = zone.zone
+	return zone
+}`
+
+const _SwapInteractables_zone_func string = `func (_zone zone) SwapInteractables(a, b int) zone {
+	zone := _zone.zone.engine.Zone(_zone.zone.ID)
+	if zone.zone.OperationKind == OperationKindDelete {
+		zone.zone.engine.recordError("SwapInteractables", ElementKindZone, int(_zone.zone.ID), ErrElementDoesNotExist)
+		return zone
+	}
+	i, j := zone.zone.indexOfInteractable(a), zone.zone.indexOfInteractable(b)
+	if i == -1 || j == -1 {
+		zone.zone.engine.recordError("SwapInteractables", ElementKindZone, int(_zone.zone.ID), ErrElementNotInSlice)
+		return zone
+	}
+	ids := make([]AnyOfItem_Player_ZoneItemID, len(zone.zone.Interactables))
+	copy(ids, zone.zone.Interactables)
+	ids[i], ids[j] = ids[j], ids[i]
+	zone.zone.Interactables = ids
+	zone.zone.OperationKind = OperationKindUpdate
+	zone.zone.engine.Patch.Zone[zone.zone.ID] = zone.zone
+	return zone
+}`
+
+const itemsKeys_zoneCore_func string = `func (_zone zoneCore) itemsKeys() []ZoneItemID {
+	return _zone.Items
+}`
+
+const indexOfItem_zoneCore_func string = `func (_zone zoneCore) indexOfItem(zoneItemID ZoneItemID) int {
+	for i, key := range _zone.itemsKeys() {
+		if key == zoneItemID {
+			return i
+		}
+	}
+	return -1
+}`
+
+const _InsertItemAt_zone_func string = `func (_zone zone) InsertItemAt(index int) zoneItem {
+	length := len(_zone.zone.engine.Zone(_zone.zone.ID).zone.Items)
+	zoneItem := _zone.AddItem()
+	zone := _zone.zone.engine.Zone(_zone.zone.ID)
+	if len(zone.zone.Items) == length {
+		return zoneItem
+	}
+	zone.zone.Items = moveZoneItemID(zone.zone.Items, length, index)
+	zone.zone.engine.Patch.Zone[zone.zone.ID] = zone.zone
+	return zoneItem
+}`
+
+const _MoveItem_zone_func string = `func (_zone zone) MoveItem(zoneItemID ZoneItemID, index int) zone {
+	zone := _zone.zone.engine.Zone(_zone.zone.ID)
+	if zone.zone.OperationKind == OperationKindDelete {
+		zone.zone.engine.recordError("MoveItem", ElementKindZone, int(_zone.zone.ID), ErrElementDoesNotExist)
+		return zone
+	}
+	from := zone.zone.indexOfItem(zoneItemID)
+	if from == -1 {
+		zone.zone.engine.recordError("MoveItem", ElementKindZone, int(_zone.zone.ID), ErrElementNotInSlice)
+		return zone
+	}
+	zone.zone.Items = moveZoneItemID(zone.zone.Items, from, index)
+	zone.zone.OperationKind = OperationKindUpdate
+	zone.zone.engine.Patch.Zone[zone.zone.ID] = zone.zone
+	return zone
+}`
+
+const _SwapItems_zone_func string = `func (_zone zone) SwapItems(a, b ZoneItemID) zone {
+	zone := _zone.zone.engine.Zone(_zone.zone.ID)
+	if zone.zone.OperationKind == OperationKindDelete {
+		zone.zone.engine.recordError("SwapItems", ElementKindZone, int(_zone.zone.ID), ErrElementDoesNotExist)
+		return zone
+	}
+	i, j := zone.zone.indexOfItem(a), zone.zone.indexOfItem(b)
+	if i == -1 || j == -1 {
+		zone.zone.engine.recordError("SwapItems", ElementKindZone, int(_zone.zone.ID), ErrElementNotInSlice)
+		return zone
+	}
+	ids := make([]ZoneItemID, len(zone.zone.Items))
+	copy(ids, zone.zone.Items)
+	ids[i], ids[j] = ids[j], ids[i]
+	zone.zone.Items = ids
+	zone.zone.OperationKind = OperationKindUpdate
+	zone.zone.engine.Patch.Zone[zone.zone.ID] = zone.zone
+	return zone
+}`
+
+const playersKeys_zoneCore_func string = `func (_zone zoneCore) playersKeys() []PlayerID {
+	return _zone.Players
+}`
+
+const indexOfPlayer_zoneCore_func string = `func (_zone zoneCore) indexOfPlayer(playerID PlayerID) int {
+	for i, key := range _zone.playersKeys() {
+		if key == playerID {
+			return i
+		}
+	}
+	return -1
+}`
+
+const _InsertPlayerAt_zone_func string = `func (_zone zone) InsertPlayerAt(index int) player {
+	length := len(_zone.zone.engine.Zone(_zone.zone.ID).zone.Players)
+	player := _zone.AddPlayer()
+	zone := _zone.zone.engine.Zone(_zone.zone.ID)
+	if len(zone.zone.Players) == length {
+		return player
+	}
+	zone.zone.Players = movePlayerID(zone.zone.Players, length, index)
+	zone.zone.engine.Patch.Zone[zone.zone.ID] = zone.zone
+	return player
+}`
+
+const _MovePlayer_zone_func string = `func (_zone zone) MovePlayer(playerID PlayerID, index int) zone {
+	zone := _zone.zone.engine.Zone(_zone.zone.ID)
+	if zone.zone.OperationKind == OperationKindDelete {
+		zone.zone.engine.recordError("MovePlayer", ElementKindZone, int(_zone.zone.ID), ErrElementDoesNotExist)
+		return zone
+	}
+	from := zone.zone.indexOfPlayer(playerID)
+	if from == -1 {
+		zone.zone.engine.recordError("MovePlayer", ElementKindZone, int(_zone.zone.ID), ErrElementNotInSlice)
+		return zone
+	}
+	zone.zone.Players = movePlayerID(zone.zone.Players, from, index)
+	zone.zone.OperationKind = OperationKindUpdate
+	zone.zone.engine.Patch.Zone[zone.zone.ID] = zone.zone
+	return zone
+}`
+
+const _SwapPlayers_zone_func string = `func (_zone zone) SwapPlayers(a, b PlayerID) zone {
+	zone := _zone.zone.engine.Zone(_zone.zone.ID)
+	if zone.zone.OperationKind == OperationKindDelete {
+		zone.zone.engine.recordError("SwapPlayers", ElementKindZone, int(_zone.zone.ID), ErrElementDoesNotExist)
+		return zone
+	}
+	i, j := zone.zone.indexOfPlayer(a), zone.zone.indexOfPlayer(b)
+	if i == -1 || j == -1 {
+		zone.zone.engine.recordError("SwapPlayers", ElementKindZone, int(_zone.zone.ID), ErrElementNotInSlice)
+		return zone
+	}
+	ids := make([]PlayerID, len(zone.zone.Players))
+	copy(ids, zone.zone.Players)
+	ids[i], ids[j] = ids[j], ids[i]
+	zone.zone.Players = ids
+	zone.zone.OperationKind = OperationKindUpdate
+	zone.zone.engine.Patch.Zone[zone.zone.ID] = zone.zone
+	return zone
+}`
+
+const _SetBoss_zone_func string = `func (_zone zone) SetBoss() player {
+	zone := _zone.zone.engine.Zone(_zone.zone.ID)
+	if zone.zone.OperationKind == OperationKindDelete {
+		zone.zone.engine.recordError("SetBoss", ElementKindZone, int(_zone.zone.ID), ErrElementDoesNotExist)
+		return player{player: playerCore{OperationKind: OperationKindDelete, engine: zone.zone.engine}}
+	}
+	if zone.zone.Boss != 0 {
+		return zone.zone.engine.Player(zone.zone.Boss)
+	}
+	player := zone.zone.engine.createPlayer(zone.zone.path.boss(), false)
+	zone.zone.Boss = player.player.ID
+	zone.zone.OperationKind = OperationKindUpdate
+	zone.zone.engine.Patch.Zone[zone.zone.ID] = zone.zone
+	return player
+}`
+
+const _UnsetBoss_zone_func string = `func (_zone zone) UnsetBoss() {
+	zone := _zone.zone.engine.Zone(_zone.zone.ID)
+	if zone.zone.OperationKind == OperationKindDelete {
+		zone.zone.engine.recordError("UnsetBoss", ElementKindZone, int(_zone.zone.ID), ErrElementDoesNotExist)
+		return
+	}
+	if zone.zone.Boss == 0 {
+		return
+	}
+	zone.zone.engine.deletePlayer(zone.zone.Boss)
+	zone.zone.Boss = 0
+	zone.zone.OperationKind = OperationKindUpdate
+	zone.zone.engine.Patch.Zone[zone.zone.ID] = zone.zone
+}`
+
+const _HasBoss_zone_func string = `func (_zone zone) HasBoss() bool {
+	zone := _zone.zone.engine.Zone(_zone.zone.ID)
+	return zone.zone.Boss != 0
+}`
+
+const ordering_go_import string = `import (
+	"encoding/json"
+	"sort"
+	"github.com/mailru/easyjson"
+	"github.com/mailru/easyjson/jwriter"
+)`
+
+const sortedMapKeys_type string = `const sortedMapKeys jwriter.Flags = 1 << 16`
+
+const marshalTree_Engine_func string = `func (engine *Engine) marshalTree(tree Tree) ([]byte, error) {
+	out := jwriter.Writer{}
+	if engine.ordered {
+		out.Flags = sortedMapKeys
+	}
+	tree.MarshalEasyJSON(&out)
+	return out.Buffer.BuildBytes(), out.Error
+}`
+
+const equipmentSetMap_type string = `type equipmentSetMap map[EquipmentSetID]EquipmentSet`
+
+const keys_equipmentSetMap_func string = `func (m equipmentSetMap) keys(flags jwriter.Flags) []EquipmentSetID {
+	ids := make([]EquipmentSetID, 0, len(m))
+	for id := range m {
+		ids = append(ids, id)
+	}
+	if flags&sortedMapKeys != 0 {
+		sort.Slice(ids, func(i, j int) bool {
+			return ids[i] < ids[j]
+		})
+	}
+	return ids
+}`
+
+const _MarshalEasyJSON_equipmentSetMap_func string = `func (m equipmentSetMap) MarshalEasyJSON(out *jwriter.Writer) {
+	if m == nil && out.Flags&jwriter.NilMapAsEmpty == 0 {
+		out.RawString("null")
+		return
+	}
+	out.RawByte('{')
+	for i, id := range m.keys(out.Flags) {
+		if i > 0 {
+			out.RawByte(',')
+		}
+		out.IntStr(int(id))
+		out.RawByte(':')
+		m[id].MarshalEasyJSON(out)
+	}
+	out.RawByte('}')
+}`
+
+const gearScoreMap_type string = `type gearScoreMap map[GearScoreID]GearScore`
+
+const keys_gearScoreMap_func string = `func (m gearScoreMap) keys(flags jwriter.Flags) []GearScoreID {
+	ids := make([]GearScoreID, 0, len(m))
+	for id := range m {
+		ids = append(ids, id)
+	}
+	if flags&sortedMapKeys != 0 {
+		sort.Slice(ids, func(i, j int) bool {
+			return ids[i] < ids[j]
+		})
+	}
+	return ids
+}`
+
+const _MarshalEasyJSON_gearScoreMap_func string = `func (m gearScoreMap) MarshalEasyJSON(out *jwriter.Writer) {
+	if m == nil && out.Flags&jwriter.NilMapAsEmpty == 0 {
+		out.RawString("null")
+		return
+	}
+	out.RawByte('{')
+	for i, id := range m.keys(out.Flags) {
+		if i > 0 {
+			out.RawByte(',')
+		}
+		out.IntStr(int(id))
+		out.RawByte(':')
+		m[id].MarshalEasyJSON(out)
+	}
+	out.RawByte('}')
+}`
+
+const itemMap_type string = `type itemMap map[ItemID]Item`
+
+const keys_itemMap_func string = `func (m itemMap) keys(flags jwriter.Flags) []ItemID {
+	ids := make([]ItemID, 0, len(m))
+	for id := range m {
+		ids = append(ids, id)
+	}
+	if flags&sortedMapKeys != 0 {
+		sort.Slice(ids, func(i, j int) bool {
+			return ids[i] < ids[j]
+		})
+	}
+	return ids
+}`
+
+const _MarshalEasyJSON_itemMap_func string = `func (m itemMap) MarshalEasyJSON(out *jwriter.Writer) {
+	if m == nil && out.Flags&jwriter.NilMapAsEmpty == 0 {
+		out.RawString("null")
+		return
+	}
+	out.RawByte('{')
+	for i, id := range m.keys(out.Flags) {
+		if i > 0 {
+			out.RawByte(',')
+		}
+		out.IntStr(int(id))
+		out.RawByte(':')
+		m[id].MarshalEasyJSON(out)
+	}
+	out.RawByte('}')
+}`
+
+const playerMap_type string = `type playerMap map[PlayerID]Player`
+
+const keys_playerMap_func string = `func (m playerMap) keys(flags jwriter.Flags) []PlayerID {
+	ids := make([]PlayerID, 0, len(m))
+	for id := range m {
+		ids = append(ids, id)
+	}
+	if flags&sortedMapKeys != 0 {
+		sort.Slice(ids, func(i, j int) bool {
+			return ids[i] < ids[j]
+		})
+	}
+	return ids
+}`
+
+const _MarshalEasyJSON_playerMap_func string = `func (m playerMap) MarshalEasyJSON(out *jwriter.Writer) {
+	if m == nil && out.Flags&jwriter.NilMapAsEmpty == 0 {
+		out.RawString("null")
+		return
+	}
+	out.RawByte('{')
+	for i, id := range m.keys(out.Flags) {
+		if i > 0 {
+			out.RawByte(',')
+		}
+		out.IntStr(int(id))
+		out.RawByte(':')
+		m[id].MarshalEasyJSON(out)
+	}
+	out.RawByte('}')
+}`
+
+const positionMap_type string = `type positionMap map[PositionID]Position`
+
+const keys_positionMap_func string = `func (m positionMap) keys(flags jwriter.Flags) []PositionID {
+	ids := make([]PositionID, 0, len(m))
+	for id := range m {
+		ids = append(ids, id)
+	}
+	if flags&sortedMapKeys != 0 {
+		sort.Slice(ids, func(i, j int) bool {
+			return ids[i] < ids[j]
+		})
+	}
+	return ids
+}`
+
+const _MarshalEasyJSON_positionMap_func string = `func (m positionMap) MarshalEasyJSON(out *jwriter.Writer) {
+	if m == nil && out.Flags&jwriter.NilMapAsEmpty == 0 {
+		out.RawString("null")
+		return
+	}
+	out.RawByte('{')
+	for i, id := range m.keys(out.Flags) {
+		if i > 0 {
+			out.RawByte(',')
+		}
+		out.IntStr(int(id))
+		out.RawByte(':')
+		m[id].MarshalEasyJSON(out)
+	}
+	out.RawByte('}')
+}`
+
+const zoneMap_type string = `type zoneMap map[ZoneID]Zone`
+
+const keys_zoneMap_func string = `func (m zoneMap) keys(flags jwriter.Flags) []ZoneID {
+	ids := make([]ZoneID, 0, len(m))
+	for id := range m {
+		ids = append(ids, id)
+	}
+	if flags&sortedMapKeys != 0 {
+		sort.Slice(ids, func(i, j int) bool {
+			return ids[i] < ids[j]
+		})
+	}
+	return ids
+}`
+
+const _MarshalEasyJSON_zoneMap_func string = `func (m zoneMap) MarshalEasyJSON(out *jwriter.Writer) {
+	if m == nil && out.Flags&jwriter.NilMapAsEmpty == 0 {
+		out.RawString("null")
+		return
+	}
+	out.RawByte('{')
+	for i, id := range m.keys(out.Flags) {
+		if i > 0 {
+			out.RawByte(',')
+		}
+		out.IntStr(int(id))
+		out.RawByte(':')
+		m[id].MarshalEasyJSON(out)
+	}
+	out.RawByte('}')
+}`
+
+const zoneItemMap_type string = `type zoneItemMap map[ZoneItemID]ZoneItem`
+
+const keys_zoneItemMap_func string = `func (m zoneItemMap) keys(flags jwriter.Flags) []ZoneItemID {
+	ids := make([]ZoneItemID, 0, len(m))
+	for id := range m {
+		ids = append(ids, id)
+	}
+	if flags&sortedMapKeys != 0 {
+		sort.Slice(ids, func(i, j int) bool {
+			return ids[i] < ids[j]
+		})
+	}
+	return ids
+}`
+
+const _MarshalEasyJSON_zoneItemMap_func string = `func (m zoneItemMap) MarshalEasyJSON(out *jwriter.Writer) {
+	if m == nil && out.Flags&jwriter.NilMapAsEmpty == 0 {
+		out.RawString("null")
+		return
+	}
+	out.RawByte('{')
+	for i, id := range m.keys(out.Flags) {
+		if i > 0 {
+			out.RawByte(',')
+		}
+		out.IntStr(int(id))
+		out.RawByte(':')
+		m[id].MarshalEasyJSON(out)
+	}
+	out.RawByte('}')
+}`
+
+const itemReferenceMap_type string = `type itemReferenceMap map[ItemID]ItemReference`
+
+const keys_itemReferenceMap_func string = `func (m itemReferenceMap) keys(flags jwriter.Flags) []ItemID {
+	ids := make([]ItemID, 0, len(m))
+	for id := range m {
+		ids = append(ids, id)
+	}
+	if flags&sortedMapKeys != 0 {
+		sort.Slice(ids, func(i, j int) bool {
+			return ids[i] < ids[j]
+		})
+	}
+	return ids
+}`
+
+const _MarshalEasyJSON_itemReferenceMap_func string = `func (m itemReferenceMap) MarshalEasyJSON(out *jwriter.Writer) {
+	if m == nil && out.Flags&jwriter.NilMapAsEmpty == 0 {
+		out.RawString("null")
+		return
+	}
+	out.RawByte('{')
+	for i, id := range m.keys(out.Flags) {
+		if i > 0 {
+			out.RawByte(',')
+		}
+		out.IntStr(int(id))
+		out.RawByte(':')
+		m[id].MarshalEasyJSON(out)
+	}
+	out.RawByte('}')
+}`
+
+const equipmentSetReferenceMap_type string = `type equipmentSetReferenceMap map[EquipmentSetID]EquipmentSetReference`
+
+const keys_equipmentSetReferenceMap_func string = `func (m equipmentSetReferenceMap) keys(flags jwriter.Flags) []EquipmentSetID {
+	ids := make([]EquipmentSetID, 0, len(m))
+	for id := range m {
+		ids = append(ids, id)
+	}
+	if flags&sortedMapKeys != 0 {
+		sort.Slice(ids, func(i, j int) bool {
+			return ids[i] < ids[j]
+		})
+	}
+	return ids
+}`
+
+const _MarshalEasyJSON_equipmentSetReferenceMap_func string = `func (m equipmentSetReferenceMap) MarshalEasyJSON(out *jwriter.Writer) {
+	if m == nil && out.Flags&jwriter.NilMapAsEmpty == 0 {
+		out.RawString("null")
+		return
+	}
+	out.RawByte('{')
+	for i, id := range m.keys(out.Flags) {
+		if i > 0 {
+			out.RawByte(',')
+		}
+		out.IntStr(int(id))
+		out.RawByte(':')
+		m[id].MarshalEasyJSON(out)
+	}
+	out.RawByte('}')
+}`
+
+const playerReferenceMap_type string = `type playerReferenceMap map[PlayerID]PlayerReference`
+
+const keys_playerReferenceMap_func string = `func (m playerReferenceMap) keys(flags jwriter.Flags) []PlayerID {
+	ids := make([]PlayerID, 0, len(m))
+	for id := range m {
+		ids = append(ids, id)
+	}
+	if flags&sortedMapKeys != 0 {
+		sort.Slice(ids, func(i, j int) bool {
+			return ids[i] < ids[j]
+		})
+	}
+	return ids
+}`
+
+const _MarshalEasyJSON_playerReferenceMap_func string = `func (m playerReferenceMap) MarshalEasyJSON(out *jwriter.Writer) {
+	if m == nil && out.Flags&jwriter.NilMapAsEmpty == 0 {
+		out.RawString("null")
+		return
+	}
+	out.RawByte('{')
+	for i, id := range m.keys(out.Flags) {
+		if i > 0 {
+			out.RawByte(',')
+		}
+		out.IntStr(int(id))
+		out.RawByte(':')
+		m[id].MarshalEasyJSON(out)
+	}
+	out.RawByte('}')
+}`
+
+const anyOfPlayer_ZoneItemReferenceMap_type string = `type anyOfPlayer_ZoneItemReferenceMap map[int]AnyOfPlayer_ZoneItemReference`
+
+const keys_anyOfPlayer_ZoneItemReferenceMap_func string = `func (m anyOfPlayer_ZoneItemReferenceMap) keys(flags jwriter.Flags) []int {
+	ids := make([]int, 0, len(m))
+	for id := range m {
+		ids = append(ids, id)
+	}
+	if flags&sortedMapKeys != 0 {
+		sort.Slice(ids, func(i, j int) bool {
+			return ids[i] < ids[j]
+		})
+	}
+	return ids
+}`
+
+const _MarshalEasyJSON_anyOfPlayer_ZoneItemReferenceMap_func string = `func (m anyOfPlayer_ZoneItemReferenceMap) MarshalEasyJSON(out *jwriter.Writer) {
+	if m == nil && out.Flags&jwriter.NilMapAsEmpty == 0 {
+		out.RawString("null")
+		return
+	}
+	out.RawByte('{')
+	for i, id := range m.keys(out.Flags) {
+		if i > 0 {
+			out.RawByte(',')
+		}
+		out.IntStr(id)
+		out.RawByte(':')
+		m[id].MarshalEasyJSON(out)
+	}
+	out.RawByte('}')
+}`
+
+const anyOfItem_Player_ZoneItemMap_type string = `type anyOfItem_Player_ZoneItemMap map[int]interface{}`
+
+const keys_anyOfItem_Player_ZoneItemMap_func string = `func (m anyOfItem_Player_ZoneItemMap) keys(flags jwriter.Flags) []int {
+	ids := make([]int, 0, len(m))
+	for id := range m {
+		ids = append(ids, id)
+	}
+	if flags&sortedMapKeys != 0 {
+		sort.Slice(ids, func(i, j int) bool {
+			return ids[i] < ids[j]
+		})
+	}
+	return ids
+}`
+
+const _MarshalEasyJSON_anyOfItem_Player_ZoneItemMap_func string = `func (m anyOfItem_Player_ZoneItemMap) MarshalEasyJSON(out *jwriter.Writer) {
+	if m == nil && out.Flags&jwriter.NilMapAsEmpty == 0 {
+		out.RawString("null")
+		return
+	}
+	out.RawByte('{')
+	for i, id := range m.keys(out.Flags) {
+		if i > 0 {
+			out.RawByte(',')
+		}
+		out.IntStr(id)
+		out.RawByte(':')
+		if marshaler, ok := m[id].(easyjson.Marshaler); ok {
+			marshaler.MarshalEasyJSON(out)
+		} else {
+			out.Raw(json.Marshal(m[id]))
+		}
+	}
+	out.RawByte('}')
+}`
+
+const path_go_import string = `import "strconv"`
+
+const equipmentSetIdentifier_type string = `const (
+	equipmentSetIdentifier	int	= -1
 	gearScoreIdentifier	int	= -2
 	itemIdentifier		int	= -3
 	originIdentifier	int	= -4
@@ -3220,9 +6433,10 @@ const equipmentSetIdentifier_type string = `const (
 	itemsIdentifier		int	= -6
 	positionIdentifier	int	= -7
 	zoneIdentifier		int	= -8
-	interactablesIdentifier	int	= -9
-	playersIdentifier	int	= -10
-	zoneItemIdentifier	int	= -11
+	bossIdentifier		int	= -9
+	interactablesIdentifier	int	= -10
+	playersIdentifier	int	= -11
+	zoneItemIdentifier	int	= -12
 )`
 
 const path_type string = `type path []int`
@@ -3294,6 +6508,13 @@ const zone_path_func string = `func (p path) zone() path {
 	return newPath
 }`
 
+const boss_path_func string = `func (p path) boss() path {
+	newPath := make([]int, len(p), len(p)+1)
+	copy(newPath, p)
+	newPath = append(newPath, bossIdentifier)
+	return newPath
+}`
+
 const zoneItem_path_func string = `func (p path) zoneItem() path {
 	newPath := make([]int, len(p), len(p)+1)
 	copy(newPath, p)
@@ -3327,6 +6548,18 @@ const equals_path_func string = `func (p path) equals(parentPath path) bool {
 	return true
 }`
 
+const isWithin_path_func string = `func (p path) isWithin(ancestorPath path) bool {
+	if len(p) < len(ancestorPath) {
+		return false
+	}
+	for i, segment := range ancestorPath {
+		if segment != p[i] {
+			return false
+		}
+	}
+	return true
+}`
+
 const toJSONPath_path_func string = `func (p path) toJSONPath() string {
 	jsonPath := "$"
 	for i, seg := range p {
@@ -3359,6 +6592,8 @@ const pathIdentifierToString_func string = `func pathIdentifierToString(identifi
 		return "position"
 	case zoneIdentifier:
 		return "zone"
+	case bossIdentifier:
+		return "boss"
 	case interactablesIdentifier:
 		return "interactables"
 	case playersIdentifier:
@@ -3381,101 +6616,571 @@ const zoneItemIDSlicePool_type string = `var zoneItemIDSlicePool = sync.Pool{New
 	return make([]ZoneItemID, 0)
 }}`
 
-const zoneCheckPool_type string = `var zoneCheckPool = sync.Pool{New: func() interface{} {
-	return make(map[ZoneID]bool)
-}}`
+const zoneCheckPool_type string = `var zoneCheckPool = sync.Pool{New: func() interface{} {
+	return make(map[ZoneID]bool)
+}}`
+
+const zoneIDSlicePool_type string = `var zoneIDSlicePool = sync.Pool{New: func() interface{} {
+	return make([]ZoneID, 0)
+}}`
+
+const playerCheckPool_type string = `var playerCheckPool = sync.Pool{New: func() interface{} {
+	return make(map[PlayerID]bool)
+}}`
+
+const playerIDSlicePool_type string = `var playerIDSlicePool = sync.Pool{New: func() interface{} {
+	return make([]PlayerID, 0)
+}}`
+
+const positionCheckPool_type string = `var positionCheckPool = sync.Pool{New: func() interface{} {
+	return make(map[PositionID]bool)
+}}`
+
+const positionIDSlicePool_type string = `var positionIDSlicePool = sync.Pool{New: func() interface{} {
+	return make([]PositionID, 0)
+}}`
+
+const itemCheckPool_type string = `var itemCheckPool = sync.Pool{New: func() interface{} {
+	return make(map[ItemID]bool)
+}}`
+
+const itemIDSlicePool_type string = `var itemIDSlicePool = sync.Pool{New: func() interface{} {
+	return make([]ItemID, 0)
+}}`
+
+const gearScoreCheckPool_type string = `var gearScoreCheckPool = sync.Pool{New: func() interface{} {
+	return make(map[GearScoreID]bool)
+}}`
+
+const gearScoreIDSlicePool_type string = `var gearScoreIDSlicePool = sync.Pool{New: func() interface{} {
+	return make([]GearScoreID, 0)
+}}`
+
+const equipmentSetCheckPool_type string = `var equipmentSetCheckPool = sync.Pool{New: func() interface{} {
+	return make(map[EquipmentSetID]bool)
+}}`
+
+const equipmentSetIDSlicePool_type string = `var equipmentSetIDSlicePool = sync.Pool{New: func() interface{} {
+	return make([]EquipmentSetID, 0)
+}}`
+
+const playerTargetedByRefCheckPool_type string = `var playerTargetedByRefCheckPool = sync.Pool{New: func() interface{} {
+	return make(map[PlayerTargetedByRefID]bool)
+}}`
+
+const playerTargetedByRefIDSlicePool_type string = `var playerTargetedByRefIDSlicePool = sync.Pool{New: func() interface{} {
+	return make([]PlayerTargetedByRefID, 0)
+}}`
+
+const playerTargetRefCheckPool_type string = `var playerTargetRefCheckPool = sync.Pool{New: func() interface{} {
+	return make(map[PlayerTargetRefID]bool)
+}}`
+
+const playerTargetRefIDSlicePool_type string = `var playerTargetRefIDSlicePool = sync.Pool{New: func() interface{} {
+	return make([]PlayerTargetRefID, 0)
+}}`
+
+const itemBoundToRefCheckPool_type string = `var itemBoundToRefCheckPool = sync.Pool{New: func() interface{} {
+	return make(map[ItemBoundToRefID]bool)
+}}`
+
+const itemBoundToRefIDSlicePool_type string = `var itemBoundToRefIDSlicePool = sync.Pool{New: func() interface{} {
+	return make([]ItemBoundToRefID, 0)
+}}`
+
+const playerGuildMemberRefCheckPool_type string = `var playerGuildMemberRefCheckPool = sync.Pool{New: func() interface{} {
+	return make(map[PlayerGuildMemberRefID]bool)
+}}`
+
+const playerGuildMemberRefIDSlicePool_type string = `var playerGuildMemberRefIDSlicePool = sync.Pool{New: func() interface{} {
+	return make([]PlayerGuildMemberRefID, 0)
+}}`
+
+const playerEquipmentSetRefCheckPool_type string = `var playerEquipmentSetRefCheckPool = sync.Pool{New: func() interface{} {
+	return make(map[PlayerEquipmentSetRefID]bool)
+}}`
+
+const playerEquipmentSetRefIDSlicePool_type string = `var playerEquipmentSetRefIDSlicePool = sync.Pool{New: func() interface{} {
+	return make([]PlayerEquipmentSetRefID, 0)
+}}`
+
+const equipmentSetEquipmentRefCheckPool_type string = `var equipmentSetEquipmentRefCheckPool = sync.Pool{New: func() interface{} {
+	return make(map[EquipmentSetEquipmentRefID]bool)
+}}`
+
+const equipmentSetEquipmentRefIDSlicePool_type string = `var equipmentSetEquipmentRefIDSlicePool = sync.Pool{New: func() interface{} {
+	return make([]EquipmentSetEquipmentRefID, 0)
+}}`
+
+const query_go_import string = `import (
+	"sort"
+)`
+
+const index_type string = `type index struct {
+	gearScoreScore	map[int]map[GearScoreID]bool
+	itemName	map[string]map[ItemID]bool
+}`
+
+const newIndex_func string = `func newIndex() index {
+	return index{gearScoreScore: make(map[int]map[GearScoreID]bool), itemName: make(map[string]map[ItemID]bool)}
+}`
+
+const indexGearScoreScore_Engine_func string = `func (engine *Engine) indexGearScoreScore(gearScoreID GearScoreID, value int) {
+	gearScoreIDs, ok := engine.index.gearScoreScore[value]
+	if !ok {
+		gearScoreIDs = make(map[GearScoreID]bool)
+		engine.index.gearScoreScore[value] = gearScoreIDs
+	}
+	gearScoreIDs[gearScoreID] = true
+}`
+
+const unindexGearScoreScore_Engine_func string = `func (engine *Engine) unindexGearScoreScore(gearScoreID GearScoreID, value int) {
+	gearScoreIDs := engine.index.gearScoreScore[value]
+	delete(gearScoreIDs, gearScoreID)
+	if len(gearScoreIDs) == 0 {
+		delete(engine.index.gearScoreScore, value)
+	}
+}`
+
+const indexItemName_Engine_func string = `func (engine *Engine) indexItemName(itemID ItemID, value string) {
+	itemIDs, ok := engine.index.itemName[value]
+	if !ok {
+		itemIDs = make(map[ItemID]bool)
+		engine.index.itemName[value] = itemIDs
+	}
+	itemIDs[itemID] = true
+}`
+
+const unindexItemName_Engine_func string = `func (engine *Engine) unindexItemName(itemID ItemID, value string) {
+	itemIDs := engine.index.itemName[value]
+	delete(itemIDs, itemID)
+	if len(itemIDs) == 0 {
+		delete(engine.index.itemName, value)
+	}
+}`
+
+const equipmentSetQuery_type string = `type equipmentSetQuery struct {
+	engine		*Engine
+	equipmentSetIDs	map[EquipmentSetID]bool
+	narrowed	bool
+	filters		[]func(equipmentSet) bool
+}`
+
+const _QueryEquipmentSets_Engine_func string = `func (engine *Engine) QueryEquipmentSets() equipmentSetQuery {
+	return equipmentSetQuery{engine: engine}
+}`
+
+const _Where_equipmentSetQuery_func string = `func (query equipmentSetQuery) Where(filter func(equipmentSet) bool) equipmentSetQuery {
+	filters := make([]func(equipmentSet) bool, len(query.filters), len(query.filters)+1)
+	copy(filters, query.filters)
+	query.filters = append(filters, filter)
+	return query
+}`
+
+const matches_equipmentSetQuery_func string = `func (query equipmentSetQuery) matches(equipmentSet equipmentSet) bool {
+	if equipmentSet.equipmentSet.OperationKind == OperationKindDelete {
+		return false
+	}
+	for _, filter := range query.filters {
+		if !filter(equipmentSet) {
+			return false
+		}
+	}
+	return true
+}`
+
+const _All_equipmentSetQuery_func string = `func (query equipmentSetQuery) All() []equipmentSet {
+	var equipmentSets []equipmentSet
+	if query.narrowed {
+		for equipmentSetID := range query.equipmentSetIDs {
+			equipmentSet := query.engine.EquipmentSet(equipmentSetID)
+			if query.matches(equipmentSet) {
+				equipmentSets = append(equipmentSets, equipmentSet)
+			}
+		}
+		if query.engine.ordered {
+			sort.Slice(equipmentSets, func(i, j int) bool {
+				return equipmentSets[i].equipmentSet.ID < equipmentSets[j].equipmentSet.ID
+			})
+		}
+		return equipmentSets
+	}
+	equipmentSetIDs := query.engine.allEquipmentSetIDs()
+	for _, equipmentSetID := range equipmentSetIDs {
+		equipmentSet := query.engine.EquipmentSet(equipmentSetID)
+		if query.matches(equipmentSet) {
+			equipmentSets = append(equipmentSets, equipmentSet)
+		}
+	}
+	equipmentSetIDSlicePool.Put(equipmentSetIDs)
+	return equipmentSets
+}`
+
+const gearScoreQuery_type string = `type gearScoreQuery struct {
+	engine		*Engine
+	gearScoreIDs	map[GearScoreID]bool
+	narrowed	bool
+	filters		[]func(gearScore) bool
+}`
+
+const _QueryGearScores_Engine_func string = `func (engine *Engine) QueryGearScores() gearScoreQuery {
+	return gearScoreQuery{engine: engine}
+}`
+
+const _Where_gearScoreQuery_func string = `func (query gearScoreQuery) Where(filter func(gearScore) bool) gearScoreQuery {
+	filters := make([]func(gearScore) bool, len(query.filters), len(query.filters)+1)
+	copy(filters, query.filters)
+	query.filters = append(filters, filter)
+	return query
+}`
+
+const _WhereScore_gearScoreQuery_func string = `func (query gearScoreQuery) WhereScore(score int) gearScoreQuery {
+	gearScoreIDs := make(map[GearScoreID]bool)
+	for gearScoreID := range query.engine.index.gearScoreScore[score] {
+		if !query.narrowed || query.gearScoreIDs[gearScoreID] {
+			gearScoreIDs[gearScoreID] = true
+		}
+	}
+	query.gearScoreIDs = gearScoreIDs
+	query.narrowed = true
+	return query
+}`
+
+const matches_gearScoreQuery_func string = `func (query gearScoreQuery) matches(gearScore gearScore) bool {
+	if gearScore.gearScore.OperationKind == OperationKindDelete {
+		return false
+	}
+	for _, filter := range query.filters {
+		if !filter(gearScore) {
+			return false
+		}
+	}
+	return true
+}`
+
+const _All_gearScoreQuery_func string = `func (query gearScoreQuery) All() []gearScore {
+	var gearScores []gearScore
+	if query.narrowed {
+		for gearScoreID := range query.gearScoreIDs {
+			gearScore := query.engine.GearScore(gearScoreID)
+			if query.matches(gearScore) {
+				gearScores = append(gearScores, gearScore)
+			}
+		}
+		if query.engine.ordered {
+			sort.Slice(gearScores, func(i, j int) bool {
+				return gearScores[i].gearScore.ID < gearScores[j].gearScore.ID
+			})
+		}
+		return gearScores
+	}
+	gearScoreIDs := query.engine.allGearScoreIDs()
+	for _, gearScoreID := range gearScoreIDs {
+		gearScore := query.engine.GearScore(gearScoreID)
+		if query.matches(gearScore) {
+			gearScores = append(gearScores, gearScore)
+		}
+	}
+	gearScoreIDSlicePool.Put(gearScoreIDs)
+	return gearScores
+}`
+
+const itemQuery_type string = `type itemQuery struct {
+	engine		*Engine
+	itemIDs		map[ItemID]bool
+	narrowed	bool
+	filters		[]func(item) bool
+}`
+
+const _QueryItems_Engine_func string = `func (engine *Engine) QueryItems() itemQuery {
+	return itemQuery{engine: engine}
+}`
+
+const _Where_itemQuery_func string = `func (query itemQuery) Where(filter func(item) bool) itemQuery {
+	filters := make([]func(item) bool, len(query.filters), len(query.filters)+1)
+	copy(filters, query.filters)
+	query.filters = append(filters, filter)
+	return query
+}`
 
-const zoneIDSlicePool_type string = `var zoneIDSlicePool = sync.Pool{New: func() interface{} {
-	return make([]ZoneID, 0)
-}}`
+const _WhereName_itemQuery_func string = `func (query itemQuery) WhereName(name string) itemQuery {
+	itemIDs := make(map[ItemID]bool)
+	for itemID := range query.engine.index.itemName[name] {
+		if !query.narrowed || query.itemIDs[itemID] {
+			itemIDs[itemID] = true
+		}
+	}
+	query.itemIDs = itemIDs
+	query.narrowed = true
+	return query
+}`
 
-const playerCheckPool_type string = `var playerCheckPool = sync.Pool{New: func() interface{} {
-	return make(map[PlayerID]bool)
-}}`
+const matches_itemQuery_func string = `func (query itemQuery) matches(item item) bool {
+	if item.item.OperationKind == OperationKindDelete {
+		return false
+	}
+	for _, filter := range query.filters {
+		if !filter(item) {
+			return false
+		}
+	}
+	return true
+}`
 
-const playerIDSlicePool_type string = `var playerIDSlicePool = sync.Pool{New: func() interface{} {
-	return make([]PlayerID, 0)
-}}`
+const _All_itemQuery_func string = `func (query itemQuery) All() []item {
+	var items []item
+	if query.narrowed {
+		for itemID := range query.itemIDs {
+			item := query.engine.Item(itemID)
+			if query.matches(item) {
+				items = append(items, item)
+			}
+		}
+		if query.engine.ordered {
+			sort.Slice(items, func(i, j int) bool {
+				return items[i].item.ID < items[j].item.ID
+			})
+		}
+		return items
+	}
+	itemIDs := query.engine.allItemIDs()
+	for _, itemID := range itemIDs {
+		item := query.engine.Item(itemID)
+		if query.matches(item) {
+			items = append(items, item)
+		}
+	}
+	itemIDSlicePool.Put(itemIDs)
+	return items
+}`
 
-const positionCheckPool_type string = `var positionCheckPool = sync.Pool{New: func() interface{} {
-	return make(map[PositionID]bool)
-}}`
+const playerQuery_type string = `type playerQuery struct {
+	engine		*Engine
+	playerIDs	map[PlayerID]bool
+	narrowed	bool
+	filters		[]func(player) bool
+}`
 
-const positionIDSlicePool_type string = `var positionIDSlicePool = sync.Pool{New: func() interface{} {
-	return make([]PositionID, 0)
-}}`
+const _QueryPlayers_Engine_func string = `func (engine *Engine) QueryPlayers() playerQuery {
+	return playerQuery{engine: engine}
+}`
 
-const itemCheckPool_type string = `var itemCheckPool = sync.Pool{New: func() interface{} {
-	return make(map[ItemID]bool)
-}}`
+const _Where_playerQuery_func string = `func (query playerQuery) Where(filter func(player) bool) playerQuery {
+	filters := make([]func(player) bool, len(query.filters), len(query.filters)+1)
+	copy(filters, query.filters)
+	query.filters = append(filters, filter)
+	return query
+}`
 
-const itemIDSlicePool_type string = `var itemIDSlicePool = sync.Pool{New: func() interface{} {
-	return make([]ItemID, 0)
-}}`
+const matches_playerQuery_func string = `func (query playerQuery) matches(player player) bool {
+	if player.player.OperationKind == OperationKindDelete {
+		return false
+	}
+	for _, filter := range query.filters {
+		if !filter(player) {
+			return false
+		}
+	}
+	return true
+}`
 
-const gearScoreCheckPool_type string = `var gearScoreCheckPool = sync.Pool{New: func() interface{} {
-	return make(map[GearScoreID]bool)
-}}`
+const _All_playerQuery_func string = `func (query playerQuery) All() []player {
+	var players []player
+	if query.narrowed {
+		for playerID := range query.playerIDs {
+			player := query.engine.Player(playerID)
+			if query.matches(player) {
+				players = append(players, player)
+			}
+		}
+		if query.engine.ordered {
+			sort.Slice(players, func(i, j int) bool {
+				return players[i].player.ID < players[j].player.ID
+			})
+		}
+		return players
+	}
+	playerIDs := query.engine.allPlayerIDs()
+	for _, playerID := range playerIDs {
+		player := query.engine.Player(playerID)
+		if query.matches(player) {
+			players = append(players, player)
+		}
+	}
+	playerIDSlicePool.Put(playerIDs)
+	return players
+}`
 
-const gearScoreIDSlicePool_type string = `var gearScoreIDSlicePool = sync.Pool{New: func() interface{} {
-	return make([]GearScoreID, 0)
-}}`
+const positionQuery_type string = `type positionQuery struct {
+	engine		*Engine
+	positionIDs	map[PositionID]bool
+	narrowed	bool
+	filters		[]func(position) bool
+}`
 
-const equipmentSetCheckPool_type string = `var equipmentSetCheckPool = sync.Pool{New: func() interface{} {
-	return make(map[EquipmentSetID]bool)
-}}`
+const _QueryPositions_Engine_func string = `func (engine *Engine) QueryPositions() positionQuery {
+	return positionQuery{engine: engine}
+}`
 
-const equipmentSetIDSlicePool_type string = `var equipmentSetIDSlicePool = sync.Pool{New: func() interface{} {
-	return make([]EquipmentSetID, 0)
-}}`
+const _Where_positionQuery_func string = `func (query positionQuery) Where(filter func(position) bool) positionQuery {
+	filters := make([]func(position) bool, len(query.filters), len(query.filters)+1)
+	copy(filters, query.filters)
+	query.filters = append(filters, filter)
+	return query
+}`
 
-const playerTargetedByRefCheckPool_type string = `var playerTargetedByRefCheckPool = sync.Pool{New: func() interface{} {
-	return make(map[PlayerTargetedByRefID]bool)
-}}`
+const matches_positionQuery_func string = `func (query positionQuery) matches(position position) bool {
+	if position.position.OperationKind == OperationKindDelete {
+		return false
+	}
+	for _, filter := range query.filters {
+		if !filter(position) {
+			return false
+		}
+	}
+	return true
+}`
 
-const playerTargetedByRefIDSlicePool_type string = `var playerTargetedByRefIDSlicePool = sync.Pool{New: func() interface{} {
-	return make([]PlayerTargetedByRefID, 0)
-}}`
+const _All_positionQuery_func string = `func (query positionQuery) All() []position {
+	var positions []position
+	if query.narrowed {
+		for positionID := range query.positionIDs {
+			position := query.engine.Position(positionID)
+			if query.matches(position) {
+				positions = append(positions, position)
+			}
+		}
+		if query.engine.ordered {
+			sort.Slice(positions, func(i, j int) bool {
+				return positions[i].position.ID < positions[j].position.ID
+			})
+		}
+		return positions
+	}
+	positionIDs := query.engine.allPositionIDs()
+	for _, positionID := range positionIDs {
+		position := query.engine.Position(positionID)
+		if query.matches(position) {
+			positions = append(positions, position)
+		}
+	}
+	positionIDSlicePool.Put(positionIDs)
+	return positions
+}`
 
-const playerTargetRefCheckPool_type string = `var playerTargetRefCheckPool = sync.Pool{New: func() interface{} {
-	return make(map[PlayerTargetRefID]bool)
-}}`
+const zoneQuery_type string = `type zoneQuery struct {
+	engine		*Engine
+	zoneIDs		map[ZoneID]bool
+	narrowed	bool
+	filters		[]func(zone) bool
+}`
 
-const playerTargetRefIDSlicePool_type string = `var playerTargetRefIDSlicePool = sync.Pool{New: func() interface{} {
-	return make([]PlayerTargetRefID, 0)
-}}`
+const _QueryZones_Engine_func string = `func (engine *Engine) QueryZones() zoneQuery {
+	return zoneQuery{engine: engine}
+}`
 
-const itemBoundToRefCheckPool_type string = `var itemBoundToRefCheckPool = sync.Pool{New: func() interface{} {
-	return make(map[ItemBoundToRefID]bool)
-}}`
+const _Where_zoneQuery_func string = `func (query zoneQuery) Where(filter func(zone) bool) zoneQuery {
+	filters := make([]func(zone) bool, len(query.filters), len(query.filters)+1)
+	copy(filters, query.filters)
+	query.filters = append(filters, filter)
+	return query
+}`
 
-const itemBoundToRefIDSlicePool_type string = `var itemBoundToRefIDSlicePool = sync.Pool{New: func() interface{} {
-	return make([]ItemBoundToRefID, 0)
-}}`
+const matches_zoneQuery_func string = `func (query zoneQuery) matches(zone zone) bool {
+	if zone.zone.OperationKind == OperationKindDelete {
+		return false
+	}
+	for _, filter := range query.filters {
+		if !filter(zone) {
+			return false
+		}
+	}
+	return true
+}`
 
-const playerGuildMemberRefCheckPool_type string = `var playerGuildMemberRefCheckPool = sync.Pool{New: func() interface{} {
-	return make(map[PlayerGuildMemberRefID]bool)
-}}`
+const _All_zoneQuery_func string = `func (query zoneQuery) All() []zone {
+	var zones []zone
+	if query.narrowed {
+		for zoneID := range query.zoneIDs {
+			zone := query.engine.Zone(zoneID)
+			if query.matches(zone) {
+				zones = append(zones, zone)
+			}
+		}
+		if query.engine.ordered {
+			sort.Slice(zones, func(i, j int) bool {
+				return zones[i].zone.ID < zones[j].zone.ID
+			})
+		}
+		return zones
+	}
+	zoneIDs := query.engine.allZoneIDs()
+	for _, zoneID := range zoneIDs {
+		zone := query.engine.Zone(zoneID)
+		if query.matches(zone) {
+			zones = append(zones, zone)
+		}
+	}
+	zoneIDSlicePool.Put(zoneIDs)
+	return zones
+}`
 
-const playerGuildMemberRefIDSlicePool_type string = `var playerGuildMemberRefIDSlicePool = sync.Pool{New: func() interface{} {
-	return make([]PlayerGuildMemberRefID, 0)
-}}`
+const zoneItemQuery_type string = `type zoneItemQuery struct {
+	engine		*Engine
+	zoneItemIDs	map[ZoneItemID]bool
+	narrowed	bool
+	filters		[]func(zoneItem) bool
+}`
 
-const playerEquipmentSetRefCheckPool_type string = `var playerEquipmentSetRefCheckPool = sync.Pool{New: func() interface{} {
-	return make(map[PlayerEquipmentSetRefID]bool)
-}}`
+const _QueryZoneItems_Engine_func string = `func (engine *Engine) QueryZoneItems() zoneItemQuery {
+	return zoneItemQuery{engine: engine}
+}`
 
-const playerEquipmentSetRefIDSlicePool_type string = `var playerEquipmentSetRefIDSlicePool = sync.Pool{New: func() interface{} {
-	return make([]PlayerEquipmentSetRefID, 0)
-}}`
+const _Where_zoneItemQuery_func string = `func (query zoneItemQuery) Where(filter func(zoneItem) bool) zoneItemQuery {
+	filters := make([]func(zoneItem) bool, len(query.filters), len(query.filters)+1)
+	copy(filters, query.filters)
+	query.filters = append(filters, filter)
+	return query
+}`
 
-const equipmentSetEquipmentRefCheckPool_type string = `var equipmentSetEquipmentRefCheckPool = sync.Pool{New: func() interface{} {
-	return make(map[EquipmentSetEquipmentRefID]bool)
-}}`
+const matches_zoneItemQuery_func string = `func (query zoneItemQuery) matches(zoneItem zoneItem) bool {
+	if zoneItem.zoneItem.OperationKind == OperationKindDelete {
+		return false
+	}
+	for _, filter := range query.filters {
+		if !filter(zoneItem) {
+			return false
+		}
+	}
+	return true
+}`
 
-const equipmentSetEquipmentRefIDSlicePool_type string = `var equipmentSetEquipmentRefIDSlicePool = sync.Pool{New: func() interface{} {
-	return make([]EquipmentSetEquipmentRefID, 0)
-}}`
+const _All_zoneItemQuery_func string = `func (query zoneItemQuery) All() []zoneItem {
+	var zoneItems []zoneItem
+	if query.narrowed {
+		for zoneItemID := range query.zoneItemIDs {
+			zoneItem := query.engine.ZoneItem(zoneItemID)
+			if query.matches(zoneItem) {
+				zoneItems = append(zoneItems, zoneItem)
+			}
+		}
+		if query.engine.ordered {
+			sort.Slice(zoneItems, func(i, j int) bool {
+				return zoneItems[i].zoneItem.ID < zoneItems[j].zoneItem.ID
+			})
+		}
+		return zoneItems
+	}
+	zoneItemIDs := query.engine.allZoneItemIDs()
+	for _, zoneItemID := range zoneItemIDs {
+		zoneItem := query.engine.ZoneItem(zoneItemID)
+		if query.matches(zoneItem) {
+			zoneItems = append(zoneItems, zoneItem)
+		}
+	}
+	zoneItemIDSlicePool.Put(zoneItemIDs)
+	return zoneItems
+}`
 
 const _IsSet_itemBoundToRef_func string = `func (_ref itemBoundToRef) IsSet() bool {
 	ref := _ref.itemBoundToRef.engine.itemBoundToRef(_ref.itemBoundToRef.ID)
@@ -3655,6 +7360,7 @@ const dereferencePlayerTargetedByRefsZoneItem_Engine_func string = `func (engine
 const _RemovePlayers_zone_func string = `func (_zone zone) RemovePlayers(playersToRemove ...PlayerID) zone {
 	zone := _zone.zone.engine.Zone(_zone.zone.ID)
 	if zone.zone.OperationKind == OperationKindDelete {
+		zone.zone.engine.recordError("RemovePlayers", ElementKindZone, int(_zone.zone.ID), ErrElementDoesNotExist)
 		return zone
 	}
 	var wereElementsAltered bool
@@ -3685,6 +7391,7 @@ const _RemovePlayers_zone_func string = `func (_zone zone) RemovePlayers(players
 const _RemoveItems_zone_func string = `func (_zone zone) RemoveItems(itemsToRemove ...ZoneItemID) zone {
 	zone := _zone.zone.engine.Zone(_zone.zone.ID)
 	if zone.zone.OperationKind == OperationKindDelete {
+		zone.zone.engine.recordError("RemoveItems", ElementKindZone, int(_zone.zone.ID), ErrElementDoesNotExist)
 		return zone
 	}
 	var wereElementsAltered bool
@@ -3715,6 +7422,7 @@ const _RemoveItems_zone_func string = `func (_zone zone) RemoveItems(itemsToRemo
 const _RemoveInteractablesItem_zone_func string = `func (_zone zone) RemoveInteractablesItem(itemsToRemove ...ItemID) zone {
 	zone := _zone.zone.engine.Zone(_zone.zone.ID)
 	if zone.zone.OperationKind == OperationKindDelete {
+		zone.zone.engine.recordError("RemoveInteractablesItem", ElementKindZone, int(_zone.zone.ID), ErrElementDoesNotExist)
 		return zone
 	}
 	var wereElementsAltered bool
@@ -3747,6 +7455,7 @@ const _RemoveInteractablesItem_zone_func string = `func (_zone zone) RemoveInter
 const _RemoveInteractablesPlayer_zone_func string = `func (_zone zone) RemoveInteractablesPlayer(playersToRemove ...PlayerID) zone {
 	zone := _zone.zone.engine.Zone(_zone.zone.ID)
 	if zone.zone.OperationKind == OperationKindDelete {
+		zone.zone.engine.recordError("RemoveInteractablesPlayer", ElementKindZone, int(_zone.zone.ID), ErrElementDoesNotExist)
 		return zone
 	}
 	var wereElementsAltered bool
@@ -3779,6 +7488,7 @@ const _RemoveInteractablesPlayer_zone_func string = `func (_zone zone) RemoveInt
 const _RemoveInteractablesZoneItem_zone_func string = `func (_zone zone) RemoveInteractablesZoneItem(zoneItemsToRemove ...ZoneItemID) zone {
 	zone := _zone.zone.engine.Zone(_zone.zone.ID)
 	if zone.zone.OperationKind == OperationKindDelete {
+		zone.zone.engine.recordError("RemoveInteractablesZoneItem", ElementKindZone, int(_zone.zone.ID), ErrElementDoesNotExist)
 		return zone
 	}
 	var wereElementsAltered bool
@@ -3811,6 +7521,7 @@ const _RemoveInteractablesZoneItem_zone_func string = `func (_zone zone) RemoveI
 const _RemoveItems_player_func string = `func (_player player) RemoveItems(itemsToRemove ...ItemID) player {
 	player := _player.player.engine.Player(_player.player.ID)
 	if player.player.OperationKind == OperationKindDelete {
+		player.player.engine.recordError("RemoveItems", ElementKindPlayer, int(_player.player.ID), ErrElementDoesNotExist)
 		return player
 	}
 	var wereElementsAltered bool
@@ -3841,6 +7552,7 @@ const _RemoveItems_player_func string = `func (_player player) RemoveItems(items
 const _RemoveEquipmentSets_player_func string = `func (_player player) RemoveEquipmentSets(equipmentSetsToRemove ...EquipmentSetID) player {
 	player := _player.player.engine.Player(_player.player.ID)
 	if player.player.OperationKind == OperationKindDelete {
+		player.player.engine.recordError("RemoveEquipmentSets", ElementKindPlayer, int(_player.player.ID), ErrElementDoesNotExist)
 		return player
 	}
 	var wereElementsAltered bool
@@ -3872,6 +7584,7 @@ const _RemoveEquipmentSets_player_func string = `func (_player player) RemoveEqu
 const _RemoveGuildMembers_player_func string = `func (_player player) RemoveGuildMembers(guildMembersToRemove ...PlayerID) player {
 	player := _player.player.engine.Player(_player.player.ID)
 	if player.player.OperationKind == OperationKindDelete {
+		player.player.engine.recordError("RemoveGuildMembers", ElementKindPlayer, int(_player.player.ID), ErrElementDoesNotExist)
 		return player
 	}
 	var wereElementsAltered bool
@@ -3903,6 +7616,7 @@ const _RemoveGuildMembers_player_func string = `func (_player player) RemoveGuil
 const _RemoveTargetedByZoneItem_player_func string = `func (_player player) RemoveTargetedByZoneItem(zoneItemsToRemove ...ZoneItemID) player {
 	player := _player.player.engine.Player(_player.player.ID)
 	if player.player.OperationKind == OperationKindDelete {
+		player.player.engine.recordError("RemoveTargetedByZoneItem", ElementKindPlayer, int(_player.player.ID), ErrElementDoesNotExist)
 		return player
 	}
 	var wereElementsAltered bool
@@ -3935,6 +7649,7 @@ const _RemoveTargetedByZoneItem_player_func string = `func (_player player) Remo
 const _RemoveTargetedByPlayer_player_func string = `func (_player player) RemoveTargetedByPlayer(playersToRemove ...PlayerID) player {
 	player := _player.player.engine.Player(_player.player.ID)
 	if player.player.OperationKind == OperationKindDelete {
+		player.player.engine.recordError("RemoveTargetedByPlayer", ElementKindPlayer, int(_player.player.ID), ErrElementDoesNotExist)
 		return player
 	}
 	var wereElementsAltered bool
@@ -3967,6 +7682,7 @@ const _RemoveTargetedByPlayer_player_func string = `func (_player player) Remove
 const _RemoveTags_zone_func string = `func (_zone zone) RemoveTags(tagsToRemove ...string) zone {
 	zone := _zone.zone.engine.Zone(_zone.zone.ID)
 	if zone.zone.OperationKind == OperationKindDelete {
+		zone.zone.engine.recordError("RemoveTags", ElementKindZone, int(_zone.zone.ID), ErrElementDoesNotExist)
 		return zone
 	}
 	var wereElementsAltered bool
@@ -3996,6 +7712,7 @@ const _RemoveTags_zone_func string = `func (_zone zone) RemoveTags(tagsToRemove
 const _RemoveEquipment_equipmentSet_func string = `func (_equipmentSet equipmentSet) RemoveEquipment(equipmentToRemove ...ItemID) equipmentSet {
 	equipmentSet := _equipmentSet.equipmentSet.engine.EquipmentSet(_equipmentSet.equipmentSet.ID)
 	if equipmentSet.equipmentSet.OperationKind == OperationKindDelete {
+		equipmentSet.equipmentSet.engine.recordError("RemoveEquipment", ElementKindEquipmentSet, int(_equipmentSet.equipmentSet.ID), ErrElementDoesNotExist)
 		return equipmentSet
 	}
 	var wereElementsAltered bool
@@ -4024,9 +7741,41 @@ const _RemoveEquipment_equipmentSet_func string = `func (_equipmentSet equipment
 	return equipmentSet
 }`
 
+const _HasGuildMember_player_func string = `func (_player player) HasGuildMember(playerID PlayerID) bool {
+	player := _player.player.engine.Player(_player.player.ID)
+	return player.player.indexOfGuildMember(playerID) != -1
+}`
+
+const _LenGuildMembers_player_func string = `func (_player player) LenGuildMembers() int {
+	player := _player.player.engine.Player(_player.player.ID)
+	return len(player.player.GuildMembers)
+}`
+
+const indexOfTag_zoneCore_func string = `func (_zone zoneCore) indexOfTag(tag string) int {
+	for i, element := range _zone.Tags {
+		if element == tag {
+			return i
+		}
+	}
+	return -1
+}`
+
+const _HasTag_zone_func string = `func (_zone zone) HasTag(tag string) bool {
+	zone := _zone.zone.engine.Zone(_zone.zone.ID)
+	return zone.zone.indexOfTag(tag) != -1
+}`
+
+const _LenTags_zone_func string = `func (_zone zone) LenTags() int {
+	zone := _zone.zone.engine.Zone(_zone.zone.ID)
+	return len(zone.zone.Tags)
+}`
+
+const setters_go_import string = `import "time"`
+
 const _SetLevel_gearScore_func string = `func (_gearScore gearScore) SetLevel(newLevel int) gearScore {
 	gearScore := _gearScore.gearScore.engine.GearScore(_gearScore.gearScore.ID)
 	if gearScore.gearScore.OperationKind == OperationKindDelete {
+		gearScore.gearScore.engine.recordError("SetLevel", ElementKindGearScore, int(_gearScore.gearScore.ID), ErrElementDoesNotExist)
 		return gearScore
 	}
 	gearScore.gearScore.Level = newLevel
@@ -4038,9 +7787,12 @@ const _SetLevel_gearScore_func string = `func (_gearScore gearScore) SetLevel(ne
 const _SetScore_gearScore_func string = `func (_gearScore gearScore) SetScore(newScore int) gearScore {
 	gearScore := _gearScore.gearScore.engine.GearScore(_gearScore.gearScore.ID)
 	if gearScore.gearScore.OperationKind == OperationKindDelete {
+		gearScore.gearScore.engine.recordError("SetScore", ElementKindGearScore, int(_gearScore.gearScore.ID), ErrElementDoesNotExist)
 		return gearScore
 	}
+	gearScore.gearScore.engine.unindexGearScoreScore(gearScore.gearScore.ID, gearScore.gearScore.Score)
 	gearScore.gearScore.Score = newScore
+	gearScore.gearScore.engine.indexGearScoreScore(gearScore.gearScore.ID, newScore)
 	gearScore.gearScore.OperationKind = OperationKindUpdate
 	gearScore.gearScore.engine.Patch.GearScore[gearScore.gearScore.ID] = gearScore.gearScore
 	return gearScore
@@ -4049,6 +7801,7 @@ const _SetScore_gearScore_func string = `func (_gearScore gearScore) SetScore(ne
 const _SetX_position_func string = `func (_position position) SetX(newX float64) position {
 	position := _position.position.engine.Position(_position.position.ID)
 	if position.position.OperationKind == OperationKindDelete {
+		position.position.engine.recordError("SetX", ElementKindPosition, int(_position.position.ID), ErrElementDoesNotExist)
 		return position
 	}
 	position.position.X = newX
@@ -4060,6 +7813,7 @@ const _SetX_position_func string = `func (_position position) SetX(newX float64)
 const _SetY_position_func string = `func (_position position) SetY(newY float64) position {
 	position := _position.position.engine.Position(_position.position.ID)
 	if position.position.OperationKind == OperationKindDelete {
+		position.position.engine.recordError("SetY", ElementKindPosition, int(_position.position.ID), ErrElementDoesNotExist)
 		return position
 	}
 	position.position.Y = newY
@@ -4071,9 +7825,12 @@ const _SetY_position_func string = `func (_position position) SetY(newY float64)
 const _SetName_item_func string = `func (_item item) SetName(newName string) item {
 	item := _item.item.engine.Item(_item.item.ID)
 	if item.item.OperationKind == OperationKindDelete {
+		item.item.engine.recordError("SetName", ElementKindItem, int(_item.item.ID), ErrElementDoesNotExist)
 		return item
 	}
+	item.item.engine.unindexItemName(item.item.ID, item.item.Name)
 	item.item.Name = newName
+	item.item.engine.indexItemName(item.item.ID, newName)
 	item.item.OperationKind = OperationKindUpdate
 	item.item.engine.Patch.Item[item.item.ID] = item.item
 	return item
@@ -4082,9 +7839,11 @@ const _SetName_item_func string = `func (_item item) SetName(newName string) ite
 const _SetBoundTo_item_func string = `func (_item item) SetBoundTo(playerID PlayerID) item {
 	item := _item.item.engine.Item(_item.item.ID)
 	if item.item.OperationKind == OperationKindDelete {
+		item.item.engine.recordError("SetBoundTo", ElementKindItem, int(_item.item.ID), ErrElementDoesNotExist)
 		return item
 	}
 	if item.item.engine.Player(playerID).player.OperationKind == OperationKindDelete {
+		item.item.engine.recordError("SetBoundTo", ElementKindPlayer, int(playerID), ErrElementDoesNotExist)
 		return item
 	}
 	if item.item.BoundTo != 0 {
@@ -4100,6 +7859,7 @@ const _SetBoundTo_item_func string = `func (_item item) SetBoundTo(playerID Play
 const _SetName_equipmentSet_func string = `func (_equipmentSet equipmentSet) SetName(newName string) equipmentSet {
 	equipmentSet := _equipmentSet.equipmentSet.engine.EquipmentSet(_equipmentSet.equipmentSet.ID)
 	if equipmentSet.equipmentSet.OperationKind == OperationKindDelete {
+		equipmentSet.equipmentSet.engine.recordError("SetName", ElementKindEquipmentSet, int(_equipmentSet.equipmentSet.ID), ErrElementDoesNotExist)
 		return equipmentSet
 	}
 	equipmentSet.equipmentSet.Name = newName
@@ -4108,12 +7868,62 @@ const _SetName_equipmentSet_func string = `func (_equipmentSet equipmentSet) Set
 	return equipmentSet
 }`
 
+const _SetCreatedAt_equipmentSet_func string = `func (_equipmentSet equipmentSet) SetCreatedAt(newCreatedAt time.Time) equipmentSet {
+	equipmentSet := _equipmentSet.equipmentSet.engine.EquipmentSet(_equipmentSet.equipmentSet.ID)
+	if equipmentSet.equipmentSet.OperationKind == OperationKindDelete {
+		equipmentSet.equipmentSet.engine.recordError("SetCreatedAt", ElementKindEquipmentSet, int(_equipmentSet.equipmentSet.ID), ErrElementDoesNotExist)
+		return equipmentSet
+	}
+	equipmentSet.equipmentSet.CreatedAt = newCreatedAt
+	equipmentSet.equipmentSet.OperationKind = OperationKindUpdate
+	equipmentSet.equipmentSet.engine.Patch.EquipmentSet[equipmentSet.equipmentSet.ID] = equipmentSet.equipmentSet
+	return equipmentSet
+}`
+
+const _SetCooldown_equipmentSet_func string = `func (_equipmentSet equipmentSet) SetCooldown(newCooldown Duration) equipmentSet {
+	equipmentSet := _equipmentSet.equipmentSet.engine.EquipmentSet(_equipmentSet.equipmentSet.ID)
+	if equipmentSet.equipmentSet.OperationKind == OperationKindDelete {
+		equipmentSet.equipmentSet.engine.recordError("SetCooldown", ElementKindEquipmentSet, int(_equipmentSet.equipmentSet.ID), ErrElementDoesNotExist)
+		return equipmentSet
+	}
+	equipmentSet.equipmentSet.Cooldown = newCooldown
+	equipmentSet.equipmentSet.OperationKind = OperationKindUpdate
+	equipmentSet.equipmentSet.engine.Patch.EquipmentSet[equipmentSet.equipmentSet.ID] = equipmentSet.equipmentSet
+	return equipmentSet
+}`
+
+const _SetExternalID_equipmentSet_func string = `func (_equipmentSet equipmentSet) SetExternalID(newExternalID BigID) equipmentSet {
+	equipmentSet := _equipmentSet.equipmentSet.engine.EquipmentSet(_equipmentSet.equipmentSet.ID)
+	if equipmentSet.equipmentSet.OperationKind == OperationKindDelete {
+		equipmentSet.equipmentSet.engine.recordError("SetExternalID", ElementKindEquipmentSet, int(_equipmentSet.equipmentSet.ID), ErrElementDoesNotExist)
+		return equipmentSet
+	}
+	equipmentSet.equipmentSet.ExternalID = newExternalID
+	equipmentSet.equipmentSet.OperationKind = OperationKindUpdate
+	equipmentSet.equipmentSet.engine.Patch.EquipmentSet[equipmentSet.equipmentSet.ID] = equipmentSet.equipmentSet
+	return equipmentSet
+}`
+
+const _SetIcon_equipmentSet_func string = `func (_equipmentSet equipmentSet) SetIcon(newIcon []byte) equipmentSet {
+	equipmentSet := _equipmentSet.equipmentSet.engine.EquipmentSet(_equipmentSet.equipmentSet.ID)
+	if equipmentSet.equipmentSet.OperationKind == OperationKindDelete {
+		equipmentSet.equipmentSet.engine.recordError("SetIcon", ElementKindEquipmentSet, int(_equipmentSet.equipmentSet.ID), ErrElementDoesNotExist)
+		return equipmentSet
+	}
+	equipmentSet.equipmentSet.Icon = append([]byte(nil), newIcon...)
+	equipmentSet.equipmentSet.OperationKind = OperationKindUpdate
+	equipmentSet.equipmentSet.engine.Patch.EquipmentSet[equipmentSet.equipmentSet.ID] = equipmentSet.equipmentSet
+	return equipmentSet
+}`
+
 const _SetTargetPlayer_player_func string = `func (_player player) SetTargetPlayer(playerID PlayerID) player {
 	player := _player.player.engine.Player(_player.player.ID)
 	if player.player.OperationKind == OperationKindDelete {
+		player.player.engine.recordError("SetTargetPlayer", ElementKindPlayer, int(_player.player.ID), ErrElementDoesNotExist)
 		return player
 	}
 	if player.player.engine.Player(playerID).player.OperationKind == OperationKindDelete {
+		player.player.engine.recordError("SetTargetPlayer", ElementKindPlayer, int(playerID), ErrElementDoesNotExist)
 		return player
 	}
 	if player.player.Target != 0 {
@@ -4131,9 +7941,11 @@ const _SetTargetPlayer_player_func string = `func (_player player) SetTargetPlay
 const _SetTargetZoneItem_player_func string = `func (_player player) SetTargetZoneItem(zoneItemID ZoneItemID) player {
 	player := _player.player.engine.Player(_player.player.ID)
 	if player.player.OperationKind == OperationKindDelete {
+		player.player.engine.recordError("SetTargetZoneItem", ElementKindPlayer, int(_player.player.ID), ErrElementDoesNotExist)
 		return player
 	}
 	if player.player.engine.ZoneItem(zoneItemID).zoneItem.OperationKind == OperationKindDelete {
+		player.player.engine.recordError("SetTargetZoneItem", ElementKindZoneItem, int(zoneItemID), ErrElementDoesNotExist)
 		return player
 	}
 	if player.player.Target != 0 {
@@ -4148,6 +7960,8 @@ const _SetTargetZoneItem_player_func string = `func (_player player) SetTargetZo
 	return player
 }`
 
+const state_go_import string = `import "time"`
+
 const _EquipmentSetID_type string = `type EquipmentSetID int`
 
 const _GearScoreID_type string = `type GearScoreID int`
@@ -4205,6 +8019,7 @@ const newState_func string = `func newState() State {
 
 const zoneCore_type string = `type zoneCore struct {
 	ID		ZoneID				` + "`" + `json:"id"` + "`" + `
+	Boss		PlayerID			` + "`" + `json:"boss"` + "`" + `
 	Interactables	[]AnyOfItem_Player_ZoneItemID	` + "`" + `json:"interactables"` + "`" + `
 	Items		[]ZoneItemID			` + "`" + `json:"items"` + "`" + `
 	Players		[]PlayerID			` + "`" + `json:"players"` + "`" + `
@@ -4218,6 +8033,8 @@ const zoneCore_type string = `type zoneCore struct {
 
 const zone_type string = `type zone struct{ zone zoneCore }`
 
+const _ZoneElement_type string = `type ZoneElement = zone`
+
 const zoneItemCore_type string = `type zoneItemCore struct {
 	ID		ZoneItemID	` + "`" + `json:"id"` + "`" + `
 	Item		ItemID		` + "`" + `json:"item"` + "`" + `
@@ -4231,6 +8048,8 @@ const zoneItemCore_type string = `type zoneItemCore struct {
 
 const zoneItem_type string = `type zoneItem struct{ zoneItem zoneItemCore }`
 
+const _ZoneItemElement_type string = `type ZoneItemElement = zoneItem`
+
 const itemCore_type string = `type itemCore struct {
 	ID		ItemID			` + "`" + `json:"id"` + "`" + `
 	BoundTo		ItemBoundToRefID	` + "`" + `json:"boundTo"` + "`" + `
@@ -4246,6 +8065,8 @@ const itemCore_type string = `type itemCore struct {
 
 const item_type string = `type item struct{ item itemCore }`
 
+const _ItemElement_type string = `type ItemElement = item`
+
 const playerCore_type string = `type playerCore struct {
 	ID		PlayerID			` + "`" + `json:"id"` + "`" + `
 	EquipmentSets	[]PlayerEquipmentSetRefID	` + "`" + `json:"equipmentSets"` + "`" + `
@@ -4264,6 +8085,8 @@ const playerCore_type string = `type playerCore struct {
 
 const player_type string = `type player struct{ player playerCore }`
 
+const _PlayerElement_type string = `type PlayerElement = player`
+
 const gearScoreCore_type string = `type gearScoreCore struct {
 	ID		GearScoreID	` + "`" + `json:"id"` + "`" + `
 	Level		int		` + "`" + `json:"level"` + "`" + `
@@ -4277,6 +8100,8 @@ const gearScoreCore_type string = `type gearScoreCore struct {
 
 const gearScore_type string = `type gearScore struct{ gearScore gearScoreCore }`
 
+const _GearScoreElement_type string = `type GearScoreElement = gearScore`
+
 const positionCore_type string = `type positionCore struct {
 	ID		PositionID	` + "`" + `json:"id"` + "`" + `
 	X		float64		` + "`" + `json:"x"` + "`" + `
@@ -4290,9 +8115,15 @@ const positionCore_type string = `type positionCore struct {
 
 const position_type string = `type position struct{ position positionCore }`
 
+const _PositionElement_type string = `type PositionElement = position`
+
 const equipmentSetCore_type string = `type equipmentSetCore struct {
 	ID		EquipmentSetID			` + "`" + `json:"id"` + "`" + `
+	Cooldown	Duration			` + "`" + `json:"cooldown"` + "`" + `
+	CreatedAt	time.Time			` + "`" + `json:"createdAt"` + "`" + `
 	Equipment	[]EquipmentSetEquipmentRefID	` + "`" + `json:"equipment"` + "`" + `
+	ExternalID	BigID				` + "`" + `json:"externalID"` + "`" + `
+	Icon		[]byte				` + "`" + `json:"icon"` + "`" + `
 	Name		string				` + "`" + `json:"name"` + "`" + `
 	OperationKind	OperationKind			` + "`" + `json:"operationKind"` + "`" + `
 	HasParent	bool				` + "`" + `json:"hasParent"` + "`" + `
@@ -4303,6 +8134,8 @@ const equipmentSetCore_type string = `type equipmentSetCore struct {
 
 const equipmentSet_type string = `type equipmentSet struct{ equipmentSet equipmentSetCore }`
 
+const _EquipmentSetElement_type string = `type EquipmentSetElement = equipmentSet`
+
 const itemBoundToRefCore_type string = `type itemBoundToRefCore struct {
 	ID			ItemBoundToRefID	` + "`" + `json:"id"` + "`" + `
 	ParentID		ItemID			` + "`" + `json:"parentID"` + "`" + `
@@ -4415,10 +8248,16 @@ const _Engine_type string = `type Engine struct {
 	assembleCache			assembleCache
 	forceIncludeAssembleCache	assembleCache
 	IDgen				int
+	index				index
+	listeners			listeners
+	strict				bool
+	errs				[]error
+	ordered				bool
+	queueEvent			func(message []byte, clientIDs map[string]bool) error
 }`
 
 const newEngine_func string = `func newEngine() *Engine {
-	return &Engine{IDgen: 1, Patch: newState(), State: newState(), Tree: newTree(), assembleCache: newAssembleCache(), forceIncludeAssembleCache: newAssembleCache()}
+	return &Engine{IDgen: 1, Patch: newState(), State: newState(), Tree: newTree(), assembleCache: newAssembleCache(), forceIncludeAssembleCache: newAssembleCache(), index: newIndex()}
 }`
 
 const _GenerateID_Engine_func string = `func (engine *Engine) GenerateID() int {
@@ -4428,6 +8267,8 @@ const _GenerateID_Engine_func string = `func (engine *Engine) GenerateID() int {
 }`
 
 const _UpdateState_Engine_func string = `func (engine *Engine) UpdateState() {
+	engine.emitChanges()
+	engine.listeners.emitted = false
 	for _, equipmentSet := range engine.Patch.EquipmentSet {
 		if equipmentSet.OperationKind == OperationKindDelete {
 			delete(engine.State.EquipmentSet, equipmentSet.ID)
@@ -4604,8 +8445,11 @@ const _UpdateState_Engine_func string = `func (engine *Engine) UpdateState() {
 	for key := range engine.Patch.AnyOfItem_Player_ZoneItem {
 		delete(engine.Patch.AnyOfItem_Player_ZoneItem, key)
 	}
+	engine.errs = nil
 }`
 
+const tree_go_import string = `import "time"`
+
 const _ReferencedDataStatus_type string = `type ReferencedDataStatus string`
 
 const _ReferencedDataModified_type string = `const (
@@ -4626,17 +8470,17 @@ const _ElementKindEquipmentSet_type string = `const (
 )`
 
 const _Tree_type string = `type Tree struct {
-	EquipmentSet	map[EquipmentSetID]EquipmentSet	` + "`" + `json:"equipmentSet"` + "`" + `
-	GearScore	map[GearScoreID]GearScore	` + "`" + `json:"gearScore"` + "`" + `
-	Item		map[ItemID]Item			` + "`" + `json:"item"` + "`" + `
-	Player		map[PlayerID]Player		` + "`" + `json:"player"` + "`" + `
-	Position	map[PositionID]Position		` + "`" + `json:"position"` + "`" + `
-	Zone		map[ZoneID]Zone			` + "`" + `json:"zone"` + "`" + `
-	ZoneItem	map[ZoneItemID]ZoneItem		` + "`" + `json:"zoneItem"` + "`" + `
+	EquipmentSet	equipmentSetMap	` + "`" + `json:"equipmentSet"` + "`" + `
+	GearScore	gearScoreMap	` + "`" + `json:"gearScore"` + "`" + `
+	Item		itemMap		` + "`" + `json:"item"` + "`" + `
+	Player		playerMap	` + "`" + `json:"player"` + "`" + `
+	Position	positionMap	` + "`" + `json:"position"` + "`" + `
+	Zone		zoneMap		` + "`" + `json:"zone"` + "`" + `
+	ZoneItem	zoneItemMap	` + "`" + `json:"zoneItem"` + "`" + `
 }`
 
 const newTree_func string = `func newTree() Tree {
-	return Tree{EquipmentSet: make(map[EquipmentSetID]EquipmentSet), GearScore: make(map[GearScoreID]GearScore), Item: make(map[ItemID]Item), Player: make(map[PlayerID]Player), Position: make(map[PositionID]Position), Zone: make(map[ZoneID]Zone), ZoneItem: make(map[ZoneItemID]ZoneItem)}
+	return Tree{EquipmentSet: make(equipmentSetMap), GearScore: make(gearScoreMap), Item: make(itemMap), Player: make(playerMap), Position: make(positionMap), Zone: make(zoneMap), ZoneItem: make(zoneItemMap)}
 }`
 
 const _ZoneItem_type string = `type ZoneItem struct {
@@ -4674,10 +8518,15 @@ const _ItemReference_type string = `type ItemReference struct {
 }`
 
 const _EquipmentSet_type string = `type EquipmentSet struct {
-	ID		EquipmentSetID			` + "`" + `json:"id"` + "`" + `
-	Equipment	map[ItemID]ItemReference	` + "`" + `json:"equipment"` + "`" + `
-	Name		string				` + "`" + `json:"name"` + "`" + `
-	OperationKind	OperationKind			` + "`" + `json:"operationKind"` + "`" + `
+	ID		EquipmentSetID		` + "`" + `json:"id"` + "`" + `
+	Cooldown	Duration		` + "`" + `json:"cooldown"` + "`" + `
+	CreatedAt	time.Time		` + "`" + `json:"createdAt"` + "`" + `
+	Equipment	itemReferenceMap	` + "`" + `json:"equipment"` + "`" + `
+	EquipmentOrder	[]ItemID		` + "`" + `json:"equipmentOrder,omitempty"` + "`" + `
+	ExternalID	BigID			` + "`" + `json:"externalID"` + "`" + `
+	Icon		[]byte			` + "`" + `json:"icon"` + "`" + `
+	Name		string			` + "`" + `json:"name"` + "`" + `
+	OperationKind	OperationKind		` + "`" + `json:"operationKind"` + "`" + `
 }`
 
 const _EquipmentSetReference_type string = `type EquipmentSetReference struct {
@@ -4722,15 +8571,19 @@ const _GearScoreReference_type string = `type GearScoreReference struct {
 }`
 
 const _Player_type string = `type Player struct {
-	ID		PlayerID					` + "`" + `json:"id"` + "`" + `
-	EquipmentSets	map[EquipmentSetID]EquipmentSetReference	` + "`" + `json:"equipmentSets"` + "`" + `
-	GearScore	*GearScore					` + "`" + `json:"gearScore"` + "`" + `
-	GuildMembers	map[PlayerID]PlayerReference			` + "`" + `json:"guildMembers"` + "`" + `
-	Items		map[ItemID]Item					` + "`" + `json:"items"` + "`" + `
-	Position	*Position					` + "`" + `json:"position"` + "`" + `
-	Target		*AnyOfPlayer_ZoneItemReference			` + "`" + `json:"target"` + "`" + `
-	TargetedBy	map[int]AnyOfPlayer_ZoneItemReference		` + "`" + `json:"targetedBy"` + "`" + `
-	OperationKind	OperationKind					` + "`" + `json:"operationKind"` + "`" + `
+	ID			PlayerID				` + "`" + `json:"id"` + "`" + `
+	EquipmentSets		equipmentSetReferenceMap		` + "`" + `json:"equipmentSets"` + "`" + `
+	EquipmentSetsOrder	[]EquipmentSetID			` + "`" + `json:"equipmentSetsOrder,omitempty"` + "`" + `
+	GearScore		*GearScore				` + "`" + `json:"gearScore"` + "`" + `
+	GuildMembers		playerReferenceMap			` + "`" + `json:"guildMembers"` + "`" + `
+	GuildMembersOrder	[]PlayerID				` + "`" + `json:"guildMembersOrder,omitempty"` + "`" + `
+	Items			itemMap					` + "`" + `json:"items"` + "`" + `
+	ItemsOrder		[]ItemID				` + "`" + `json:"itemsOrder,omitempty"` + "`" + `
+	Position		*Position				` + "`" + `json:"position"` + "`" + `
+	Target			*AnyOfPlayer_ZoneItemReference		` + "`" + `json:"target"` + "`" + `
+	TargetedBy		anyOfPlayer_ZoneItemReferenceMap	` + "`" + `json:"targetedBy"` + "`" + `
+	TargetedByOrder		[]int					` + "`" + `json:"targetedByOrder,omitempty"` + "`" + `
+	OperationKind		OperationKind				` + "`" + `json:"operationKind"` + "`" + `
 }`
 
 const _PlayerReference_type string = `type PlayerReference struct {
@@ -4743,12 +8596,16 @@ const _PlayerReference_type string = `type PlayerReference struct {
 }`
 
 const _Zone_type string = `type Zone struct {
-	ID		ZoneID			` + "`" + `json:"id"` + "`" + `
-	Interactables	map[int]interface{}	` + "`" + `json:"interactables"` + "`" + `
-	Items		map[ZoneItemID]ZoneItem	` + "`" + `json:"items"` + "`" + `
-	Players		map[PlayerID]Player	` + "`" + `json:"players"` + "`" + `
-	Tags		[]string		` + "`" + `json:"tags"` + "`" + `
-	OperationKind	OperationKind		` + "`" + `json:"operationKind"` + "`" + `
+	ID			ZoneID				` + "`" + `json:"id"` + "`" + `
+	Boss			*Player				` + "`" + `json:"boss"` + "`" + `
+	Interactables		anyOfItem_Player_ZoneItemMap	` + "`" + `json:"interactables"` + "`" + `
+	InteractablesOrder	[]int				` + "`" + `json:"interactablesOrder,omitempty"` + "`" + `
+	Items			zoneItemMap			` + "`" + `json:"items"` + "`" + `
+	ItemsOrder		[]ZoneItemID			` + "`" + `json:"itemsOrder,omitempty"` + "`" + `
+	Players			playerMap			` + "`" + `json:"players"` + "`" + `
+	PlayersOrder		[]PlayerID			` + "`" + `json:"playersOrder,omitempty"` + "`" + `
+	Tags			[]string			` + "`" + `json:"tags"` + "`" + `
+	OperationKind		OperationKind			` + "`" + `json:"operationKind"` + "`" + `
 }`
 
 const _ZoneReference_type string = `type ZoneReference struct {
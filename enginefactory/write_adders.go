@@ -27,9 +27,14 @@ func (s *EngineFactory) writeAdders() *EngineFactory {
 				decls.File.Func().Params(a.receiverParams()).Id(a.name()).Params(a.params()).Id(a.returns()).Block(
 					a.reassignElement(),
 					If(a.isOperationKindDelete()).Block(
+						a.recordElementDoesNotExist(),
 						Return(a.returnDeletedElement()),
 					),
 					OnlyIf(field.HasPointerValue, If(a.referencedElementDoesntExist()).Block(
+						a.recordReferencedElementDoesNotExist(),
+						Return(),
+					)),
+					OnlyIf(field.HasSetValue && field.HasPointerValue, If(a.isInSet(Id(a.idParam()))).Block(
 						Return(),
 					)),
 					OnlyIf(!valueType.IsBasicType && !field.HasPointerValue, a.createNewElement()),
@@ -38,7 +43,18 @@ func (s *EngineFactory) writeAdders() *EngineFactory {
 						a.setAnyContainer(),
 					}),
 					OnlyIf(field.HasPointerValue, a.createRef()),
-					a.appendElement(),
+					OnlyIf(!(field.HasSetValue && valueType.IsBasicType), a.appendElement()),
+					OnlyIf(field.HasSetValue && valueType.IsBasicType, Var().Id("wereElementsAltered").Bool()),
+					OnlyIf(field.HasSetValue && valueType.IsBasicType, For(a.valuesLoopConditions()).Block(
+						If(a.isInSet(Id(a.valueName()))).Block(
+							Continue(),
+						),
+						a.appendValue(),
+						Id("wereElementsAltered").Op("=").True(),
+					)),
+					OnlyIf(field.HasSetValue && valueType.IsBasicType, If(Op("!").Id("wereElementsAltered")).Block(
+						Return(),
+					)),
 					a.setOperationKindUpdate(),
 					a.updateElementInPatch(),
 					OnlyIf(!valueType.IsBasicType && !field.HasPointerValue, Return(Id(valueType.Name))),
@@ -61,6 +61,14 @@ func (a adderWriter) referencedElementDoesntExist() *Statement {
 	return Id(a.t.Name).Dot(a.t.Name).Dot("engine").Dot(Title(a.v.Name)).Call(Id(a.idParam())).Dot(a.v.Name).Dot("OperationKind").Op("==").Id("OperationKindDelete")
 }
 
+func (a adderWriter) recordElementDoesNotExist() *Statement {
+	return recordError(Id(a.t.Name).Dot(a.t.Name).Dot("engine"), a.name(), a.t.Name, Id(a.receiverName()).Dot(a.t.Name).Dot("ID"), "ErrElementDoesNotExist")
+}
+
+func (a adderWriter) recordReferencedElementDoesNotExist() *Statement {
+	return recordError(Id(a.t.Name).Dot(a.t.Name).Dot("engine"), a.name(), a.v.Name, Id(a.idParam()), "ErrElementDoesNotExist")
+}
+
 func (a adderWriter) returnDeletedElement() *Statement {
 	if a.v.IsBasicType || a.f.HasPointerValue {
 		return Empty()
@@ -125,6 +133,30 @@ func (a adderWriter) appendElement() *Statement {
 	return appendStatement
 }
 
+// isInSet checks whether the key is already in the set, keys of
+// references to anyOf types are the IDs of the referenced elements
+func (a adderWriter) isInSet(key *Statement) *Statement {
+	if a.f.HasAnyValue {
+		key = Int().Call(key)
+	}
+	return Id(a.t.Name).Dot(a.t.Name).Dot("indexOf" + Title(Singular(a.f.Name))).Call(key).Op("!=").Lit(-1)
+}
+
+func (a adderWriter) valueName() string {
+	return Singular(a.f.Name)
+}
+
+func (a adderWriter) valuesLoopConditions() *Statement {
+	return List(Id("_"), Id(a.valueName())).Op(":=").Range().Id(a.f.Name)
+}
+
+func (a adderWriter) appendValue() *Statement {
+	return Id(a.t.Name).Dot(a.t.Name).Dot(Title(a.f.Name)).Op("=").Append(
+		Id(a.t.Name).Dot(a.t.Name).Dot(Title(a.f.Name)),
+		Id(a.valueName()),
+	)
+}
+
 func (a adderWriter) setOperationKindUpdate() *Statement {
 	return Id(a.t.Name).Dot(a.t.Name).Dot("OperationKind").Op("=").Id("OperationKindUpdate")
 }
@@ -0,0 +1,168 @@
+package enginefactory
+
+import (
+	"github.com/jobergner/backent-cli/ast"
+	. "github.com/jobergner/backent-cli/factoryutils"
+
+	. "github.com/dave/jennifer/jen"
+)
+
+func (s *EngineFactory) writeAdopters() *EngineFactory {
+	decls := NewDeclSet()
+
+	s.config.RangeTypes(func(configType ast.ConfigType) {
+		a := adopterWriter{t: configType}
+
+		if a.hasParents(s.config) {
+			decls.File.Func().Params(a.engineParams()).Id(a.setPathName(configType.Name)).Params(a.setPathParams()).Block(
+				a.declareElement(),
+				a.setHasParent(),
+				a.setPath(),
+				If(Id("extendWithID")).Block(
+					a.extendPathWithID(),
+				),
+				a.setJSONPath(),
+				a.setOperationKind(),
+				a.updateElementInPatch(),
+				ForEachFieldInType(configType, func(field ast.Field) *Statement {
+					if !isOwnedField(field) {
+						return Empty()
+					}
+					a.f = field
+					if field.HasSliceValue {
+						return For(a.childIDsLoopConditions()).Block(
+							a.setChildPath(Id(a.childIDName()), true),
+						)
+					}
+					if field.HasOptionalValue {
+						return If(Id(configType.Name).Dot(Title(field.Name)).Op("!=").Lit(0)).Block(
+							a.setChildPath(Id(configType.Name).Dot(Title(field.Name)), false),
+						)
+					}
+					return a.setChildPath(Id(configType.Name).Dot(Title(field.Name)), false)
+				}),
+			)
+		}
+
+		if a.isAdoptable(s.config) {
+			if !a.hasSlicedParents(s.config) {
+				decls.File.Func().Params(a.engineParams()).Id("detach" + Title(configType.Name)).Params(a.detachParams()).Bool().Block(
+					Return(Op("!").Id(configType.Name).Dot("HasParent")),
+				)
+			} else {
+				decls.File.Func().Params(a.engineParams()).Id("detach"+Title(configType.Name)).Params(a.detachParams()).Bool().Block(
+					If(Op("!").Id(configType.Name).Dot("HasParent")).Block(
+						Return(True()),
+					),
+					If(a.pathSegment(1).Op("<").Lit(0)).Block(
+						Return(False()),
+					),
+					a.declareParentElement(),
+					If(Op("!").Id("ok")).Block(
+						Return(False()),
+					),
+					Switch().Block(
+						a.forEachSlicedParentField(s.config, func(d detacherWriter) *Statement {
+							return Case(d.isParentField()).Block(
+								d.declareParent(),
+								d.declareRemainingIDs(),
+								For(d.idsLoopConditions()).Block(
+									If(d.isRemaining()).Block(
+										d.appendRemainingID(),
+									),
+								),
+								d.assignRemainingIDs(),
+								d.setOperationKind(),
+								d.updateParentInPatch(),
+								Return(True()),
+							)
+						}),
+						a.forEachAnySlicedParentField(s.config, func(d detacherWriter) *Statement {
+							// children of anyOf slices live in containers which would be left behind
+							return Case(d.isParentField()).Block(
+								Return(False()),
+							)
+						}),
+					),
+					Return(False()),
+				)
+				decls.File.Func().Params(a.engineParams()).Id(a.wasDetachedName()).Params(a.wasDetachedParams()).Bool().Block(
+					a.declarePatchElement(),
+					Return(a.wasDetached()),
+				)
+			}
+		}
+
+		configType.RangeFields(func(field ast.Field) {
+			if !isOwnedField(field) || field.HasAnyValue {
+				return
+			}
+			a.f = field
+			childType := field.ValueType().Name
+
+			decls.File.Func().Params(Id("_"+configType.Name).Id(configType.Name)).Id(a.adopterName()).Params(Id(childType+"ID").Id(Title(childType)+"ID")).Id(childType).Block(
+				a.reassignElement(),
+				a.declareChild(),
+				If(a.isElementOperationKindDelete()).Block(
+					a.recordElementError("ErrElementDoesNotExist"),
+					Return(Id("child")),
+				),
+				If(a.isChildOperationKindDelete()).Block(
+					a.recordChildError("ErrElementDoesNotExist"),
+					Return(Id("child")),
+				),
+				If(a.isWithinChild()).Block(
+					a.recordChildError("ErrElementIsAncestor"),
+					Return(Id("child")),
+				),
+				If(Op("!").Add(a.detachChild())).Block(
+					a.recordChildError("ErrElementNotDetachable"),
+					Return(Id("child")),
+				),
+				a.refetchElement(),
+				// the replaced child is unlinked before it is deleted so its deletion shows up at the root of the tree
+				OnlyIf(!field.HasSliceValue && !field.HasOptionalValue, a.unlinkCurrentChild()),
+				OnlyIf(!field.HasSliceValue && !field.HasOptionalValue, a.deleteCurrentChild()),
+				OnlyIf(field.HasOptionalValue, If(a.hasCurrentChild()).Block(
+					a.unlinkCurrentChild(),
+					a.deleteCurrentChild(),
+				)),
+				a.setAdoptedChildPath(),
+				a.assignChild(),
+				a.setElementOperationKind(),
+				a.updateElementInPatchFromWrapper(),
+				Return(a.getChild()),
+			)
+		})
+	})
+
+	alreadyWrittenCheck := make(map[string]bool)
+	s.config.RangeTypes(func(configType ast.ConfigType) {
+		if !(adopterWriter{t: configType}).hasParents(s.config) {
+			return
+		}
+		configType.RangeFields(func(field ast.Field) {
+			if !isOwnedField(field) || !field.HasAnyValue || alreadyWrittenCheck[anyNameByField(field)] {
+				return
+			}
+			alreadyWrittenCheck[anyNameByField(field)] = true
+			a := anyAdopterWriter{f: field}
+
+			decls.File.Func().Params(Id("engine").Id("*Engine")).Id(a.setPathName()).Params(a.params()).Block(
+				a.declareAny(),
+				a.setChildElementPath(),
+				a.updateAnyInPatch(),
+				Switch(Id("any").Dot("ElementKind")).Block(
+					ForEachValueOfField(field, func(v *ast.ConfigType) *Statement {
+						return Case(Id("ElementKind" + Title(v.Name))).Block(
+							a.setChildPath(v),
+						)
+					}),
+				),
+			)
+		})
+	})
+
+	decls.Render(s.buf)
+	return s
+}
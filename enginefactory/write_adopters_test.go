@@ -0,0 +1,46 @@
+package enginefactory
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jobergner/backent-cli/testutils"
+)
+
+func TestWriteAdopters(t *testing.T) {
+	t.Run("writes adopters", func(t *testing.T) {
+		sf := newStateFactory(newSimpleASTExample())
+		sf.writeAdopters()
+
+		actual := testutils.FormatCode(sf.buf.String())
+		expected := testutils.FormatCode(strings.Join([]string{
+			setGearScorePath_Engine_func,
+			detachGearScore_Engine_func,
+			setItemPath_Engine_func,
+			detachItem_Engine_func,
+			wasItemDetached_Engine_func,
+			_AdoptGearScore_item_func,
+			setPlayerPath_Engine_func,
+			detachPlayer_Engine_func,
+			wasPlayerDetached_Engine_func,
+			_AdoptGearScore_player_func,
+			_AdoptItem_player_func,
+			_AdoptPosition_player_func,
+			setPositionPath_Engine_func,
+			detachPosition_Engine_func,
+			_AdoptBoss_zone_func,
+			_AdoptItem_zone_func,
+			_AdoptPlayer_zone_func,
+			setZoneItemPath_Engine_func,
+			detachZoneItem_Engine_func,
+			wasZoneItemDetached_Engine_func,
+			_AdoptItem_zoneItem_func,
+			_AdoptPosition_zoneItem_func,
+			setAnyOfPlayer_PositionPath_Engine_func,
+		}, "\n"))
+
+		if expected != actual {
+			t.Errorf(testutils.Diff(actual, expected))
+		}
+	})
+}
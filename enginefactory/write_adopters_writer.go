@@ -0,0 +1,346 @@
+package enginefactory
+
+import (
+	"github.com/jobergner/backent-cli/ast"
+	. "github.com/jobergner/backent-cli/factoryutils"
+
+	. "github.com/dave/jennifer/jen"
+)
+
+// isOwnedField is true for fields whose values are elements
+// that live within the field's parent
+func isOwnedField(field ast.Field) bool {
+	return !field.HasPointerValue && !field.ValueType().IsBasicType
+}
+
+type adopterWriter struct {
+	t ast.ConfigType
+	f ast.Field
+}
+
+// hasParents is true if elements of the type can be the child
+// of another element, in which case their paths need to be
+// rewritten when one of their ancestors gets adopted
+func (a adopterWriter) hasParents(config *ast.AST) bool {
+	var hasParents bool
+	config.RangeTypes(func(configType ast.ConfigType) {
+		configType.RangeFields(func(field ast.Field) {
+			if !isOwnedField(field) {
+				return
+			}
+			if _, ok := field.ValueTypes[a.t.Name]; ok {
+				hasParents = true
+			}
+		})
+	})
+	return hasParents
+}
+
+// isAdoptable is true if elements of the type can be
+// adopted by another element (not through anyOf fields)
+func (a adopterWriter) isAdoptable(config *ast.AST) bool {
+	var isAdoptable bool
+	config.RangeTypes(func(configType ast.ConfigType) {
+		configType.RangeFields(func(field ast.Field) {
+			if isOwnedField(field) && !field.HasAnyValue && field.ValueType().Name == a.t.Name {
+				isAdoptable = true
+			}
+		})
+	})
+	return isAdoptable
+}
+
+func (a adopterWriter) forEachSlicedParentField(config *ast.AST, fn func(d detacherWriter) *Statement) *Statement {
+	var statements Statement
+	config.RangeTypes(func(configType ast.ConfigType) {
+		configType.RangeFields(func(field ast.Field) {
+			if !isOwnedField(field) || field.HasAnyValue || !field.HasSliceValue || field.ValueType().Name != a.t.Name {
+				return
+			}
+			statements = append(statements, fn(detacherWriter{t: a.t, f: field}))
+		})
+	})
+	return &statements
+}
+
+// forEachAnySlicedParentField calls fn for every anyOf slice field
+// which can hold elements of the type
+func (a adopterWriter) forEachAnySlicedParentField(config *ast.AST, fn func(d detacherWriter) *Statement) *Statement {
+	var statements Statement
+	config.RangeTypes(func(configType ast.ConfigType) {
+		configType.RangeFields(func(field ast.Field) {
+			if !isOwnedField(field) || !field.HasAnyValue || !field.HasSliceValue {
+				return
+			}
+			if _, ok := field.ValueTypes[a.t.Name]; !ok {
+				return
+			}
+			statements = append(statements, fn(detacherWriter{t: a.t, f: field}))
+		})
+	})
+	return &statements
+}
+
+func (a adopterWriter) hasSlicedParents(config *ast.AST) bool {
+	return len(*a.forEachSlicedParentField(config, func(d detacherWriter) *Statement { return Empty() })) > 0
+}
+
+func (a adopterWriter) engineParams() *Statement {
+	return Id("engine").Id("*Engine")
+}
+
+func (a adopterWriter) setPathName(typeName string) string {
+	return "set" + Title(typeName) + "Path"
+}
+
+func (a adopterWriter) setPathParams() *Statement {
+	return List(Id(a.t.Name+"ID").Id(Title(a.t.Name)+"ID"), Id("p").Id("path"), Id("extendWithID").Bool())
+}
+
+func (a adopterWriter) declareElement() *Statement {
+	return Id(a.t.Name).Op(":=").Id("engine").Dot(Title(a.t.Name)).Call(Id(a.t.Name + "ID")).Dot(a.t.Name)
+}
+
+func (a adopterWriter) setHasParent() *Statement {
+	return Id(a.t.Name).Dot("HasParent").Op("=").Len(Id("p")).Op(">").Lit(1)
+}
+
+func (a adopterWriter) setPath() *Statement {
+	return Id(a.t.Name).Dot("path").Op("=").Id("p")
+}
+
+func (a adopterWriter) extendPathWithID() *Statement {
+	return Id(a.t.Name).Dot("path").Op("=").Id(a.t.Name).Dot("path").Dot("id").Call(Int().Call(Id(a.t.Name).Dot("ID")))
+}
+
+func (a adopterWriter) setJSONPath() *Statement {
+	return Id(a.t.Name).Dot("Path").Op("=").Id(a.t.Name).Dot("path").Dot("toJSONPath").Call()
+}
+
+func (a adopterWriter) setOperationKind() *Statement {
+	return Id(a.t.Name).Dot("OperationKind").Op("=").Id("OperationKindUpdate")
+}
+
+func (a adopterWriter) updateElementInPatch() *Statement {
+	return Id("engine").Dot("Patch").Dot(Title(a.t.Name)).Index(Id(a.t.Name).Dot("ID")).Op("=").Id(a.t.Name)
+}
+
+func (a adopterWriter) childIDName() string {
+	if a.f.HasAnyValue {
+		return Singular(a.f.Name) + "ID"
+	}
+	return a.f.ValueType().Name + "ID"
+}
+
+func (a adopterWriter) childIDsLoopConditions() *Statement {
+	return List(Id("_"), Id(a.childIDName())).Op(":=").Range().Id(a.t.Name).Dot(Title(a.f.Name))
+}
+
+func (a adopterWriter) setChildPath(childID *Statement, extendWithID bool) *Statement {
+	name := a.setPathName(a.f.ValueType().Name)
+	if a.f.HasAnyValue {
+		name = a.setPathName(anyNameByField(a.f))
+	}
+	return Id("engine").Dot(name).Call(childID, Id(a.t.Name).Dot("path").Dot(a.f.Name).Call(), Lit(extendWithID))
+}
+
+func (a adopterWriter) detachParams() *Statement {
+	return Id(a.t.Name).Id(a.t.Name + "Core")
+}
+
+func (a adopterWriter) pathSegment(fromEnd int) *Statement {
+	return Id(a.t.Name).Dot("path").Index(Len(Id(a.t.Name).Dot("path")).Op("-").Lit(fromEnd))
+}
+
+func (a adopterWriter) declareParentElement() *Statement {
+	parentPath := Id(a.t.Name).Dot("path").Index(Empty(), Len(Id(a.t.Name).Dot("path")).Op("-").Lit(2))
+	return List(Id("parentElement"), Id("ok")).Op(":=").Id("engine").Dot("ElementByPath").Call(parentPath.Dot("toJSONPath").Call())
+}
+
+func (a adopterWriter) wasDetachedName() string {
+	return "was" + Title(a.t.Name) + "Detached"
+}
+
+func (a adopterWriter) wasDetachedParams() *Statement {
+	return List(Id(a.t.Name+"ID").Id(Title(a.t.Name)+"ID"), Id("slicePath").Id("path"))
+}
+
+func (a adopterWriter) declarePatchElement() *Statement {
+	return List(Id(a.t.Name), Id("ok")).Op(":=").Id("engine").Dot("Patch").Dot(Title(a.t.Name)).Index(Id(a.t.Name + "ID"))
+}
+
+func (a adopterWriter) wasDetached() *Statement {
+	isNotDeleted := Id(a.t.Name).Dot("OperationKind").Op("!=").Id("OperationKindDelete")
+	slotPath := Id("slicePath").Dot("id").Call(Int().Call(Id(a.t.Name).Dot("ID")))
+	return Id("ok").Op("&&").Add(isNotDeleted).Op("&&").Op("!").Id(a.t.Name).Dot("path").Dot("equals").Call(slotPath)
+}
+
+func (a adopterWriter) adopterName() string {
+	if a.f.HasSliceValue {
+		return "Adopt" + Title(Singular(a.f.Name))
+	}
+	return "Adopt" + Title(a.f.Name)
+}
+
+func (a adopterWriter) element() *Statement {
+	return Id(a.t.Name).Dot(a.t.Name)
+}
+
+func (a adopterWriter) engine() *Statement {
+	return a.element().Dot("engine")
+}
+
+func (a adopterWriter) childType() string {
+	return a.f.ValueType().Name
+}
+
+func (a adopterWriter) child() *Statement {
+	return Id("child").Dot(a.childType())
+}
+
+func (a adopterWriter) reassignElement() *Statement {
+	return Id(a.t.Name).Op(":=").Id("_" + a.t.Name).Dot(a.t.Name).Dot("engine").Dot(Title(a.t.Name)).Call(Id("_" + a.t.Name).Dot(a.t.Name).Dot("ID"))
+}
+
+func (a adopterWriter) getChild() *Statement {
+	return a.engine().Dot(Title(a.childType())).Call(Id(a.childType() + "ID"))
+}
+
+func (a adopterWriter) declareChild() *Statement {
+	return Id("child").Op(":=").Add(a.getChild())
+}
+
+func (a adopterWriter) isElementOperationKindDelete() *Statement {
+	return a.element().Dot("OperationKind").Op("==").Id("OperationKindDelete")
+}
+
+func (a adopterWriter) isChildOperationKindDelete() *Statement {
+	return a.child().Dot("OperationKind").Op("==").Id("OperationKindDelete")
+}
+
+func (a adopterWriter) recordElementError(err string) *Statement {
+	return recordError(a.engine(), a.adopterName(), a.t.Name, Id("_"+a.t.Name).Dot(a.t.Name).Dot("ID"), err)
+}
+
+func (a adopterWriter) recordChildError(err string) *Statement {
+	return recordError(a.engine(), a.adopterName(), a.childType(), Id(a.childType()+"ID"), err)
+}
+
+func (a adopterWriter) isWithinChild() *Statement {
+	return a.element().Dot("path").Dot("isWithin").Call(a.child().Dot("path"))
+}
+
+func (a adopterWriter) detachChild() *Statement {
+	return a.engine().Dot("detach" + Title(a.childType())).Call(a.child())
+}
+
+func (a adopterWriter) refetchElement() *Statement {
+	return Id(a.t.Name).Op("=").Add(a.engine()).Dot(Title(a.t.Name)).Call(a.element().Dot("ID"))
+}
+
+func (a adopterWriter) hasCurrentChild() *Statement {
+	return a.element().Dot(Title(a.f.Name)).Op("!=").Lit(0)
+}
+
+func (a adopterWriter) unlinkCurrentChild() *Statement {
+	rootPath := Id("newPath").Call(Id(a.childType() + "Identifier"))
+	return a.engine().Dot(a.setPathName(a.childType())).Call(a.element().Dot(Title(a.f.Name)), rootPath, True())
+}
+
+func (a adopterWriter) deleteCurrentChild() *Statement {
+	return a.engine().Dot("delete" + Title(a.childType())).Call(a.element().Dot(Title(a.f.Name)))
+}
+
+func (a adopterWriter) setAdoptedChildPath() *Statement {
+	return a.engine().Dot(a.setPathName(a.childType())).Call(a.child().Dot("ID"), a.element().Dot("path").Dot(a.f.Name).Call(), Lit(a.f.HasSliceValue))
+}
+
+func (a adopterWriter) assignChild() *Statement {
+	field := a.element().Dot(Title(a.f.Name))
+	if a.f.HasSliceValue {
+		return field.Clone().Op("=").Append(field, a.child().Dot("ID"))
+	}
+	return field.Op("=").Add(a.child()).Dot("ID")
+}
+
+func (a adopterWriter) setElementOperationKind() *Statement {
+	return a.element().Dot("OperationKind").Op("=").Id("OperationKindUpdate")
+}
+
+func (a adopterWriter) updateElementInPatchFromWrapper() *Statement {
+	return a.engine().Dot("Patch").Dot(Title(a.t.Name)).Index(a.element().Dot("ID")).Op("=").Add(a.element())
+}
+
+type detacherWriter struct {
+	t ast.ConfigType
+	f ast.Field
+}
+
+func (d detacherWriter) isParentField() *Statement {
+	identifier := Id(d.t.Name).Dot("path").Index(Len(Id(d.t.Name).Dot("path")).Op("-").Lit(2))
+	return Id("parentElement").Dot("Kind").Call().Op("==").Id("ElementKind" + Title(d.f.Parent.Name)).Op("&&").Add(identifier).Op("==").Id(d.f.Name + "Identifier")
+}
+
+func (d detacherWriter) declareParent() *Statement {
+	return Id("parent").Op(":=").Id("parentElement").Dot(Title(d.f.Parent.Name)).Call().Dot(d.f.Parent.Name)
+}
+
+func (d detacherWriter) declareRemainingIDs() *Statement {
+	return Var().Id(d.f.Name).Index().Id(Title(d.t.Name) + "ID")
+}
+
+func (d detacherWriter) idsLoopConditions() *Statement {
+	return List(Id("_"), Id(d.t.Name+"ID")).Op(":=").Range().Id("parent").Dot(Title(d.f.Name))
+}
+
+func (d detacherWriter) isRemaining() *Statement {
+	return Id(d.t.Name + "ID").Op("!=").Id(d.t.Name).Dot("ID")
+}
+
+func (d detacherWriter) appendRemainingID() *Statement {
+	return Id(d.f.Name).Op("=").Append(Id(d.f.Name), Id(d.t.Name+"ID"))
+}
+
+func (d detacherWriter) assignRemainingIDs() *Statement {
+	return Id("parent").Dot(Title(d.f.Name)).Op("=").Id(d.f.Name)
+}
+
+func (d detacherWriter) setOperationKind() *Statement {
+	return Id("parent").Dot("OperationKind").Op("=").Id("OperationKindUpdate")
+}
+
+func (d detacherWriter) updateParentInPatch() *Statement {
+	return Id("engine").Dot("Patch").Dot(Title(d.f.Parent.Name)).Index(Id("parent").Dot("ID")).Op("=").Id("parent")
+}
+
+type anyAdopterWriter struct {
+	f ast.Field
+}
+
+func (a anyAdopterWriter) anyName() string {
+	return anyNameByField(a.f)
+}
+
+func (a anyAdopterWriter) setPathName() string {
+	return "set" + Title(a.anyName()) + "Path"
+}
+
+func (a anyAdopterWriter) params() *Statement {
+	return List(Id(a.anyName()+"ID").Id(Title(a.anyName())+"ID"), Id("childElementPath").Id("path"), Id("extendWithID").Bool())
+}
+
+func (a anyAdopterWriter) declareAny() *Statement {
+	return Id("any").Op(":=").Id("engine").Dot(a.anyName()).Call(Id(a.anyName() + "ID")).Dot(a.anyName())
+}
+
+func (a anyAdopterWriter) setChildElementPath() *Statement {
+	return Id("any").Dot("ChildElementPath").Op("=").Id("childElementPath")
+}
+
+func (a anyAdopterWriter) updateAnyInPatch() *Statement {
+	return Id("engine").Dot("Patch").Dot(Title(a.anyName())).Index(Id("any").Dot("ID")).Op("=").Id("any")
+}
+
+func (a anyAdopterWriter) setChildPath(valueType *ast.ConfigType) *Statement {
+	return Id("engine").Dot("set"+Title(valueType.Name)+"Path").Call(Id("any").Dot(Title(valueType.Name)), Id("childElementPath"), Id("extendWithID"))
+}
@@ -53,6 +53,13 @@ func (s *EngineFactory) writeAssembleTree() *EngineFactory {
 				For(a.stateLoopConditions()).Block(
 					If(a.elementHasNoParent()).Block(
 						If(a.elementNonExistentInTree()).Block(
+							// an adopted element is only removed from the root
+							OnlyIf(adopterWriter{t: configType}.isAdoptable(s.config), If(a.elementHasParentInPatch()).Block(
+								If(Id("!assembleEntireTree")).Block(
+									a.setDetachedElementInTree(),
+								),
+								Continue(),
+							)),
 							a.assembleElement(),
 							If(Id("include")).Block(
 								a.setElementInTree(),
@@ -107,7 +114,7 @@ func (s *EngineFactory) writeAssembleTreeElement() *EngineFactory {
 
 				if field.HasSliceValue {
 					if field.HasAnyValue && !field.HasPointerValue {
-						return For(a.sliceFieldLoopConditions()).Block(
+						return &Statement{For(a.sliceFieldLoopConditions()).Block(
 							OnlyIf(!field.HasPointerValue, a.createAnyContainer()),
 							ForEachFieldValueComparison(field, *Id(a.anyContainerName()).Dot("ElementKind"), func(valueType *ast.ConfigType) *Statement {
 								return &Statement{
@@ -121,9 +128,22 @@ func (s *EngineFactory) writeAssembleTreeElement() *EngineFactory {
 									),
 								}
 							}),
-						)
+						).Line(),
+							If(a.orderIsRequired()).Block(
+								a.assignOrder(),
+							),
+						}
 					} else {
-						return For(a.sliceFieldLoopConditions()).Block(
+						return &Statement{For(a.sliceFieldLoopConditions()).Block(
+							// a child adopted by another parent is only removed from this one
+							OnlyIf(!field.HasPointerValue, If(a.wasChildDetached()).Block(
+								If(Id("!config").Dot("forceInclude")).Block(
+									a.makeMap(),
+									a.setDetachedChild(),
+									a.setHasUpdatedTrue(),
+								),
+								Continue(),
+							)),
 							If(a.elementHasUpdated(field.ValueType(), a.usedAssembleID(configType, field, field.ValueType()))).Block(
 								If(Id("childHasUpdated")).Block(
 									a.setHasUpdatedTrue(),
@@ -131,7 +151,11 @@ func (s *EngineFactory) writeAssembleTreeElement() *EngineFactory {
 								a.makeMap(),
 								a.appendToElementsInField(field.ValueType()),
 							),
-						)
+						).Line(),
+							If(a.orderIsRequired()).Block(
+								a.assignOrder(),
+							),
+						}
 					}
 				}
 
@@ -152,6 +176,24 @@ func (s *EngineFactory) writeAssembleTreeElement() *EngineFactory {
 						}),
 					}
 				}
+				if field.HasOptionalValue {
+					return &Statement{
+						a.declareOptionalID().Line(),
+						// an unset child is absent from the tree, only the patch it was unset in
+						// assembles the previous child from the state to carry its deletion
+						If(Id(a.optionalIDName()).Op("==").Lit(0).Op("&&").Op("!").Id("config").Dot("forceInclude")).Block(
+							Id(a.optionalIDName()).Op("=").Add(a.typeFieldOn("State")),
+						).Line(),
+						If(Id(a.optionalIDName()).Op("!=").Lit(0)).Block(
+							If(a.elementHasUpdated(field.ValueType(), Id(a.optionalIDName()))).Block(
+								If(Id("childHasUpdated")).Block(
+									a.setHasUpdatedTrue(),
+								),
+								a.setFieldElement(field.ValueType()),
+							),
+						),
+					}
+				}
 				return If(a.elementHasUpdated(field.ValueType(), a.usedAssembleID(configType, field, field.ValueType()))).Block(
 					If(Id("childHasUpdated")).Block(
 						a.setHasUpdatedTrue(),
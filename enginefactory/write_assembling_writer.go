@@ -55,6 +55,18 @@ func (a assembleTreeWriter) setElementInTree() *Statement {
 	return Id("engine").Dot("Tree").Dot(Title(a.t.Name)).Index(Id(a.dataElementName()).Dot("ID")).Op("=").Id(a.t.Name)
 }
 
+func (a assembleTreeWriter) elementHasParentInPatch() *Statement {
+	return Id("engine").Dot("Patch").Dot(Title(a.t.Name)).Index(Id(a.dataElementName()).Dot("ID")).Dot("HasParent")
+}
+
+func (a assembleTreeWriter) setDetachedElementInTree() *Statement {
+	detachedElement := Id(Title(a.t.Name)).Values(Dict{
+		Id("ID"):            Id(a.dataElementName()).Dot("ID"),
+		Id("OperationKind"): Id("OperationKindDelete"),
+	})
+	return Id("engine").Dot("Tree").Dot(Title(a.t.Name)).Index(Id(a.dataElementName()).Dot("ID")).Op("=").Add(detachedElement)
+}
+
 func (a assembleTreeWriter) stateLoopConditions() *Statement {
 	return List(Id("_"), Id(a.dataElementName())).Op(":=").Range().Id("engine").Dot("State").Dot(Title(a.t.Name))
 }
@@ -154,6 +166,29 @@ func (a assembleElementWriter) sliceFieldLoopConditions() *Statement {
 	return loopVars.Op(":=").Range().Id(mergeFuncName).Call(a.typeFieldOn("State"), a.typeFieldOn("Patch"))
 }
 
+func (a assembleElementWriter) wasChildDetached() *Statement {
+	slicePath := Id(a.dataElementName()).Dot("path").Dot(a.f.Name).Call()
+	return Id("engine").Dot("was"+Title(a.f.ValueTypeName)+"Detached").Call(Id(a.f.ValueTypeName+"ID"), slicePath)
+}
+
+func (a assembleElementWriter) setDetachedChild() *Statement {
+	detachedChild := Id(Title(a.f.ValueTypeName)).Values(Dict{
+		Id("ID"):            Id(a.f.ValueTypeName + "ID"),
+		Id("OperationKind"): Id("OperationKindDelete"),
+	})
+	return Id(a.treeElementName()).Dot(Title(a.f.Name)).Index(Id(a.f.ValueTypeName + "ID")).Op("=").Add(detachedChild)
+}
+
+func (a assembleElementWriter) orderIsRequired() *Statement {
+	reorderedFuncName := "were" + Title(a.f.ValueTypeName) + "IDsReordered"
+	hasMultipleEntries := Len(Id(a.dataElementName()).Dot(Title(a.f.Name))).Op(">").Lit(1)
+	return hasMultipleEntries.Op("&&").Parens(Id("config").Dot("forceInclude").Op("||").Id(reorderedFuncName).Call(a.typeFieldOn("State"), a.typeFieldOn("Patch")))
+}
+
+func (a assembleElementWriter) assignOrder() *Statement {
+	return Id(a.t.Name).Dot(Title(a.f.Name)+"Order").Op("=").Id(a.dataElementName()).Dot(a.f.Name + "Keys").Call()
+}
+
 func (a assembleElementWriter) usedAssembleID(configType ast.ConfigType, field ast.Field, valueType *ast.ConfigType) *Statement {
 	if !field.HasPointerValue && !field.HasAnyValue && !field.HasSliceValue {
 		return Id(a.dataElementName()).Dot(Title(field.Name))
@@ -168,6 +203,14 @@ func (a assembleElementWriter) usedAssembleID(configType ast.ConfigType, field a
 	return Id(valueType.Name + "ID")
 }
 
+func (a assembleElementWriter) optionalIDName() string {
+	return a.f.Name + "ID"
+}
+
+func (a assembleElementWriter) declareOptionalID() *Statement {
+	return Id(a.optionalIDName()).Op(":=").Id(a.dataElementName()).Dot(Title(a.f.Name))
+}
+
 func (a assembleElementWriter) elementHasUpdated(valueType *ast.ConfigType, assembleID *Statement) (*Statement, *Statement) {
 	handledElementTypeName := a.f.ValueTypeName
 	if a.f.HasAnyValue && !a.f.HasPointerValue {
@@ -0,0 +1,38 @@
+package enginefactory
+
+import (
+	. "github.com/jobergner/backent-cli/factoryutils"
+
+	. "github.com/dave/jennifer/jen"
+)
+
+func (s *EngineFactory) writeBigID() *EngineFactory {
+	decls := NewDeclSet()
+
+	b := bigIDWriter{}
+
+	decls.File.Type().Id("BigID").Uint64()
+
+	decls.File.Func().Params(b.receiverParams()).Id("MarshalJSON").Params().Params(Index().Byte(), Error()).Block(
+		Return(b.quoteID(), Nil()),
+	)
+
+	decls.File.Func().Params(b.pointerReceiverParams()).Id("UnmarshalJSON").Params(Id("data").Index().Byte()).Error().Block(
+		If(isNullData()).Block(
+			Return(Nil()),
+		),
+		b.unquoteData(),
+		If(Err().Op("!=").Nil()).Block(
+			Return(Err()),
+		),
+		b.parseID(),
+		If(Err().Op("!=").Nil()).Block(
+			Return(Err()),
+		),
+		b.assignID(),
+		Return(Nil()),
+	)
+
+	decls.Render(s.buf)
+	return s
+}
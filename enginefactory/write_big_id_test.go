@@ -0,0 +1,26 @@
+package enginefactory
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jobergner/backent-cli/testutils"
+)
+
+func TestWriteBigID(t *testing.T) {
+	t.Run("writes big ID", func(t *testing.T) {
+		sf := newStateFactory(newSimpleASTExample())
+		sf.writeBigID()
+
+		actual := testutils.FormatCode(sf.buf.String())
+		expected := testutils.FormatCode(strings.Join([]string{
+			_BigID_type,
+			_MarshalJSON_BigID_func,
+			_UnmarshalJSON_BigID_func,
+		}, "\n"))
+
+		if expected != actual {
+			t.Errorf(testutils.Diff(actual, expected))
+		}
+	})
+}
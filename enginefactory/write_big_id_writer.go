@@ -0,0 +1,32 @@
+package enginefactory
+
+import (
+	. "github.com/dave/jennifer/jen"
+)
+
+type bigIDWriter struct{}
+
+func (b bigIDWriter) receiverParams() *Statement {
+	return Id("id").Id("BigID")
+}
+
+func (b bigIDWriter) pointerReceiverParams() *Statement {
+	return Id("id").Id("*BigID")
+}
+
+func (b bigIDWriter) quoteID() *Statement {
+	id := Id("strconv").Dot("FormatUint").Call(Uint64().Call(Id("id")), Lit(10))
+	return Index().Byte().Call(Id("strconv").Dot("Quote").Call(id))
+}
+
+func (b bigIDWriter) unquoteData() *Statement {
+	return List(Id("unquoted"), Err()).Op(":=").Id("strconv").Dot("Unquote").Call(Id("string").Call(Id("data")))
+}
+
+func (b bigIDWriter) parseID() *Statement {
+	return List(Id("value"), Err()).Op(":=").Id("strconv").Dot("ParseUint").Call(Id("unquoted"), Lit(10), Lit(64))
+}
+
+func (b bigIDWriter) assignID() *Statement {
+	return Op("*").Id("id").Op("=").Id("BigID").Call(Id("value"))
+}
@@ -0,0 +1,79 @@
+package enginefactory
+
+import (
+	"github.com/jobergner/backent-cli/ast"
+	. "github.com/jobergner/backent-cli/factoryutils"
+
+	. "github.com/dave/jennifer/jen"
+)
+
+func (s *EngineFactory) writeClone() *EngineFactory {
+	decls := NewDeclSet()
+
+	s.config.RangeTypes(func(configType ast.ConfigType) {
+		c := cloneWriter{t: configType}
+
+		decls.File.Func().Params(c.receiverParams()).Id("Clone").Params().Id(configType.Name).Block(
+			c.reassignElement(),
+			If(c.isOperationKindDelete()).Block(
+				c.recordElementDoesNotExist(),
+				Return(Id(configType.Name)),
+			),
+			c.createClone(),
+			c.copyElement(),
+			Return(Id("clone")),
+		)
+
+		decls.File.Func().Params(Id("engine").Id("*Engine")).Id(c.copyName()).Params(Id("dst"), Id("src").Id(configType.Name)).Block(
+			ForEachFieldInType(configType, func(field ast.Field) *Statement {
+				c.f = field
+
+				switch {
+				case field.ValueType().IsBasicType && field.HasSliceValue:
+					return c.addBasicValues()
+				case field.ValueType().IsBasicType:
+					return c.setBasicValue()
+				case field.HasPointerValue && field.HasSliceValue:
+					return For(c.loopConditions()).Block(
+						c.forEachKind(c.referencedElement(), func(v *ast.ConfigType) *Statement {
+							return c.addReference(v)
+						}),
+					)
+				case field.HasPointerValue:
+					return If(c.getReference(), Id("ok")).Block(
+						c.forEachKind(c.referencedElement(), func(v *ast.ConfigType) *Statement {
+							return c.setReference(v)
+						}),
+					)
+				case field.HasSliceValue:
+					return For(c.loopConditions()).Block(
+						c.forEachKind(Id(Singular(field.Name)), func(v *ast.ConfigType) *Statement {
+							return c.copyAddedChild(v)
+						}),
+					)
+				case field.HasOptionalValue:
+					return If(c.declareOptionalChild(), Id("ok")).Block(
+						c.copyOptionalChild(),
+					)
+				case field.HasAnyValue:
+					return &Statement{
+						c.declareAnyChild(),
+						Line(),
+						Switch(Id(field.Name).Dot("Kind").Call()).Block(
+							ForEachValueOfField(field, func(v *ast.ConfigType) *Statement {
+								return Case(Id("ElementKind" + Title(v.Name))).Block(
+									c.copyAnyChild(v),
+								)
+							}),
+						),
+					}
+				default:
+					return c.copyChild()
+				}
+			}),
+		)
+	})
+
+	decls.Render(s.buf)
+	return s
+}
@@ -0,0 +1,37 @@
+package enginefactory
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jobergner/backent-cli/testutils"
+)
+
+func TestWriteClone(t *testing.T) {
+	t.Run("writes clone", func(t *testing.T) {
+		sf := newStateFactory(newSimpleASTExample())
+		sf.writeClone()
+
+		actual := testutils.FormatCode(sf.buf.String())
+		expected := testutils.FormatCode(strings.Join([]string{
+			_Clone_equipmentSet_func,
+			copyEquipmentSet_Engine_func,
+			_Clone_gearScore_func,
+			copyGearScore_Engine_func,
+			_Clone_item_func,
+			copyItem_Engine_func,
+			_Clone_player_func,
+			copyPlayer_Engine_func,
+			_Clone_position_func,
+			copyPosition_Engine_func,
+			_Clone_zone_func,
+			copyZone_Engine_func,
+			_Clone_zoneItem_func,
+			copyZoneItem_Engine_func,
+		}, "\n"))
+
+		if expected != actual {
+			t.Errorf(testutils.Diff(actual, expected))
+		}
+	})
+}
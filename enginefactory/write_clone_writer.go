@@ -0,0 +1,148 @@
+package enginefactory
+
+import (
+	"github.com/jobergner/backent-cli/ast"
+	. "github.com/jobergner/backent-cli/factoryutils"
+
+	. "github.com/dave/jennifer/jen"
+)
+
+type cloneWriter struct {
+	t ast.ConfigType
+	f ast.Field
+}
+
+func (c cloneWriter) receiverParams() *Statement {
+	return Id("_" + c.t.Name).Id(c.t.Name)
+}
+
+func (c cloneWriter) reassignElement() *Statement {
+	return Id(c.t.Name).Op(":=").Id("_" + c.t.Name).Dot(c.t.Name).Dot("engine").Dot(Title(c.t.Name)).Call(Id("_" + c.t.Name).Dot(c.t.Name).Dot("ID"))
+}
+
+func (c cloneWriter) isOperationKindDelete() *Statement {
+	return Id(c.t.Name).Dot(c.t.Name).Dot("OperationKind").Op("==").Id("OperationKindDelete")
+}
+
+func (c cloneWriter) recordElementDoesNotExist() *Statement {
+	return recordError(c.engine(), "Clone", c.t.Name, Id("_"+c.t.Name).Dot(c.t.Name).Dot("ID"), "ErrElementDoesNotExist")
+}
+
+func (c cloneWriter) engine() *Statement {
+	return Id(c.t.Name).Dot(c.t.Name).Dot("engine")
+}
+
+func (c cloneWriter) createClone() *Statement {
+	return Id("clone").Op(":=").Add(c.engine()).Dot("Create" + Title(c.t.Name)).Call()
+}
+
+func (c cloneWriter) copyName() string {
+	return "copy" + Title(c.t.Name)
+}
+
+func (c cloneWriter) copyElement() *Statement {
+	return c.engine().Dot(c.copyName()).Call(Id("clone"), Id(c.t.Name))
+}
+
+func (c cloneWriter) getField(element string) *Statement {
+	return Id(element).Dot(Title(c.f.Name)).Call()
+}
+
+func (c cloneWriter) setBasicValue() *Statement {
+	return Id("dst").Dot("Set" + Title(c.f.Name)).Call(c.getField("src"))
+}
+
+func (c cloneWriter) addBasicValues() *Statement {
+	return Id("dst").Dot("Add" + Title(c.f.Name)).Call(c.getField("src").Op("..."))
+}
+
+// adderName mirrors the names of generated adders, which are
+// suffixed with the value type's name if the field has multiple
+func (c cloneWriter) adderName(valueType *ast.ConfigType) string {
+	var optionalSuffix string
+	if len(c.f.ValueTypes) > 1 {
+		optionalSuffix = Title(valueType.Name)
+	}
+	return "Add" + Title(Singular(c.f.Name)) + optionalSuffix
+}
+
+func (c cloneWriter) loopConditions() *Statement {
+	return List(Id("_"), Id(Singular(c.f.Name))).Op(":=").Range().Add(c.getField("src"))
+}
+
+// forEachKind writes a type switch over the kind of the element
+// if the field has an anyOf value, or only the statement otherwise
+func (c cloneWriter) forEachKind(element *Statement, fn func(*ast.ConfigType) *Statement) *Statement {
+	if !c.f.HasAnyValue {
+		return fn(c.f.ValueType())
+	}
+	return Switch(element.Clone().Dot("Kind").Call()).Block(
+		ForEachValueOfField(c.f, func(v *ast.ConfigType) *Statement {
+			return Case(Id("ElementKind" + Title(v.Name))).Block(
+				fn(v),
+			)
+		}),
+	)
+}
+
+func (c cloneWriter) referenceName() string {
+	if c.f.HasSliceValue {
+		return Singular(c.f.Name)
+	}
+	return c.f.Name
+}
+
+func (c cloneWriter) referencedElement() *Statement {
+	return Id(c.referenceName()).Dot("Get").Call()
+}
+
+func (c cloneWriter) referencedID(valueType *ast.ConfigType) *Statement {
+	if !c.f.HasAnyValue {
+		return Id(c.referenceName()).Dot("ID").Call()
+	}
+	return c.referencedElement().Dot(Title(valueType.Name)).Call().Dot("ID").Call()
+}
+
+func (c cloneWriter) addReference(valueType *ast.ConfigType) *Statement {
+	return Id("dst").Dot(c.adderName(valueType)).Call(c.referencedID(valueType))
+}
+
+func (c cloneWriter) getReference() *Statement {
+	return List(Id(c.f.Name), Id("ok")).Op(":=").Add(c.getField("src"))
+}
+
+func (c cloneWriter) setReference(valueType *ast.ConfigType) *Statement {
+	var optionalSuffix string
+	if c.f.HasAnyValue {
+		optionalSuffix = Title(valueType.Name)
+	}
+	return Id("dst").Dot("Set" + Title(c.f.Name) + optionalSuffix).Call(c.referencedID(valueType))
+}
+
+func (c cloneWriter) copyAddedChild(valueType *ast.ConfigType) *Statement {
+	child := Id(Singular(c.f.Name))
+	if c.f.HasAnyValue {
+		child = child.Dot(Title(valueType.Name)).Call()
+	}
+	return Id("engine").Dot("copy"+Title(valueType.Name)).Call(Id("dst").Dot(c.adderName(valueType)).Call(), child)
+}
+
+func (c cloneWriter) declareAnyChild() *Statement {
+	return Id(c.f.Name).Op(":=").Add(c.getField("src"))
+}
+
+func (c cloneWriter) copyAnyChild(valueType *ast.ConfigType) *Statement {
+	return Id("engine").Dot("copy"+Title(valueType.Name)).Call(c.getField("dst").Dot("Set"+Title(valueType.Name)).Call(), Id(c.f.Name).Dot(Title(valueType.Name)).Call())
+}
+
+func (c cloneWriter) declareOptionalChild() *Statement {
+	return List(Id(c.f.Name), Id("ok")).Op(":=").Add(c.getField("src"))
+}
+
+func (c cloneWriter) copyOptionalChild() *Statement {
+	return Id("engine").Dot("copy"+Title(c.f.ValueType().Name)).Call(Id("dst").Dot("Set"+Title(c.f.Name)).Call(), Id(c.f.Name))
+}
+
+func (c cloneWriter) copyChild() *Statement {
+	return Id("engine").Dot("copy"+Title(c.f.ValueType().Name)).Call(c.getField("dst"), c.getField("src"))
+}
@@ -28,9 +28,16 @@ func (s *EngineFactory) writeCreators() *EngineFactory {
 			c.declareElement(),
 			c.assignEngine(),
 			c.generateID(),
+			c.setHasParent(),
+			c.setPath(),
+			If(Id("extendWithID")).Block(
+				c.extendPathWithID(),
+			),
+			c.setJSONPath(),
 			ForEachFieldInType(configType, func(field ast.Field) *Statement {
 				c.f = &field
-				if field.HasSliceValue || field.ValueType().IsBasicType || field.HasPointerValue {
+				// children of optional fields are only created when set
+				if field.HasSliceValue || field.ValueType().IsBasicType || field.HasPointerValue || field.HasOptionalValue {
 					return Empty()
 				}
 				return &Statement{
@@ -39,13 +46,11 @@ func (s *EngineFactory) writeCreators() *EngineFactory {
 				}
 			}),
 			c.setOperationKind(),
-			c.setHasParent(),
-			c.setPath(),
-			If(Id("extendWithID")).Block(
-				c.extendPathWithID(),
-			),
-			c.setJSONPath(),
 			c.updateElementInPatch(),
+			ForEachFieldInType(configType, func(field ast.Field) *Statement {
+				c.f = &field
+				return OnlyIf(field.IsIndexed, c.indexField())
+			}),
 			Return(c.returnElement()),
 		)
 	})
@@ -79,9 +79,9 @@ func (c creatorWriter) setJSONPath() *Statement {
 func (c creatorWriter) createChildElement() *Statement {
 	statement := Id("element" + Title(c.f.Name)).Op(":=").Id("engine").Dot("create" + Title(c.f.ValueTypeName))
 	if c.f.HasAnyValue {
-		return statement.Call(True(), Id("p").Dot(c.f.Name).Call())
+		return statement.Call(True(), Id("element").Dot("path").Dot(c.f.Name).Call())
 	}
-	return statement.Call(Id("p").Dot(c.f.Name).Call(), False())
+	return statement.Call(Id("element").Dot("path").Dot(c.f.Name).Call(), False())
 }
 func (c creatorWriter) setChildElement() *Statement {
 	return Id("element").Dot(Title(c.f.Name)).Op("=").Id("element" + Title(c.f.Name)).Dot(c.f.ValueTypeName).Dot("ID")
@@ -95,6 +95,10 @@ func (c creatorWriter) updateElementInPatch() *Statement {
 	return Id("engine").Dot("Patch").Dot(Title(c.t.Name)).Index(Id("element").Dot("ID")).Op("=").Id("element")
 }
 
+func (c creatorWriter) indexField() *Statement {
+	return Id("engine").Dot("index"+Title(c.t.Name)+Title(c.f.Name)).Call(Id("element").Dot("ID"), Id("element").Dot(Title(c.f.Name)))
+}
+
 func (c creatorWriter) returnElement() *Statement {
 	return Id(c.t.Name).Values(Dict{
 		Id(c.t.Name): Id("element"),
@@ -16,8 +16,13 @@ func (s *EngineFactory) writeDeleters() *EngineFactory {
 		}
 
 		decls.File.Func().Params(w.receiverParams()).Id(w.name()).Params(w.params()).Block(
-			OnlyIf(!configType.IsRootType, w.getElement()),
+			w.getElement(),
+			If(w.isOperationKindDelete()).Block(
+				w.recordError("ErrElementDoesNotExist"),
+				Return(),
+			),
 			OnlyIf(!configType.IsRootType, If(w.hasParent()).Block(
+				w.recordError("ErrElementHasParent"),
 				Return(),
 			)),
 			w.deleteElement(),
@@ -30,6 +35,10 @@ func (s *EngineFactory) writeDeleters() *EngineFactory {
 
 		decls.File.Func().Params(d.receiverParams()).Id(d.name()).Params(d.params()).Block(
 			d.getElement(),
+			ForEachFieldInType(configType, func(field ast.Field) *Statement {
+				d.f = &field
+				return OnlyIf(field.IsIndexed, d.unindexField())
+			}),
 			ForEachReferenceOfType(configType, func(field *ast.Field) *Statement {
 				return d.dereferenceField(field)
 			}),
@@ -43,6 +52,11 @@ func (s *EngineFactory) writeDeleters() *EngineFactory {
 						d.deleteElementInLoop(),
 					))
 				}
+				if field.HasOptionalValue {
+					return If(d.isSet()).Block(
+						d.deleteElement(),
+					)
+				}
 				return d.deleteElement()
 			}),
 			If(d.existsInState()).Block(
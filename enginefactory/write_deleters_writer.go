@@ -31,6 +31,14 @@ func (d deleteTypeWrapperWriter) getElement() *Statement {
 	return Id(d.t.Name).Op(":=").Id("engine").Dot(Title(d.t.Name)).Call(Id(d.idParam())).Dot(d.t.Name)
 }
 
+func (d deleteTypeWrapperWriter) isOperationKindDelete() *Statement {
+	return Id(d.t.Name).Dot("OperationKind").Op("==").Id("OperationKindDelete")
+}
+
+func (d deleteTypeWrapperWriter) recordError(err string) *Statement {
+	return recordError(Id("engine"), d.name(), d.t.Name, Id(d.idParam()), err)
+}
+
 func (d deleteTypeWrapperWriter) hasParent() *Statement {
 	return Id(d.t.Name).Dot("HasParent")
 }
@@ -64,6 +72,10 @@ func (d deleteTypeWriter) getElement() *Statement {
 	return Id(d.t.Name).Op(":=").Id("engine").Dot(Title(d.t.Name)).Call(Id(d.idParam())).Dot(d.t.Name)
 }
 
+func (d deleteTypeWriter) unindexField() *Statement {
+	return Id("engine").Dot("unindex"+Title(d.t.Name)+Title(d.f.Name)).Call(Id(d.idParam()), Id(d.t.Name).Dot(Title(d.f.Name)))
+}
+
 func (d deleteTypeWriter) setOperationKind() *Statement {
 	return Id(d.t.Name).Dot("OperationKind").Op("=").Id("OperationKindDelete")
 }
@@ -96,6 +108,10 @@ func (d deleteTypeWriter) deleteElement() *Statement {
 	return deleteFunc.Call(Id(d.t.Name).Dot(Title(d.f.Name)))
 }
 
+func (d deleteTypeWriter) isSet() *Statement {
+	return Id(d.t.Name).Dot(Title(d.f.Name)).Op("!=").Lit(0)
+}
+
 func (d deleteTypeWriter) existsInState() *Statement {
 	return List(Id("_"), Id("ok")).Op(":=").Id("engine").Dot("State").Dot(Title(d.t.Name)).Index(Id(d.idParam())).Id(";").Id("ok")
 }
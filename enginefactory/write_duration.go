@@ -0,0 +1,34 @@
+package enginefactory
+
+import (
+	. "github.com/jobergner/backent-cli/factoryutils"
+
+	. "github.com/dave/jennifer/jen"
+)
+
+func (s *EngineFactory) writeDuration() *EngineFactory {
+	decls := NewDeclSet()
+
+	d := durationWriter{}
+
+	decls.File.Type().Id("Duration").Id("time").Dot("Duration")
+
+	decls.File.Func().Params(d.receiverParams()).Id("MarshalJSON").Params().Params(Index().Byte(), Error()).Block(
+		Return(d.formatMilliseconds(), Nil()),
+	)
+
+	decls.File.Func().Params(d.pointerReceiverParams()).Id("UnmarshalJSON").Params(Id("data").Index().Byte()).Error().Block(
+		If(isNullData()).Block(
+			Return(Nil()),
+		),
+		d.parseMilliseconds(),
+		If(Err().Op("!=").Nil()).Block(
+			Return(Err()),
+		),
+		d.assignDuration(),
+		Return(Nil()),
+	)
+
+	decls.Render(s.buf)
+	return s
+}
@@ -0,0 +1,26 @@
+package enginefactory
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jobergner/backent-cli/testutils"
+)
+
+func TestWriteDuration(t *testing.T) {
+	t.Run("writes duration", func(t *testing.T) {
+		sf := newStateFactory(newSimpleASTExample())
+		sf.writeDuration()
+
+		actual := testutils.FormatCode(sf.buf.String())
+		expected := testutils.FormatCode(strings.Join([]string{
+			_Duration_type,
+			_MarshalJSON_Duration_func,
+			_UnmarshalJSON_Duration_func,
+		}, "\n"))
+
+		if expected != actual {
+			t.Errorf(testutils.Diff(actual, expected))
+		}
+	})
+}
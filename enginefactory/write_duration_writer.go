@@ -0,0 +1,29 @@
+package enginefactory
+
+import (
+	. "github.com/dave/jennifer/jen"
+)
+
+type durationWriter struct{}
+
+func (d durationWriter) receiverParams() *Statement {
+	return Id("d").Id("Duration")
+}
+
+func (d durationWriter) pointerReceiverParams() *Statement {
+	return Id("d").Id("*Duration")
+}
+
+func (d durationWriter) formatMilliseconds() *Statement {
+	milliseconds := Id("time").Dot("Duration").Call(Id("d")).Dot("Milliseconds").Call()
+	return Index().Byte().Call(Id("strconv").Dot("FormatInt").Call(milliseconds, Lit(10)))
+}
+
+func (d durationWriter) parseMilliseconds() *Statement {
+	return List(Id("milliseconds"), Err()).Op(":=").Id("strconv").Dot("ParseFloat").Call(Id("string").Call(Id("data")), Lit(64))
+}
+
+func (d durationWriter) assignDuration() *Statement {
+	duration := Id("milliseconds").Op("*").Id("float64").Call(Id("time").Dot("Millisecond"))
+	return Op("*").Id("d").Op("=").Id("Duration").Call(duration)
+}
@@ -0,0 +1,137 @@
+package enginefactory
+
+import (
+	"github.com/jobergner/backent-cli/ast"
+	. "github.com/jobergner/backent-cli/factoryutils"
+
+	. "github.com/dave/jennifer/jen"
+)
+
+func (s *EngineFactory) writeAnyElement() *EngineFactory {
+	decls := NewDeclSet()
+
+	decls.File.Type().Id("AnyElement").Struct(
+		Id("kind").Id("ElementKind"),
+		Id("id").Int(),
+		Id("engine").Id("*Engine"),
+	)
+
+	decls.File.Func().Params(Id("element").Id("AnyElement")).Id("Kind").Params().Id("ElementKind").Block(
+		Return(Id("element").Dot("kind")),
+	)
+
+	s.config.RangeTypes(func(configType ast.ConfigType) {
+		a := anyElementWriter{t: configType}
+
+		decls.File.Func().Params(a.receiverParams()).Id(Title(configType.Name)).Params().Id(configType.Name).Block(
+			Return(a.getElement()),
+		)
+	})
+
+	decls.File.Func().Params(Id("element").Id("AnyElement")).Id("path").Params().String().Block(
+		Switch(Id("element").Dot("kind")).Block(
+			ForEachTypeInAST(s.config, func(configType ast.ConfigType) *Statement {
+				a := anyElementWriter{t: configType}
+				return Case(a.elementKind()).Block(
+					Return(Id("element").Dot(Title(configType.Name)).Call().Dot("Path").Call()),
+				)
+			}),
+		),
+		Return(Lit("")),
+	)
+
+	decls.Render(s.buf)
+	return s
+}
+
+func (s *EngineFactory) writeElementByPath() *EngineFactory {
+	decls := NewDeclSet()
+
+	decls.File.Func().Params(Id("engine").Id("*Engine")).Id("elementByID").Params(Id("id").Int()).Params(Id("AnyElement"), Bool()).Block(
+		ForEachTypeInAST(s.config, func(configType ast.ConfigType) *Statement {
+			a := anyElementWriter{t: configType}
+			return If(a.elementExists()).Block(
+				Return(a.anyElement(Id("id"), Id("engine")), True()),
+			)
+		}),
+		Return(Id("AnyElement").Values(), False()),
+	)
+
+	decls.File.Func().Params(Id("element").Id("AnyElement")).Id("child").Params(Id("identifier").String()).Params(Id("AnyElement"), Bool()).Block(
+		Switch(Id("element").Dot("kind")).Block(
+			ForEachTypeInAST(s.config, func(configType ast.ConfigType) *Statement {
+				a := anyElementWriter{t: configType}
+				if !a.hasChildElements() {
+					return Empty()
+				}
+				return Case(a.elementKind()).Block(
+					Id(configType.Name).Op(":=").Id("element").Dot(Title(configType.Name)).Call(),
+					Switch(Id("identifier")).Block(
+						ForEachFieldInType(configType, func(field ast.Field) *Statement {
+							if !isChildElementField(field) {
+								return Empty()
+							}
+							a.f = field
+							if field.HasOptionalValue {
+								return Case(Lit(field.Name)).Block(
+									If(List(Id(field.Name), Id("ok")).Op(":=").Id(configType.Name).Dot(Title(field.Name)).Call(), Id("ok")).Block(
+										Return(anyElementWriter{t: *field.ValueType()}.anyElement(a.childID(Id(field.Name)), Id("element").Dot("engine")), True()),
+									),
+								)
+							}
+							if !field.HasAnyValue {
+								return Case(Lit(field.Name)).Block(
+									Return(anyElementWriter{t: *field.ValueType()}.anyElement(a.childID(Id(configType.Name).Dot(Title(field.Name)).Call()), Id("element").Dot("engine")), True()),
+								)
+							}
+							return Case(Lit(field.Name)).Block(
+								Id(field.Name).Op(":=").Id(configType.Name).Dot(Title(field.Name)).Call(),
+								Switch(Id(field.Name).Dot("Kind").Call()).Block(
+									ForEachValueOfField(field, func(valueType *ast.ConfigType) *Statement {
+										v := anyElementWriter{t: *valueType}
+										return Case(v.elementKind()).Block(
+											Return(v.anyElement(a.childID(Id(field.Name).Dot(Title(valueType.Name)).Call()), Id("element").Dot("engine")), True()),
+										)
+									}),
+								),
+							)
+						}),
+					),
+				)
+			}),
+		),
+		Return(Id("AnyElement").Values(), False()),
+	)
+
+	decls.File.Func().Params(Id("engine").Id("*Engine")).Id("ElementByPath").Params(Id("jsonPath").String()).Params(Id("AnyElement"), Bool()).Block(
+		Id("segments").Op(":=").Id("strings").Dot("Split").Call(
+			Id("strings").Dot("NewReplacer").Call(Lit("["), Lit("."), Lit("]"), Lit("")).Dot("Replace").Call(Id("strings").Dot("TrimPrefix").Call(Id("jsonPath"), Lit("$."))),
+			Lit("."),
+		),
+		Id("lastIDIndex").Op(":=").Lit(-1),
+		Var().Id("id").Int(),
+		For(List(Id("i"), Id("segment")).Op(":=").Range().Id("segments")).Block(
+			If(List(Id("segmentID"), Id("err")).Op(":=").Id("strconv").Dot("Atoi").Call(Id("segment")), Id("err").Op("==").Nil()).Block(
+				Id("lastIDIndex").Op("=").Id("i"),
+				Id("id").Op("=").Id("segmentID"),
+			),
+		),
+		If(Id("lastIDIndex").Op("==").Lit(-1)).Block(
+			Return(Id("AnyElement").Values(), False()),
+		),
+		List(Id("element"), Id("ok")).Op(":=").Id("engine").Dot("elementByID").Call(Id("id")),
+		For(List(Id("_"), Id("identifier")).Op(":=").Range().Id("segments").Index(Id("lastIDIndex").Op("+").Lit(1).Op(":"))).Block(
+			If(Op("!").Id("ok")).Block(
+				Break(),
+			),
+			List(Id("element"), Id("ok")).Op("=").Id("element").Dot("child").Call(Id("identifier")),
+		),
+		If(Op("!").Id("ok").Op("||").Id("element").Dot("path").Call().Op("!=").Id("jsonPath")).Block(
+			Return(Id("AnyElement").Values(), False()),
+		),
+		Return(Id("element"), True()),
+	)
+
+	decls.Render(s.buf)
+	return s
+}
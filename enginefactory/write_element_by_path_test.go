@@ -0,0 +1,48 @@
+package enginefactory
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jobergner/backent-cli/testutils"
+)
+
+func TestWriteElementByPath(t *testing.T) {
+	t.Run("writes any element", func(t *testing.T) {
+		sf := newStateFactory(newSimpleASTExample())
+		sf.writeAnyElement()
+
+		actual := testutils.FormatCode(sf.buf.String())
+		expected := testutils.FormatCode(strings.Join([]string{
+			_AnyElement_type,
+			_Kind_AnyElement_func,
+			_EquipmentSet_AnyElement_func,
+			_GearScore_AnyElement_func,
+			_Item_AnyElement_func,
+			_Player_AnyElement_func,
+			_Position_AnyElement_func,
+			_Zone_AnyElement_func,
+			_ZoneItem_AnyElement_func,
+			path_AnyElement_func,
+		}, "\n"))
+
+		if expected != actual {
+			t.Errorf(testutils.Diff(actual, expected))
+		}
+	})
+	t.Run("writes element by path", func(t *testing.T) {
+		sf := newStateFactory(newSimpleASTExample())
+		sf.writeElementByPath()
+
+		actual := testutils.FormatCode(sf.buf.String())
+		expected := testutils.FormatCode(strings.Join([]string{
+			elementByID_Engine_func,
+			child_AnyElement_func,
+			_ElementByPath_Engine_func,
+		}, "\n"))
+
+		if expected != actual {
+			t.Errorf(testutils.Diff(actual, expected))
+		}
+	})
+}
@@ -0,0 +1,61 @@
+package enginefactory
+
+import (
+	"github.com/jobergner/backent-cli/ast"
+	. "github.com/jobergner/backent-cli/factoryutils"
+
+	. "github.com/dave/jennifer/jen"
+)
+
+// isChildElementField is true for fields which own exactly one element,
+// those elements' paths end with the field's name instead of an ID
+func isChildElementField(field ast.Field) bool {
+	return !field.HasSliceValue && !field.HasPointerValue && !field.ValueType().IsBasicType
+}
+
+type anyElementWriter struct {
+	t ast.ConfigType
+	f ast.Field
+}
+
+func (a anyElementWriter) receiverParams() *Statement {
+	return Id("element").Id("AnyElement")
+}
+
+func (a anyElementWriter) idType() string {
+	return Title(a.t.Name) + "ID"
+}
+
+func (a anyElementWriter) elementKind() *Statement {
+	return Id("ElementKind" + Title(a.t.Name))
+}
+
+func (a anyElementWriter) getElement() *Statement {
+	return Id("element").Dot("engine").Dot(Title(a.t.Name)).Call(Id(a.idType()).Call(Id("element").Dot("id")))
+}
+
+func (a anyElementWriter) elementExists() *Statement {
+	return Id("engine").Dot(Title(a.t.Name)).Call(Id(a.idType()).Call(Id("id"))).Dot(a.t.Name).Dot("OperationKind").Op("!=").Id("OperationKindDelete")
+}
+
+func (a anyElementWriter) anyElement(id, engine *Statement) *Statement {
+	return Id("AnyElement").Values(Dict{
+		Id("kind"):   a.elementKind(),
+		Id("id"):     id,
+		Id("engine"): engine,
+	})
+}
+
+func (a anyElementWriter) childID(child *Statement) *Statement {
+	return Int().Call(child.Dot("ID").Call())
+}
+
+func (a anyElementWriter) hasChildElements() bool {
+	var hasChildElements bool
+	a.t.RangeFields(func(field ast.Field) {
+		if isChildElementField(field) {
+			hasChildElements = true
+		}
+	})
+	return hasChildElements
+}
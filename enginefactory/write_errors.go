@@ -0,0 +1,37 @@
+package enginefactory
+
+import (
+	. "github.com/jobergner/backent-cli/factoryutils"
+
+	. "github.com/dave/jennifer/jen"
+)
+
+func (s *EngineFactory) writeErrors() *EngineFactory {
+	decls := NewDeclSet()
+
+	e := errorsWriter{}
+
+	decls.File.Var().Id("ErrElementDoesNotExist").Op("=").Add(e.newError("element does not exist"))
+	decls.File.Var().Id("ErrElementHasParent").Op("=").Add(e.newError("element has a parent"))
+	decls.File.Var().Id("ErrElementNotDetachable").Op("=").Add(e.newError("element cannot be detached from its parent"))
+	decls.File.Var().Id("ErrElementIsAncestor").Op("=").Add(e.newError("element is an ancestor of the adopting element"))
+	decls.File.Var().Id("ErrElementNotInSlice").Op("=").Add(e.newError("element is not in slice"))
+
+	decls.File.Func().Params(e.receiverParams()).Id("EnableStrictMode").Params().Block(
+		Id("engine").Dot("strict").Op("=").True(),
+	)
+
+	decls.File.Func().Params(e.receiverParams()).Id("Errors").Params().Index().Error().Block(
+		Return(Id("engine").Dot("errs")),
+	)
+
+	decls.File.Func().Params(e.receiverParams()).Id("recordError").Params(e.recordErrorParams()).Block(
+		If(Op("!").Id("engine").Dot("strict")).Block(
+			Return(),
+		),
+		e.appendError(),
+	)
+
+	decls.Render(s.buf)
+	return s
+}
@@ -0,0 +1,31 @@
+package enginefactory
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jobergner/backent-cli/testutils"
+)
+
+func TestWriteErrors(t *testing.T) {
+	t.Run("writes errors", func(t *testing.T) {
+		sf := newStateFactory(newSimpleASTExample())
+		sf.writeErrors()
+
+		actual := testutils.FormatCode(sf.buf.String())
+		expected := testutils.FormatCode(strings.Join([]string{
+			_ErrElementDoesNotExist_type,
+			_ErrElementHasParent_type,
+			_ErrElementNotDetachable_type,
+			_ErrElementIsAncestor_type,
+			_ErrElementNotInSlice_type,
+			_EnableStrictMode_Engine_func,
+			_Errors_Engine_func,
+			recordError_Engine_func,
+		}, "\n"))
+
+		if expected != actual {
+			t.Errorf(testutils.Diff(actual, expected))
+		}
+	})
+}
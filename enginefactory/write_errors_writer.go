@@ -0,0 +1,32 @@
+package enginefactory
+
+import (
+	. "github.com/jobergner/backent-cli/factoryutils"
+
+	. "github.com/dave/jennifer/jen"
+)
+
+// recordError writes a call to the engine's recordError method, which is
+// used by mutations to report why they did nothing when in strict mode
+func recordError(engine *Statement, operation, typeName string, id *Statement, err string) *Statement {
+	return engine.Dot("recordError").Call(Lit(operation), Id("ElementKind"+Title(typeName)), Int().Call(id), Id(err))
+}
+
+type errorsWriter struct{}
+
+func (e errorsWriter) receiverParams() *Statement {
+	return Id("engine").Id("*Engine")
+}
+
+func (e errorsWriter) newError(message string) *Statement {
+	return Id("errors").Dot("New").Call(Lit(message))
+}
+
+func (e errorsWriter) recordErrorParams() *Statement {
+	return List(Id("operation").String(), Id("kind").Id("ElementKind"), Id("id").Int(), Id("err").Error())
+}
+
+func (e errorsWriter) appendError() *Statement {
+	formatted := Id("fmt").Dot("Errorf").Call(Lit("%s: %s %d: %w"), Id("operation"), Id("kind"), Id("id"), Id("err"))
+	return Id("engine").Dot("errs").Op("=").Append(Id("engine").Dot("errs"), formatted)
+}
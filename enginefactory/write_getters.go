@@ -26,6 +26,17 @@ func (s *EngineFactory) writeGetters() *EngineFactory {
 		}
 		writeTypeGetter(&decls, t)
 
+		l := lookupWriter{
+			t: configType,
+		}
+		decls.File.Func().Params(l.receiverParams()).Id(l.existsName()).Params(l.params()).Bool().Block(
+			Return(l.getElementExists()),
+		)
+		decls.File.Func().Params(l.receiverParams()).Id(l.lookupName()).Params(l.params()).Params(Id(configType.Name), Bool()).Block(
+			Id(configType.Name).Op(":=").Add(l.getElement()),
+			Return(Id(configType.Name), l.exists()),
+		)
+
 		i := idGetterWriter{
 			typeName: func() string {
 				return configType.Name
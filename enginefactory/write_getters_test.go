@@ -16,18 +16,28 @@ func TestWriteGetters(t *testing.T) {
 		expected := testutils.FormatCode(strings.Join([]string{
 			_EveryEquipmentSet_Engine_func,
 			_EquipmentSet_Engine_func,
+			_EquipmentSetExists_Engine_func,
+			_LookupEquipmentSet_Engine_func,
 			_ID_equipmentSet_func,
 			_Path_equipmentSet_func,
+			_Cooldown_equipmentSet_func,
+			_CreatedAt_equipmentSet_func,
 			_Equipment_equipmentSet_func,
+			_ExternalID_equipmentSet_func,
+			_Icon_equipmentSet_func,
 			_Name_equipmentSet_func,
 			_EveryGearScore_Engine_func,
 			_GearScore_Engine_func,
+			_GearScoreExists_Engine_func,
+			_LookupGearScore_Engine_func,
 			_ID_gearScore_func,
 			_Path_gearScore_func,
 			_Level_gearScore_func,
 			_Score_gearScore_func,
 			_EveryItem_Engine_func,
 			_Item_Engine_func,
+			_ItemExists_Engine_func,
+			_LookupItem_Engine_func,
 			_ID_item_func,
 			_Path_item_func,
 			_BoundTo_item_func,
@@ -36,6 +46,8 @@ func TestWriteGetters(t *testing.T) {
 			_Origin_item_func,
 			_EveryPlayer_Engine_func,
 			_Player_Engine_func,
+			_PlayerExists_Engine_func,
+			_LookupPlayer_Engine_func,
 			_ID_player_func,
 			_Path_player_func,
 			_EquipmentSets_player_func,
@@ -47,20 +59,27 @@ func TestWriteGetters(t *testing.T) {
 			_TargetedBy_player_func,
 			_EveryPosition_Engine_func,
 			_Position_Engine_func,
+			_PositionExists_Engine_func,
+			_LookupPosition_Engine_func,
 			_ID_position_func,
 			_Path_position_func,
 			_X_position_func,
 			_Y_position_func,
 			_EveryZone_Engine_func,
 			_Zone_Engine_func,
+			_ZoneExists_Engine_func,
+			_LookupZone_Engine_func,
 			_ID_zone_func,
 			_Path_zone_func,
+			_Boss_zone_func,
 			_Interactables_zone_func,
 			_Items_zone_func,
 			_Players_zone_func,
 			_Tags_zone_func,
 			_EveryZoneItem_Engine_func,
 			_ZoneItem_Engine_func,
+			_ZoneItemExists_Engine_func,
+			_LookupZoneItem_Engine_func,
 			_ID_zoneItem_func,
 			_Path_zoneItem_func,
 			_Item_zoneItem_func,
@@ -92,6 +92,38 @@ func (t typeGetterWriter) finalReturn() *Statement {
 	return Id(t.typeName()).Values(Dict{Id(t.typeName()): Id(t.typeName() + "Core").Values(Dict{Id("OperationKind"): Id("OperationKindDelete"), Id("engine"): Id("engine")})})
 }
 
+type lookupWriter struct {
+	t ast.ConfigType
+}
+
+func (l lookupWriter) receiverParams() *Statement {
+	return Id("engine").Id("*Engine")
+}
+
+func (l lookupWriter) existsName() string {
+	return Title(l.t.Name) + "Exists"
+}
+
+func (l lookupWriter) lookupName() string {
+	return "Lookup" + Title(l.t.Name)
+}
+
+func (l lookupWriter) params() *Statement {
+	return Id(l.t.Name + "ID").Id(Title(l.t.Name) + "ID")
+}
+
+func (l lookupWriter) getElement() *Statement {
+	return Id("engine").Dot(Title(l.t.Name)).Call(Id(l.t.Name + "ID"))
+}
+
+func (l lookupWriter) getElementExists() *Statement {
+	return l.getElement().Dot(l.t.Name).Dot("OperationKind").Op("!=").Id("OperationKindDelete")
+}
+
+func (l lookupWriter) exists() *Statement {
+	return Id(l.t.Name).Dot(l.t.Name).Dot("OperationKind").Op("!=").Id("OperationKindDelete")
+}
+
 type idGetterWriter struct {
 	typeName        func() string
 	returns         func() string
@@ -149,7 +181,7 @@ func (f fieldGetterWriter) returns() string {
 	returnedLiteral := f.returnedType()
 	if f.f.HasSliceValue {
 		return "[]" + returnedLiteral
-	} else if f.f.HasPointerValue {
+	} else if f.f.HasPointerValue || f.f.HasOptionalValue {
 		return "(" + returnedLiteral + ", bool)"
 	}
 	return returnedLiteral
@@ -205,7 +237,7 @@ func (f fieldGetterWriter) returnSliceOfType() *Statement {
 }
 
 func (f fieldGetterWriter) returnBasicType() *Statement {
-	return Id(f.t.Name).Dot(f.t.Name).Dot(Title(f.f.Name))
+	return copyBasicValue(f.f.ValueType(), Id(f.t.Name).Dot(f.t.Name).Dot(Title(f.f.Name)))
 }
 
 func (f fieldGetterWriter) returnNamedType() *Statement {
@@ -217,6 +249,9 @@ func (f fieldGetterWriter) returnNamedType() *Statement {
 	if !f.f.HasAnyValue {
 		returnedType = Title(returnedType)
 	}
+	if f.f.HasOptionalValue {
+		return engine.Dot(returnedType).Call(Id(f.t.Name).Dot(f.t.Name).Dot(Title(f.f.Name))).Id(",").Id(f.t.Name).Dot(f.t.Name).Dot(Title(f.f.Name)).Op("!=").Lit(0)
+	}
 	return engine.Dot(returnedType).Call(Id(f.t.Name).Dot(f.t.Name).Dot(Title(f.f.Name)))
 }
 
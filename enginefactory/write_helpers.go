@@ -96,6 +96,9 @@ func writeAllIDsMethod(decls *DeclSet, a allIDsMehtodWriter) {
 			a.appendPatchID(),
 		),
 		a.declareDedupedIDs(),
+		If(Id("engine").Dot("ordered")).Block(
+			a.sortDedupedIDs(),
+		),
 		a.returnIdSliceToPool("state"),
 		a.returnIdSliceToPool("patch"),
 		Return(Id("dedupedIDs")),
@@ -155,4 +158,38 @@ func writeMergeIDs(decls *DeclSet, m mergeIDsWriter) {
 		),
 		Return(Id("ids")),
 	)
+
+	decls.File.Func().Id(m.reorderedName()).Params(m.params()).Bool().Block(
+		m.declareCounter(),
+		For(m.currentIDsLoopConditions()).Block(
+			If(m.idDoesNotMatch()).Block(
+				Continue(),
+			),
+			m.incrementCounter(),
+		),
+		If(Len(Id("nextIDs").Index(Id("j:"))).Op(">").Lit(1)).Block(
+			Return(True()),
+		),
+		For(m.nextIDsLoopConditions()).Block(
+			For(m.currentIDsLoopConditions()).Block(
+				If(Id("nextID").Op("==").Id("currentID")).Block(
+					Return(True()),
+				),
+			),
+		),
+		Return(False()),
+	)
+
+	decls.File.Func().Id(m.moveName()).Params(Id("ids").Id("[]"+m.idType()), List(Id("from"), Id("to")).Int()).Id(m.returns()).Block(
+		If(Id("to").Op("<").Lit(0)).Block(
+			Id("to").Op("=").Lit(0),
+		),
+		If(Id("to").Op(">").Len(Id("ids")).Op("-").Lit(1)).Block(
+			Id("to").Op("=").Len(Id("ids")).Op("-").Lit(1),
+		),
+		m.declareMovedIDs(),
+		Id("movedIDs").Op("=").Append(Id("movedIDs"), Id("ids").Index(Id(":from")).Op("...")),
+		Id("movedIDs").Op("=").Append(Id("movedIDs"), Id("ids").Index(Id("from+1:")).Op("...")),
+		Return(Append(Id("movedIDs").Index(Id(":to")), m.insertMovedID().Op("..."))),
+	)
 }
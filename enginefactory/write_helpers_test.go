@@ -65,21 +65,53 @@ func TestWriteHelpers(t *testing.T) {
 		actual := testutils.FormatCode(sf.buf.String())
 		expected := testutils.FormatCode(strings.Join([]string{
 			mergeEquipmentSetIDs_func,
+			wereEquipmentSetIDsReordered_func,
+			moveEquipmentSetID_func,
 			mergeGearScoreIDs_func,
+			wereGearScoreIDsReordered_func,
+			moveGearScoreID_func,
 			mergeItemIDs_func,
+			wereItemIDsReordered_func,
+			moveItemID_func,
 			mergePlayerIDs_func,
+			werePlayerIDsReordered_func,
+			movePlayerID_func,
 			mergePositionIDs_func,
+			werePositionIDsReordered_func,
+			movePositionID_func,
 			mergeZoneIDs_func,
+			wereZoneIDsReordered_func,
+			moveZoneID_func,
 			mergeZoneItemIDs_func,
+			wereZoneItemIDsReordered_func,
+			moveZoneItemID_func,
 			mergeEquipmentSetEquipmentRefIDs_func,
+			wereEquipmentSetEquipmentRefIDsReordered_func,
+			moveEquipmentSetEquipmentRefID_func,
 			mergeItemBoundToRefIDs_func,
+			wereItemBoundToRefIDsReordered_func,
+			moveItemBoundToRefID_func,
 			mergePlayerEquipmentSetRefIDs_func,
+			werePlayerEquipmentSetRefIDsReordered_func,
+			movePlayerEquipmentSetRefID_func,
 			mergePlayerGuildMemberRefIDs_func,
+			werePlayerGuildMemberRefIDsReordered_func,
+			movePlayerGuildMemberRefID_func,
 			mergePlayerTargetRefIDs_func,
+			werePlayerTargetRefIDsReordered_func,
+			movePlayerTargetRefID_func,
 			mergePlayerTargetedByRefIDs_func,
+			werePlayerTargetedByRefIDsReordered_func,
+			movePlayerTargetedByRefID_func,
 			mergeAnyOfPlayer_PositionIDs_func,
+			wereAnyOfPlayer_PositionIDsReordered_func,
+			moveAnyOfPlayer_PositionID_func,
 			mergeAnyOfPlayer_ZoneItemIDs_func,
+			wereAnyOfPlayer_ZoneItemIDsReordered_func,
+			moveAnyOfPlayer_ZoneItemID_func,
 			mergeAnyOfItem_Player_ZoneItemIDs_func,
+			wereAnyOfItem_Player_ZoneItemIDsReordered_func,
+			moveAnyOfItem_Player_ZoneItemID_func,
 		}, "\n"))
 
 		if expected != actual {
@@ -123,6 +123,22 @@ func (m mergeIDsWriter) name() string {
 	return "merge" + m.idType() + "s"
 }
 
+func (m mergeIDsWriter) reorderedName() string {
+	return "were" + m.idType() + "sReordered"
+}
+
+func (m mergeIDsWriter) moveName() string {
+	return "move" + m.idType()
+}
+
+func (m mergeIDsWriter) declareMovedIDs() *Statement {
+	return Id("movedIDs").Op(":=").Make(Id("[]"+m.idType()), Lit(0), Len(Id("ids")))
+}
+
+func (m mergeIDsWriter) insertMovedID() *Statement {
+	return Append(Id("[]"+m.idType()).Values(Id("ids").Index(Id("from"))), Id("movedIDs").Index(Id("to:")).Op("..."))
+}
+
 func (m mergeIDsWriter) params() *Statement {
 	return List(Id("currentIDs, nextIDs").Id("[]" + m.idType()))
 }
@@ -162,3 +178,10 @@ func (m mergeIDsWriter) nextIDsLoopConditions() *Statement {
 func (m mergeIDsWriter) appendID() *Statement {
 	return Id("ids").Op("=").Append(Id("ids"), Id("nextID"))
 }
+
+func (a allIDsMehtodWriter) sortDedupedIDs() *Statement {
+	less := Func().Params(Id("i"), Id("j").Int()).Bool().Block(
+		Return(Id("dedupedIDs").Index(Id("i")).Op("<").Id("dedupedIDs").Index(Id("j"))),
+	)
+	return Id("sort").Dot("Slice").Call(Id("dedupedIDs"), less)
+}
@@ -0,0 +1,113 @@
+package enginefactory
+
+import (
+	"github.com/jobergner/backent-cli/ast"
+	. "github.com/jobergner/backent-cli/factoryutils"
+
+	. "github.com/dave/jennifer/jen"
+)
+
+func (s *EngineFactory) writeListeners() *EngineFactory {
+	decls := NewDeclSet()
+
+	decls.File.Type().Id("listeners").Struct(
+		Id("registered").Bool(),
+		Id("emitted").Bool(),
+		ForEachTypeInAST(s.config, func(configType ast.ConfigType) *Statement {
+			l := listenersWriter{t: configType}
+			return &Statement{
+				Id(l.listenersName("Created")).Index().Add(l.listenerType()), Line(),
+				Id(l.listenersName("Updated")).Index().Add(l.updateListenerType()), Line(),
+				Id(l.listenersName("Deleted")).Index().Add(l.listenerType()),
+			}
+		}),
+	)
+
+	s.config.RangeTypes(func(configType ast.ConfigType) {
+		l := listenersWriter{t: configType}
+
+		decls.File.Func().Params(l.receiverParams()).Id(l.name("Created")).Params(Id("listener").Add(l.listenerType())).Block(
+			l.setRegistered(),
+			l.appendListener("Created"),
+		)
+
+		decls.File.Func().Params(l.receiverParams()).Id(l.name("Updated")).Params(Id("listener").Add(l.updateListenerType())).Block(
+			l.setRegistered(),
+			l.appendListener("Updated"),
+		)
+
+		decls.File.Func().Params(l.receiverParams()).Id(l.name("Deleted")).Params(Id("listener").Add(l.listenerType())).Block(
+			l.setRegistered(),
+			l.appendListener("Deleted"),
+		)
+	})
+
+	decls.Render(s.buf)
+	return s
+}
+
+func (s *EngineFactory) writeEmitChanges() *EngineFactory {
+	decls := NewDeclSet()
+
+	decls.File.Func().Params(Id("engine").Id("*Engine")).Id("emitChanges").Params().Block(
+		If(Id("engine").Dot("listeners").Dot("emitted").Op("||").Op("!").Id("engine").Dot("listeners").Dot("registered")).Block(
+			Return(),
+		),
+		Id("engine").Dot("listeners").Dot("emitted").Op("=").True(),
+		Id("previous").Op(":=").Op("&").Id("Engine").Values(Dict{
+			Id("State"): Id("engine").Dot("State"),
+			Id("Patch"): Id("newState").Call(),
+		}),
+		Var().Id("emits").Index().Func().Params(),
+		ForEachTypeInAST(s.config, func(configType ast.ConfigType) *Statement {
+			l := listenersWriter{t: configType}
+			return Id("emits").Op("=").Id("engine").Dot(l.collectName()).Call(Id("emits"), Id("previous"))
+		}),
+		For(List(Id("_"), Id("emit")).Op(":=").Range().Id("emits")).Block(
+			Id("emit").Call(),
+		),
+	)
+
+	s.config.RangeTypes(func(configType ast.ConfigType) {
+		l := listenersWriter{t: configType}
+
+		decls.File.Func().Params(l.receiverParams()).Id(l.collectName()).Params(Id("emits").Index().Func().Params(), Id("previous").Id("*Engine")).Index().Func().Params().Block(
+			l.declareIDs(),
+			For(l.idsLoopConditions()).Block(
+				Id(l.idsName()).Op("=").Append(Id(l.idsName()), Id(l.idName())),
+			),
+			l.sortIDs(),
+			For(l.patchLoopConditions()).Block(
+				l.declareData(),
+				l.declareCurrent(),
+				l.declareOldData(),
+				If(l.isOperationKindDelete()).Block(
+					writeEmit(l, "Deleted", Id("current")),
+					Continue(),
+				),
+				If(Op("!").Id("existed")).Block(
+					writeEmit(l, "Created", Id("current")),
+					Continue(),
+				),
+				If(l.isOperationKindUpdate()).Block(
+					l.setPreviousEngine(),
+					l.declareOld(),
+					writeEmit(l, "Updated", Id("old"), Id("current")),
+				),
+			),
+			Return(Id("emits")),
+		)
+	})
+
+	decls.Render(s.buf)
+	return s
+}
+
+func writeEmit(l listenersWriter, change string, args ...Code) *Statement {
+	return For(l.listenersLoopConditions(change)).Block(
+		Id("listener").Op(":=").Id("listener"),
+		Id("emits").Op("=").Append(Id("emits"), Func().Params().Block(
+			Id("listener").Call(args...),
+		)),
+	)
+}
@@ -0,0 +1,65 @@
+package enginefactory
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jobergner/backent-cli/testutils"
+)
+
+func TestWriteListeners(t *testing.T) {
+	t.Run("writes listeners", func(t *testing.T) {
+		sf := newStateFactory(newSimpleASTExample())
+		sf.writeListeners()
+
+		actual := testutils.FormatCode(sf.buf.String())
+		expected := testutils.FormatCode(strings.Join([]string{
+			listeners_type,
+			_OnEquipmentSetCreated_Engine_func,
+			_OnEquipmentSetUpdated_Engine_func,
+			_OnEquipmentSetDeleted_Engine_func,
+			_OnGearScoreCreated_Engine_func,
+			_OnGearScoreUpdated_Engine_func,
+			_OnGearScoreDeleted_Engine_func,
+			_OnItemCreated_Engine_func,
+			_OnItemUpdated_Engine_func,
+			_OnItemDeleted_Engine_func,
+			_OnPlayerCreated_Engine_func,
+			_OnPlayerUpdated_Engine_func,
+			_OnPlayerDeleted_Engine_func,
+			_OnPositionCreated_Engine_func,
+			_OnPositionUpdated_Engine_func,
+			_OnPositionDeleted_Engine_func,
+			_OnZoneCreated_Engine_func,
+			_OnZoneUpdated_Engine_func,
+			_OnZoneDeleted_Engine_func,
+			_OnZoneItemCreated_Engine_func,
+			_OnZoneItemUpdated_Engine_func,
+			_OnZoneItemDeleted_Engine_func,
+		}, "\n"))
+
+		if expected != actual {
+			t.Errorf(testutils.Diff(actual, expected))
+		}
+	})
+	t.Run("writes emit changes", func(t *testing.T) {
+		sf := newStateFactory(newSimpleASTExample())
+		sf.writeEmitChanges()
+
+		actual := testutils.FormatCode(sf.buf.String())
+		expected := testutils.FormatCode(strings.Join([]string{
+			emitChanges_Engine_func,
+			collectEquipmentSetChanges_Engine_func,
+			collectGearScoreChanges_Engine_func,
+			collectItemChanges_Engine_func,
+			collectPlayerChanges_Engine_func,
+			collectPositionChanges_Engine_func,
+			collectZoneChanges_Engine_func,
+			collectZoneItemChanges_Engine_func,
+		}, "\n"))
+
+		if expected != actual {
+			t.Errorf(testutils.Diff(actual, expected))
+		}
+	})
+}
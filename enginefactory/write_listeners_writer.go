@@ -0,0 +1,112 @@
+package enginefactory
+
+import (
+	"github.com/jobergner/backent-cli/ast"
+	. "github.com/jobergner/backent-cli/factoryutils"
+
+	. "github.com/dave/jennifer/jen"
+)
+
+type listenersWriter struct {
+	t ast.ConfigType
+}
+
+func (l listenersWriter) name(change string) string {
+	return "On" + Title(l.t.Name) + change
+}
+
+func (l listenersWriter) listenersName(change string) string {
+	return l.t.Name + change
+}
+
+func (l listenersWriter) listenerType() *Statement {
+	return Func().Params(Id(l.t.Name))
+}
+
+func (l listenersWriter) updateListenerType() *Statement {
+	return Func().Params(List(Id("old"), Id("new")).Id(l.t.Name))
+}
+
+func (l listenersWriter) receiverParams() *Statement {
+	return Id("engine").Id("*Engine")
+}
+
+func (l listenersWriter) setRegistered() *Statement {
+	return Id("engine").Dot("listeners").Dot("registered").Op("=").True()
+}
+
+func (l listenersWriter) appendListener(change string) *Statement {
+	listeners := Id("engine").Dot("listeners").Dot(l.listenersName(change))
+	return listeners.Clone().Op("=").Append(listeners, Id("listener"))
+}
+
+func (l listenersWriter) collectName() string {
+	return "collect" + Title(l.t.Name) + "Changes"
+}
+
+func (l listenersWriter) dataName() string {
+	return l.t.Name + "Data"
+}
+
+func (l listenersWriter) idName() string {
+	return l.t.Name + "ID"
+}
+
+func (l listenersWriter) idsName() string {
+	return l.t.Name + "IDs"
+}
+
+func (l listenersWriter) declareIDs() *Statement {
+	return Id(l.idsName()).Op(":=").Make(Index().Id(Title(l.t.Name)+"ID"), Lit(0), Len(Id("engine").Dot("Patch").Dot(Title(l.t.Name))))
+}
+
+func (l listenersWriter) idsLoopConditions() *Statement {
+	return Id(l.idName()).Op(":=").Range().Id("engine").Dot("Patch").Dot(Title(l.t.Name))
+}
+
+func (l listenersWriter) sortIDs() *Statement {
+	less := Func().Params(Id("i"), Id("j").Int()).Bool().Block(
+		Return(Id(l.idsName()).Index(Id("i")).Op("<").Id(l.idsName()).Index(Id("j"))),
+	)
+	return Id("sort").Dot("Slice").Call(Id(l.idsName()), less)
+}
+
+func (l listenersWriter) patchLoopConditions() *Statement {
+	return List(Id("_"), Id(l.idName())).Op(":=").Range().Id(l.idsName())
+}
+
+func (l listenersWriter) declareData() *Statement {
+	return Id(l.dataName()).Op(":=").Id("engine").Dot("Patch").Dot(Title(l.t.Name)).Index(Id(l.idName()))
+}
+
+func (l listenersWriter) declareCurrent() *Statement {
+	return Id("current").Op(":=").Id(l.t.Name).Values(Dict{
+		Id(l.t.Name): Id(l.dataName()),
+	})
+}
+
+func (l listenersWriter) declareOldData() *Statement {
+	return List(Id("oldData"), Id("existed")).Op(":=").Id("engine").Dot("State").Dot(Title(l.t.Name)).Index(Id(l.dataName()).Dot("ID"))
+}
+
+func (l listenersWriter) isOperationKindDelete() *Statement {
+	return Id(l.dataName()).Dot("OperationKind").Op("==").Id("OperationKindDelete")
+}
+
+func (l listenersWriter) isOperationKindUpdate() *Statement {
+	return Id(l.dataName()).Dot("OperationKind").Op("==").Id("OperationKindUpdate")
+}
+
+func (l listenersWriter) setPreviousEngine() *Statement {
+	return Id("oldData").Dot("engine").Op("=").Id("previous")
+}
+
+func (l listenersWriter) declareOld() *Statement {
+	return Id("old").Op(":=").Id(l.t.Name).Values(Dict{
+		Id(l.t.Name): Id("oldData"),
+	})
+}
+
+func (l listenersWriter) listenersLoopConditions(change string) *Statement {
+	return List(Id("_"), Id("listener")).Op(":=").Range().Id("engine").Dot("listeners").Dot(l.listenersName(change))
+}
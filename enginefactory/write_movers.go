@@ -0,0 +1,120 @@
+package enginefactory
+
+import (
+	"github.com/jobergner/backent-cli/ast"
+	. "github.com/jobergner/backent-cli/factoryutils"
+
+	. "github.com/dave/jennifer/jen"
+)
+
+func (s *EngineFactory) writeMovers() *EngineFactory {
+	decls := NewDeclSet()
+
+	s.config.RangeAnyFields(func(field ast.Field) {
+		c := childIDWriter{
+			f: field,
+		}
+
+		decls.File.Func().Params(c.receiverParams()).Id("childID").Params().Int().Block(
+			Switch(Id("_any").Dot("ElementKind")).Block(
+				ForEachValueOfField(field, func(valueType *ast.ConfigType) *Statement {
+					return Case(Id("ElementKind" + Title(valueType.Name))).Block(
+						Return(Int().Call(Id("_any").Dot(Title(valueType.Name)))),
+					)
+				}),
+			),
+			Return(Lit(0)),
+		)
+	})
+
+	s.config.RangeTypes(func(configType ast.ConfigType) {
+		configType.RangeFields(func(field ast.Field) {
+			if !field.HasSliceValue || field.ValueType().IsBasicType {
+				return
+			}
+
+			m := moverWriter{
+				t: configType,
+				f: field,
+			}
+
+			if !field.HasPointerValue && !field.HasAnyValue {
+				decls.File.Func().Params(m.coreReceiverParams()).Id(m.keysName()).Params().Index().Add(m.keyType()).Block(
+					Return(m.coreIDs()),
+				)
+			} else {
+				decls.File.Func().Params(m.coreReceiverParams()).Id(m.keysName()).Params().Index().Add(m.keyType()).Block(
+					m.declareKeys(),
+					For(m.idsLoopConditions()).Block(
+						m.appendKey(),
+					),
+					Return(Id("keys")),
+				)
+			}
+
+			decls.File.Func().Params(m.coreReceiverParams()).Id(m.indexOfName()).Params(Id(m.keyParam()).Add(m.keyType())).Int().Block(
+				For(m.keysLoopConditions()).Block(
+					If(Id("key").Op("==").Id(m.keyParam())).Block(
+						Return(Id("i")),
+					),
+				),
+				Return(Lit(-1)),
+			)
+
+			field.RangeValueTypes(func(valueType *ast.ConfigType) {
+				m.v = valueType
+				decls.File.Func().Params(m.receiverParams()).Id(m.inserterName()).Params(m.inserterParams()).Id(m.inserterReturns()).Block(
+					m.declareLength(),
+					m.callAdder(),
+					m.reassignElement(),
+					If(m.lengthIsUnchanged()).Block(
+						Return(m.inserterReturnValue()),
+					),
+					m.moveID(Id("length")),
+					m.updateElementInPatch(),
+					OnlyIf(!field.HasPointerValue, Return(m.inserterReturnValue())),
+				)
+			})
+
+			decls.File.Func().Params(m.receiverParams()).Id(m.moverName()).Params(Id(m.keyParam()).Add(m.keyType()), Id("index").Int()).Id(configType.Name).Block(
+				m.reassignElement(),
+				If(m.isOperationKindDelete()).Block(
+					m.recordError(m.moverName(), "ErrElementDoesNotExist"),
+					Return(Id(configType.Name)),
+				),
+				Id("from").Op(":=").Add(m.indexOf(Id(m.keyParam()))),
+				If(Id("from").Op("==").Lit(-1)).Block(
+					m.recordError(m.moverName(), "ErrElementNotInSlice"),
+					Return(Id(configType.Name)),
+				),
+				m.moveID(Id("from")),
+				m.setOperationKindUpdate(),
+				m.updateElementInPatch(),
+				Return(Id(configType.Name)),
+			)
+
+			decls.File.Func().Params(m.receiverParams()).Id(m.swapperName()).Params(List(Id("a"), Id("b")).Add(m.keyType())).Id(configType.Name).Block(
+				m.reassignElement(),
+				If(m.isOperationKindDelete()).Block(
+					m.recordError(m.swapperName(), "ErrElementDoesNotExist"),
+					Return(Id(configType.Name)),
+				),
+				List(Id("i"), Id("j")).Op(":=").List(m.indexOf(Id("a")), m.indexOf(Id("b"))),
+				If(Id("i").Op("==").Lit(-1).Op("||").Id("j").Op("==").Lit(-1)).Block(
+					m.recordError(m.swapperName(), "ErrElementNotInSlice"),
+					Return(Id(configType.Name)),
+				),
+				m.copyIDs(),
+				Copy(Id("ids"), m.ids()),
+				Id("ids").Index(Id("i")).Op(",").Id("ids").Index(Id("j")).Op("=").Id("ids").Index(Id("j")).Op(",").Id("ids").Index(Id("i")),
+				m.ids().Op("=").Id("ids"),
+				m.setOperationKindUpdate(),
+				m.updateElementInPatch(),
+				Return(Id(configType.Name)),
+			)
+		})
+	})
+
+	decls.Render(s.buf)
+	return s
+}
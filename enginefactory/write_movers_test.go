@@ -0,0 +1,69 @@
+package enginefactory
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jobergner/backent-cli/testutils"
+)
+
+func TestWriteMovers(t *testing.T) {
+	t.Run("writes movers", func(t *testing.T) {
+		sf := newStateFactory(newSimpleASTExample())
+		sf.writeMovers()
+
+		actual := testutils.FormatCode(sf.buf.String())
+		expected := testutils.FormatCode(strings.Join([]string{
+			childID_anyOfPlayer_PositionCore_func,
+			childID_anyOfPlayer_ZoneItemCore_func,
+			childID_anyOfItem_Player_ZoneItemCore_func,
+			equipmentKeys_equipmentSetCore_func,
+			indexOfEquipment_equipmentSetCore_func,
+			_InsertEquipmentAt_equipmentSet_func,
+			_MoveEquipment_equipmentSet_func,
+			_SwapEquipment_equipmentSet_func,
+			equipmentSetsKeys_playerCore_func,
+			indexOfEquipmentSet_playerCore_func,
+			_InsertEquipmentSetAt_player_func,
+			_MoveEquipmentSet_player_func,
+			_SwapEquipmentSets_player_func,
+			guildMembersKeys_playerCore_func,
+			indexOfGuildMember_playerCore_func,
+			_InsertGuildMemberAt_player_func,
+			_MoveGuildMember_player_func,
+			_SwapGuildMembers_player_func,
+			itemsKeys_playerCore_func,
+			indexOfItem_playerCore_func,
+			_InsertItemAt_player_func,
+			_MoveItem_player_func,
+			_SwapItems_player_func,
+			targetedByKeys_playerCore_func,
+			indexOfTargetedBy_playerCore_func,
+			_InsertTargetedByPlayerAt_player_func,
+			_InsertTargetedByZoneItemAt_player_func,
+			_MoveTargetedBy_player_func,
+			_SwapTargetedBy_player_func,
+			interactablesKeys_zoneCore_func,
+			indexOfInteractable_zoneCore_func,
+			_InsertInteractableItemAt_zone_func,
+			_InsertInteractablePlayerAt_zone_func,
+			_InsertInteractableZoneItemAt_zone_func,
+			_MoveInteractable_zone_func,
+			_SwapInteractables_zone_func,
+			itemsKeys_zoneCore_func,
+			indexOfItem_zoneCore_func,
+			_InsertItemAt_zone_func,
+			_MoveItem_zone_func,
+			_SwapItems_zone_func,
+			playersKeys_zoneCore_func,
+			indexOfPlayer_zoneCore_func,
+			_InsertPlayerAt_zone_func,
+			_MovePlayer_zone_func,
+			_SwapPlayers_zone_func,
+		}, "\n"))
+
+		if expected != actual {
+			t.Errorf(testutils.Diff(actual, expected))
+		}
+	})
+}
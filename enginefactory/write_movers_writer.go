@@ -0,0 +1,206 @@
+package enginefactory
+
+import (
+	"github.com/jobergner/backent-cli/ast"
+	. "github.com/jobergner/backent-cli/factoryutils"
+
+	. "github.com/dave/jennifer/jen"
+)
+
+type childIDWriter struct {
+	f ast.Field
+}
+
+func (c childIDWriter) receiverParams() *Statement {
+	return Id("_any").Id(anyNameByField(c.f) + "Core")
+}
+
+type moverWriter struct {
+	t ast.ConfigType
+	f ast.Field
+	v *ast.ConfigType
+}
+
+func (m moverWriter) receiverName() string {
+	return "_" + m.t.Name
+}
+
+func (m moverWriter) receiverParams() *Statement {
+	return Id(m.receiverName()).Id(m.t.Name)
+}
+
+func (m moverWriter) coreReceiverParams() *Statement {
+	return Id(m.receiverName()).Id(m.t.Name + "Core")
+}
+
+// keyType is the type elements of the field are keyed by in the tree
+func (m moverWriter) keyType() *Statement {
+	if m.f.HasAnyValue {
+		return Int()
+	}
+	return Id(Title(m.f.ValueType().Name) + "ID")
+}
+
+// idType is the type of the IDs the field stores
+func (m moverWriter) idType() string {
+	if m.f.HasPointerValue {
+		return Title(m.f.ValueTypeName) + "ID"
+	}
+	if m.f.HasAnyValue {
+		return Title(anyNameByField(m.f)) + "ID"
+	}
+	return Title(m.f.ValueType().Name) + "ID"
+}
+
+func (m moverWriter) keyParam() string {
+	if m.f.HasAnyValue {
+		return Singular(m.f.Name) + "ID"
+	}
+	return m.f.ValueType().Name + "ID"
+}
+
+func (m moverWriter) keysName() string {
+	return m.f.Name + "Keys"
+}
+
+func (m moverWriter) indexOfName() string {
+	return "indexOf" + Title(Singular(m.f.Name))
+}
+
+func (m moverWriter) inserterName() string {
+	var optionalSuffix string
+	if len(m.f.ValueTypes) > 1 {
+		optionalSuffix = Title(m.v.Name)
+	}
+	return "Insert" + Title(Singular(m.f.Name)) + optionalSuffix + "At"
+}
+
+func (m moverWriter) adderName() string {
+	var optionalSuffix string
+	if len(m.f.ValueTypes) > 1 {
+		optionalSuffix = Title(m.v.Name)
+	}
+	return "Add" + Title(Singular(m.f.Name)) + optionalSuffix
+}
+
+func (m moverWriter) moverName() string {
+	return "Move" + Title(Singular(m.f.Name))
+}
+
+func (m moverWriter) swapperName() string {
+	return "Swap" + Title(m.f.Name)
+}
+
+func (m moverWriter) coreIDs() *Statement {
+	return Id(m.receiverName()).Dot(Title(m.f.Name))
+}
+
+func (m moverWriter) element() *Statement {
+	return Id(m.t.Name).Dot(m.t.Name)
+}
+
+func (m moverWriter) ids() *Statement {
+	return m.element().Dot(Title(m.f.Name))
+}
+
+func (m moverWriter) declareKeys() *Statement {
+	return Id("keys").Op(":=").Make(Index().Add(m.keyType()), Lit(0), Len(m.coreIDs()))
+}
+
+func (m moverWriter) idsLoopConditions() *Statement {
+	return List(Id("_"), Id("id")).Op(":=").Range().Add(m.coreIDs())
+}
+
+func (m moverWriter) engine() *Statement {
+	return Id(m.receiverName()).Dot("engine")
+}
+
+func (m moverWriter) key() *Statement {
+	anyName := anyNameByField(m.f)
+	switch {
+	case m.f.HasPointerValue && m.f.HasAnyValue:
+		ref := m.engine().Dot(m.f.ValueTypeName).Call(Id("id")).Dot(m.f.ValueTypeName).Dot("ReferencedElementID")
+		return m.engine().Dot(anyName).Call(ref).Dot(anyName).Dot("childID").Call()
+	case m.f.HasPointerValue:
+		return m.engine().Dot(m.f.ValueTypeName).Call(Id("id")).Dot(m.f.ValueTypeName).Dot("ReferencedElementID")
+	default:
+		return m.engine().Dot(anyName).Call(Id("id")).Dot(anyName).Dot("childID").Call()
+	}
+}
+
+func (m moverWriter) appendKey() *Statement {
+	return Id("keys").Op("=").Append(Id("keys"), m.key())
+}
+
+func (m moverWriter) keysLoopConditions() *Statement {
+	return List(Id("i"), Id("key")).Op(":=").Range().Id(m.receiverName()).Dot(m.keysName()).Call()
+}
+
+func (m moverWriter) inserterParams() *Statement {
+	if m.f.HasPointerValue {
+		return List(Id("index").Int(), Id(m.v.Name+"ID").Id(Title(m.v.Name)+"ID"))
+	}
+	return Id("index").Int()
+}
+
+func (m moverWriter) inserterReturns() string {
+	if m.f.HasPointerValue {
+		return ""
+	}
+	return m.v.Name
+}
+
+func (m moverWriter) inserterReturnValue() *Statement {
+	if m.f.HasPointerValue {
+		return Empty()
+	}
+	return Id(m.v.Name)
+}
+
+func (m moverWriter) declareLength() *Statement {
+	element := Id(m.receiverName()).Dot(m.t.Name).Dot("engine").Dot(Title(m.t.Name)).Call(Id(m.receiverName()).Dot(m.t.Name).Dot("ID"))
+	return Id("length").Op(":=").Len(element.Dot(m.t.Name).Dot(Title(m.f.Name)))
+}
+
+func (m moverWriter) callAdder() *Statement {
+	if m.f.HasPointerValue {
+		return Id(m.receiverName()).Dot(m.adderName()).Call(Id(m.v.Name + "ID"))
+	}
+	return Id(m.v.Name).Op(":=").Id(m.receiverName()).Dot(m.adderName()).Call()
+}
+
+func (m moverWriter) reassignElement() *Statement {
+	return Id(m.t.Name).Op(":=").Id(m.receiverName()).Dot(m.t.Name).Dot("engine").Dot(Title(m.t.Name)).Call(Id(m.receiverName()).Dot(m.t.Name).Dot("ID"))
+}
+
+func (m moverWriter) lengthIsUnchanged() *Statement {
+	return Len(m.ids()).Op("==").Id("length")
+}
+
+func (m moverWriter) moveID(from *Statement) *Statement {
+	return m.ids().Op("=").Id("move"+m.idType()).Call(m.ids(), from, Id("index"))
+}
+
+func (m moverWriter) isOperationKindDelete() *Statement {
+	return m.element().Dot("OperationKind").Op("==").Id("OperationKindDelete")
+}
+
+func (m moverWriter) recordError(operation, err string) *Statement {
+	return recordError(m.element().Dot("engine"), operation, m.t.Name, Id(m.receiverName()).Dot(m.t.Name).Dot("ID"), err)
+}
+
+func (m moverWriter) indexOf(key *Statement) *Statement {
+	return m.element().Dot(m.indexOfName()).Call(key)
+}
+
+func (m moverWriter) copyIDs() *Statement {
+	return Id("ids").Op(":=").Make(Index().Id(m.idType()), Len(m.ids()))
+}
+
+func (m moverWriter) setOperationKindUpdate() *Statement {
+	return m.element().Dot("OperationKind").Op("=").Id("OperationKindUpdate")
+}
+
+func (m moverWriter) updateElementInPatch() *Statement {
+	return m.element().Dot("engine").Dot("Patch").Dot(Title(m.t.Name)).Index(m.element().Dot("ID")).Op("=").Add(m.element())
+}
@@ -0,0 +1,63 @@
+package enginefactory
+
+import (
+	"github.com/jobergner/backent-cli/ast"
+	. "github.com/jobergner/backent-cli/factoryutils"
+
+	. "github.com/dave/jennifer/jen"
+)
+
+func (s *EngineFactory) writeOptionals() *EngineFactory {
+	decls := NewDeclSet()
+	s.config.RangeTypes(func(configType ast.ConfigType) {
+		configType.RangeFields(func(field ast.Field) {
+			if !field.HasOptionalValue {
+				return
+			}
+
+			o := optionalWriter{
+				t: configType,
+				f: field,
+			}
+
+			decls.File.Func().Params(o.receiverParams()).Id(o.setName()).Params().Id(o.valueTypeName()).Block(
+				o.reassignElement(),
+				If(o.isOperationKindDelete()).Block(
+					o.recordElementDoesNotExist(o.setName()),
+					Return(o.returnDeletedChild()),
+				),
+				If(o.isSet()).Block(
+					Return(o.currentChild()),
+				),
+				o.createChild(),
+				o.assignChild(),
+				o.setOperationKindUpdate(),
+				o.updateElementInPatch(),
+				Return(Id(o.valueTypeName())),
+			)
+
+			decls.File.Func().Params(o.receiverParams()).Id(o.unsetName()).Params().Block(
+				o.reassignElement(),
+				If(o.isOperationKindDelete()).Block(
+					o.recordElementDoesNotExist(o.unsetName()),
+					Return(),
+				),
+				If(o.isUnset()).Block(
+					Return(),
+				),
+				o.deleteChild(),
+				o.resetField(),
+				o.setOperationKindUpdate(),
+				o.updateElementInPatch(),
+			)
+
+			decls.File.Func().Params(o.receiverParams()).Id(o.hasName()).Params().Bool().Block(
+				o.reassignElement(),
+				Return(o.isSet()),
+			)
+		})
+	})
+
+	decls.Render(s.buf)
+	return s
+}
@@ -0,0 +1,26 @@
+package enginefactory
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jobergner/backent-cli/testutils"
+)
+
+func TestWriteOptionals(t *testing.T) {
+	t.Run("writes optionals", func(t *testing.T) {
+		sf := newStateFactory(newSimpleASTExample())
+		sf.writeOptionals()
+
+		actual := testutils.FormatCode(sf.buf.String())
+		expected := testutils.FormatCode(strings.Join([]string{
+			_SetBoss_zone_func,
+			_UnsetBoss_zone_func,
+			_HasBoss_zone_func,
+		}, "\n"))
+
+		if expected != actual {
+			t.Errorf(testutils.Diff(actual, expected))
+		}
+	})
+}
@@ -0,0 +1,97 @@
+package enginefactory
+
+import (
+	"github.com/jobergner/backent-cli/ast"
+	. "github.com/jobergner/backent-cli/factoryutils"
+
+	. "github.com/dave/jennifer/jen"
+)
+
+type optionalWriter struct {
+	t ast.ConfigType
+	f ast.Field
+}
+
+func (o optionalWriter) receiverName() string {
+	return "_" + o.t.Name
+}
+
+func (o optionalWriter) receiverParams() *Statement {
+	return Id(o.receiverName()).Id(o.t.Name)
+}
+
+func (o optionalWriter) setName() string {
+	return "Set" + Title(o.f.Name)
+}
+
+func (o optionalWriter) unsetName() string {
+	return "Unset" + Title(o.f.Name)
+}
+
+func (o optionalWriter) hasName() string {
+	return "Has" + Title(o.f.Name)
+}
+
+func (o optionalWriter) valueTypeName() string {
+	return o.f.ValueType().Name
+}
+
+func (o optionalWriter) element() *Statement {
+	return Id(o.t.Name).Dot(o.t.Name)
+}
+
+func (o optionalWriter) reassignElement() *Statement {
+	return Id(o.t.Name).Op(":=").Id(o.receiverName()).Dot(o.t.Name).Dot("engine").Dot(Title(o.t.Name)).Call(Id(o.receiverName()).Dot(o.t.Name).Dot("ID"))
+}
+
+func (o optionalWriter) isOperationKindDelete() *Statement {
+	return o.element().Dot("OperationKind").Op("==").Id("OperationKindDelete")
+}
+
+func (o optionalWriter) recordElementDoesNotExist(method string) *Statement {
+	return recordError(o.element().Dot("engine"), method, o.t.Name, Id(o.receiverName()).Dot(o.t.Name).Dot("ID"), "ErrElementDoesNotExist")
+}
+
+func (o optionalWriter) returnDeletedChild() *Statement {
+	return Id(o.valueTypeName()).Values(Dict{
+		Id(o.valueTypeName()): Id(o.valueTypeName() + "Core").Values(Dict{
+			Id("OperationKind"): Id("OperationKindDelete"),
+			Id("engine"):        o.element().Dot("engine"),
+		})})
+}
+
+func (o optionalWriter) isSet() *Statement {
+	return o.element().Dot(Title(o.f.Name)).Op("!=").Lit(0)
+}
+
+func (o optionalWriter) isUnset() *Statement {
+	return o.element().Dot(Title(o.f.Name)).Op("==").Lit(0)
+}
+
+func (o optionalWriter) currentChild() *Statement {
+	return o.element().Dot("engine").Dot(Title(o.valueTypeName())).Call(o.element().Dot(Title(o.f.Name)))
+}
+
+func (o optionalWriter) createChild() *Statement {
+	return Id(o.valueTypeName()).Op(":=").Add(o.element()).Dot("engine").Dot("create"+Title(o.valueTypeName())).Call(o.element().Dot("path").Dot(o.f.Name).Call(), False())
+}
+
+func (o optionalWriter) assignChild() *Statement {
+	return o.element().Dot(Title(o.f.Name)).Op("=").Id(o.valueTypeName()).Dot(o.valueTypeName()).Dot("ID")
+}
+
+func (o optionalWriter) deleteChild() *Statement {
+	return o.element().Dot("engine").Dot("delete" + Title(o.valueTypeName())).Call(o.element().Dot(Title(o.f.Name)))
+}
+
+func (o optionalWriter) resetField() *Statement {
+	return o.element().Dot(Title(o.f.Name)).Op("=").Lit(0)
+}
+
+func (o optionalWriter) setOperationKindUpdate() *Statement {
+	return o.element().Dot("OperationKind").Op("=").Id("OperationKindUpdate")
+}
+
+func (o optionalWriter) updateElementInPatch() *Statement {
+	return o.element().Dot("engine").Dot("Patch").Dot(Title(o.t.Name)).Index(o.element().Dot("ID")).Op("=").Add(o.element())
+}
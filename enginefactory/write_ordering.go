@@ -0,0 +1,83 @@
+package enginefactory
+
+import (
+	"github.com/jobergner/backent-cli/ast"
+	. "github.com/jobergner/backent-cli/factoryutils"
+
+	. "github.com/dave/jennifer/jen"
+)
+
+func (s *EngineFactory) writeOrdering() *EngineFactory {
+	decls := NewDeclSet()
+
+	decls.File.Commentf("sortedMapKeys is set on the writer of ordered engines, the maps of\n// the tree pass it on to each other and write their IDs in ascending order")
+	decls.File.Const().Id("sortedMapKeys").Id("jwriter").Dot("Flags").Op("=").Lit(1).Op("<<").Lit(16)
+
+	decls.File.Commentf("marshalTree is the only way to marshal ordered trees,\n// Tree.MarshalJSON does not know about the engine")
+	decls.File.Func().Params(Id("engine").Id("*Engine")).Id("marshalTree").Params(Id("tree").Id("Tree")).Params(Index().Byte(), Error()).Block(
+		Id("out").Op(":=").Id("jwriter").Dot("Writer").Values(),
+		If(Id("engine").Dot("ordered")).Block(
+			Id("out").Dot("Flags").Op("=").Id("sortedMapKeys"),
+		),
+		Id("tree").Dot("MarshalEasyJSON").Call(Id("&out")),
+		Return(Id("out").Dot("Buffer").Dot("BuildBytes").Call(), Id("out").Dot("Error")),
+	)
+
+	s.rangeTreeMaps(func(o orderingWriter) {
+		decls.File.Type().Id(o.name).Map(o.key).Add(o.value)
+
+		decls.File.Func().Params(Id("m").Id(o.name)).Id("keys").Params(Id("flags").Id("jwriter").Dot("Flags")).Index().Add(o.key).Block(
+			Id("ids").Op(":=").Make(Index().Add(o.key), Lit(0), Len(Id("m"))),
+			For(Id("id").Op(":=").Range().Id("m")).Block(
+				Id("ids").Op("=").Append(Id("ids"), Id("id")),
+			),
+			If(o.isSorted()).Block(
+				o.sortIDs(),
+			),
+			Return(Id("ids")),
+		)
+
+		decls.File.Func().Params(Id("m").Id(o.name)).Id("MarshalEasyJSON").Params(Id("out").Id("*jwriter").Dot("Writer")).Block(
+			If(o.isNil()).Block(
+				Id("out").Dot("RawString").Call(Lit("null")),
+				Return(),
+			),
+			o.writeByte('{'),
+			For(List(Id("i"), Id("id")).Op(":=").Range().Id("m").Dot("keys").Call(Id("out").Dot("Flags"))).Block(
+				If(Id("i").Op(">").Lit(0)).Block(
+					o.writeByte(','),
+				),
+				Id("out").Dot("IntStr").Call(o.idAsInt()),
+				o.writeByte(':'),
+				o.marshalValue(Id("m").Index(Id("id"))),
+			),
+			o.writeByte('}'),
+		)
+	})
+
+	decls.Render(s.buf)
+	return s
+}
+
+// rangeTreeMaps calls fn for every distinct map type of the tree,
+// starting with the maps of elements
+func (s *EngineFactory) rangeTreeMaps(fn func(o orderingWriter)) {
+	seen := make(map[string]bool)
+
+	s.config.RangeTypes(func(configType ast.ConfigType) {
+		t := treeWriter{configType}
+		seen[t.mapType()] = true
+		fn(orderingWriter{name: t.mapType(), key: t.mapKey(), value: Id(t.mapValue())})
+	})
+
+	s.config.RangeTypes(func(configType ast.ConfigType) {
+		configType.RangeFields(func(field ast.Field) {
+			e := treeElementWriter{t: configType, f: &field}
+			if !e.hasOrder() || seen[e.mapTypeName()] {
+				return
+			}
+			seen[e.mapTypeName()] = true
+			fn(orderingWriter{name: e.mapTypeName(), key: e.mapKeyType(), value: e.mapValueType(), hasIntKey: field.HasAnyValue, hasInterfaceValue: field.HasAnyValue && !field.HasPointerValue})
+		})
+	})
+}
@@ -0,0 +1,61 @@
+package enginefactory
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jobergner/backent-cli/testutils"
+)
+
+func TestWriteOrdering(t *testing.T) {
+	t.Run("writes ordering", func(t *testing.T) {
+		sf := newStateFactory(newSimpleASTExample())
+		sf.writeOrdering()
+
+		actual := testutils.FormatCode(sf.buf.String())
+		expected := testutils.FormatCode(strings.Join([]string{
+			sortedMapKeys_type,
+			marshalTree_Engine_func,
+			equipmentSetMap_type,
+			keys_equipmentSetMap_func,
+			_MarshalEasyJSON_equipmentSetMap_func,
+			gearScoreMap_type,
+			keys_gearScoreMap_func,
+			_MarshalEasyJSON_gearScoreMap_func,
+			itemMap_type,
+			keys_itemMap_func,
+			_MarshalEasyJSON_itemMap_func,
+			playerMap_type,
+			keys_playerMap_func,
+			_MarshalEasyJSON_playerMap_func,
+			positionMap_type,
+			keys_positionMap_func,
+			_MarshalEasyJSON_positionMap_func,
+			zoneMap_type,
+			keys_zoneMap_func,
+			_MarshalEasyJSON_zoneMap_func,
+			zoneItemMap_type,
+			keys_zoneItemMap_func,
+			_MarshalEasyJSON_zoneItemMap_func,
+			itemReferenceMap_type,
+			keys_itemReferenceMap_func,
+			_MarshalEasyJSON_itemReferenceMap_func,
+			equipmentSetReferenceMap_type,
+			keys_equipmentSetReferenceMap_func,
+			_MarshalEasyJSON_equipmentSetReferenceMap_func,
+			playerReferenceMap_type,
+			keys_playerReferenceMap_func,
+			_MarshalEasyJSON_playerReferenceMap_func,
+			anyOfPlayer_ZoneItemReferenceMap_type,
+			keys_anyOfPlayer_ZoneItemReferenceMap_func,
+			_MarshalEasyJSON_anyOfPlayer_ZoneItemReferenceMap_func,
+			anyOfItem_Player_ZoneItemMap_type,
+			keys_anyOfItem_Player_ZoneItemMap_func,
+			_MarshalEasyJSON_anyOfItem_Player_ZoneItemMap_func,
+		}, "\n"))
+
+		if expected != actual {
+			t.Errorf(testutils.Diff(actual, expected))
+		}
+	})
+}
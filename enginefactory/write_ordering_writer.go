@@ -0,0 +1,52 @@
+package enginefactory
+
+import (
+	. "github.com/dave/jennifer/jen"
+)
+
+type orderingWriter struct {
+	name  string
+	key   *Statement
+	value *Statement
+	// anyOf fields are keyed by int and only
+	// non-reference anyOf fields hold interface{} values
+	hasIntKey         bool
+	hasInterfaceValue bool
+}
+
+func (o orderingWriter) isNil() *Statement {
+	return Id("m").Op("==").Nil().Op("&&").Id("out").Dot("Flags").Op("&").Id("jwriter").Dot("NilMapAsEmpty").Op("==").Lit(0)
+}
+
+func (o orderingWriter) isSorted() *Statement {
+	return Id("flags").Op("&").Id("sortedMapKeys").Op("!=").Lit(0)
+}
+
+func (o orderingWriter) sortIDs() *Statement {
+	less := Func().Params(Id("i"), Id("j").Int()).Bool().Block(
+		Return(Id("ids").Index(Id("i")).Op("<").Id("ids").Index(Id("j"))),
+	)
+	return Id("sort").Dot("Slice").Call(Id("ids"), less)
+}
+
+func (o orderingWriter) writeByte(b rune) *Statement {
+	return Id("out").Dot("RawByte").Call(LitRune(b))
+}
+
+func (o orderingWriter) idAsInt() *Statement {
+	if o.hasIntKey {
+		return Id("id")
+	}
+	return Int().Call(Id("id"))
+}
+
+func (o orderingWriter) marshalValue(value *Statement) *Statement {
+	if !o.hasInterfaceValue {
+		return value.Clone().Dot("MarshalEasyJSON").Call(Id("out"))
+	}
+	return If(List(Id("marshaler"), Id("ok")).Op(":=").Add(value.Clone()).Assert(Id("easyjson").Dot("Marshaler")), Id("ok")).Block(
+		Id("marshaler").Dot("MarshalEasyJSON").Call(Id("out")),
+	).Else().Block(
+		Id("out").Dot("Raw").Call(Id("json").Dot("Marshal").Call(value.Clone())),
+	)
+}
@@ -105,6 +105,18 @@ func (s *EngineFactory) writePath() *EngineFactory {
 		Return(True()),
 	)
 
+	decls.File.Func().Params(Id("p").Id("path")).Id("isWithin").Params(Id("ancestorPath").Id("path")).Bool().Block(
+		If(Len(Id("p")).Op("<").Len(Id("ancestorPath"))).Block(
+			Return(False()),
+		),
+		For(List(Id("i"), Id("segment")).Op(":=").Range().Id("ancestorPath")).Block(
+			If(Id("segment").Op("!=").Id("p").Index(Id("i"))).Block(
+				Return(False()),
+			),
+		),
+		Return(True()),
+	)
+
 	decls.File.Func().Params(Id("p").Id("path")).Id("toJSONPath").Params().String().Block(
 		Id("jsonPath").Op(":=").Lit("$"),
 		For(List(Id("i"), Id("seg")).Op(":=").Range().Id("p")).Block(
@@ -35,6 +35,7 @@ func TestWritePath(t *testing.T) {
 			items_path_func,
 			position_path_func,
 			zone_path_func,
+			boss_path_func,
 			interactables_path_func,
 			players_path_func,
 			zoneItem_path_func,
@@ -54,6 +55,7 @@ func TestWritePath(t *testing.T) {
 			newPath_func,
 			id_path_func,
 			equals_path_func,
+			isWithin_path_func,
 			toJSONPath_path_func,
 			pathIdentifierToString_func,
 		}, "\n"))
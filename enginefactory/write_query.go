@@ -0,0 +1,153 @@
+package enginefactory
+
+import (
+	"github.com/jobergner/backent-cli/ast"
+	. "github.com/jobergner/backent-cli/factoryutils"
+
+	. "github.com/dave/jennifer/jen"
+)
+
+func (s *EngineFactory) writeIndex() *EngineFactory {
+	decls := NewDeclSet()
+
+	decls.File.Type().Id("index").Struct(
+		ForEachTypeInAST(s.config, func(configType ast.ConfigType) *Statement {
+			return ForEachFieldInType(configType, func(field ast.Field) *Statement {
+				i := indexWriter{t: configType, f: field}
+				return OnlyIf(field.IsIndexed, Id(i.indexName()).Add(i.indexType()))
+			})
+		}),
+	)
+
+	indexes := Dict{}
+	s.config.RangeTypes(func(configType ast.ConfigType) {
+		configType.RangeFields(func(field ast.Field) {
+			if !field.IsIndexed {
+				return
+			}
+			i := indexWriter{t: configType, f: field}
+			indexes[Id(i.indexName())] = Make(i.indexType())
+		})
+	})
+
+	decls.File.Func().Id("newIndex").Params().Id("index").Block(
+		Return(Id("index").Values(indexes)),
+	)
+
+	s.config.RangeTypes(func(configType ast.ConfigType) {
+		configType.RangeFields(func(field ast.Field) {
+			if !field.IsIndexed {
+				return
+			}
+
+			i := indexWriter{t: configType, f: field}
+
+			decls.File.Func().Params(i.receiverParams()).Id("index"+i.name()).Params(i.params()).Block(
+				i.declareIDs(),
+				If(Op("!").Id("ok")).Block(
+					i.makeIDs(),
+					i.assignIDs(),
+				),
+				i.addID(),
+			)
+
+			decls.File.Func().Params(i.receiverParams()).Id("unindex"+i.name()).Params(i.params()).Block(
+				i.getIDs(),
+				i.deleteID(),
+				If(Len(Id(i.idsName())).Op("==").Lit(0)).Block(
+					i.deleteIDs(),
+				),
+			)
+		})
+	})
+
+	decls.Render(s.buf)
+	return s
+}
+
+func (s *EngineFactory) writeQueries() *EngineFactory {
+	decls := NewDeclSet()
+
+	s.config.RangeTypes(func(configType ast.ConfigType) {
+		q := queryWriter{t: configType}
+
+		decls.File.Type().Id(q.queryType()).Struct(
+			Id("engine").Id("*Engine"),
+			Id(q.idsName()).Map(Id(q.idType())).Bool(),
+			Id("narrowed").Bool(),
+			Id("filters").Index().Add(q.filterType()),
+		)
+
+		decls.File.Func().Params(Id("engine").Id("*Engine")).Id(q.constructorName()).Params().Id(q.queryType()).Block(
+			Return(Id(q.queryType()).Values(Dict{
+				Id("engine"): Id("engine"),
+			})),
+		)
+
+		decls.File.Func().Params(q.receiverParams()).Id("Where").Params(Id("filter").Add(q.filterType())).Id(q.queryType()).Block(
+			q.declareFilters(),
+			q.copyFilters(),
+			q.appendFilter(),
+			Return(Id("query")),
+		)
+
+		configType.RangeFields(func(field ast.Field) {
+			if !field.IsIndexed {
+				return
+			}
+			q.f = field
+
+			decls.File.Func().Params(q.receiverParams()).Id(q.whereFieldName()).Params(Id(q.f.Name).Id(q.f.ValueTypeName)).Id(q.queryType()).Block(
+				q.declareIDs(),
+				For(q.indexedIDsLoopConditions()).Block(
+					If(q.isInQuery()).Block(
+						q.addID(),
+					),
+				),
+				q.assignIDs(),
+				q.setNarrowed(),
+				Return(Id("query")),
+			)
+		})
+
+		decls.File.Func().Params(q.receiverParams()).Id("matches").Params(Id(configType.Name).Id(configType.Name)).Bool().Block(
+			If(q.isOperationKindDelete()).Block(
+				Return(False()),
+			),
+			For(q.filtersLoopConditions()).Block(
+				If(q.filterDoesNotMatch()).Block(
+					Return(False()),
+				),
+			),
+			Return(True()),
+		)
+
+		decls.File.Func().Params(q.receiverParams()).Id("All").Params().Index().Id(configType.Name).Block(
+			q.declareElements(),
+			If(Id("query").Dot("narrowed")).Block(
+				For(q.narrowedIDsLoopConditions()).Block(
+					q.getElement(),
+					If(q.elementMatches()).Block(
+						q.appendElement(),
+					),
+				),
+				If(Id("query").Dot("engine").Dot("ordered")).Block(
+					q.sortElements(),
+				),
+				Return(Id(q.elementsName())),
+			),
+			q.declareAllIDs(),
+			For(q.allIDsLoopConditions()).Block(
+				q.getElement(),
+				If(q.elementMatches()).Block(
+					q.appendElement(),
+				),
+			),
+			q.returnIDsToPool(),
+			Return(Id(q.elementsName())),
+		)
+	})
+
+	decls.Render(s.buf)
+	return s
+}
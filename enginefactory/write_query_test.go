@@ -0,0 +1,78 @@
+package enginefactory
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jobergner/backent-cli/testutils"
+)
+
+func TestWriteQuery(t *testing.T) {
+	t.Run("writes index", func(t *testing.T) {
+		sf := newStateFactory(newSimpleASTExample())
+		sf.writeIndex()
+
+		actual := testutils.FormatCode(sf.buf.String())
+		expected := testutils.FormatCode(strings.Join([]string{
+			index_type,
+			newIndex_func,
+			indexGearScoreScore_Engine_func,
+			unindexGearScoreScore_Engine_func,
+			indexItemName_Engine_func,
+			unindexItemName_Engine_func,
+		}, "\n"))
+
+		if expected != actual {
+			t.Errorf(testutils.Diff(actual, expected))
+		}
+	})
+	t.Run("writes queries", func(t *testing.T) {
+		sf := newStateFactory(newSimpleASTExample())
+		sf.writeQueries()
+
+		actual := testutils.FormatCode(sf.buf.String())
+		expected := testutils.FormatCode(strings.Join([]string{
+			equipmentSetQuery_type,
+			_QueryEquipmentSets_Engine_func,
+			_Where_equipmentSetQuery_func,
+			matches_equipmentSetQuery_func,
+			_All_equipmentSetQuery_func,
+			gearScoreQuery_type,
+			_QueryGearScores_Engine_func,
+			_Where_gearScoreQuery_func,
+			_WhereScore_gearScoreQuery_func,
+			matches_gearScoreQuery_func,
+			_All_gearScoreQuery_func,
+			itemQuery_type,
+			_QueryItems_Engine_func,
+			_Where_itemQuery_func,
+			_WhereName_itemQuery_func,
+			matches_itemQuery_func,
+			_All_itemQuery_func,
+			playerQuery_type,
+			_QueryPlayers_Engine_func,
+			_Where_playerQuery_func,
+			matches_playerQuery_func,
+			_All_playerQuery_func,
+			positionQuery_type,
+			_QueryPositions_Engine_func,
+			_Where_positionQuery_func,
+			matches_positionQuery_func,
+			_All_positionQuery_func,
+			zoneQuery_type,
+			_QueryZones_Engine_func,
+			_Where_zoneQuery_func,
+			matches_zoneQuery_func,
+			_All_zoneQuery_func,
+			zoneItemQuery_type,
+			_QueryZoneItems_Engine_func,
+			_Where_zoneItemQuery_func,
+			matches_zoneItemQuery_func,
+			_All_zoneItemQuery_func,
+		}, "\n"))
+
+		if expected != actual {
+			t.Errorf(testutils.Diff(actual, expected))
+		}
+	})
+}
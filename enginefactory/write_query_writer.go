@@ -0,0 +1,208 @@
+package enginefactory
+
+import (
+	"github.com/jobergner/backent-cli/ast"
+	. "github.com/jobergner/backent-cli/factoryutils"
+
+	. "github.com/dave/jennifer/jen"
+)
+
+type indexWriter struct {
+	t ast.ConfigType
+	f ast.Field
+}
+
+func (i indexWriter) name() string {
+	return Title(i.t.Name) + Title(i.f.Name)
+}
+
+func (i indexWriter) indexName() string {
+	return i.t.Name + Title(i.f.Name)
+}
+
+func (i indexWriter) idType() string {
+	return Title(i.t.Name) + "ID"
+}
+
+func (i indexWriter) idsName() string {
+	return i.t.Name + "IDs"
+}
+
+func (i indexWriter) indexType() *Statement {
+	return Map(Id(i.f.ValueTypeName)).Map(Id(i.idType())).Bool()
+}
+
+func (i indexWriter) receiverParams() *Statement {
+	return Id("engine").Id("*Engine")
+}
+
+func (i indexWriter) params() *Statement {
+	return List(Id(i.t.Name+"ID").Id(i.idType()), Id("value").Id(i.f.ValueTypeName))
+}
+
+func (i indexWriter) index() *Statement {
+	return Id("engine").Dot("index").Dot(i.indexName())
+}
+
+func (i indexWriter) declareIDs() *Statement {
+	return List(Id(i.idsName()), Id("ok")).Op(":=").Add(i.index()).Index(Id("value"))
+}
+
+func (i indexWriter) makeIDs() *Statement {
+	return Id(i.idsName()).Op("=").Make(Map(Id(i.idType())).Bool())
+}
+
+func (i indexWriter) assignIDs() *Statement {
+	return i.index().Index(Id("value")).Op("=").Id(i.idsName())
+}
+
+func (i indexWriter) addID() *Statement {
+	return Id(i.idsName()).Index(Id(i.t.Name + "ID")).Op("=").True()
+}
+
+func (i indexWriter) getIDs() *Statement {
+	return Id(i.idsName()).Op(":=").Add(i.index()).Index(Id("value"))
+}
+
+func (i indexWriter) deleteID() *Statement {
+	return Delete(Id(i.idsName()), Id(i.t.Name+"ID"))
+}
+
+func (i indexWriter) deleteIDs() *Statement {
+	return Delete(i.index(), Id("value"))
+}
+
+type queryWriter struct {
+	t ast.ConfigType
+	f ast.Field
+}
+
+func (q queryWriter) queryType() string {
+	return q.t.Name + "Query"
+}
+
+func (q queryWriter) idType() string {
+	return Title(q.t.Name) + "ID"
+}
+
+func (q queryWriter) idName() string {
+	return q.t.Name + "ID"
+}
+
+func (q queryWriter) idsName() string {
+	return q.t.Name + "IDs"
+}
+
+func (q queryWriter) elementsName() string {
+	plural := Plural(q.t.Name)
+	// uncountable names like "sheep" would collide with the element variable
+	if plural == q.t.Name {
+		return plural + "Elements"
+	}
+	return plural
+}
+
+func (q queryWriter) filterType() *Statement {
+	return Func().Params(Id(q.t.Name)).Bool()
+}
+
+func (q queryWriter) constructorName() string {
+	return "Query" + Title(Plural(q.t.Name))
+}
+
+func (q queryWriter) receiverParams() *Statement {
+	return Id("query").Id(q.queryType())
+}
+
+func (q queryWriter) declareFilters() *Statement {
+	return Id("filters").Op(":=").Make(Index().Add(q.filterType()), Len(Id("query").Dot("filters")), Len(Id("query").Dot("filters")).Op("+").Lit(1))
+}
+
+func (q queryWriter) copyFilters() *Statement {
+	return Copy(Id("filters"), Id("query").Dot("filters"))
+}
+
+func (q queryWriter) appendFilter() *Statement {
+	return Id("query").Dot("filters").Op("=").Append(Id("filters"), Id("filter"))
+}
+
+func (q queryWriter) whereFieldName() string {
+	return "Where" + Title(q.f.Name)
+}
+
+func (q queryWriter) declareIDs() *Statement {
+	return Id(q.idsName()).Op(":=").Make(Map(Id(q.idType())).Bool())
+}
+
+func (q queryWriter) indexedIDsLoopConditions() *Statement {
+	return Id(q.idName()).Op(":=").Range().Id("query").Dot("engine").Dot("index").Dot(q.t.Name + Title(q.f.Name)).Index(Id(q.f.Name))
+}
+
+func (q queryWriter) isInQuery() *Statement {
+	return Op("!").Id("query").Dot("narrowed").Op("||").Id("query").Dot(q.idsName()).Index(Id(q.idName()))
+}
+
+func (q queryWriter) addID() *Statement {
+	return Id(q.idsName()).Index(Id(q.idName())).Op("=").True()
+}
+
+func (q queryWriter) assignIDs() *Statement {
+	return Id("query").Dot(q.idsName()).Op("=").Id(q.idsName())
+}
+
+func (q queryWriter) setNarrowed() *Statement {
+	return Id("query").Dot("narrowed").Op("=").True()
+}
+
+func (q queryWriter) isOperationKindDelete() *Statement {
+	return Id(q.t.Name).Dot(q.t.Name).Dot("OperationKind").Op("==").Id("OperationKindDelete")
+}
+
+func (q queryWriter) filtersLoopConditions() *Statement {
+	return List(Id("_"), Id("filter")).Op(":=").Range().Id("query").Dot("filters")
+}
+
+func (q queryWriter) filterDoesNotMatch() *Statement {
+	return Op("!").Id("filter").Call(Id(q.t.Name))
+}
+
+func (q queryWriter) declareElements() *Statement {
+	return Var().Id(q.elementsName()).Index().Id(q.t.Name)
+}
+
+func (q queryWriter) narrowedIDsLoopConditions() *Statement {
+	return Id(q.idName()).Op(":=").Range().Id("query").Dot(q.idsName())
+}
+
+func (q queryWriter) getElement() *Statement {
+	return Id(q.t.Name).Op(":=").Id("query").Dot("engine").Dot(Title(q.t.Name)).Call(Id(q.idName()))
+}
+
+func (q queryWriter) elementMatches() *Statement {
+	return Id("query").Dot("matches").Call(Id(q.t.Name))
+}
+
+func (q queryWriter) appendElement() *Statement {
+	return Id(q.elementsName()).Op("=").Append(Id(q.elementsName()), Id(q.t.Name))
+}
+
+// sortElements sorts elements of narrowed queries as their IDs
+// come from index maps and aren't sorted by allIDs
+func (q queryWriter) sortElements() *Statement {
+	less := Func().Params(Id("i"), Id("j").Int()).Bool().Block(
+		Return(Id(q.elementsName()).Index(Id("i")).Dot(q.t.Name).Dot("ID").Op("<").Id(q.elementsName()).Index(Id("j")).Dot(q.t.Name).Dot("ID")),
+	)
+	return Id("sort").Dot("Slice").Call(Id(q.elementsName()), less)
+}
+
+func (q queryWriter) declareAllIDs() *Statement {
+	return Id(q.idsName()).Op(":=").Id("query").Dot("engine").Dot("all" + q.idType() + "s").Call()
+}
+
+func (q queryWriter) allIDsLoopConditions() *Statement {
+	return List(Id("_"), Id(q.idName())).Op(":=").Range().Id(q.idsName())
+}
+
+func (q queryWriter) returnIDsToPool() *Statement {
+	return Id(q.idName() + "SlicePool").Dot("Put").Call(Id(q.idsName()))
+}
@@ -28,6 +28,7 @@ func (s *EngineFactory) writeRemovers() *EngineFactory {
 				decls.File.Func().Params(r.receiverParams()).Id(r.name()).Params(r.params()).Id(r.returns()).Block(
 					r.reassignElement(),
 					If(r.isOperationKindDelete()).Block(
+						r.recordElementDoesNotExist(),
 						Return(Id(configType.Name)),
 					),
 					r.declareWereElementsAltered(),
@@ -52,6 +52,10 @@ func (r remover) isOperationKindDelete() *Statement {
 	return Id(r.t.Name).Dot(r.t.Name).Dot("OperationKind").Op("==").Id("OperationKindDelete")
 }
 
+func (r remover) recordElementDoesNotExist() *Statement {
+	return recordError(Id(r.t.Name).Dot(r.t.Name).Dot("engine"), r.name(), r.t.Name, Id(r.receiverName()).Dot(r.t.Name).Dot("ID"), "ErrElementDoesNotExist")
+}
+
 func (r remover) declareWereElementsAltered() *Statement {
 	return Var().Id("wereElementsAltered").Bool()
 }
@@ -85,6 +89,9 @@ func (r remover) elementsToDeteleLoopConditions() *Statement {
 }
 
 func (r remover) isElementMatching() *Statement {
+	if r.v.IsBasicType {
+		return equalBasicValues(r.v, Id("element"), Id("elementToRemove"))
+	}
 	return Id("element").Op("==").Id("elementToRemove")
 }
 
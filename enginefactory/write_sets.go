@@ -0,0 +1,49 @@
+package enginefactory
+
+import (
+	"github.com/jobergner/backent-cli/ast"
+	. "github.com/jobergner/backent-cli/factoryutils"
+
+	. "github.com/dave/jennifer/jen"
+)
+
+func (s *EngineFactory) writeSets() *EngineFactory {
+	decls := NewDeclSet()
+	s.config.RangeTypes(func(configType ast.ConfigType) {
+		configType.RangeFields(func(field ast.Field) {
+			if !field.HasSetValue {
+				return
+			}
+
+			w := setWriter{
+				t: configType,
+				f: field,
+			}
+
+			// indexOf functions of non-basic fields are written with the movers
+			if field.ValueType().IsBasicType {
+				decls.File.Func().Params(w.coreReceiverParams()).Id(w.indexOfName()).Params(Id(w.keyParam()).Add(w.keyType())).Int().Block(
+					For(w.valuesLoopConditions()).Block(
+						If(equalBasicValues(field.ValueType(), Id("element"), Id(w.keyParam()))).Block(
+							Return(Id("i")),
+						),
+					),
+					Return(Lit(-1)),
+				)
+			}
+
+			decls.File.Func().Params(w.receiverParams()).Id(w.hasName()).Params(Id(w.keyParam()).Add(w.keyType())).Bool().Block(
+				w.reassignElement(),
+				Return(w.indexOf().Op("!=").Lit(-1)),
+			)
+
+			decls.File.Func().Params(w.receiverParams()).Id(w.lenName()).Params().Int().Block(
+				w.reassignElement(),
+				Return(Len(w.values())),
+			)
+		})
+	})
+
+	decls.Render(s.buf)
+	return s
+}
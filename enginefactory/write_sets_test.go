@@ -0,0 +1,28 @@
+package enginefactory
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jobergner/backent-cli/testutils"
+)
+
+func TestWriteSets(t *testing.T) {
+	t.Run("writes sets", func(t *testing.T) {
+		sf := newStateFactory(newSimpleASTExample())
+		sf.writeSets()
+
+		actual := testutils.FormatCode(sf.buf.String())
+		expected := testutils.FormatCode(strings.Join([]string{
+			_HasGuildMember_player_func,
+			_LenGuildMembers_player_func,
+			indexOfTag_zoneCore_func,
+			_HasTag_zone_func,
+			_LenTags_zone_func,
+		}, "\n"))
+
+		if expected != actual {
+			t.Errorf(testutils.Diff(actual, expected))
+		}
+	})
+}
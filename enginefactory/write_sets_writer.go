@@ -0,0 +1,75 @@
+package enginefactory
+
+import (
+	"github.com/jobergner/backent-cli/ast"
+	. "github.com/jobergner/backent-cli/factoryutils"
+
+	. "github.com/dave/jennifer/jen"
+)
+
+type setWriter struct {
+	t ast.ConfigType
+	f ast.Field
+}
+
+func (w setWriter) receiverName() string {
+	return "_" + w.t.Name
+}
+
+func (w setWriter) receiverParams() *Statement {
+	return Id(w.receiverName()).Id(w.t.Name)
+}
+
+func (w setWriter) coreReceiverParams() *Statement {
+	return Id(w.receiverName()).Id(w.t.Name + "Core")
+}
+
+func (w setWriter) indexOfName() string {
+	return "indexOf" + Title(Singular(w.f.Name))
+}
+
+func (w setWriter) hasName() string {
+	return "Has" + Title(Singular(w.f.Name))
+}
+
+func (w setWriter) lenName() string {
+	return "Len" + Title(w.f.Name)
+}
+
+// keyType is the type elements of the set are identified by,
+// which is the same type they are keyed by in the tree
+func (w setWriter) keyType() *Statement {
+	if w.f.ValueType().IsBasicType {
+		return Id(w.f.ValueType().Name)
+	}
+	if w.f.HasAnyValue {
+		return Int()
+	}
+	return Id(Title(w.f.ValueType().Name) + "ID")
+}
+
+func (w setWriter) keyParam() string {
+	if w.f.ValueType().IsBasicType {
+		return Singular(w.f.Name)
+	}
+	if w.f.HasAnyValue {
+		return Singular(w.f.Name) + "ID"
+	}
+	return w.f.ValueType().Name + "ID"
+}
+
+func (w setWriter) valuesLoopConditions() *Statement {
+	return List(Id("i"), Id("element")).Op(":=").Range().Id(w.receiverName()).Dot(Title(w.f.Name))
+}
+
+func (w setWriter) reassignElement() *Statement {
+	return Id(w.t.Name).Op(":=").Id(w.receiverName()).Dot(w.t.Name).Dot("engine").Dot(Title(w.t.Name)).Call(Id(w.receiverName()).Dot(w.t.Name).Dot("ID"))
+}
+
+func (w setWriter) indexOf() *Statement {
+	return Id(w.t.Name).Dot(w.t.Name).Dot(w.indexOfName()).Call(Id(w.keyParam()))
+}
+
+func (w setWriter) values() *Statement {
+	return Id(w.t.Name).Dot(w.t.Name).Dot(Title(w.f.Name))
+}
@@ -24,9 +24,12 @@ func (s *EngineFactory) writeSetters() *EngineFactory {
 			decls.File.Func().Params(s.receiverParams()).Id(s.name()).Params(s.params()).Id(s.returns()).Block(
 				s.reassignElement(),
 				If(s.isOperationKindDelete()).Block(
+					s.recordElementDoesNotExist(),
 					Return(Id(configType.Name)),
 				),
+				OnlyIf(field.IsIndexed, s.unindexAttribute()),
 				s.setAttribute(),
+				OnlyIf(field.IsIndexed, s.indexAttribute()),
 				s.setOperationKind(),
 				s.updateElementInPatch(),
 				Return(Id(configType.Name)),
@@ -48,9 +51,11 @@ func (s *EngineFactory) writeSetters() *EngineFactory {
 			decls.File.Func().Params(s.receiverParams()).Id(s.name()).Params(s.params()).Id(s.returns()).Block(
 				s.reassignElement(),
 				If(s.isOperationKindDelete()).Block(
+					s.recordElementDoesNotExist(),
 					Return(Id(field.Parent.Name)),
 				),
 				If(s.isReferencedElementDeleted()).Block(
+					s.recordReferencedElementDoesNotExist(),
 					Return(Id(field.Parent.Name)),
 				),
 				If(s.isRefAlreadyAssigned()).Block(
@@ -14,6 +14,10 @@ func TestWriteSetters(t *testing.T) {
 
 		actual := testutils.FormatCode(sf.buf.String())
 		expected := testutils.FormatCode(strings.Join([]string{
+			_SetCooldown_equipmentSet_func,
+			_SetCreatedAt_equipmentSet_func,
+			_SetExternalID_equipmentSet_func,
+			_SetIcon_equipmentSet_func,
 			_SetName_equipmentSet_func,
 			_SetLevel_gearScore_func,
 			_SetScore_gearScore_func,
@@ -43,8 +43,20 @@ func (s setterWriter) isOperationKindDelete() *Statement {
 	return Id(s.t.Name).Dot(s.t.Name).Dot("OperationKind").Op("==").Id("OperationKindDelete")
 }
 
+func (s setterWriter) recordElementDoesNotExist() *Statement {
+	return recordError(Id(s.t.Name).Dot(s.t.Name).Dot("engine"), s.name(), s.t.Name, Id(s.receiverName()).Dot(s.t.Name).Dot("ID"), "ErrElementDoesNotExist")
+}
+
 func (s setterWriter) setAttribute() *Statement {
-	return Id(s.t.Name).Dot(s.t.Name).Dot(Title(s.f.Name)).Op("=").Id(s.newValueParam())
+	return Id(s.t.Name).Dot(s.t.Name).Dot(Title(s.f.Name)).Op("=").Add(copyBasicValue(s.f.ValueType(), Id(s.newValueParam())))
+}
+
+func (s setterWriter) unindexAttribute() *Statement {
+	return Id(s.t.Name).Dot(s.t.Name).Dot("engine").Dot("unindex"+Title(s.t.Name)+Title(s.f.Name)).Call(s.elementID(), Id(s.t.Name).Dot(s.t.Name).Dot(Title(s.f.Name)))
+}
+
+func (s setterWriter) indexAttribute() *Statement {
+	return Id(s.t.Name).Dot(s.t.Name).Dot("engine").Dot("index"+Title(s.t.Name)+Title(s.f.Name)).Call(s.elementID(), Id(s.newValueParam()))
 }
 
 func (s setterWriter) setOperationKind() *Statement {
@@ -108,6 +120,14 @@ func (s setRefFieldWeiter) isReferencedElementDeleted() *Statement {
 	return Id(s.f.Parent.Name).Dot(s.f.Parent.Name).Dot("engine").Dot(Title(s.v.Name)).Call(Id(s.idParam())).Dot(s.v.Name).Dot("OperationKind").Op("==").Id("OperationKindDelete")
 }
 
+func (s setRefFieldWeiter) recordElementDoesNotExist() *Statement {
+	return recordError(Id(s.f.Parent.Name).Dot(s.f.Parent.Name).Dot("engine"), s.name(), s.f.Parent.Name, Id("_"+s.f.Parent.Name).Dot(s.f.Parent.Name).Dot("ID"), "ErrElementDoesNotExist")
+}
+
+func (s setRefFieldWeiter) recordReferencedElementDoesNotExist() *Statement {
+	return recordError(Id(s.f.Parent.Name).Dot(s.f.Parent.Name).Dot("engine"), s.name(), s.v.Name, Id(s.idParam()), "ErrElementDoesNotExist")
+}
+
 func (s setRefFieldWeiter) isRefAlreadyAssigned() *Statement {
 	return Id(s.f.Parent.Name).Dot(s.f.Parent.Name).Dot(Title(s.f.Name)).Op("!=").Lit(0)
 }
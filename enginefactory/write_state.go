@@ -119,6 +119,7 @@ func (s *EngineFactory) writeElements() *EngineFactory {
 		)
 
 		decls.File.Type().Id(configType.Name).Struct(Id(configType.Name).Id(e.name()))
+		decls.File.Type().Id(e.aliasName()).Op("=").Id(configType.Name)
 	})
 
 	s.config.RangeRefFields(func(field ast.Field) {
@@ -32,17 +32,29 @@ func (s *EngineFactory) writeEngine() *EngineFactory {
 		Id("assembleCache").Id("assembleCache"),
 		Id("forceIncludeAssembleCache").Id("assembleCache"),
 		Id("IDgen").Int(),
+		Id("index").Id("index"),
+		Id("listeners").Id("listeners"),
+		Id("strict").Bool(),
+		Id("errs").Index().Error(),
+		Id("ordered").Bool(),
+		Id("queueEvent").Func().Params(Id("message").Index().Byte(), Id("clientIDs").Map(String()).Bool()).Error(),
 	)
 
+	engineValues := Dict{
+		Id("State"):                     Id("newState").Call(),
+		Id("Patch"):                     Id("newState").Call(),
+		Id("Tree"):                      Id("newTree").Call(),
+		Id("assembleCache"):             Id("newAssembleCache").Call(),
+		Id("forceIncludeAssembleCache"): Id("newAssembleCache").Call(),
+		Id("IDgen"):                     Lit(1),
+		Id("index"):                     Id("newIndex").Call(),
+	}
+	if s.ordered {
+		engineValues[Id("ordered")] = True()
+	}
+
 	decls.File.Func().Id("newEngine").Params().Id("*Engine").Block(
-		Return(Id("&Engine").Values(Dict{
-			Id("State"):                     Id("newState").Call(),
-			Id("Patch"):                     Id("newState").Call(),
-			Id("Tree"):                      Id("newTree").Call(),
-			Id("assembleCache"):             Id("newAssembleCache").Call(),
-			Id("forceIncludeAssembleCache"): Id("newAssembleCache").Call(),
-			Id("IDgen"):                     Lit(1),
-		})),
+		Return(Id("&Engine").Values(engineValues)),
 	)
 
 	decls.Render(s.buf)
@@ -68,6 +80,8 @@ func (s *EngineFactory) writeUpdateState() *EngineFactory {
 	u := updateStateWriter{}
 
 	decls.File.Func().Params(u.receiverParams()).Id("UpdateState").Params().Block(
+		Id("engine").Dot("emitChanges").Call(),
+		Id("engine").Dot("listeners").Dot("emitted").Op("=").False(),
 		ForEachTypeInAST(s.config, func(configType ast.ConfigType) *Statement {
 			u.typeName = func() string {
 				return configType.Name
@@ -104,6 +118,7 @@ func (s *EngineFactory) writeUpdateState() *EngineFactory {
 			}
 			return writeClearPatch(u)
 		}),
+		u.resetErrors(),
 	)
 
 	decls.Render(s.buf)
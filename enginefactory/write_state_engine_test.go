@@ -36,6 +36,20 @@ func TestWriteEngine(t *testing.T) {
 			t.Errorf(testutils.Diff(actual, expected))
 		}
 	})
+	t.Run("writes ordered Engine", func(t *testing.T) {
+		sf := newStateFactory(newSimpleASTExample()).withOrdering(true)
+		sf.writeEngine()
+
+		actual := testutils.FormatCode(sf.buf.String())
+		expected := testutils.FormatCode(strings.Join([]string{
+			_Engine_type,
+			strings.Replace(newEngine_func, "newIndex()}", "newIndex(), ordered: true}", 1),
+		}, "\n"))
+
+		if expected != actual {
+			t.Errorf(testutils.Diff(actual, expected))
+		}
+	})
 	t.Run("writes generateID method", func(t *testing.T) {
 		sf := newStateFactory(newSimpleASTExample())
 		sf.writeGenerateID()
@@ -41,3 +41,7 @@ func (u updateStateWriter) loopPatchKeysConditions() *Statement {
 func (u updateStateWriter) clearElementFromPatch() *Statement {
 	return Id("delete").Call(Id("engine").Dot("Patch").Dot(Title(u.typeName())), Id("key"))
 }
+
+func (u updateStateWriter) resetErrors() *Statement {
+	return Id("engine").Dot("errs").Op("=").Nil()
+}
@@ -58,18 +58,25 @@ func TestWriteState(t *testing.T) {
 		expected := testutils.FormatCode(strings.Join([]string{
 			equipmentSetCore_type,
 			equipmentSet_type,
+			_EquipmentSetElement_type,
 			gearScoreCore_type,
 			gearScore_type,
+			_GearScoreElement_type,
 			itemCore_type,
 			item_type,
+			_ItemElement_type,
 			playerCore_type,
 			player_type,
+			_PlayerElement_type,
 			positionCore_type,
 			position_type,
+			_PositionElement_type,
 			zoneCore_type,
 			zone_type,
+			_ZoneElement_type,
 			zoneItemCore_type,
 			zoneItem_type,
+			_ZoneItemElement_type,
 			equipmentSetEquipmentRefCore_type,
 			equipmentSetEquipmentRef_type,
 			itemBoundToRefCore_type,
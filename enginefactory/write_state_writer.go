@@ -68,6 +68,10 @@ func (e elementWriter) name() string {
 	return e.t.Name + "Core"
 }
 
+func (e elementWriter) aliasName() string {
+	return Title(e.t.Name) + "Element"
+}
+
 func (e elementWriter) idType() string {
 	return Title(e.t.Name) + "ID"
 }
@@ -41,7 +41,7 @@ func (s *EngineFactory) writeTree() *EngineFactory {
 	decls.File.Type().Id("Tree").Struct(
 		ForEachTypeInAST(s.config, func(configType ast.ConfigType) *Statement {
 			s := treeWriter{configType}
-			return Id(s.fieldName()).Map(s.mapKey()).Id(s.mapValue()).Id(s.fieldTag()).Line()
+			return Id(s.fieldName()).Id(s.mapType()).Id(s.fieldTag()).Line()
 		}),
 	)
 
@@ -49,7 +49,7 @@ func (s *EngineFactory) writeTree() *EngineFactory {
 		Return(Id("Tree").Values(
 			ForEachTypeInAST(s.config, func(configType ast.ConfigType) *Statement {
 				s := treeWriter{configType}
-				return Id(s.fieldName()).Id(":").Make(Map(s.mapKey()).Id(s.mapValue())).Id(",")
+				return Id(s.fieldName()).Id(":").Make(Id(s.mapType())).Id(",")
 			}),
 		)),
 	)
@@ -71,7 +71,7 @@ func (s *EngineFactory) writeTreeElements() *EngineFactory {
 			Id("ID").Id(e.idType()).Id(e.metaFieldTag("id")).Line(),
 			ForEachFieldInType(configType, func(field ast.Field) *Statement {
 				e.f = &field
-				return Id(e.fieldName()).Add(e.fieldValue()).Id(e.fieldTag()).Line()
+				return Id(e.fieldName()).Add(e.fieldValue()).Id(e.fieldTag()).Line().Add(OnlyIf(e.hasOrder(), Id(e.orderFieldName()).Index().Add(e.mapKeyType()).Id(e.orderFieldTag()).Line()))
 			}),
 			Id("OperationKind").Id("OperationKind").Id(e.metaFieldTag("operationKind")).Line(),
 		)
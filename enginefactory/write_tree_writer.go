@@ -15,6 +15,10 @@ func (s treeWriter) fieldName() string {
 	return Title(s.t.Name)
 }
 
+func (s treeWriter) mapType() string {
+	return s.t.Name + "Map"
+}
+
 func (s treeWriter) mapKey() *Statement {
 	return Id(Title(s.t.Name) + "ID")
 }
@@ -32,22 +36,50 @@ type treeElementWriter struct {
 	f *ast.Field
 }
 
-func (e treeElementWriter) fieldValueMapDefinition() *Statement {
-	mapValueType := Id(Title(e.f.ValueType().Name) + "Reference")
-	if e.f.HasAnyValue && !e.f.HasPointerValue {
-		mapValueType = Id("interface{}")
-	}
-	if e.f.HasPointerValue && e.f.HasAnyValue {
-		mapValueType = Id(Title(anyNameByField(*e.f) + "Reference"))
+// mapTypeName is the name of the map type the field is represented as in the tree
+func (e treeElementWriter) mapTypeName() string {
+	switch {
+	case e.f.HasAnyValue && e.f.HasPointerValue:
+		return anyNameByField(*e.f) + "ReferenceMap"
+	case e.f.HasAnyValue:
+		return anyNameByField(*e.f) + "Map"
+	case e.f.HasPointerValue:
+		return e.f.ValueType().Name + "ReferenceMap"
 	}
-	if !e.f.HasPointerValue && !e.f.HasAnyValue {
-		mapValueType = Id(Title(e.f.ValueType().Name))
+	return e.f.ValueType().Name + "Map"
+}
+
+func (e treeElementWriter) mapValueType() *Statement {
+	switch {
+	case e.f.HasAnyValue && e.f.HasPointerValue:
+		return Id(Title(anyNameByField(*e.f)) + "Reference")
+	case e.f.HasAnyValue:
+		return Id("interface{}")
+	case e.f.HasPointerValue:
+		return Id(Title(e.f.ValueType().Name) + "Reference")
 	}
-	mapKeyType := Id(Title(e.f.ValueType().Name) + "ID")
+	return Id(Title(e.f.ValueType().Name))
+}
+
+func (e treeElementWriter) mapKeyType() *Statement {
 	if e.f.HasAnyValue {
-		mapKeyType = Int()
+		return Int()
 	}
-	return Map(mapKeyType).Add(mapValueType)
+	return Id(Title(e.f.ValueType().Name) + "ID")
+}
+
+// hasOrder is true for fields which are represented as maps in the tree,
+// their order is carried by an additional field
+func (e treeElementWriter) hasOrder() bool {
+	return e.f.HasSliceValue && !e.f.ValueType().IsBasicType
+}
+
+func (e treeElementWriter) orderFieldName() string {
+	return Title(e.f.Name) + "Order"
+}
+
+func (e treeElementWriter) orderFieldTag() string {
+	return "`json:\"" + e.f.Name + "Order,omitempty\"`"
 }
 
 func (e treeElementWriter) fieldValue() *Statement {
@@ -57,7 +89,7 @@ func (e treeElementWriter) fieldValue() *Statement {
 		if !e.f.HasSliceValue {
 			return Id("interface{}")
 		}
-		return e.fieldValueMapDefinition()
+		return Id(e.mapTypeName())
 	}
 
 	if e.f.ValueType().IsBasicType {
@@ -77,7 +109,7 @@ func (e treeElementWriter) fieldValue() *Statement {
 		if e.f.ValueType().IsBasicType {
 			return Id("[]" + typeName)
 		}
-		return e.fieldValueMapDefinition()
+		return Id(e.mapTypeName())
 	} else if !e.f.ValueType().IsBasicType {
 		return Id("*" + typeName)
 	}
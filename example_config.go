@@ -83,4 +83,17 @@ var exampleConfig = jsonConfig{
 			"itemPath": "string",
 		},
 	},
+	Events: map[string]interface{}{
+		"chatMessage": map[string]interface{}{
+			"sender": "playerID",
+			"text":   "string",
+		},
+		"combatEnded": map[string]interface{}{
+			"winner": "playerID",
+		},
+	},
+	Indexes: map[string]interface{}{
+		"item":   []interface{}{"isRare"},
+		"player": []interface{}{"name"},
+	},
 }
@@ -9,10 +9,10 @@ import (
 var playerID state.PlayerID
 
 var actions = state.Actions{
-	AddItemToPlayer: func(a state.AddItemToPlayerParams, e *state.Engine) state.AddItemToPlayerResponse {
+	AddItemToPlayer: func(a state.AddItemToPlayerParams, e *state.Engine, _ state.Identity) state.AddItemToPlayerResponse {
 		return state.AddItemToPlayerResponse{}
 	},
-	MovePlayer: func(p state.MovePlayerParams, e *state.Engine) {
+	MovePlayer: func(p state.MovePlayerParams, e *state.Engine, _ state.Identity) {
 		if playerID == 0 {
 			player := e.CreatePlayer()
 			log.Println(player.ID())
@@ -21,14 +21,14 @@ var actions = state.Actions{
 		log.Println("moving player..")
 		e.Player(playerID).Position().SetX(p.ChangeX)
 	},
-	SpawnZoneItems: func(a state.SpawnZoneItemsParams, e *state.Engine) state.SpawnZoneItemsResponse {
+	SpawnZoneItems: func(a state.SpawnZoneItemsParams, e *state.Engine, _ state.Identity) state.SpawnZoneItemsResponse {
 		return state.SpawnZoneItemsResponse{}
 	},
 }
 
 var sideEffects = state.SideEffects{
 	OnDeploy:    func(*state.Engine) {},
-	OnFrameTick: func(*state.Engine) {},
+	OnFrameTick: func(*state.Engine, state.TickInfo) {},
 }
 
 func main() {
@@ -0,0 +1,239 @@
+package state
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sync/atomic"
+	"time"
+)
+
+// adminClient describes a client in the response of GET /admin/clients
+type adminClient struct {
+	ID        string `json:"id"`
+	UserID    string `json:"userID"`
+	Spectator bool   `json:"spectator"`
+	// Status is "connected", "incoming" or "spectating"
+	Status           string `json:"status"`
+	BufferedMessages int    `json:"bufferedMessages"`
+	BufferSize       int    `json:"bufferSize"`
+}
+
+func (r *Room) findClient(clientID string) *Client {
+	for client := range r.clients {
+		if client.identity.ClientID == clientID {
+			return client
+		}
+	}
+	for client := range r.incomingClients {
+		if client.identity.ClientID == clientID {
+			return client
+		}
+	}
+	for _, client := range r.delayedSpectators() {
+		if client.identity.ClientID == clientID {
+			return client
+		}
+	}
+	return nil
+}
+
+func (r *Room) adminClients() []adminClient {
+	clients := make([]adminClient, 0, len(r.clients)+len(r.incomingClients))
+	add := func(client *Client, status string) {
+		clients = append(clients, adminClient{
+			ID:               client.identity.ClientID,
+			UserID:           client.identity.UserID,
+			Spectator:        client.identity.Spectator,
+			Status:           status,
+			BufferedMessages: len(client.messageChannel),
+			BufferSize:       cap(client.messageChannel),
+		})
+	}
+	for client := range r.clients {
+		add(client, "connected")
+	}
+	for client := range r.incomingClients {
+		add(client, "incoming")
+	}
+	for _, client := range r.delayedSpectators() {
+		add(client, "spectating")
+	}
+	return clients
+}
+
+func (s *Server) adminHandler() http.Handler {
+	handler := http.NewServeMux()
+	handler.HandleFunc("/admin/clients", s.adminClientsEndpoint)
+	handler.HandleFunc("/admin/kick", s.adminKickEndpoint)
+	handler.HandleFunc("/admin/action", s.adminActionEndpoint)
+	handler.HandleFunc("/admin/pause", s.adminPauseEndpoint)
+	handler.HandleFunc("/admin/resume", s.adminPauseEndpoint)
+	handler.HandleFunc("/admin/step", s.adminStepEndpoint)
+	handler.HandleFunc("/admin/snapshot", s.adminSnapshotEndpoint)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		err := s.options.AdminAuthenticate(r)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("authentication failed: %s", err), http.StatusUnauthorized)
+			return
+		}
+		handler.ServeHTTP(w, r)
+	})
+}
+
+func requireMethod(w http.ResponseWriter, r *http.Request, method string) bool {
+	if r.Method != method {
+		w.Header().Set("Allow", method)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return false
+	}
+	return true
+}
+
+func (s *Server) adminClientsEndpoint(w http.ResponseWriter, r *http.Request) {
+	if !requireMethod(w, r, http.MethodGet) {
+		return
+	}
+	var clients []adminClient
+	if !s.room.runOnRoom(func() { clients = s.room.adminClients() }) {
+		http.Error(w, "server is shut down", http.StatusServiceUnavailable)
+		return
+	}
+	clientsBytes, err := json.Marshal(clients)
+	if err != nil {
+		http.Error(w, "error marshalling clients", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(clientsBytes)
+}
+
+func (s *Server) adminKickEndpoint(w http.ResponseWriter, r *http.Request) {
+	if !requireMethod(w, r, http.MethodPost) {
+		return
+	}
+	clientID := r.URL.Query().Get("client")
+	var found bool
+	ok := s.room.runOnRoom(func() {
+		client := s.room.findClient(clientID)
+		if client == nil {
+			return
+		}
+		found = true
+		atomic.StoreInt32(&client.kicked, 1)
+		s.room.log(LogLevelInfo, "client kicked by admin", LogField{"client", client.id})
+		s.room.unregisterClient(client)
+	})
+	switch {
+	case !ok:
+		http.Error(w, "server is shut down", http.StatusServiceUnavailable)
+	case !found:
+		http.Error(w, "unknown client", http.StatusNotFound)
+	default:
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// adminActionEndpoint processes the message with the next tick as if the client sent it,
+// the client's limits do not apply
+func (s *Server) adminActionEndpoint(w http.ResponseWriter, r *http.Request) {
+	if !requireMethod(w, r, http.MethodPost) {
+		return
+	}
+	msgBytes, err := ioutil.ReadAll(io.LimitReader(r.Body, defaultReadLimit))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("error reading body: %s", err), http.StatusBadRequest)
+		return
+	}
+	var msg Message
+	err = msg.UnmarshalJSON(msgBytes)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("error parsing message: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	clientID := r.URL.Query().Get("client")
+	var found bool
+	ok := s.room.runOnRoom(func() {
+		client := s.room.findClient(clientID)
+		if client == nil {
+			return
+		}
+		found = true
+		s.room.log(LogLevelInfo, "action injected by admin", LogField{"client", client.id}, LogField{"action", msg.Kind})
+		msg.client = client
+		client.forwardToRoom(msg)
+	})
+	switch {
+	case !ok:
+		http.Error(w, "server is shut down", http.StatusServiceUnavailable)
+	case !found:
+		http.Error(w, "unknown client", http.StatusNotFound)
+	default:
+		w.WriteHeader(http.StatusAccepted)
+	}
+}
+
+// adminPauseEndpoint handles both /admin/pause and /admin/resume
+func (s *Server) adminPauseEndpoint(w http.ResponseWriter, r *http.Request) {
+	if !requireMethod(w, r, http.MethodPost) {
+		return
+	}
+	paused := r.URL.Path == "/admin/pause"
+	ok := s.room.runOnRoom(func() {
+		s.room.paused = paused
+		s.room.log(LogLevelInfo, "tick loop paused by admin", LogField{"paused", paused})
+	})
+	if !ok {
+		http.Error(w, "server is shut down", http.StatusServiceUnavailable)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) adminStepEndpoint(w http.ResponseWriter, r *http.Request) {
+	if !requireMethod(w, r, http.MethodPost) {
+		return
+	}
+	var paused bool
+	ok := s.room.runOnRoom(func() {
+		paused = s.room.paused
+		if paused {
+			s.room.process(TickInfo{Delta: time.Second / time.Duration(s.room.fps)})
+		}
+	})
+	switch {
+	case !ok:
+		http.Error(w, "server is shut down", http.StatusServiceUnavailable)
+	case !paused:
+		http.Error(w, "tick loop is not paused", http.StatusConflict)
+	default:
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (s *Server) adminSnapshotEndpoint(w http.ResponseWriter, r *http.Request) {
+	if !requireMethod(w, r, http.MethodGet) {
+		return
+	}
+	var stateBytes []byte
+	var err error
+	ok := s.room.runOnRoom(func() {
+		tree := s.room.state.assembleTree(true)
+		stateBytes, err = s.room.state.marshalTree(tree)
+	})
+	if !ok {
+		http.Error(w, "server is shut down", http.StatusServiceUnavailable)
+		return
+	}
+	if err != nil {
+		http.Error(w, "error marshalling tree", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"snapshot-%d.json\"", s.room.clock.Now().Unix()))
+	w.Write(stateBytes)
+}
@@ -0,0 +1,146 @@
+package state
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sync/atomic"
+)
+
+const (
+	defaultClientBufferSize = 32
+	defaultRoomBufferSize   = 1024
+)
+
+// BackpressurePolicy decides what happens to a client
+// whose message buffer is full
+type BackpressurePolicy string
+
+const (
+	// BackpressureDrop disconnects the client
+	BackpressureDrop BackpressurePolicy = "drop"
+	// BackpressureCoalesce merges all patches the client could not receive
+	// into a single patch, which is sent as soon as the buffer has space again
+	BackpressureCoalesce BackpressurePolicy = "coalesce"
+	// BackpressureResync skips all patches and sends the client
+	// the complete current state as soon as the buffer has space again
+	BackpressureResync BackpressurePolicy = "resync"
+)
+
+// easyjson:skip
+type BackpressureOptions struct {
+	// Policy defaults to BackpressureDrop
+	Policy BackpressurePolicy
+	// ClientBufferSize is the number of messages which can be
+	// queued for a single client, defaults to 32
+	ClientBufferSize int
+	// RoomBufferSize is the number of incoming messages and pending responses
+	// which can be queued in the room, defaults to 1024
+	RoomBufferSize int
+}
+
+func (o BackpressureOptions) withDefaults() BackpressureOptions {
+	if o.Policy == "" {
+		o.Policy = BackpressureDrop
+	}
+	if o.ClientBufferSize < 1 {
+		o.ClientBufferSize = defaultClientBufferSize
+	}
+	if o.RoomBufferSize < 1 {
+		o.RoomBufferSize = defaultRoomBufferSize
+	}
+	return o
+}
+
+// BackpressureMetrics counts how often the backpressure policies were applied
+// easyjson:skip
+type BackpressureMetrics struct {
+	// ClientsDropped counts clients disconnected due to a full buffer
+	ClientsDropped uint64
+	// PatchesCoalesced counts patches merged into a client's coalesced patch
+	PatchesCoalesced uint64
+	// PatchesSkipped counts patches not sent to clients awaiting a resync
+	PatchesSkipped uint64
+	// ClientsResynced counts complete states sent to clients which missed patches
+	ClientsResynced uint64
+	// ResponsesDropped counts responses and events which could not be sent to a client
+	ResponsesDropped uint64
+}
+
+func (m *BackpressureMetrics) snapshot() BackpressureMetrics {
+	return BackpressureMetrics{
+		ClientsDropped:   atomic.LoadUint64(&m.ClientsDropped),
+		PatchesCoalesced: atomic.LoadUint64(&m.PatchesCoalesced),
+		PatchesSkipped:   atomic.LoadUint64(&m.PatchesSkipped),
+		ClientsResynced:  atomic.LoadUint64(&m.ClientsResynced),
+		ResponsesDropped: atomic.LoadUint64(&m.ResponsesDropped),
+	}
+}
+
+// mergePatches merges the JSON objects of two patches, values of the later
+// patch take precedence unless the operation kinds of the elements say otherwise
+func mergePatches(earlier, later []byte) ([]byte, error) {
+	earlierPatch, err := decodePatch(earlier)
+	if err != nil {
+		return nil, err
+	}
+	laterPatch, err := decodePatch(later)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(mergeObjects(earlierPatch, laterPatch))
+}
+
+// decodePatch keeps numbers as json.Number so
+// large integers don't lose precision as float64
+func decodePatch(patch []byte) (map[string]interface{}, error) {
+	var decoded map[string]interface{}
+	decoder := json.NewDecoder(bytes.NewReader(patch))
+	decoder.UseNumber()
+	if err := decoder.Decode(&decoded); err != nil {
+		return nil, fmt.Errorf("error unmarshalling patch for merging: %s", err)
+	}
+	return decoded, nil
+}
+
+// operationKindRanks decides which operation kind an element has after
+// merging, a deletion can't be undone and an update is never downgraded
+var operationKindRanks = map[OperationKind]int{
+	OperationKindUnchanged: 0,
+	OperationKindUpdate:    1,
+	OperationKindDelete:    2,
+}
+
+func operationKindOf(object map[string]interface{}) (OperationKind, bool) {
+	operationKind, ok := object["operationKind"].(string)
+	return OperationKind(operationKind), ok
+}
+
+func mergeObjects(earlier, later map[string]interface{}) map[string]interface{} {
+	if earlier == nil {
+		return later
+	}
+	earlierKind, earlierHasKind := operationKindOf(earlier)
+	laterKind, laterHasKind := operationKindOf(later)
+	if earlierHasKind && laterHasKind {
+		switch {
+		case earlierKind == OperationKindDelete:
+			return earlier
+		case laterKind == OperationKindDelete:
+			return later
+		}
+	}
+	for key, laterValue := range later {
+		earlierObject, earlierIsObject := earlier[key].(map[string]interface{})
+		laterObject, laterIsObject := laterValue.(map[string]interface{})
+		if earlierIsObject && laterIsObject {
+			earlier[key] = mergeObjects(earlierObject, laterObject)
+			continue
+		}
+		earlier[key] = laterValue
+	}
+	if earlierHasKind && operationKindRanks[earlierKind] > operationKindRanks[laterKind] {
+		earlier["operationKind"] = string(earlierKind)
+	}
+	return earlier
+}
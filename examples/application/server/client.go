@@ -2,47 +2,109 @@ package state
 
 import (
 	"fmt"
-	"log"
+	"sync/atomic"
 
 	"github.com/google/uuid"
+	"nhooyr.io/websocket"
 )
 
+// easyjson:skip
 type Client struct {
 	room           *Room
 	conn           Connector
 	messageChannel chan []byte
 	id             uuid.UUID
+	identity       Identity
+	rateLimiter    *tokenBucket
+	violations     int32
+	kicked         int32
+	// coalescedPatch holds patches the client could not receive yet
+	coalescedPatch []byte
+	awaitingResync bool
+	// pendingEvents holds events sent to the client before it
+	// received the current state or while its buffer was full
+	pendingEvents [][]byte
+	logger         Logger
 }
 
-func newClient(websocketConnector Connector) (*Client, error) {
+func newClient(websocketConnector Connector, identity Identity) (*Client, error) {
 	clientID, err := uuid.NewRandom()
 	if err != nil {
 		return nil, fmt.Errorf("error generating client ID: %s", err)
 	}
+	identity.ClientID = clientID.String()
 	c := Client{
-		conn:           websocketConnector,
-		messageChannel: make(chan []byte, 32),
-		id:             clientID,
+		conn:     websocketConnector,
+		id:       clientID,
+		identity: identity,
 	}
 
 	return &c, nil
 }
 
 func (c *Client) discontinue() {
-	c.room.unregisterChannel <- c
-	c.conn.Close()
+	select {
+	case c.room.unregisterChannel <- c:
+	case <-c.room.doneChannel:
+	}
+	c.conn.Close(c.closeStatus())
+}
+
+// closeStatus tells the client why it is being disconnected
+func (c *Client) closeStatus() (websocket.StatusCode, string) {
+	if c.hasExceededViolations() {
+		return websocket.StatusPolicyViolation, "too many client limit violations"
+	}
+	if atomic.LoadInt32(&c.kicked) == 1 {
+		return websocket.StatusPolicyViolation, "kicked by admin"
+	}
+	select {
+	case <-c.room.shutdownChannel:
+		return websocket.StatusGoingAway, "server shutting down"
+	default:
+		return websocket.StatusNormalClosure, ""
+	}
 }
 
 func (c *Client) assignToRoom(room *Room) {
 	c.room = room
+	c.logger = withFields(room.logger, LogField{"client", c.id})
+	c.messageChannel = make(chan []byte, room.backpressure.ClientBufferSize)
+	if room.limits.ActionsPerSecond > 0 {
+		c.rateLimiter = newTokenBucket(room.limits.ActionsPerSecond, room.limits.ActionBurst)
+	}
+}
+
+// handleLimitViolation informs the client about the violation,
+// clients which exceed the maximum number of violations
+// are disconnected by the room
+func (c *Client) handleLimitViolation(violation string) {
+	c.logger.Log(LogLevelWarn, "client violated limit", LogField{"violation", violation})
+	c.sendError(clientLimitViolationError(violation))
+	atomic.AddInt32(&c.violations, 1)
+}
+
+// sendError queues an error message for the client without blocking
+func (c *Client) sendError(content []byte) {
+	select {
+	case c.room.pendingResponsesChannel <- Message{MessageKindError, content, c}:
+	default:
+		c.logger.Log(LogLevelWarn, "pending responses channel full, skipping response")
+		c.room.metrics.messageDropped("pending_responses")
+	}
+}
+
+func (c *Client) hasExceededViolations() bool {
+	maxViolations := c.room.limits.MaxViolations
+	return maxViolations > 0 && int(atomic.LoadInt32(&c.violations)) > maxViolations
 }
 
 func (c *Client) forwardToRoom(msg Message) {
 	select {
 	case c.room.clientMessageChannel <- msg:
 	default:
-		log.Println("room's message buffer full -> message dropped:")
-		log.Println(printMessage(msg))
+		c.logger.Log(LogLevelWarn, "room's message buffer full -> message dropped", LogField{"action", msg.Kind})
+		c.room.metrics.messageDropped("room")
 	}
 }
 
@@ -51,30 +113,54 @@ func (c *Client) runReadMessages() {
 	for {
 		_, msgBytes, err := c.conn.ReadMessage()
 		if err != nil {
-			log.Printf("unregistering client due to error while reading connection: %s", err)
-			c.room.unregisterChannel <- c
+			c.logger.Log(LogLevelInfo, "unregistering client due to error while reading connection", LogField{"error", err})
 			break
 		}
 
-		var msg Message
-		err = msg.UnmarshalJSON(msgBytes)
-		if err != nil {
-			log.Printf("error parsing message \"%s\" with error %s", string(msgBytes), err)
-			c.room.pendingResponsesChannel <- Message{MessageKindError, messageUnmarshallingError(msgBytes, err), c}
-			continue
-		}
+		c.handleMessageBytes(msgBytes)
+	}
+}
+
+func (c *Client) handleMessageBytes(msgBytes []byte) {
+	// messages are ignored until the room disconnects the client
+	if c.hasExceededViolations() {
+		return
+	}
 
-		msg.client = c
-		c.forwardToRoom(msg)
+	if maxMessageBytes := c.room.limits.MaxMessageBytes; maxMessageBytes > 0 && len(msgBytes) > maxMessageBytes {
+		c.handleLimitViolation(fmt.Sprintf("message exceeds %d bytes", maxMessageBytes))
+		return
 	}
+
+	if c.rateLimiter != nil && !c.rateLimiter.take() {
+		c.handleLimitViolation(fmt.Sprintf("more than %v actions per second", c.room.limits.ActionsPerSecond))
+		return
+	}
+
+	if c.identity.Spectator {
+		c.sendError([]byte(spectatorActionError))
+		return
+	}
+
+	var msg Message
+	err := msg.UnmarshalJSON(msgBytes)
+	if err != nil {
+		c.logger.Log(LogLevelWarn, "error parsing message", LogField{"message", string(msgBytes)}, LogField{"error", err})
+		c.room.pendingResponsesChannel <- Message{MessageKindError, messageUnmarshallingError(msgBytes, err), c}
+		return
+	}
+
+	msg.client = c
+	c.forwardToRoom(msg)
 }
 
 func (c *Client) runWriteMessages() {
+	defer c.room.connectedClients.Done()
 	defer c.discontinue()
 	for {
 		msg, ok := <-c.messageChannel
 		if !ok {
-			log.Printf("messageChannel of client %s has been closed", c.id)
+			c.logger.Log(LogLevelDebug, "messageChannel of client has been closed")
 			return
 		}
 		c.conn.WriteMessage(msg)
@@ -0,0 +1,132 @@
+package state
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock is the source of time of the room,
+// it can be replaced e.g. to step ticks manually in tests
+type Clock interface {
+	Now() time.Time
+	NewTimer(d time.Duration) Timer
+}
+
+// Timer sends the current time on its channel once it fires
+type Timer interface {
+	C() <-chan time.Time
+	// Reset must only be called after the timer fired
+	Reset(d time.Duration)
+	Stop()
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) NewTimer(d time.Duration) Timer {
+	return realTimer{time.NewTimer(d)}
+}
+
+// easyjson:skip
+type realTimer struct {
+	timer *time.Timer
+}
+
+func (t realTimer) C() <-chan time.Time {
+	return t.timer.C
+}
+
+func (t realTimer) Reset(d time.Duration) {
+	t.timer.Reset(d)
+}
+
+func (t realTimer) Stop() {
+	t.timer.Stop()
+}
+
+// ManualClock only advances when Advance is called
+// easyjson:skip
+type ManualClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers []*manualTimer
+}
+
+func NewManualClock(start time.Time) *ManualClock {
+	return &ManualClock{
+		now: start,
+	}
+}
+
+func (c *ManualClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *ManualClock) NewTimer(d time.Duration) Timer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	timer := &manualTimer{
+		clock: c,
+		c:     make(chan time.Time, 1),
+	}
+	c.timers = append(c.timers, timer)
+	timer.reset(d)
+	return timer
+}
+
+// Advance moves the clock forward and fires all timers which are due
+func (c *ManualClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	for _, timer := range c.timers {
+		timer.fireIfDue()
+	}
+}
+
+// easyjson:skip
+type manualTimer struct {
+	clock    *ManualClock
+	c        chan time.Time
+	deadline time.Time
+	active   bool
+}
+
+func (t *manualTimer) C() <-chan time.Time {
+	return t.c
+}
+
+func (t *manualTimer) Reset(d time.Duration) {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	t.reset(d)
+}
+
+func (t *manualTimer) Stop() {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	t.active = false
+}
+
+// reset and fireIfDue expect the clock to be locked
+func (t *manualTimer) reset(d time.Duration) {
+	t.deadline = t.clock.now.Add(d)
+	t.active = true
+	t.fireIfDue()
+}
+
+func (t *manualTimer) fireIfDue() {
+	if !t.active || t.clock.now.Before(t.deadline) {
+		return
+	}
+	t.active = false
+	select {
+	case t.c <- t.clock.now:
+	default:
+	}
+}
@@ -9,11 +9,12 @@ import (
 )
 
 type Connector interface {
-	Close()
+	Close(code websocket.StatusCode, reason string)
 	ReadMessage() (messageType int, p []byte, err error)
 	WriteMessage(messageType []byte) error
 }
 
+// easyjson:skip
 type Connection struct {
 	Conn          *websocket.Conn
 	ctx           context.Context
@@ -29,8 +30,8 @@ func NewConnection(conn *websocket.Conn, r *http.Request) *Connection {
 	}
 }
 
-func (c *Connection) Close() {
-	c.Conn.Close(websocket.StatusNormalClosure, "")
+func (c *Connection) Close(code websocket.StatusCode, reason string) {
+	c.Conn.Close(code, reason)
 }
 
 func (c *Connection) ReadMessage() (int, []byte, error) {
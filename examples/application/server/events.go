@@ -0,0 +1,186 @@
+package state
+
+import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+)
+
+var (
+	errEventQueueFull    = errors.New("event queue full")
+	errEngineWithoutRoom = errors.New("engine does not belong to a room")
+)
+
+// Event is implemented by all events declared in the "events" section of the config
+type Event interface {
+	kind() MessageKind
+	MarshalJSON() ([]byte, error)
+}
+
+// easyjson:skip
+type eventDelivery struct {
+	message []byte
+	// clientIDs is nil if the event is broadcast to all clients
+	clientIDs map[string]bool
+}
+
+// SendEventToClient queues the event for delivery to the client after the current tick
+func (s *Server) SendEventToClient(clientID string, event Event) error {
+	return s.SendEventToClients([]string{clientID}, event)
+}
+
+// SendEventToClients queues the event for delivery to the clients after the current tick,
+// unknown client IDs are ignored
+func (s *Server) SendEventToClients(clientIDs []string, event Event) error {
+	recipients := make(map[string]bool, len(clientIDs))
+	for _, clientID := range clientIDs {
+		recipients[clientID] = true
+	}
+	return s.room.queueEvent(event, recipients)
+}
+
+// BroadcastEvent queues the event for delivery to all clients after the current tick
+func (s *Server) BroadcastEvent(event Event) error {
+	return s.room.queueEvent(event, nil)
+}
+
+// SendEventToClient queues the event for delivery to the client after the current tick,
+// it can be used in actions and side effects of the room the engine belongs to
+func (engine *Engine) SendEventToClient(clientID string, event Event) error {
+	return engine.SendEventToClients([]string{clientID}, event)
+}
+
+// SendEventToClients queues the event for delivery to the clients after the current tick,
+// unknown client IDs are ignored
+func (engine *Engine) SendEventToClients(clientIDs []string, event Event) error {
+	recipients := make(map[string]bool, len(clientIDs))
+	for _, clientID := range clientIDs {
+		recipients[clientID] = true
+	}
+	return engine.sendEvent(event, recipients)
+}
+
+// BroadcastEvent queues the event for delivery to all clients after the current tick
+func (engine *Engine) BroadcastEvent(event Event) error {
+	return engine.sendEvent(event, nil)
+}
+
+func (engine *Engine) sendEvent(event Event, clientIDs map[string]bool) error {
+	if engine.queueEvent == nil {
+		return errEngineWithoutRoom
+	}
+	message, err := eventMessage(event)
+	if err != nil {
+		return err
+	}
+	return engine.queueEvent(message, clientIDs)
+}
+
+func eventMessage(event Event) ([]byte, error) {
+	eventBytes, err := event.MarshalJSON()
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling event: %s", err)
+	}
+
+	eventMsg := Message{
+		Kind:    event.kind(),
+		Content: eventBytes,
+	}
+	message, err := eventMsg.MarshalJSON()
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling event message: %s", err)
+	}
+	return message, nil
+}
+
+// queueEvent is safe for concurrent use so events can be
+// sent from actions, side effects and other goroutines alike
+func (r *Room) queueEvent(event Event, clientIDs map[string]bool) error {
+	message, err := eventMessage(event)
+	if err != nil {
+		return err
+	}
+	return r.queueEventMessage(message, clientIDs)
+}
+
+func (r *Room) queueEventMessage(message []byte, clientIDs map[string]bool) error {
+	select {
+	case r.eventsChannel <- eventDelivery{message, clientIDs}:
+		return nil
+	default:
+		r.metrics.messageDropped("events")
+		return errEventQueueFull
+	}
+}
+
+// handlePendingEvents is called at the end of each tick so clients
+// receive the events after the state they refer to
+func (r *Room) handlePendingEvents() {
+	for client := range r.clients {
+		r.sendPendingEvents(client)
+	}
+Exit:
+	for {
+		select {
+		case delivery := <-r.eventsChannel:
+			if delivery.clientIDs == nil {
+				r.recordFrame(delayedFrame{message: delivery.message})
+			}
+			for client := range r.clients {
+				if delivery.clientIDs != nil && !delivery.clientIDs[client.identity.ClientID] {
+					continue
+				}
+				// events must not overtake the ones still pending
+				if len(client.pendingEvents) > 0 {
+					r.queuePendingEvent(client, delivery.message)
+					continue
+				}
+				r.sendMessage(client, delivery.message)
+			}
+			// clients which did not receive the current state yet
+			// get the event right after receiving it
+			for client := range r.incomingClients {
+				if delivery.clientIDs != nil && !delivery.clientIDs[client.identity.ClientID] {
+					continue
+				}
+				r.queuePendingEvent(client, delivery.message)
+			}
+			// delayed spectators receive broadcasts through the spectator stream
+			if r.spectators == nil {
+				continue
+			}
+			for client := range r.spectators.clients {
+				if delivery.clientIDs[client.identity.ClientID] {
+					r.sendMessage(client, delivery.message)
+				}
+			}
+		default:
+			break Exit
+		}
+	}
+}
+
+// queuePendingEvent holds at most as many
+// events per client as the room can queue
+func (r *Room) queuePendingEvent(client *Client, message []byte) {
+	if len(client.pendingEvents) >= r.backpressure.RoomBufferSize {
+		r.log(LogLevelWarn, "client's pending events full -> dropping event", LogField{"client", client.id})
+		atomic.AddUint64(&r.backpressureMetrics.ResponsesDropped, 1)
+		return
+	}
+	client.pendingEvents = append(client.pendingEvents, message)
+}
+
+// sendPendingEvents sends as many pending events as fit into the
+// client's buffer, the others are sent after the following ticks
+func (r *Room) sendPendingEvents(client *Client) {
+	for len(client.pendingEvents) > 0 {
+		select {
+		case client.messageChannel <- client.pendingEvents[0]:
+			client.pendingEvents = client.pendingEvents[1:]
+		default:
+			return
+		}
+	}
+	client.pendingEvents = nil
+}
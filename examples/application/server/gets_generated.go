@@ -9,6 +9,8 @@ const (
 	MessageKindAction_addItemToPlayer MessageKind = "addItemToPlayer"
 	MessageKindAction_movePlayer      MessageKind = "movePlayer"
 	MessageKindAction_spawnZoneItems  MessageKind = "spawnZoneItems"
+	MessageKindEvent_chatMessage      MessageKind = "chatMessage"
+	MessageKindEvent_matchEnded       MessageKind = "matchEnded"
 )
 
 type MovePlayerParams struct {
@@ -34,15 +36,34 @@ type SpawnZoneItemsResponse struct {
 	NewZoneItemPaths []string `json:"newZoneItemPaths"`
 }
 
+type ChatMessageEvent struct {
+	Author PlayerID `json:"author"`
+	Text   string   `json:"text"`
+}
+
+func (e ChatMessageEvent) kind() MessageKind {
+	return MessageKindEvent_chatMessage
+}
+
+type MatchEndedEvent struct {
+	Winners []PlayerID `json:"winners"`
+}
+
+func (e MatchEndedEvent) kind() MessageKind {
+	return MessageKindEvent_matchEnded
+}
+
 type Actions struct {
-	AddItemToPlayer func(AddItemToPlayerParams, *Engine) AddItemToPlayerResponse
-	MovePlayer      func(MovePlayerParams, *Engine)
-	SpawnZoneItems  func(SpawnZoneItemsParams, *Engine) SpawnZoneItemsResponse
+	AddItemToPlayer func(AddItemToPlayerParams, *Engine, Identity) AddItemToPlayerResponse
+	MovePlayer      func(MovePlayerParams, *Engine, Identity)
+	SpawnZoneItems  func(SpawnZoneItemsParams, *Engine, Identity) SpawnZoneItemsResponse
 }
 
 type SideEffects struct {
-	OnDeploy    func(*Engine)
-	OnFrameTick func(*Engine)
+	OnClientConnect func(*Engine, Identity)
+	OnDeploy        func(*Engine)
+	OnFrameTick     func(*Engine, TickInfo)
+	OnShutdown      func(*Engine)
 }
 
 func (r *Room) processClientMessage(msg Message) (Message, error) {
@@ -56,7 +77,7 @@ func (r *Room) processClientMessage(msg Message) (Message, error) {
 		if err != nil {
 			return Message{MessageKindError, messageUnmarshallingError(msg.Content, err), msg.client}, err
 		}
-		res := r.actions.AddItemToPlayer(params, r.state)
+		res := r.actions.AddItemToPlayer(params, r.state, msg.client.identity)
 		resContent, err := res.MarshalJSON()
 		if err != nil {
 			return Message{MessageKindError, responseMarshallingError(msg.Content, err), msg.client}, err
@@ -71,7 +92,7 @@ func (r *Room) processClientMessage(msg Message) (Message, error) {
 		if err != nil {
 			return Message{MessageKindError, messageUnmarshallingError(msg.Content, err), msg.client}, err
 		}
-		r.actions.MovePlayer(params, r.state)
+		r.actions.MovePlayer(params, r.state, msg.client.identity)
 		return Message{}, nil
 	case MessageKindAction_spawnZoneItems:
 		if r.actions.SpawnZoneItems == nil {
@@ -82,7 +103,7 @@ func (r *Room) processClientMessage(msg Message) (Message, error) {
 		if err != nil {
 			return Message{MessageKindError, messageUnmarshallingError(msg.Content, err), msg.client}, err
 		}
-		res := r.actions.SpawnZoneItems(params, r.state)
+		res := r.actions.SpawnZoneItems(params, r.state, msg.client.identity)
 		resContent, err := res.MarshalJSON()
 		if err != nil {
 			return Message{MessageKindError, responseMarshallingError(msg.Content, err), msg.client}, err
@@ -95,6 +116,24 @@ func (r *Room) processClientMessage(msg Message) (Message, error) {
 	return Message{}, nil
 }
 
+var actionKinds = []MessageKind{
+	MessageKindAction_addItemToPlayer,
+	MessageKindAction_movePlayer,
+	MessageKindAction_spawnZoneItems,
+}
+
+func (r *Room) elementCounts() map[ElementKind]int {
+	return map[ElementKind]int{
+		ElementKindEquipmentSet: len(r.state.State.EquipmentSet),
+		ElementKindGearScore:    len(r.state.State.GearScore),
+		ElementKindItem:         len(r.state.State.Item),
+		ElementKindPlayer:       len(r.state.State.Player),
+		ElementKindPosition:     len(r.state.State.Position),
+		ElementKindZone:         len(r.state.State.Zone),
+		ElementKindZoneItem:     len(r.state.State.ZoneItem),
+	}
+}
+
 func inspectHandler(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Access-Control-Allow-Origin", "*")
 
@@ -105,14 +144,15 @@ func inspectHandler(w http.ResponseWriter, r *http.Request) {
       "equipmentSets": "[]*equipmentSet",
       "gearScore": "gearScore",
       "position": "position",
-      "guildMembers": "[]*player",
+      "guildMembers": "set<*player>",
       "target": "*anyOf<player,zoneItem>",
       "targetedBy": "[]*anyOf<player,zoneItem>"
     },
     "zone": {
       "items": "[]zoneItem",
       "players": "[]player",
-      "tags": "[]string",
+      "boss": "?player",
+      "tags": "set<string>",
       "interactables": "[]anyOf<item,player,zoneItem>"
     },
     "zoneItem": {
@@ -135,6 +175,10 @@ func inspectHandler(w http.ResponseWriter, r *http.Request) {
     },
     "equipmentSet": {
       "name": "string",
+      "createdAt": "time",
+      "cooldown": "duration",
+      "icon": "bytes",
+      "externalID": "bigID",
       "equipment": "[]*item"
     }
   },
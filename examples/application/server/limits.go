@@ -0,0 +1,79 @@
+package state
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+// the websocket library's default read limit in bytes
+const defaultReadLimit = 32768
+
+// ClientLimits restricts what a single client can send to the server.
+// Every limit with a zero value is disabled.
+// easyjson:skip
+type ClientLimits struct {
+	// ActionsPerSecond is the rate at which a client may send actions
+	ActionsPerSecond float64
+	// ActionBurst is how many actions a client may send at once,
+	// defaults to ActionsPerSecond rounded up
+	ActionBurst int
+	// MaxMessageBytes is the maximum size of a single message
+	MaxMessageBytes int
+	// MaxPendingActions is the maximum number of actions
+	// processed for a single client within one tick
+	MaxPendingActions int
+	// MaxViolations is how many violations of the above limits
+	// a client may commit before it is disconnected
+	MaxViolations int
+}
+
+// readLimit is the read limit of connections, it stays above MaxMessageBytes so oversized
+// messages are read and reported, only messages beyond it close the connection
+func (l ClientLimits) readLimit() int {
+	if l.MaxMessageBytes < defaultReadLimit {
+		return defaultReadLimit
+	}
+	return l.MaxMessageBytes + defaultReadLimit
+}
+
+// tokenBucket is safe for concurrent use, as actions
+// sent via POST /action are handled by multiple goroutines
+// easyjson:skip
+type tokenBucket struct {
+	mu         sync.Mutex
+	tokens     float64
+	capacity   float64
+	refillRate float64
+	lastRefill time.Time
+}
+
+func newTokenBucket(refillRate float64, capacity int) *tokenBucket {
+	if capacity < 1 {
+		capacity = int(math.Ceil(refillRate))
+	}
+	return &tokenBucket{
+		tokens:     float64(capacity),
+		capacity:   float64(capacity),
+		refillRate: refillRate,
+		lastRefill: time.Now(),
+	}
+}
+
+func (b *tokenBucket) take() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	now := time.Now()
+	b.tokens = math.Min(b.capacity, b.tokens+now.Sub(b.lastRefill).Seconds()*b.refillRate)
+	b.lastRefill = now
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+func clientLimitViolationError(violation string) []byte {
+	return []byte(fmt.Sprintf("client limit violated: %s", violation))
+}
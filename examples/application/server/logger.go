@@ -0,0 +1,102 @@
+package state
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"strings"
+)
+
+type LogLevel int
+
+const (
+	LogLevelDebug LogLevel = iota
+	LogLevelInfo
+	LogLevelWarn
+	LogLevelError
+)
+
+func (l LogLevel) String() string {
+	switch l {
+	case LogLevelDebug:
+		return "DEBUG"
+	case LogLevelInfo:
+		return "INFO"
+	case LogLevelWarn:
+		return "WARN"
+	case LogLevelError:
+		return "ERROR"
+	default:
+		return fmt.Sprintf("LEVEL(%d)", int(l))
+	}
+}
+
+// LogField adds structured context like the client's ID to a log entry
+type LogField struct {
+	Key   string
+	Value interface{}
+}
+
+// Logger receives all log entries of the server
+type Logger interface {
+	Log(level LogLevel, msg string, fields ...LogField)
+}
+
+// easyjson:skip
+type stdLogger struct {
+	logger   *log.Logger
+	minLevel LogLevel
+}
+
+// NewStdLogger writes all entries with at least the given level as lines
+// like `2021/01/02 15:04:05 INFO unregistering client room=... client=...`
+func NewStdLogger(w io.Writer, minLevel LogLevel) Logger {
+	return &stdLogger{
+		logger:   log.New(w, "", log.LstdFlags),
+		minLevel: minLevel,
+	}
+}
+
+func (l *stdLogger) Log(level LogLevel, msg string, fields ...LogField) {
+	if level < l.minLevel {
+		return
+	}
+	var line strings.Builder
+	line.WriteString(level.String())
+	line.WriteString(" ")
+	line.WriteString(msg)
+	for _, field := range fields {
+		fmt.Fprintf(&line, " %s=%v", field.Key, field.Value)
+	}
+	l.logger.Println(line.String())
+}
+
+type nopLogger struct{}
+
+func (nopLogger) Log(LogLevel, string, ...LogField) {}
+
+// NopLogger discards all entries, e.g. to keep the output of tests clean
+func NopLogger() Logger {
+	return nopLogger{}
+}
+
+// easyjson:skip
+type fieldLogger struct {
+	logger Logger
+	fields []LogField
+}
+
+// withFields returns a logger which adds the fields to every entry
+func withFields(logger Logger, fields ...LogField) Logger {
+	return &fieldLogger{
+		logger: logger,
+		fields: fields,
+	}
+}
+
+func (l *fieldLogger) Log(level LogLevel, msg string, fields ...LogField) {
+	allFields := make([]LogField, 0, len(l.fields)+len(fields))
+	allFields = append(allFields, l.fields...)
+	allFields = append(allFields, fields...)
+	l.logger.Log(level, msg, allFields...)
+}
@@ -2,7 +2,6 @@ package state
 
 import (
 	"fmt"
-	"log"
 	"net/http"
 
 	"nhooyr.io/websocket"
@@ -12,54 +11,88 @@ func homePageHandler(w http.ResponseWriter, r *http.Request) {
 	fmt.Fprintf(w, "Home Page")
 }
 
-func wsEndpoint(w http.ResponseWriter, r *http.Request, room *Room) {
-	log.SetFlags(log.LstdFlags | log.Lshortfile)
-	websocketConnection, err := websocket.Accept(w, r, &websocket.AcceptOptions{InsecureSkipVerify: true})
+func (s *Server) wsEndpoint(w http.ResponseWriter, r *http.Request) {
+	room := s.room
+	select {
+	case <-room.shutdownChannel:
+		http.Error(w, "server is shutting down", http.StatusServiceUnavailable)
+		return
+	default:
+	}
+
+	identity, ok := s.authenticate(w, r)
+	if !ok || !applyMode(w, r, &identity) {
+		return
+	}
+
+	websocketConnection, err := websocket.Accept(w, r, s.acceptOptions())
 	if err != nil {
-		log.Println(err)
+		room.logger.Log(LogLevelWarn, "error accepting websocket connection", LogField{"error", err})
 		return
 	}
+	// oversized messages need to be read for the violation to be reported,
+	// messages exceeding the connection's read limit close the connection
+	if readLimit := s.options.ClientLimits.readLimit(); readLimit > defaultReadLimit {
+		websocketConnection.SetReadLimit(int64(readLimit))
+	}
 
-	c, err := newClient(NewConnection(websocketConnection, r))
+	_, err = s.Connect(NewConnection(websocketConnection, r), identity)
 	if err != nil {
-		log.Println(err)
+		room.logger.Log(LogLevelWarn, "error connecting client", LogField{"error", err})
 		return
 	}
-	c.assignToRoom(room)
-	room.registerChannel <- c
 
-	go c.runReadMessages()
-	go c.runWriteMessages()
+	// wait until client disconnects or server shuts down
+	select {
+	case <-r.Context().Done():
+	case <-room.doneChannel:
+	}
+}
+
+// authenticate responds with an error if the request could not be authenticated
+func (s *Server) authenticate(w http.ResponseWriter, r *http.Request) (Identity, bool) {
+	if s.options.Authenticate == nil {
+		return Identity{}, true
+	}
+	identity, err := s.options.Authenticate(r)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("authentication failed: %s", err), http.StatusUnauthorized)
+		return Identity{}, false
+	}
+	return identity, true
+}
 
-	// wait until client disconnects
-	<-r.Context().Done()
+func (s *Server) acceptOptions() *websocket.AcceptOptions {
+	if len(s.options.AllowedOrigins) == 0 {
+		return &websocket.AcceptOptions{InsecureSkipVerify: true}
+	}
+	return &websocket.AcceptOptions{OriginPatterns: s.options.AllowedOrigins}
 }
 
-func setupRoutes(actions Actions, sideEffects SideEffects, fps int) {
-	room := newRoom(actions, sideEffects, fps)
-	room.Deploy()
+func (s *Server) setupRoutes() *http.ServeMux {
+	handler := http.NewServeMux()
 
-	http.HandleFunc("/", homePageHandler)
-	http.HandleFunc("/inspect", inspectHandler)
-	http.HandleFunc("/ws", func(w http.ResponseWriter, r *http.Request) { wsEndpoint(w, r, room) })
-	http.HandleFunc("/state", func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Access-Control-Allow-Origin", "*")
-		tree := room.state.assembleTree(true)
-		stateBytes, err := tree.MarshalJSON()
-		if err != nil {
-			http.Error(w, "Error marshalling tree", 500)
-		}
-		fmt.Fprint(w, string(stateBytes))
-	})
+	handler.HandleFunc("/", homePageHandler)
+	handler.HandleFunc("/inspect", inspectHandler)
+	handler.HandleFunc("/ws", s.wsEndpoint)
+	handler.HandleFunc("/sse", s.sseEndpoint)
+	handler.HandleFunc("/action", s.actionEndpoint)
+	handler.HandleFunc("/metrics", s.metricsHandler)
+	if s.options.AdminAuthenticate != nil {
+		handler.Handle("/admin/", s.adminHandler())
+	}
+	handler.HandleFunc("/state", s.stateEndpoint)
+
+	return handler
 }
 
 func Start(actions Actions, sideEffects SideEffects, fps int, port int) error {
-	if fps < 1 {
-		setupRoutes(actions, sideEffects, 1)
-	} else {
-		setupRoutes(actions, sideEffects, fps)
-	}
+	server := NewServer(Options{
+		Actions:     actions,
+		SideEffects: sideEffects,
+		FPS:         fps,
+	})
 	fmt.Printf("backent running on port %d\n", port)
-	err := http.ListenAndServe(fmt.Sprintf(":%d", port), nil)
+	err := http.ListenAndServe(fmt.Sprintf(":%d", port), server)
 	return err
 }
@@ -0,0 +1,115 @@
+package state
+
+import (
+	"errors"
+	"sync"
+
+	"nhooyr.io/websocket"
+)
+
+var errConnectionClosed = errors.New("connection closed")
+
+// memoryPipe is shared by both ends of a MemoryConnector pair
+// easyjson:skip
+type memoryPipe struct {
+	mu          sync.Mutex
+	closed      bool
+	closeCode   websocket.StatusCode
+	closeReason string
+	// closedChannel wakes up blocked readers once the pipe is closed
+	closedChannel chan struct{}
+}
+
+// MemoryConnector is an in-memory Connector,
+// messages written to one end of a pair can be read from the other
+// easyjson:skip
+type MemoryConnector struct {
+	pipe *memoryPipe
+	peer *MemoryConnector
+	mu   sync.Mutex
+	// inbox is unbounded so writes never block
+	inbox [][]byte
+	// notify receives a value whenever the inbox is filled
+	notify chan struct{}
+}
+
+// NewMemoryConnectorPair returns two connected ends,
+// e.g. one for the server's client and one for the test
+func NewMemoryConnectorPair() (*MemoryConnector, *MemoryConnector) {
+	pipe := &memoryPipe{closedChannel: make(chan struct{})}
+	a := &MemoryConnector{pipe: pipe, notify: make(chan struct{}, 1)}
+	b := &MemoryConnector{pipe: pipe, notify: make(chan struct{}, 1)}
+	a.peer = b
+	b.peer = a
+	return a, b
+}
+
+// Close closes both ends of the pair
+func (c *MemoryConnector) Close(code websocket.StatusCode, reason string) {
+	c.pipe.mu.Lock()
+	defer c.pipe.mu.Unlock()
+	if c.pipe.closed {
+		return
+	}
+	c.pipe.closed = true
+	c.pipe.closeCode = code
+	c.pipe.closeReason = reason
+	close(c.pipe.closedChannel)
+}
+
+// CloseStatus returns the status the pair was closed with and whether it is closed
+func (c *MemoryConnector) CloseStatus() (websocket.StatusCode, string, bool) {
+	c.pipe.mu.Lock()
+	defer c.pipe.mu.Unlock()
+	return c.pipe.closeCode, c.pipe.closeReason, c.pipe.closed
+}
+
+func (c *MemoryConnector) isClosed() bool {
+	_, _, closed := c.CloseStatus()
+	return closed
+}
+
+// ReadMessage blocks until a message is available or the pair is closed
+func (c *MemoryConnector) ReadMessage() (int, []byte, error) {
+	for {
+		if msg, ok := c.tryRead(); ok {
+			return int(websocket.MessageText), msg, nil
+		}
+		select {
+		case <-c.notify:
+		case <-c.pipe.closedChannel:
+			// messages written before closing can still be read
+			if msg, ok := c.tryRead(); ok {
+				return int(websocket.MessageText), msg, nil
+			}
+			return 0, nil, errConnectionClosed
+		}
+	}
+}
+
+// tryRead returns the next message without blocking
+func (c *MemoryConnector) tryRead() ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.inbox) == 0 {
+		return nil, false
+	}
+	msg := c.inbox[0]
+	c.inbox = c.inbox[1:]
+	return msg, true
+}
+
+func (c *MemoryConnector) WriteMessage(msg []byte) error {
+	if c.isClosed() {
+		return errConnectionClosed
+	}
+	peer := c.peer
+	peer.mu.Lock()
+	peer.inbox = append(peer.inbox, msg)
+	peer.mu.Unlock()
+	select {
+	case peer.notify <- struct{}{}:
+	default:
+	}
+	return nil
+}
@@ -0,0 +1,200 @@
+package state
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+var durationBuckets = []float64{0.0001, 0.0005, 0.001, 0.0025, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1}
+var byteBuckets = []float64{64, 256, 1024, 4096, 16384, 65536, 262144, 1048576}
+
+// easyjson:skip
+type histogram struct {
+	buckets []float64
+	counts  []uint64
+	sum     float64
+	count   uint64
+}
+
+func newHistogram(buckets []float64) *histogram {
+	return &histogram{
+		buckets: buckets,
+		counts:  make([]uint64, len(buckets)),
+	}
+}
+
+func (h *histogram) observe(value float64) {
+	for i, upperBound := range h.buckets {
+		if value <= upperBound {
+			h.counts[i]++
+		}
+	}
+	h.sum += value
+	h.count++
+}
+
+func (h *histogram) write(w io.Writer, name, labels string) {
+	labelPrefix := labels
+	if labelPrefix != "" {
+		labelPrefix += ","
+	}
+	for i, upperBound := range h.buckets {
+		fmt.Fprintf(w, "%s_bucket{%sle=\"%s\"} %d\n", name, labelPrefix, formatFloat(upperBound), h.counts[i])
+	}
+	fmt.Fprintf(w, "%s_bucket{%sle=\"+Inf\"} %d\n", name, labelPrefix, h.count)
+	fmt.Fprintf(w, "%s_sum%s %s\n", name, wrapLabels(labels), formatFloat(h.sum))
+	fmt.Fprintf(w, "%s_count%s %d\n", name, wrapLabels(labels), h.count)
+}
+
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+func wrapLabels(labels string) string {
+	if labels == "" {
+		return ""
+	}
+	return "{" + labels + "}"
+}
+
+func writeMetricHeader(w io.Writer, name, metricType, help string) {
+	fmt.Fprintf(w, "# HELP %s %s\n", name, help)
+	fmt.Fprintf(w, "# TYPE %s %s\n", name, metricType)
+}
+
+// serverMetrics is written by the room and read by the /metrics endpoint
+// easyjson:skip
+type serverMetrics struct {
+	mu                   sync.Mutex
+	tickDuration         *histogram
+	assembleTreeDuration *histogram
+	updateStateDuration  *histogram
+	patchBytes           *histogram
+	connectedClients     int
+	incomingClients      int
+	messagesDropped      map[string]uint64
+	actionCounts         map[MessageKind]uint64
+	actionDurations      map[MessageKind]*histogram
+	elementCounts        map[ElementKind]int
+}
+
+func newServerMetrics() *serverMetrics {
+	m := serverMetrics{
+		tickDuration:         newHistogram(durationBuckets),
+		assembleTreeDuration: newHistogram(durationBuckets),
+		updateStateDuration:  newHistogram(durationBuckets),
+		patchBytes:           newHistogram(byteBuckets),
+		messagesDropped:      make(map[string]uint64),
+		actionCounts:         make(map[MessageKind]uint64),
+		actionDurations:      make(map[MessageKind]*histogram),
+	}
+	// only known actions are tracked so clients cannot create arbitrary labels
+	for _, kind := range actionKinds {
+		m.actionDurations[kind] = newHistogram(durationBuckets)
+	}
+	return &m
+}
+
+func (m *serverMetrics) observeDuration(h *histogram, duration time.Duration) {
+	m.mu.Lock()
+	h.observe(duration.Seconds())
+	m.mu.Unlock()
+}
+
+func (m *serverMetrics) observePatch(patchBytes []byte) {
+	m.mu.Lock()
+	m.patchBytes.observe(float64(len(patchBytes)))
+	m.mu.Unlock()
+}
+
+func (m *serverMetrics) observeAction(kind MessageKind, duration time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	h, ok := m.actionDurations[kind]
+	if !ok {
+		return
+	}
+	m.actionCounts[kind]++
+	h.observe(duration.Seconds())
+}
+
+// messageDropped counts messages which did not fit into one of the room's buffers
+func (m *serverMetrics) messageDropped(buffer string) {
+	m.mu.Lock()
+	m.messagesDropped[buffer]++
+	m.mu.Unlock()
+}
+
+func (m *serverMetrics) setRoomStatus(connectedClients, incomingClients int, elementCounts map[ElementKind]int) {
+	m.mu.Lock()
+	m.connectedClients = connectedClients
+	m.incomingClients = incomingClients
+	m.elementCounts = elementCounts
+	m.mu.Unlock()
+}
+
+func (m *serverMetrics) write(w io.Writer, backpressureMetrics BackpressureMetrics) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	writeMetricHeader(w, "backent_tick_duration_seconds", "histogram", "Time spent processing a tick.")
+	m.tickDuration.write(w, "backent_tick_duration_seconds", "")
+	writeMetricHeader(w, "backent_assemble_tree_duration_seconds", "histogram", "Time spent assembling trees.")
+	m.assembleTreeDuration.write(w, "backent_assemble_tree_duration_seconds", "")
+	writeMetricHeader(w, "backent_update_state_duration_seconds", "histogram", "Time spent applying the patch to the state.")
+	m.updateStateDuration.write(w, "backent_update_state_duration_seconds", "")
+	writeMetricHeader(w, "backent_patch_bytes", "histogram", "Size of published patches in bytes.")
+	m.patchBytes.write(w, "backent_patch_bytes", "")
+
+	writeMetricHeader(w, "backent_connected_clients", "gauge", "Number of connected clients.")
+	fmt.Fprintf(w, "backent_connected_clients %d\n", m.connectedClients)
+	writeMetricHeader(w, "backent_incoming_clients", "gauge", "Number of clients waiting for the current state.")
+	fmt.Fprintf(w, "backent_incoming_clients %d\n", m.incomingClients)
+
+	writeMetricHeader(w, "backent_messages_dropped_total", "counter", "Messages dropped due to a full room buffer.")
+	for _, buffer := range []string{"room", "pending_responses", "events"} {
+		fmt.Fprintf(w, "backent_messages_dropped_total{buffer=%q} %d\n", buffer, m.messagesDropped[buffer])
+	}
+	writeMetricHeader(w, "backent_clients_dropped_total", "counter", "Clients disconnected due to a full buffer.")
+	fmt.Fprintf(w, "backent_clients_dropped_total %d\n", backpressureMetrics.ClientsDropped)
+	writeMetricHeader(w, "backent_patches_coalesced_total", "counter", "Patches coalesced for slow clients.")
+	fmt.Fprintf(w, "backent_patches_coalesced_total %d\n", backpressureMetrics.PatchesCoalesced)
+	writeMetricHeader(w, "backent_patches_skipped_total", "counter", "Patches skipped for clients awaiting a resync.")
+	fmt.Fprintf(w, "backent_patches_skipped_total %d\n", backpressureMetrics.PatchesSkipped)
+	writeMetricHeader(w, "backent_clients_resynced_total", "counter", "Complete states sent to clients which missed patches.")
+	fmt.Fprintf(w, "backent_clients_resynced_total %d\n", backpressureMetrics.ClientsResynced)
+	writeMetricHeader(w, "backent_responses_dropped_total", "counter", "Responses and events dropped due to a full client buffer.")
+	fmt.Fprintf(w, "backent_responses_dropped_total %d\n", backpressureMetrics.ResponsesDropped)
+
+	writeMetricHeader(w, "backent_actions_total", "counter", "Number of processed actions.")
+	for _, kind := range actionKinds {
+		fmt.Fprintf(w, "backent_actions_total{action=%q} %d\n", kind, m.actionCounts[kind])
+	}
+	writeMetricHeader(w, "backent_action_duration_seconds", "histogram", "Time spent processing actions.")
+	for _, kind := range actionKinds {
+		m.actionDurations[kind].write(w, "backent_action_duration_seconds", fmt.Sprintf("action=%q", kind))
+	}
+
+	writeMetricHeader(w, "backent_elements", "gauge", "Number of elements in the state.")
+	elementKinds := make([]string, 0, len(m.elementCounts))
+	for kind := range m.elementCounts {
+		elementKinds = append(elementKinds, string(kind))
+	}
+	sort.Strings(elementKinds)
+	for _, kind := range elementKinds {
+		fmt.Fprintf(w, "backent_elements{kind=%q} %d\n", kind, m.elementCounts[ElementKind(kind)])
+	}
+}
+
+func (s *Server) metricsHandler(w http.ResponseWriter, r *http.Request) {
+	var buf bytes.Buffer
+	s.room.metrics.write(&buf, s.BackpressureMetrics())
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	w.Write(buf.Bytes())
+}
@@ -2,14 +2,19 @@ package state
 
 import (
 	"fmt"
-	"log"
+	"sync"
+	"sync/atomic"
 	"time"
+
+	"github.com/google/uuid"
 )
 
+// easyjson:skip
 type Room struct {
 	clients                 map[*Client]bool
 	clientMessageChannel    chan Message
 	pendingResponsesChannel chan Message
+	eventsChannel           chan eventDelivery
 	registerChannel         chan *Client
 	unregisterChannel       chan *Client
 	incomingClients         map[*Client]bool
@@ -17,25 +22,90 @@ type Room struct {
 	actions                 Actions
 	sideEffects             SideEffects
 	fps                     int
+	limits                  ClientLimits
+	backpressure            BackpressureOptions
+	backpressureMetrics     *BackpressureMetrics
+	metrics                 *serverMetrics
+	logger                  Logger
+	scheduler               *Scheduler
+	spectators              *spectatorStream
+	clock                   Clock
+	timestep                TimestepOptions
+	timer                   Timer
+	tick                    int
+	paused                  bool
+	adminChannel            chan func()
+	shutdownChannel         chan struct{}
+	shutdownOnce            sync.Once
+	doneChannel             chan struct{}
+	connectedClients        sync.WaitGroup
 }
 
-func newRoom(a Actions, sideEffects SideEffects, fps int) *Room {
-	return &Room{
+func newRoom(options Options) *Room {
+	state := newEngine()
+	if options.StrictMode {
+		state.EnableStrictMode()
+	}
+	room := Room{
+		logger:                  withFields(options.Logger, LogField{"room", uuid.New().String()}),
 		clients:                 make(map[*Client]bool),
-		clientMessageChannel:    make(chan Message, 1024),
-		pendingResponsesChannel: make(chan Message, 1024),
+		clientMessageChannel:    make(chan Message, options.Backpressure.RoomBufferSize),
+		pendingResponsesChannel: make(chan Message, options.Backpressure.RoomBufferSize),
+		eventsChannel:           make(chan eventDelivery, options.Backpressure.RoomBufferSize),
 		unregisterChannel:       make(chan *Client),
 		registerChannel:         make(chan *Client),
 		incomingClients:         make(map[*Client]bool),
-		state:                   newEngine(),
-		sideEffects:             sideEffects,
-		actions:                 a,
-		fps:                     fps,
+		state:                   state,
+		sideEffects:             options.SideEffects,
+		actions:                 options.Actions,
+		fps:                     options.FPS,
+		limits:                  options.ClientLimits,
+		backpressure:            options.Backpressure,
+		backpressureMetrics:     &BackpressureMetrics{},
+		metrics:                 newServerMetrics(),
+		scheduler:               newScheduler(options.Clock.Now()),
+		spectators:              newSpectatorStream(options.SpectatorDelay),
+		clock:                   options.Clock,
+		timestep:                options.Timestep,
+		adminChannel:            make(chan func()),
+		shutdownChannel:         make(chan struct{}),
+		doneChannel:             make(chan struct{}),
 	}
+	// actions and side effects send events through the engine
+	state.queueEvent = room.queueEventMessage
+	return &room
+}
+
+// runOnRoom runs fn on the room's goroutine so it can safely access
+// the clients and the state, it returns false if the room is shut down
+func (r *Room) runOnRoom(fn func()) bool {
+	done := make(chan struct{})
+	select {
+	case r.adminChannel <- func() {
+		fn()
+		close(done)
+	}:
+	case <-r.doneChannel:
+		return false
+	}
+	<-done
+	return true
+}
+
+// log adds the current tick to the entry
+func (r *Room) log(level LogLevel, msg string, fields ...LogField) {
+	r.logger.Log(level, msg, append(fields, LogField{"tick", r.tick})...)
 }
 
 func (r *Room) registerClient(client *Client) {
-	r.incomingClients[client] = true
+	if r.isDelayedSpectator(client) {
+		r.spectators.incoming[client] = true
+	} else {
+		r.incomingClients[client] = true
+	}
+	if r.sideEffects.OnClientConnect != nil {
+		r.sideEffects.OnClientConnect(r.state, client.identity)
+	}
 }
 
 func (r *Room) promoteIncomingClient(client *Client) {
@@ -45,33 +115,122 @@ func (r *Room) promoteIncomingClient(client *Client) {
 
 func (r *Room) unregisterClient(client *Client) {
 	if _, ok := r.clients[client]; ok {
-		log.Printf("unregistering client %s", client.id)
+		r.log(LogLevelInfo, "unregistering client", LogField{"client", client.id})
 		close(client.messageChannel)
 		delete(r.clients, client)
 	} else if _, ok := r.incomingClients[client]; ok {
-		log.Printf("unregistering incoming client %s", client.id)
+		r.log(LogLevelInfo, "unregistering incoming client", LogField{"client", client.id})
 		close(client.messageChannel)
 		delete(r.incomingClients, client)
+	} else if r.spectators.has(client) {
+		r.log(LogLevelInfo, "unregistering spectator", LogField{"client", client.id})
+		close(client.messageChannel)
+		delete(r.spectators.clients, client)
+		delete(r.spectators.incoming, client)
+	}
+}
+
+func (r *Room) dropClient(client *Client) {
+	r.log(LogLevelWarn, "client's message buffer full -> dropping client", LogField{"client", client.id})
+	atomic.AddUint64(&r.backpressureMetrics.ClientsDropped, 1)
+	r.unregisterClient(client)
+}
+
+// handleFullBuffer applies the backpressure policy to a client
+// which could not receive the patch
+func (r *Room) handleFullBuffer(client *Client, patchBytes []byte) {
+	switch r.backpressure.Policy {
+	case BackpressureCoalesce:
+		client.coalescedPatch = patchBytes
+		atomic.AddUint64(&r.backpressureMetrics.PatchesCoalesced, 1)
+	case BackpressureResync:
+		// incoming clients receive the complete current state
+		// and no patches until then
+		r.log(LogLevelWarn, "client's message buffer full -> resyncing client", LogField{"client", client.id})
+		delete(r.clients, client)
+		r.incomingClients[client] = true
+		client.awaitingResync = true
+		atomic.AddUint64(&r.backpressureMetrics.PatchesSkipped, 1)
+	default:
+		r.dropClient(client)
+	}
+}
+
+// sendCoalescedPatch merges the patch into the client's coalesced patch
+// and tries to send it, patchBytes is nil if there is no new patch
+func (r *Room) sendCoalescedPatch(client *Client, patchBytes []byte) error {
+	if patchBytes != nil {
+		mergedPatch, err := mergePatches(client.coalescedPatch, patchBytes)
+		if err != nil {
+			return err
+		}
+		client.coalescedPatch = mergedPatch
+		atomic.AddUint64(&r.backpressureMetrics.PatchesCoalesced, 1)
+	}
+
+	stateUpdateBytes, err := stateUpdateMessage(client.coalescedPatch)
+	if err != nil {
+		return err
+	}
+
+	select {
+	case client.messageChannel <- stateUpdateBytes:
+		client.coalescedPatch = nil
+	default:
 	}
+
+	return nil
 }
 
-func (r *Room) broadcastPatchToClients(stateUpdateBytes []byte) {
+func (r *Room) broadcastPatchToClients(patchBytes []byte) error {
+	var stateUpdateBytes []byte
+	if patchBytes != nil {
+		var err error
+		stateUpdateBytes, err = stateUpdateMessage(patchBytes)
+		if err != nil {
+			return err
+		}
+	}
+
 	for client := range r.clients {
+		if client.coalescedPatch != nil {
+			if err := r.sendCoalescedPatch(client, patchBytes); err != nil {
+				r.log(LogLevelError, "error coalescing patch", LogField{"client", client.id}, LogField{"error", err})
+				r.unregisterClient(client)
+			}
+			continue
+		}
+
+		if stateUpdateBytes == nil {
+			continue
+		}
+
 		select {
 		case client.messageChannel <- stateUpdateBytes:
 		default:
-			log.Printf("client's message buffer full -> dropping client %s", client.id)
-			r.unregisterClient(client)
+			r.handleFullBuffer(client, patchBytes)
 		}
 	}
+
+	if stateUpdateBytes != nil {
+		for client := range r.incomingClients {
+			if client.awaitingResync {
+				atomic.AddUint64(&r.backpressureMetrics.PatchesSkipped, 1)
+			}
+		}
+	}
+
+	return nil
 }
 
 func (r *Room) handleIncomingClients() error {
 	if len(r.incomingClients) == 0 {
 		return nil
 	}
+	assembleStart := time.Now()
 	tree := r.state.assembleTree(true)
-	stateBytes, err := tree.MarshalJSON()
+	r.metrics.observeDuration(r.metrics.assembleTreeDuration, time.Since(assembleStart))
+	stateBytes, err := r.state.marshalTree(tree)
 	if err != nil {
 		return fmt.Errorf("error marshalling tree for init request: %s", err)
 	}
@@ -89,23 +248,64 @@ func (r *Room) handleIncomingClients() error {
 		select {
 		case client.messageChannel <- response:
 			r.promoteIncomingClient(client)
+			if client.awaitingResync {
+				client.awaitingResync = false
+				atomic.AddUint64(&r.backpressureMetrics.ClientsResynced, 1)
+			}
+			r.sendPendingEvents(client)
 		default:
-			log.Printf("client's message buffer full -> dropping client %s", client.id)
-			r.unregisterClient(client)
+			// with other policies than drop the current state
+			// is sent once the buffer has space again
+			if r.backpressure.Policy == BackpressureDrop {
+				r.dropClient(client)
+			}
 		}
 	}
 
 	return nil
 }
 
-func (r *Room) processFrame() error {
+func (r *Room) isRegistered(client *Client) bool {
+	if r.spectators.has(client) {
+		return true
+	}
+	return r.clients[client] || r.incomingClients[client]
+}
+
+func (r *Room) processFrame(tickInfo TickInfo) error {
+	r.processClientMessages()
+
+	// scheduled tasks run after the actions so their changes are part of the same patch
+	r.scheduler.run(r.state, r.tick, r.clock.Now())
+
+	if r.sideEffects.OnFrameTick != nil {
+		r.sideEffects.OnFrameTick(r.state, tickInfo)
+	}
+
+	return nil
+}
+
+// processClientMessages runs the actions of all received
+// messages and queues their responses
+func (r *Room) processClientMessages() {
+	processedActions := make(map[*Client]int)
 Exit:
 	for {
 		select {
 		case msg := <-r.clientMessageChannel:
+			if r.limits.MaxPendingActions > 0 {
+				processedActions[msg.client]++
+				if processedActions[msg.client] > r.limits.MaxPendingActions {
+					msg.client.handleLimitViolation(fmt.Sprintf("more than %d actions per tick", r.limits.MaxPendingActions))
+					continue
+				}
+			}
+
+			actionStart := time.Now()
 			response, err := r.processClientMessage(msg)
+			r.metrics.observeAction(msg.Kind, time.Since(actionStart))
 			if err != nil {
-				log.Println("error processing client message:", err)
+				r.log(LogLevelWarn, "error processing client message", LogField{"client", msg.client.id}, LogField{"action", msg.Kind}, LogField{"error", err})
 			}
 			if response.client == nil {
 				continue
@@ -114,43 +314,52 @@ Exit:
 			select {
 			case r.pendingResponsesChannel <- response:
 			default:
-				log.Printf("pending responses channel full, skipping response")
+				r.log(LogLevelWarn, "pending responses channel full, skipping response", LogField{"client", msg.client.id}, LogField{"action", msg.Kind})
+				r.metrics.messageDropped("pending_responses")
 			}
 
 		default:
 			break Exit
 		}
 	}
-
-	if r.sideEffects.OnFrameTick != nil {
-		r.sideEffects.OnFrameTick(r.state)
-	}
-
-	return nil
 }
 
 func (r *Room) publishPatch() error {
+	// listeners run before assembling so their changes are part of the patch
+	r.state.emitChanges()
+	assembleStart := time.Now()
 	tree := r.state.assembleTree(false)
-	patchBytes, err := tree.MarshalJSON()
+	r.metrics.observeDuration(r.metrics.assembleTreeDuration, time.Since(assembleStart))
+	patchBytes, err := r.state.marshalTree(tree)
 	if err != nil {
 		return fmt.Errorf("error marshalling tree for patch: %s", err)
 	}
 	// TODO: if patch is empty -> find better way for evaluation
 	if len(patchBytes) == 2 {
-		return nil
+		// coalesced patches still need to be sent
+		patchBytes = nil
+	} else {
+		r.metrics.observePatch(patchBytes)
+		updateMessage, err := stateUpdateMessage(patchBytes)
+		if err != nil {
+			return err
+		}
+		r.recordFrame(delayedFrame{message: updateMessage, patch: patchBytes})
 	}
 
+	return r.broadcastPatchToClients(patchBytes)
+}
+
+func stateUpdateMessage(patchBytes []byte) ([]byte, error) {
 	stateUpdateMsg := Message{
 		Kind:    MessageKindUpdate,
 		Content: patchBytes,
 	}
 	stateUpdateBytes, err := stateUpdateMsg.MarshalJSON()
 	if err != nil {
-		return fmt.Errorf("error marshalling state update message: %s", err)
+		return nil, fmt.Errorf("error marshalling state update message: %s", err)
 	}
-
-	r.broadcastPatchToClients(stateUpdateBytes)
-	return nil
+	return stateUpdateBytes, nil
 }
 
 func (r *Room) handlePendingResponses() {
@@ -158,18 +367,18 @@ Exit:
 	for {
 		select {
 		case pendingResponse := <-r.pendingResponsesChannel:
+			// the client may have been unregistered since the response was queued
+			if !r.isRegistered(pendingResponse.client) {
+				continue
+			}
+
 			response, err := pendingResponse.MarshalJSON()
 			if err != nil {
-				log.Printf("error marshalling pending response message: %s", err)
+				r.log(LogLevelError, "error marshalling pending response message", LogField{"client", pendingResponse.client.id}, LogField{"error", err})
 				continue
 			}
 
-			select {
-			case pendingResponse.client.messageChannel <- response:
-			default:
-				log.Printf("client's message buffer full -> dropping client %s", pendingResponse.client.id)
-				r.unregisterClient(pendingResponse.client)
-			}
+			r.sendMessage(pendingResponse.client, response)
 
 		default:
 			break Exit
@@ -177,40 +386,146 @@ Exit:
 	}
 }
 
-func (r *Room) process() {
-	err := r.processFrame()
+// sendMessage sends responses and events which can
+// neither be coalesced nor resynced
+func (r *Room) sendMessage(client *Client, message []byte) {
+	select {
+	case client.messageChannel <- message:
+	default:
+		if r.backpressure.Policy == BackpressureDrop {
+			r.dropClient(client)
+			return
+		}
+		r.log(LogLevelWarn, "client's message buffer full -> dropping message", LogField{"client", client.id})
+		atomic.AddUint64(&r.backpressureMetrics.ResponsesDropped, 1)
+	}
+}
+
+// disconnectViolatingClients is called after pending responses are handled
+// so clients receive the reports of their violations before being disconnected
+func (r *Room) disconnectViolatingClients() {
+	for client := range r.clients {
+		if client.hasExceededViolations() {
+			r.unregisterClient(client)
+		}
+	}
+	for client := range r.incomingClients {
+		if client.hasExceededViolations() {
+			r.unregisterClient(client)
+		}
+	}
+	for _, client := range r.delayedSpectators() {
+		if client.hasExceededViolations() {
+			r.unregisterClient(client)
+		}
+	}
+}
+
+func (r *Room) process(tickInfo TickInfo) {
+	r.tick++
+	tickInfo.Number = r.tick
+	tickStart := time.Now()
+	defer func() {
+		r.metrics.observeDuration(r.metrics.tickDuration, time.Since(tickStart))
+		r.metrics.setRoomStatus(len(r.clients), len(r.incomingClients), r.elementCounts())
+	}()
+
+	err := r.processFrame(tickInfo)
 	r.handlePendingResponses()
+	r.disconnectViolatingClients()
 	if err != nil {
-		log.Println(err)
+		r.log(LogLevelError, err.Error())
 	}
 	err = r.publishPatch()
 	if err != nil {
-		log.Println(err)
+		r.log(LogLevelError, err.Error())
+	}
+	for _, err := range r.state.Errors() {
+		r.log(LogLevelWarn, "mutation had no effect", LogField{"error", err})
 	}
+	updateStart := time.Now()
 	r.state.UpdateState()
+	r.metrics.observeDuration(r.metrics.updateStateDuration, time.Since(updateStart))
+	err = r.recordSnapshot()
+	if err != nil {
+		r.log(LogLevelError, err.Error())
+	}
 	err = r.handleIncomingClients()
 	if err != nil {
-		log.Println(err)
+		r.log(LogLevelError, err.Error())
 	}
+	r.handlePendingEvents()
+	r.publishDelayedFrames()
 }
 
-func (r *Room) run() {
-	ticker := time.NewTicker(time.Second / time.Duration(r.fps))
+// run processes ticks by a fixed time step rather than the
+// time the previous tick finished, so slow ticks do not cause drift
+func (r *Room) run(nextTick time.Time) {
 	for {
 		select {
 		case client := <-r.registerChannel:
 			r.registerClient(client)
 		case client := <-r.unregisterChannel:
 			r.unregisterClient(client)
-		case <-ticker.C:
-			r.process()
+		case fn := <-r.adminChannel:
+			fn()
+		case <-r.timer.C():
+			if r.paused {
+				nextTick = r.clock.Now().Add(time.Second / time.Duration(r.fps))
+			} else {
+				nextTick = r.processDueTicks(nextTick)
+			}
+			r.timer.Reset(nextTick.Sub(r.clock.Now()))
+		case <-r.shutdownChannel:
+			r.timer.Stop()
+			r.shutdown()
+			return
 		}
 	}
 }
 
+// initiateShutdown signals the room to stop running,
+// it is safe to call it multiple times
+func (r *Room) initiateShutdown() {
+	r.shutdownOnce.Do(func() {
+		close(r.shutdownChannel)
+	})
+}
+
+func (r *Room) shutdown() {
+	// actions which were received before shutting down are still
+	// processed, so their clients get the responses and changes
+	r.processClientMessages()
+	r.handlePendingResponses()
+	err := r.publishPatch()
+	if err != nil {
+		r.log(LogLevelError, err.Error())
+	}
+	r.handlePendingEvents()
+	// closing the message channels makes the clients write
+	// all remaining messages before closing their connections
+	for client := range r.clients {
+		r.unregisterClient(client)
+	}
+	for client := range r.incomingClients {
+		r.unregisterClient(client)
+	}
+	for _, client := range r.delayedSpectators() {
+		r.unregisterClient(client)
+	}
+	if r.sideEffects.OnShutdown != nil {
+		r.sideEffects.OnShutdown(r.state)
+	}
+	close(r.doneChannel)
+}
+
 func (r *Room) Deploy() {
 	if r.sideEffects.OnDeploy != nil {
 		r.sideEffects.OnDeploy(r.state)
 	}
-	go r.run()
+	// the timer is created before the room runs so a manual
+	// clock can be advanced as soon as the room is deployed
+	step := time.Second / time.Duration(r.fps)
+	r.timer = r.clock.NewTimer(step)
+	go r.run(r.clock.Now().Add(step))
 }
@@ -0,0 +1,162 @@
+package state
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Task is a handle of a scheduled function
+// easyjson:skip
+type Task struct {
+	fn        func(*Engine)
+	cancelled int32
+	tickBased bool
+	// a zero interval means the task runs only once
+	dueTime      time.Time
+	interval     time.Duration
+	dueTick      int
+	tickInterval int
+}
+
+// Cancel prevents all future runs of the task,
+// it is safe to call it multiple times and from within the task itself
+func (t *Task) Cancel() {
+	atomic.StoreInt32(&t.cancelled, 1)
+}
+
+func (t *Task) isCancelled() bool {
+	return atomic.LoadInt32(&t.cancelled) == 1
+}
+
+func (t *Task) isRepeating() bool {
+	return t.interval > 0 || t.tickInterval > 0
+}
+
+func (t *Task) isDue(tick int, now time.Time) bool {
+	if t.tickBased {
+		return tick >= t.dueTick
+	}
+	return !now.Before(t.dueTime)
+}
+
+// reschedule moves the due time of repeating tasks past the current tick,
+// runs which were missed e.g. due to a low FPS are skipped
+func (t *Task) reschedule(tick int, now time.Time) {
+	if t.tickBased {
+		for t.dueTick <= tick {
+			t.dueTick += t.tickInterval
+		}
+		return
+	}
+	for !t.dueTime.After(now) {
+		t.dueTime = t.dueTime.Add(t.interval)
+	}
+}
+
+// Scheduler runs functions on the room's tick goroutine, so engine access
+// stays single-threaded and all changes end up in the tick's patch.
+// Its methods are safe for concurrent use.
+// easyjson:skip
+type Scheduler struct {
+	mu    sync.Mutex
+	tasks []*Task
+	tick  int
+	now   time.Time
+}
+
+func newScheduler(now time.Time) *Scheduler {
+	return &Scheduler{
+		now: now,
+	}
+}
+
+func (s *Scheduler) scheduleAfter(delay time.Duration, task *Task) *Task {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	task.dueTime = s.now.Add(delay)
+	s.tasks = append(s.tasks, task)
+	return task
+}
+
+func (s *Scheduler) scheduleAfterTicks(ticks int, task *Task) *Task {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	task.tickBased = true
+	task.dueTick = s.tick + ticks
+	s.tasks = append(s.tasks, task)
+	return task
+}
+
+// After runs fn once in the first tick after the duration has passed
+func (s *Scheduler) After(duration time.Duration, fn func(*Engine)) *Task {
+	return s.scheduleAfter(duration, &Task{fn: fn})
+}
+
+// Every runs fn in the first tick after each interval
+func (s *Scheduler) Every(interval time.Duration, fn func(*Engine)) *Task {
+	if interval <= 0 {
+		panic("scheduler interval must be positive")
+	}
+	return s.scheduleAfter(interval, &Task{fn: fn, interval: interval})
+}
+
+// AfterTicks runs fn once, the given number of ticks from now.
+// Unlike durations, ticks make the schedule deterministic for replays.
+func (s *Scheduler) AfterTicks(ticks int, fn func(*Engine)) *Task {
+	if ticks < 1 {
+		ticks = 1
+	}
+	return s.scheduleAfterTicks(ticks, &Task{fn: fn})
+}
+
+// EveryTicks runs fn every given number of ticks
+func (s *Scheduler) EveryTicks(ticks int, fn func(*Engine)) *Task {
+	if ticks < 1 {
+		panic("scheduler interval must be positive")
+	}
+	return s.scheduleAfterTicks(ticks, &Task{fn: fn, tickInterval: ticks})
+}
+
+// dueTasks removes all due and cancelled tasks and returns the due ones
+func (s *Scheduler) dueTasks(tick int, now time.Time) []*Task {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tick = tick
+	s.now = now
+
+	var due []*Task
+	pending := s.tasks[:0]
+	for _, task := range s.tasks {
+		if task.isCancelled() {
+			continue
+		}
+		if task.isDue(tick, now) {
+			due = append(due, task)
+			if !task.isRepeating() {
+				continue
+			}
+		}
+		pending = append(pending, task)
+	}
+	s.tasks = pending
+
+	return due
+}
+
+// run is called by the room on every tick,
+// tasks are run in the order they were scheduled
+func (s *Scheduler) run(engine *Engine, tick int, now time.Time) {
+	// tasks are run without holding the lock so they can schedule other tasks
+	for _, task := range s.dueTasks(tick, now) {
+		if task.isCancelled() {
+			continue
+		}
+		task.fn(engine)
+		if task.isRepeating() {
+			s.mu.Lock()
+			task.reschedule(tick, now)
+			s.mu.Unlock()
+		}
+	}
+}
@@ -0,0 +1,175 @@
+package state
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+var errServerShutDown = errors.New("server is shut down")
+
+// easyjson:skip
+type Options struct {
+	Actions     Actions
+	SideEffects SideEffects
+	FPS         int
+	// Authenticate is called before a websocket connection is accepted,
+	// returning an error rejects the connection
+	Authenticate func(r *http.Request) (Identity, error)
+	// AllowedOrigins holds host patterns of origins which are allowed to connect,
+	// all origins are allowed if empty
+	AllowedOrigins []string
+	ClientLimits   ClientLimits
+	Backpressure   BackpressureOptions
+	Timestep       TimestepOptions
+	// SpectatorDelay serves spectators the messages clients received
+	// the given duration ago, e.g. to prevent ghosting in tournaments
+	SpectatorDelay time.Duration
+	// Clock defaults to the system clock, a ManualClock
+	// allows stepping ticks without waiting
+	Clock Clock
+	// AdminAuthenticate is called for every request to the admin endpoints,
+	// which are only served if it is set
+	AdminAuthenticate func(r *http.Request) error
+	// Logger defaults to NewStdLogger(os.Stderr, LogLevelInfo),
+	// NopLogger() discards all entries
+	Logger Logger
+	// StrictMode makes the engine record mutations which had no effect,
+	// the recorded errors are logged as warnings at the end of each tick
+	StrictMode bool
+	// TCPWriteTimeout is how long writing a single message to a TCP client
+	// may take before the client is disconnected, defaults to 10 seconds
+	TCPWriteTimeout time.Duration
+}
+
+func (o Options) withDefaults() Options {
+	if o.FPS < 1 {
+		o.FPS = 1
+	}
+	o.Backpressure = o.Backpressure.withDefaults()
+	o.Timestep = o.Timestep.withDefaults()
+	if o.Clock == nil {
+		o.Clock = realClock{}
+	}
+	if o.Logger == nil {
+		o.Logger = NewStdLogger(os.Stderr, LogLevelInfo)
+	}
+	if o.TCPWriteTimeout <= 0 {
+		o.TCPWriteTimeout = defaultTCPWriteTimeout
+	}
+	return o
+}
+
+// Identity describes who is behind a client connection.
+// ClientID is assigned by the server, all other fields
+// can be populated by the Authenticate hook.
+type Identity struct {
+	ClientID string
+	UserID   string
+	Claims   map[string]interface{}
+	// Spectator clients receive the state but cannot send actions,
+	// it is also set by connecting with `?mode=spectate`
+	Spectator bool
+}
+
+// easyjson:skip
+type Server struct {
+	room    *Room
+	options Options
+	handler *http.ServeMux
+	// sseClients are looked up when actions are posted to /action
+	sseClients   map[string]*Client
+	sseClientsMu sync.Mutex
+}
+
+// NewServer deploys a room and returns a server which can be mounted
+// as http.Handler, e.g. `http.ListenAndServe(":3496", state.NewServer(options))`
+func NewServer(options Options) *Server {
+	options = options.withDefaults()
+
+	room := newRoom(options)
+	room.Deploy()
+
+	s := Server{
+		room:       room,
+		options:    options,
+		sseClients: make(map[string]*Client),
+	}
+	s.handler = s.setupRoutes()
+
+	return &s
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.handler.ServeHTTP(w, r)
+}
+
+// BackpressureMetrics returns how often the backpressure policies were applied
+func (s *Server) BackpressureMetrics() BackpressureMetrics {
+	return s.room.backpressureMetrics.snapshot()
+}
+
+// Connect adds a client which communicates through the connector,
+// e.g. one end of NewMemoryConnectorPair, and returns its client ID
+func (s *Server) Connect(conn Connector, identity Identity) (string, error) {
+	c, err := newClient(conn, identity)
+	if err != nil {
+		return "", fmt.Errorf("error creating client: %s", err)
+	}
+	err = s.registerClient(c)
+	if err != nil {
+		return "", err
+	}
+	return c.identity.ClientID, nil
+}
+
+// registerClient adds the client to the room and starts reading and writing its connection
+func (s *Server) registerClient(c *Client) error {
+	c.assignToRoom(s.room)
+
+	s.room.connectedClients.Add(1)
+	select {
+	case s.room.registerChannel <- c:
+	case <-s.room.doneChannel:
+		s.room.connectedClients.Done()
+		c.conn.Close(c.closeStatus())
+		return errServerShutDown
+	}
+
+	go c.runReadMessages()
+	go c.runWriteMessages()
+
+	return nil
+}
+
+// Scheduler returns the room's scheduler for delayed and repeating tasks
+func (s *Server) Scheduler() *Scheduler {
+	return s.room.scheduler
+}
+
+// Shutdown stops the room's tick loop, processes the actions which were already
+// received, delivers all pending responses and changes, closes the
+// connections of all clients and runs the OnShutdown side effect.
+// It returns the context's error if the context expires before all
+// connections are closed.
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.room.initiateShutdown()
+
+	connectionsClosed := make(chan struct{})
+	go func() {
+		<-s.room.doneChannel
+		s.room.connectedClients.Wait()
+		close(connectionsClosed)
+	}()
+
+	select {
+	case <-connectionsClosed:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
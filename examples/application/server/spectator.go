@@ -0,0 +1,214 @@
+package state
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const spectatorActionError = "spectators cannot send actions"
+
+// applyMode marks the identity as spectator for requests with `?mode=spectate`
+func applyMode(w http.ResponseWriter, r *http.Request, identity *Identity) bool {
+	switch mode := r.URL.Query().Get("mode"); mode {
+	case "":
+	case "spectate":
+		identity.Spectator = true
+	default:
+		http.Error(w, fmt.Sprintf("unknown mode \"%s\"", mode), http.StatusBadRequest)
+		return false
+	}
+	return true
+}
+
+// delayedFrame is either a message or a snapshot of the state,
+// the patch of update messages is kept for catching up spectators
+// easyjson:skip
+type delayedFrame struct {
+	at       time.Time
+	message  []byte
+	patch    []byte
+	snapshot []byte
+}
+
+// spectatorStream replays the messages clients received to spectators
+// once they are older than the delay. New spectators receive the latest
+// released snapshot followed by all released messages recorded after it.
+// easyjson:skip
+type spectatorStream struct {
+	delay        time.Duration
+	frames       []delayedFrame
+	released     int
+	lastSnapshot time.Time
+	clients      map[*Client]bool
+	incoming     map[*Client]bool
+}
+
+// newSpectatorStream returns nil unless spectators are served with a delay
+func newSpectatorStream(delay time.Duration) *spectatorStream {
+	if delay <= 0 {
+		return nil
+	}
+	return &spectatorStream{
+		delay:    delay,
+		clients:  make(map[*Client]bool),
+		incoming: make(map[*Client]bool),
+	}
+}
+
+func (stream *spectatorStream) has(client *Client) bool {
+	return stream != nil && (stream.clients[client] || stream.incoming[client])
+}
+
+func (r *Room) isDelayedSpectator(client *Client) bool {
+	return r.spectators != nil && client.identity.Spectator
+}
+
+func (r *Room) delayedSpectators() []*Client {
+	if r.spectators == nil {
+		return nil
+	}
+	clients := make([]*Client, 0, len(r.spectators.clients)+len(r.spectators.incoming))
+	for client := range r.spectators.clients {
+		clients = append(clients, client)
+	}
+	for client := range r.spectators.incoming {
+		clients = append(clients, client)
+	}
+	return clients
+}
+
+// recordFrame keeps an update or broadcast event for delayed spectators
+func (r *Room) recordFrame(frame delayedFrame) {
+	if r.spectators == nil {
+		return
+	}
+	frame.at = r.clock.Now()
+	r.spectators.frames = append(r.spectators.frames, frame)
+}
+
+// recordSnapshot is called after the state was updated, a snapshot is
+// taken once per delay so a released snapshot is always available
+func (r *Room) recordSnapshot() error {
+	if r.spectators == nil {
+		return nil
+	}
+	now := r.clock.Now()
+	if !r.spectators.lastSnapshot.IsZero() && now.Sub(r.spectators.lastSnapshot) < r.spectators.delay {
+		return nil
+	}
+
+	tree := r.state.assembleTree(true)
+	stateBytes, err := r.state.marshalTree(tree)
+	if err != nil {
+		return fmt.Errorf("error marshalling tree for spectator snapshot: %s", err)
+	}
+	snapshot, err := Message{Kind: MessageKindCurrentState, Content: stateBytes}.MarshalJSON()
+	if err != nil {
+		return fmt.Errorf("error marshalling spectator snapshot message: %s", err)
+	}
+
+	r.spectators.frames = append(r.spectators.frames, delayedFrame{at: now, snapshot: snapshot})
+	r.spectators.lastSnapshot = now
+	return nil
+}
+
+// sendDelayed drops spectators whose buffer is full regardless of the backpressure
+// policy, as coalescing or resyncing with the live state would defeat the delay
+func (r *Room) sendDelayed(client *Client, message []byte) bool {
+	select {
+	case client.messageChannel <- message:
+		return true
+	default:
+		r.dropClient(client)
+		return false
+	}
+}
+
+// catchUpMessages are the snapshot and all released patches after it merged into one
+// update by operation kind (see mergePatches), so elements created after the snapshot
+// stay created when they are updated later on. Like live clients, new spectators do
+// not receive events of the past
+func (stream *spectatorStream) catchUpMessages(snapshotIndex int) ([][]byte, error) {
+	var mergedPatch []byte
+	for _, frame := range stream.frames[snapshotIndex+1 : stream.released] {
+		if frame.patch == nil {
+			continue
+		}
+		if mergedPatch == nil {
+			mergedPatch = frame.patch
+			continue
+		}
+		var err error
+		mergedPatch, err = mergePatches(mergedPatch, frame.patch)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	messages := [][]byte{stream.frames[snapshotIndex].snapshot}
+	if mergedPatch != nil {
+		updateMessage, err := stateUpdateMessage(mergedPatch)
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, updateMessage)
+	}
+	return messages, nil
+}
+
+// publishDelayedFrames is called at the end of each tick
+func (r *Room) publishDelayedFrames() {
+	stream := r.spectators
+	if stream == nil {
+		return
+	}
+
+	cutoff := r.clock.Now().Add(-stream.delay)
+	for ; stream.released < len(stream.frames); stream.released++ {
+		frame := stream.frames[stream.released]
+		if frame.at.After(cutoff) {
+			break
+		}
+		if frame.message == nil {
+			continue
+		}
+		for client := range stream.clients {
+			r.sendDelayed(client, frame.message)
+		}
+	}
+
+	latestSnapshot := -1
+	for i := stream.released - 1; i >= 0; i-- {
+		if stream.frames[i].snapshot != nil {
+			latestSnapshot = i
+			break
+		}
+	}
+	// spectators wait until the first snapshot is released
+	if latestSnapshot == -1 {
+		return
+	}
+
+	if len(stream.incoming) > 0 {
+		catchUpMessages, err := stream.catchUpMessages(latestSnapshot)
+		if err != nil {
+			r.log(LogLevelError, err.Error())
+			return
+		}
+	Incoming:
+		for client := range stream.incoming {
+			delete(stream.incoming, client)
+			stream.clients[client] = true
+			for _, message := range catchUpMessages {
+				if !r.sendDelayed(client, message) {
+					continue Incoming
+				}
+			}
+		}
+	}
+
+	// frames before the latest released snapshot are not needed anymore
+	stream.frames = stream.frames[latestSnapshot:]
+	stream.released -= latestSnapshot
+}
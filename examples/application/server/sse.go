@@ -0,0 +1,170 @@
+package state
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sync"
+
+	"nhooyr.io/websocket"
+)
+
+// sseConnection is a write-only Connector which streams messages as
+// Server-Sent Events, clients send their actions via POST /action
+// easyjson:skip
+type sseConnection struct {
+	mu            sync.Mutex
+	w             http.ResponseWriter
+	flusher       http.Flusher
+	closed        bool
+	closedChannel chan struct{}
+}
+
+func newSSEConnection(w http.ResponseWriter, flusher http.Flusher) *sseConnection {
+	return &sseConnection{
+		w:             w,
+		flusher:       flusher,
+		closedChannel: make(chan struct{}),
+	}
+}
+
+// Close sends a "close" event, as EventSources
+// would otherwise reconnect automatically
+func (c *sseConnection) Close(code websocket.StatusCode, reason string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return
+	}
+	c.write("close", []byte(fmt.Sprintf(`{"code":%d,"reason":%q}`, code, reason)))
+	c.closed = true
+	close(c.closedChannel)
+}
+
+// ReadMessage blocks until the connection is closed
+func (c *sseConnection) ReadMessage() (int, []byte, error) {
+	<-c.closedChannel
+	return 0, nil, errConnectionClosed
+}
+
+func (c *sseConnection) WriteMessage(msg []byte) error {
+	return c.writeEvent("", msg)
+}
+
+func (c *sseConnection) writeEvent(event string, data []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return errConnectionClosed
+	}
+	c.write(event, data)
+	return nil
+}
+
+// write expects the connection to be locked,
+// data must not contain line breaks
+func (c *sseConnection) write(event string, data []byte) {
+	if event != "" {
+		fmt.Fprintf(c.w, "event: %s\n", event)
+	}
+	fmt.Fprintf(c.w, "data: %s\n\n", data)
+	c.flusher.Flush()
+}
+
+func (s *Server) sseEndpoint(w http.ResponseWriter, r *http.Request) {
+	room := s.room
+	select {
+	case <-room.shutdownChannel:
+		http.Error(w, "server is shutting down", http.StatusServiceUnavailable)
+		return
+	default:
+	}
+
+	identity, ok := s.authenticate(w, r)
+	if !ok || !applyMode(w, r, &identity) {
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming not supported", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	conn := newSSEConnection(w, flusher)
+	c, err := newClient(conn, identity)
+	if err != nil {
+		room.logger.Log(LogLevelError, "error creating client", LogField{"error", err})
+		return
+	}
+	// the client ID is sent first so the client can post actions
+	conn.writeEvent("connected", []byte(fmt.Sprintf(`{"clientID":%q}`, c.identity.ClientID)))
+
+	s.sseClientsMu.Lock()
+	s.sseClients[c.identity.ClientID] = c
+	s.sseClientsMu.Unlock()
+	defer func() {
+		s.sseClientsMu.Lock()
+		delete(s.sseClients, c.identity.ClientID)
+		s.sseClientsMu.Unlock()
+	}()
+
+	err = s.registerClient(c)
+	if err != nil {
+		room.logger.Log(LogLevelWarn, "error connecting client", LogField{"error", err})
+		return
+	}
+
+	// the response writer must not be used once the handler returns
+	select {
+	case <-r.Context().Done():
+		conn.Close(websocket.StatusNormalClosure, "")
+	case <-conn.closedChannel:
+	}
+}
+
+// actionEndpoint accepts the same messages as the websocket endpoint
+// for clients connected via /sse, their responses are sent through the stream
+func (s *Server) actionEndpoint(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	identity, ok := s.authenticate(w, r)
+	if !ok {
+		return
+	}
+
+	s.sseClientsMu.Lock()
+	c, ok := s.sseClients[r.URL.Query().Get("client")]
+	s.sseClientsMu.Unlock()
+	if !ok {
+		http.Error(w, "unknown client", http.StatusNotFound)
+		return
+	}
+	if s.options.Authenticate != nil && identity.UserID != c.identity.UserID {
+		http.Error(w, "client belongs to a different user", http.StatusForbidden)
+		return
+	}
+
+	readLimit := s.options.ClientLimits.readLimit()
+	msgBytes, err := ioutil.ReadAll(io.LimitReader(r.Body, int64(readLimit)+1))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("error reading body: %s", err), http.StatusBadRequest)
+		return
+	}
+	if len(msgBytes) > readLimit {
+		http.Error(w, fmt.Sprintf("message exceeds %d bytes", readLimit), http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	c.handleMessageBytes(msgBytes)
+	w.WriteHeader(http.StatusAccepted)
+}
@@ -0,0 +1,279 @@
+package state
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// stateQuery filters the elements returned by /state,
+// zero values do not filter
+type stateQuery struct {
+	kind string
+	id   int
+	path string
+	// depth is the number of nested element levels included,
+	// -1 includes all levels
+	depth int
+}
+
+// errorResponse is the body of failed /state requests
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+func writeJSONError(w http.ResponseWriter, status int, msg string) {
+	errorBytes, _ := json.Marshal(errorResponse{Error: msg})
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(errorBytes)
+}
+
+// treeKinds maps the element kinds to their keys in the tree's JSON
+func treeKinds() map[string]string {
+	kinds := make(map[string]string)
+	treeType := reflect.TypeOf(Tree{})
+	for i := 0; i < treeType.NumField(); i++ {
+		field := treeType.Field(i)
+		kinds[field.Name] = strings.Split(field.Tag.Get("json"), ",")[0]
+	}
+	return kinds
+}
+
+func parseStateQuery(r *http.Request) (stateQuery, error) {
+	params := r.URL.Query()
+	query := stateQuery{
+		kind:  params.Get("kind"),
+		path:  params.Get("path"),
+		depth: -1,
+	}
+
+	if query.kind != "" {
+		if _, ok := treeKinds()[query.kind]; !ok {
+			return stateQuery{}, fmt.Errorf("unknown kind \"%s\"", query.kind)
+		}
+	}
+	if id := params.Get("id"); id != "" {
+		var err error
+		query.id, err = strconv.Atoi(id)
+		if err != nil || query.id <= 0 {
+			return stateQuery{}, fmt.Errorf("invalid id \"%s\"", id)
+		}
+	}
+	if depth := params.Get("depth"); depth != "" {
+		var err error
+		query.depth, err = strconv.Atoi(depth)
+		if err != nil || query.depth < 0 {
+			return stateQuery{}, fmt.Errorf("invalid depth \"%s\"", depth)
+		}
+	}
+
+	return query, nil
+}
+
+func (q stateQuery) filters() bool {
+	return q.kind != "" || q.id != 0 || q.path != ""
+}
+
+// elementLocation is the part of an element's core needed to find it in the tree
+type elementLocation struct {
+	ID   int    `json:"id"`
+	Path string `json:"path"`
+}
+
+// queryState returns the tree's JSON, or only the elements matching the query
+// in the same structure, including those which are children of other elements
+func (engine *Engine) queryState(query stateQuery) ([]byte, int, error) {
+	treeBytes, err := engine.marshalTree(engine.assembleTree(true))
+	if err != nil {
+		return nil, http.StatusInternalServerError, fmt.Errorf("error marshalling tree: %s", err)
+	}
+	var tree map[string]interface{}
+	err = json.Unmarshal(treeBytes, &tree)
+	if err != nil {
+		return nil, http.StatusInternalServerError, fmt.Errorf("error unmarshalling tree: %s", err)
+	}
+
+	if !query.filters() {
+		if query.depth == -1 {
+			return treeBytes, http.StatusOK, nil
+		}
+		for _, elements := range tree {
+			for id, element := range elements.(map[string]interface{}) {
+				elements.(map[string]interface{})[id] = limitDepth(element, query.depth)
+			}
+		}
+		return marshalQueryResult(tree)
+	}
+
+	// the tree also includes elements which were created since the last update,
+	// State and Patch are decoded separately as decoding would replace the maps of each kind
+	locations := make(map[string]map[string]elementLocation)
+	for _, state := range []State{engine.State, engine.Patch} {
+		stateBytes, err := json.Marshal(state)
+		if err != nil {
+			return nil, http.StatusInternalServerError, fmt.Errorf("error marshalling state: %s", err)
+		}
+		var stateLocations map[string]map[string]elementLocation
+		err = json.Unmarshal(stateBytes, &stateLocations)
+		if err != nil {
+			return nil, http.StatusInternalServerError, fmt.Errorf("error unmarshalling state: %s", err)
+		}
+		for key, kindLocations := range stateLocations {
+			if _, ok := locations[key]; !ok {
+				locations[key] = make(map[string]elementLocation)
+			}
+			for id, location := range kindLocations {
+				locations[key][id] = location
+			}
+		}
+	}
+
+	result := make(map[string]interface{})
+	for kind, key := range treeKinds() {
+		if query.kind != "" && query.kind != kind {
+			continue
+		}
+		for id, location := range locations[key] {
+			if query.id != 0 && query.id != location.ID {
+				continue
+			}
+			if query.path != "" && query.path != location.Path {
+				continue
+			}
+			element, ok := elementAtPath(tree, location.Path)
+			if !ok {
+				continue
+			}
+			if _, ok := result[key]; !ok {
+				result[key] = make(map[string]interface{})
+			}
+			result[key].(map[string]interface{})[id] = limitDepth(element, query.depth)
+		}
+	}
+
+	if len(result) == 0 && (query.id != 0 || query.path != "") {
+		return nil, http.StatusNotFound, fmt.Errorf("element not found")
+	}
+
+	return marshalQueryResult(result)
+}
+
+func marshalQueryResult(result map[string]interface{}) ([]byte, int, error) {
+	resultBytes, err := json.Marshal(result)
+	if err != nil {
+		return nil, http.StatusInternalServerError, fmt.Errorf("error marshalling result: %s", err)
+	}
+	return resultBytes, http.StatusOK, nil
+}
+
+// elementAtPath follows paths like "$.player.3.items[5]" through the tree's JSON
+func elementAtPath(tree map[string]interface{}, path string) (interface{}, bool) {
+	path = strings.TrimPrefix(path, "$")
+	path = strings.NewReplacer("[", ".", "]", "").Replace(path)
+
+	var current interface{} = tree
+	for _, segment := range strings.Split(strings.TrimPrefix(path, "."), ".") {
+		object, ok := current.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		current, ok = object[segment]
+		if !ok {
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+func isReference(object map[string]interface{}) bool {
+	_, ok := object["elementKind"]
+	return ok
+}
+
+// limitDepth replaces nested elements beyond the depth with their IDs
+// and removes the referenced elements from references
+func limitDepth(value interface{}, depth int) interface{} {
+	object, ok := value.(map[string]interface{})
+	if !ok || depth == -1 {
+		return value
+	}
+
+	limited := make(map[string]interface{}, len(object))
+	for key, fieldValue := range object {
+		fieldObject, ok := fieldValue.(map[string]interface{})
+		if !ok {
+			limited[key] = fieldValue
+			continue
+		}
+
+		if _, isElement := fieldObject["id"]; isElement || isReference(object) {
+			if limitedField, include := limitNested(fieldObject, object, depth); include {
+				limited[key] = limitedField
+			}
+			continue
+		}
+
+		// maps of elements or references keyed by their ID
+		limitedMap := make(map[string]interface{}, len(fieldObject))
+		for id, mapValue := range fieldObject {
+			nested, ok := mapValue.(map[string]interface{})
+			if !ok {
+				limitedMap[id] = mapValue
+				continue
+			}
+			if limitedValue, include := limitNested(nested, fieldObject, depth); include {
+				limitedMap[id] = limitedValue
+			}
+		}
+		limited[key] = limitedMap
+	}
+	return limited
+}
+
+func limitNested(nested, parent map[string]interface{}, depth int) (interface{}, bool) {
+	switch {
+	case isReference(parent):
+		// the referenced element
+		if depth == 0 {
+			return nil, false
+		}
+		return limitDepth(nested, depth-1), true
+	case isReference(nested):
+		return limitDepth(nested, depth), true
+	case depth == 0:
+		return map[string]interface{}{"id": nested["id"]}, true
+	default:
+		return limitDepth(nested, depth-1), true
+	}
+}
+
+func (s *Server) stateEndpoint(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+
+	query, err := parseStateQuery(r)
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var stateBytes []byte
+	var status int
+	ok := s.room.runOnRoom(func() {
+		stateBytes, status, err = s.room.state.queryState(query)
+	})
+	if !ok {
+		writeJSONError(w, http.StatusServiceUnavailable, errServerShutDown.Error())
+		return
+	}
+	if err != nil {
+		writeJSONError(w, status, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(stateBytes)
+}
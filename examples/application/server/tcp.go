@@ -0,0 +1,196 @@
+package state
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"nhooyr.io/websocket"
+)
+
+const (
+	tcpHandshakeTimeout    = 10 * time.Second
+	defaultTCPWriteTimeout = 10 * time.Second
+	tcpCloseWriteTimeout   = time.Second
+)
+
+// messageKindClose is the last message written before
+// a TCP connection is closed by the server
+const messageKindClose MessageKind = "close"
+
+// tcpHandshake is the first frame a TCP client sends,
+// its headers are passed to the Authenticate hook
+type tcpHandshake struct {
+	Headers map[string]string `json:"headers"`
+	// Mode is "spectate" for spectators
+	Mode string `json:"mode"`
+}
+
+// tcpConnection is a Connector which frames each message
+// with its length as 4 byte big-endian unsigned integer
+// easyjson:skip
+type tcpConnection struct {
+	conn      net.Conn
+	reader    *bufio.Reader
+	readLimit int
+	// writeTimeout keeps a client which stops reading
+	// from blocking its writer forever
+	writeTimeout time.Duration
+	writeMu      sync.Mutex
+	closeOnce    sync.Once
+}
+
+func newTCPConnection(conn net.Conn, readLimit int, writeTimeout time.Duration) *tcpConnection {
+	return &tcpConnection{
+		conn:         conn,
+		reader:       bufio.NewReader(conn),
+		readLimit:    readLimit,
+		writeTimeout: writeTimeout,
+	}
+}
+
+// Close informs the client about the status before closing the connection
+func (c *tcpConnection) Close(code websocket.StatusCode, reason string) {
+	c.closeOnce.Do(func() {
+		content := []byte(fmt.Sprintf(`{"code":%d,"reason":%q}`, code, reason))
+		closeMsg, err := Message{Kind: messageKindClose, Content: content}.MarshalJSON()
+		if err == nil {
+			c.writeFrame(closeMsg, tcpCloseWriteTimeout)
+		}
+		c.conn.Close()
+	})
+}
+
+func (c *tcpConnection) ReadMessage() (int, []byte, error) {
+	var header [4]byte
+	_, err := io.ReadFull(c.reader, header[:])
+	if err != nil {
+		return 0, nil, fmt.Errorf("error reading frame header: %s", err)
+	}
+	length := binary.BigEndian.Uint32(header[:])
+	if int64(length) > int64(c.readLimit) {
+		return 0, nil, fmt.Errorf("frame of %d bytes exceeds read limit of %d bytes", length, c.readLimit)
+	}
+	msg := make([]byte, length)
+	_, err = io.ReadFull(c.reader, msg)
+	if err != nil {
+		return 0, nil, fmt.Errorf("error reading frame: %s", err)
+	}
+	return int(websocket.MessageText), msg, nil
+}
+
+func (c *tcpConnection) WriteMessage(msg []byte) error {
+	return c.writeFrame(msg, c.writeTimeout)
+}
+
+// writeFrame closes the connection if the frame can't be written in time,
+// as a partially written frame would corrupt all following ones
+func (c *tcpConnection) writeFrame(msg []byte, timeout time.Duration) error {
+	frame := make([]byte, 4+len(msg))
+	binary.BigEndian.PutUint32(frame, uint32(len(msg)))
+	copy(frame[4:], msg)
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	c.conn.SetWriteDeadline(time.Now().Add(timeout))
+	_, err := c.conn.Write(frame)
+	if err != nil {
+		c.conn.Close()
+	}
+	return err
+}
+
+// ListenAndServeTCP listens on the TCP network address and serves clients
+// speaking length-prefixed frames, e.g. `go server.ListenAndServeTCP(":3497")`
+func (s *Server) ListenAndServeTCP(addr string) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	return s.ServeTCP(listener)
+}
+
+// ServeTCP accepts connections on the listener until it is closed,
+// the listener is closed when the server shuts down
+func (s *Server) ServeTCP(listener net.Listener) error {
+	go func() {
+		<-s.room.shutdownChannel
+		listener.Close()
+	}()
+
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			select {
+			case <-s.room.shutdownChannel:
+				return nil
+			default:
+			}
+			var netErr net.Error
+			if errors.As(err, &netErr) && netErr.Temporary() {
+				time.Sleep(10 * time.Millisecond)
+				continue
+			}
+			return err
+		}
+		go s.connectTCP(conn)
+	}
+}
+
+func (s *Server) connectTCP(netConn net.Conn) {
+	conn := newTCPConnection(netConn, s.options.ClientLimits.readLimit(), s.options.TCPWriteTimeout)
+
+	netConn.SetReadDeadline(time.Now().Add(tcpHandshakeTimeout))
+	_, handshakeBytes, err := conn.ReadMessage()
+	if err != nil {
+		s.room.logger.Log(LogLevelWarn, "error reading TCP handshake", LogField{"error", err})
+		conn.Close(websocket.StatusProtocolError, "handshake expected")
+		return
+	}
+	netConn.SetReadDeadline(time.Time{})
+
+	var handshake tcpHandshake
+	err = json.Unmarshal(handshakeBytes, &handshake)
+	if err != nil {
+		conn.Close(websocket.StatusProtocolError, fmt.Sprintf("invalid handshake: %s", err))
+		return
+	}
+
+	var identity Identity
+	if s.options.Authenticate != nil {
+		r, err := http.NewRequest(http.MethodGet, "/tcp", nil)
+		if err != nil {
+			conn.Close(websocket.StatusInternalError, "")
+			return
+		}
+		r.RemoteAddr = netConn.RemoteAddr().String()
+		for key, value := range handshake.Headers {
+			r.Header.Set(key, value)
+		}
+		identity, err = s.options.Authenticate(r)
+		if err != nil {
+			conn.Close(websocket.StatusPolicyViolation, fmt.Sprintf("authentication failed: %s", err))
+			return
+		}
+	}
+
+	switch handshake.Mode {
+	case "":
+	case "spectate":
+		identity.Spectator = true
+	default:
+		conn.Close(websocket.StatusProtocolError, fmt.Sprintf("unknown mode \"%s\"", handshake.Mode))
+		return
+	}
+
+	_, err = s.Connect(conn, identity)
+	if err != nil {
+		s.room.logger.Log(LogLevelWarn, "error connecting client", LogField{"error", err})
+	}
+}
@@ -0,0 +1,201 @@
+package state
+
+import (
+	"fmt"
+	"time"
+
+	"nhooyr.io/websocket"
+)
+
+// TestRoom runs a room synchronously on the calling goroutine,
+// clients are connected through MemoryConnectors so actions can be
+// tested end to end without networking or sleeps
+// easyjson:skip
+type TestRoom struct {
+	room     *Room
+	clock    *ManualClock
+	nextTick time.Time
+	// clients are kept in the order they connected
+	// so their actions are processed deterministically
+	clients []*TestClient
+}
+
+// NewTestRoom deploys a room which only processes ticks when Tick is called.
+// The Clock option is replaced by a ManualClock and the Logger defaults to NopLogger().
+func NewTestRoom(options Options) *TestRoom {
+	if options.Logger == nil {
+		options.Logger = NopLogger()
+	}
+	clock := NewManualClock(time.Unix(0, 0))
+	options.Clock = clock
+	options = options.withDefaults()
+
+	room := newRoom(options)
+	if room.sideEffects.OnDeploy != nil {
+		room.sideEffects.OnDeploy(room.state)
+	}
+
+	return &TestRoom{
+		room:     room,
+		clock:    clock,
+		nextTick: clock.Now().Add(time.Second / time.Duration(options.FPS)),
+	}
+}
+
+// Engine gives access to the state for setting up and asserting,
+// changes made between ticks are published with the next tick
+func (t *TestRoom) Engine() *Engine {
+	return t.room.state
+}
+
+func (t *TestRoom) Scheduler() *Scheduler {
+	return t.room.scheduler
+}
+
+// BroadcastEvent queues the event for delivery to all clients after the next tick
+func (t *TestRoom) BroadcastEvent(event Event) error {
+	return t.room.queueEvent(event, nil)
+}
+
+// SendEventToClient queues the event for delivery to the client after the next tick
+func (t *TestRoom) SendEventToClient(clientID string, event Event) error {
+	return t.room.queueEvent(event, map[string]bool{clientID: true})
+}
+
+// Connect adds a client, it receives the current state with the next tick
+func (t *TestRoom) Connect(identity Identity) *TestClient {
+	serverConn, clientConn := NewMemoryConnectorPair()
+	c, err := newClient(serverConn, identity)
+	if err != nil {
+		panic(fmt.Sprintf("error creating client: %s", err))
+	}
+	c.assignToRoom(t.room)
+	t.room.registerClient(c)
+
+	testClient := TestClient{
+		ID:         c.identity.ClientID,
+		client:     c,
+		conn:       clientConn,
+		serverConn: serverConn,
+	}
+	t.clients = append(t.clients, &testClient)
+	return &testClient
+}
+
+// Tick advances the clock by one time step and processes the tick,
+// all messages of the tick are delivered to the clients once it returns
+func (t *TestRoom) Tick() {
+	t.readClientMessages()
+	t.clock.Advance(t.nextTick.Sub(t.clock.Now()))
+	t.nextTick = t.room.processDueTicks(t.nextTick)
+	t.writeClientMessages()
+}
+
+// Ticks calls Tick n times
+func (t *TestRoom) Ticks(n int) {
+	for i := 0; i < n; i++ {
+		t.Tick()
+	}
+}
+
+// Shutdown delivers all pending messages, runs the OnShutdown side effect
+// and closes all connections
+func (t *TestRoom) Shutdown() {
+	t.readClientMessages()
+	t.room.initiateShutdown()
+	t.room.shutdown()
+	t.writeClientMessages()
+}
+
+// readClientMessages forwards all messages the clients sent since the last tick to the room
+func (t *TestRoom) readClientMessages() {
+	for _, testClient := range t.clients {
+		c := testClient.client
+		if !t.room.isRegistered(c) {
+			continue
+		}
+		for {
+			msgBytes, ok := testClient.serverConn.tryRead()
+			if !ok {
+				break
+			}
+			c.handleMessageBytes(msgBytes)
+		}
+		if testClient.conn.isClosed() {
+			t.room.unregisterClient(c)
+		}
+	}
+}
+
+// writeClientMessages writes the messages buffered for each client to its connection
+func (t *TestRoom) writeClientMessages() {
+	for _, testClient := range t.clients {
+		c := testClient.client
+		if testClient.conn.isClosed() {
+			continue
+		}
+	Drain:
+		for {
+			select {
+			case msg, ok := <-c.messageChannel:
+				if !ok {
+					c.conn.Close(c.closeStatus())
+					break Drain
+				}
+				c.conn.WriteMessage(msg)
+			default:
+				break Drain
+			}
+		}
+	}
+}
+
+// TestClient is a client of a TestRoom
+// easyjson:skip
+type TestClient struct {
+	// ID is the client ID assigned by the room
+	ID         string
+	client     *Client
+	conn       *MemoryConnector
+	serverConn *MemoryConnector
+}
+
+// Send queues a message which is processed with the next tick
+func (c *TestClient) Send(kind MessageKind, params interface{ MarshalJSON() ([]byte, error) }) error {
+	content, err := params.MarshalJSON()
+	if err != nil {
+		return fmt.Errorf("error marshalling params: %s", err)
+	}
+	msgBytes, err := Message{Kind: kind, Content: content}.MarshalJSON()
+	if err != nil {
+		return fmt.Errorf("error marshalling message: %s", err)
+	}
+	return c.conn.WriteMessage(msgBytes)
+}
+
+// Messages returns all messages received since the last call
+func (c *TestClient) Messages() []Message {
+	var messages []Message
+	for {
+		msgBytes, ok := c.conn.tryRead()
+		if !ok {
+			return messages
+		}
+		var msg Message
+		err := msg.UnmarshalJSON(msgBytes)
+		if err != nil {
+			panic(fmt.Sprintf("error unmarshalling message: %s", err))
+		}
+		messages = append(messages, msg)
+	}
+}
+
+// Disconnect closes the connection, the client is unregistered with the next tick
+func (c *TestClient) Disconnect() {
+	c.conn.Close(websocket.StatusNormalClosure, "")
+}
+
+// CloseStatus returns the status the connection was closed with and whether it is closed
+func (c *TestClient) CloseStatus() (websocket.StatusCode, string, bool) {
+	return c.conn.CloseStatus()
+}
@@ -0,0 +1,93 @@
+package state
+
+import (
+	"time"
+)
+
+const defaultMaxCatchUpTicks = 5
+
+// TickInfo is passed to OnFrameTick
+type TickInfo struct {
+	// Number starts at 1 with the first tick
+	Number int
+	// Delta is the simulated time covered by the tick, which is the
+	// fixed time step unless ticks were skipped to catch up
+	Delta time.Duration
+	// Overrun is how late the tick started compared to its schedule. With the
+	// system clock timer and scheduling jitter make it slightly positive even
+	// while processing stays within the budget, only a ManualClock yields zero
+	Overrun time.Duration
+}
+
+// CatchUpPolicy decides how the room catches up on ticks it missed
+// because processing took longer than the time step
+type CatchUpPolicy string
+
+const (
+	// CatchUpBurst processes missed ticks back to back, with the
+	// fixed time step as Delta. Ticks exceeding MaxCatchUpTicks are skipped.
+	CatchUpBurst CatchUpPolicy = "burst"
+	// CatchUpSkip processes a single tick whose Delta
+	// covers the time of all missed ticks
+	CatchUpSkip CatchUpPolicy = "skip"
+)
+
+// easyjson:skip
+type TimestepOptions struct {
+	// CatchUp defaults to CatchUpBurst
+	CatchUp CatchUpPolicy
+	// MaxCatchUpTicks is the number of ticks processed at once
+	// with CatchUpBurst, defaults to 5
+	MaxCatchUpTicks int
+}
+
+func (o TimestepOptions) withDefaults() TimestepOptions {
+	if o.CatchUp == "" {
+		o.CatchUp = CatchUpBurst
+	}
+	if o.MaxCatchUpTicks < 1 {
+		o.MaxCatchUpTicks = defaultMaxCatchUpTicks
+	}
+	return o
+}
+
+// processDueTicks is called whenever the tick timer fires and
+// returns the time at which the next tick is due
+func (r *Room) processDueTicks(nextTick time.Time) time.Time {
+	step := time.Second / time.Duration(r.fps)
+	now := r.clock.Now()
+	dueTicks := 1 + int(now.Sub(nextTick)/step)
+	if dueTicks < 1 {
+		dueTicks = 1
+	}
+
+	ticksToProcess := 1
+	delta := step * time.Duration(dueTicks)
+	if r.timestep.CatchUp == CatchUpBurst {
+		ticksToProcess = dueTicks
+		delta = step
+		if ticksToProcess > r.timestep.MaxCatchUpTicks {
+			r.log(LogLevelWarn, "skipping ticks to catch up", LogField{"skipped_ticks", dueTicks - r.timestep.MaxCatchUpTicks})
+			ticksToProcess = r.timestep.MaxCatchUpTicks
+		}
+	}
+
+	for i := 0; i < ticksToProcess; i++ {
+		scheduled := nextTick.Add(step * time.Duration(i))
+		var overrun time.Duration
+		if late := r.clock.Now().Sub(scheduled); late > 0 {
+			overrun = late
+		}
+
+		tickStart := r.clock.Now()
+		r.process(TickInfo{
+			Delta:   delta,
+			Overrun: overrun,
+		})
+		if duration := r.clock.Now().Sub(tickStart); duration > step {
+			r.log(LogLevelWarn, "tick exceeded time step", LogField{"duration", duration}, LogField{"step", step})
+		}
+	}
+
+	return nextTick.Add(step * time.Duration(dueTicks))
+}
@@ -0,0 +1,11 @@
+package configs
+
+var EventsConfig = map[interface{}]interface{}{
+	"chatMessage": map[interface{}]interface{}{
+		"author": "playerID",
+		"text":   "string",
+	},
+	"matchEnded": map[interface{}]interface{}{
+		"winners": "[]playerID",
+	},
+}
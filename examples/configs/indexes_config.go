@@ -0,0 +1,6 @@
+package configs
+
+var IndexesConfig = map[interface{}]interface{}{
+	"gearScore": []interface{}{"score"},
+	"item":      []interface{}{"name"},
+}
@@ -6,14 +6,15 @@ var StateConfig = map[interface{}]interface{}{
 		"equipmentSets": "[]*equipmentSet",
 		"gearScore":     "gearScore",
 		"position":      "position",
-		"guildMembers":  "[]*player",
+		"guildMembers":  "set<*player>",
 		"target":        "*anyOf<player,zoneItem>",
 		"targetedBy":    "[]*anyOf<player,zoneItem>",
 	},
 	"zone": map[interface{}]interface{}{
 		"items":         "[]zoneItem",
 		"players":       "[]player",
-		"tags":          "[]string",
+		"boss":          "?player",
+		"tags":          "set<string>",
 		"interactables": "[]anyOf<item,player,zoneItem>",
 	},
 	"zoneItem": map[interface{}]interface{}{
@@ -35,7 +36,11 @@ var StateConfig = map[interface{}]interface{}{
 		"score": "int",
 	},
 	"equipmentSet": map[interface{}]interface{}{
-		"name":      "string",
-		"equipment": "[]*item",
+		"name":       "string",
+		"createdAt":  "time",
+		"cooldown":   "duration",
+		"icon":       "bytes",
+		"externalID": "bigID",
+		"equipment":  "[]*item",
 	},
 }
@@ -3,6 +3,7 @@ package state
 func (_zone zone) AddPlayer() player {
 	zone := _zone.zone.engine.Zone(_zone.zone.ID)
 	if zone.zone.OperationKind == OperationKindDelete {
+		zone.zone.engine.recordError("AddPlayer", ElementKindZone, int(_zone.zone.ID), ErrElementDoesNotExist)
 		return player{player: playerCore{OperationKind: OperationKindDelete, engine: zone.zone.engine}}
 	}
 	player := zone.zone.engine.createPlayer(zone.zone.path.players(), true)
@@ -15,6 +16,7 @@ func (_zone zone) AddPlayer() player {
 func (_zone zone) AddItem() zoneItem {
 	zone := _zone.zone.engine.Zone(_zone.zone.ID)
 	if zone.zone.OperationKind == OperationKindDelete {
+		zone.zone.engine.recordError("AddItem", ElementKindZone, int(_zone.zone.ID), ErrElementDoesNotExist)
 		return zoneItem{zoneItem: zoneItemCore{OperationKind: OperationKindDelete, engine: zone.zone.engine}}
 	}
 	zoneItem := zone.zone.engine.createZoneItem(zone.zone.path.items(), true)
@@ -27,6 +29,7 @@ func (_zone zone) AddItem() zoneItem {
 func (_zone zone) AddInteractablePlayer() player {
 	zone := _zone.zone.engine.Zone(_zone.zone.ID)
 	if zone.zone.OperationKind == OperationKindDelete {
+		zone.zone.engine.recordError("AddInteractablePlayer", ElementKindZone, int(_zone.zone.ID), ErrElementDoesNotExist)
 		return player{player: playerCore{OperationKind: OperationKindDelete, engine: zone.zone.engine}}
 	}
 	player := zone.zone.engine.createPlayer(zone.zone.path.interactables(), true)
@@ -41,6 +44,7 @@ func (_zone zone) AddInteractablePlayer() player {
 func (_zone zone) AddInteractableZoneItem() zoneItem {
 	zone := _zone.zone.engine.Zone(_zone.zone.ID)
 	if zone.zone.OperationKind == OperationKindDelete {
+		zone.zone.engine.recordError("AddInteractableZoneItem", ElementKindZone, int(_zone.zone.ID), ErrElementDoesNotExist)
 		return zoneItem{zoneItem: zoneItemCore{OperationKind: OperationKindDelete, engine: zone.zone.engine}}
 	}
 	zoneItem := zone.zone.engine.createZoneItem(zone.zone.path.interactables(), true)
@@ -55,6 +59,7 @@ func (_zone zone) AddInteractableZoneItem() zoneItem {
 func (_zone zone) AddInteractableItem() item {
 	zone := _zone.zone.engine.Zone(_zone.zone.ID)
 	if zone.zone.OperationKind == OperationKindDelete {
+		zone.zone.engine.recordError("AddInteractableItem", ElementKindZone, int(_zone.zone.ID), ErrElementDoesNotExist)
 		return item{item: itemCore{OperationKind: OperationKindDelete, engine: zone.zone.engine}}
 	}
 	item := zone.zone.engine.createItem(zone.zone.path.interactables(), true)
@@ -69,9 +74,20 @@ func (_zone zone) AddInteractableItem() item {
 func (_zone zone) AddTags(tags ...string) {
 	zone := _zone.zone.engine.Zone(_zone.zone.ID)
 	if zone.zone.OperationKind == OperationKindDelete {
+		zone.zone.engine.recordError("AddTags", ElementKindZone, int(_zone.zone.ID), ErrElementDoesNotExist)
+		return
+	}
+	var wereElementsAltered bool
+	for _, tag := range tags {
+		if zone.zone.indexOfTag(tag) != -1 {
+			continue
+		}
+		zone.zone.Tags = append(zone.zone.Tags, tag)
+		wereElementsAltered = true
+	}
+	if !wereElementsAltered {
 		return
 	}
-	zone.zone.Tags = append(zone.zone.Tags, tags...)
 	zone.zone.OperationKind = OperationKindUpdate
 	zone.zone.engine.Patch.Zone[zone.zone.ID] = zone.zone
 }
@@ -79,6 +95,7 @@ func (_zone zone) AddTags(tags ...string) {
 func (_player player) AddItem() item {
 	player := _player.player.engine.Player(_player.player.ID)
 	if player.player.OperationKind == OperationKindDelete {
+		player.player.engine.recordError("AddItem", ElementKindPlayer, int(_player.player.ID), ErrElementDoesNotExist)
 		return item{item: itemCore{OperationKind: OperationKindDelete, engine: player.player.engine}}
 	}
 	item := player.player.engine.createItem(player.player.path.items(), true)
@@ -91,9 +108,14 @@ func (_player player) AddItem() item {
 func (_player player) AddGuildMember(playerID PlayerID) {
 	player := _player.player.engine.Player(_player.player.ID)
 	if player.player.OperationKind == OperationKindDelete {
+		player.player.engine.recordError("AddGuildMember", ElementKindPlayer, int(_player.player.ID), ErrElementDoesNotExist)
 		return
 	}
 	if player.player.engine.Player(playerID).player.OperationKind == OperationKindDelete {
+		player.player.engine.recordError("AddGuildMember", ElementKindPlayer, int(playerID), ErrElementDoesNotExist)
+		return
+	}
+	if player.player.indexOfGuildMember(playerID) != -1 {
 		return
 	}
 	ref := player.player.engine.createPlayerGuildMemberRef(playerID, player.player.ID)
@@ -105,9 +127,11 @@ func (_player player) AddGuildMember(playerID PlayerID) {
 func (_player player) AddTargetedByPlayer(playerID PlayerID) {
 	player := _player.player.engine.Player(_player.player.ID)
 	if player.player.OperationKind == OperationKindDelete {
+		player.player.engine.recordError("AddTargetedByPlayer", ElementKindPlayer, int(_player.player.ID), ErrElementDoesNotExist)
 		return
 	}
 	if player.player.engine.Player(playerID).player.OperationKind == OperationKindDelete {
+		player.player.engine.recordError("AddTargetedByPlayer", ElementKindPlayer, int(playerID), ErrElementDoesNotExist)
 		return
 	}
 	anyContainer := player.player.engine.createAnyOfPlayer_ZoneItem(false, nil).anyOfPlayer_ZoneItem
@@ -121,9 +145,11 @@ func (_player player) AddTargetedByPlayer(playerID PlayerID) {
 func (_player player) AddTargetedByZoneItem(zoneItemID ZoneItemID) {
 	player := _player.player.engine.Player(_player.player.ID)
 	if player.player.OperationKind == OperationKindDelete {
+		player.player.engine.recordError("AddTargetedByZoneItem", ElementKindPlayer, int(_player.player.ID), ErrElementDoesNotExist)
 		return
 	}
 	if player.player.engine.ZoneItem(zoneItemID).zoneItem.OperationKind == OperationKindDelete {
+		player.player.engine.recordError("AddTargetedByZoneItem", ElementKindZoneItem, int(zoneItemID), ErrElementDoesNotExist)
 		return
 	}
 	anyContainer := player.player.engine.createAnyOfPlayer_ZoneItem(false, nil).anyOfPlayer_ZoneItem
@@ -137,9 +163,11 @@ func (_player player) AddTargetedByZoneItem(zoneItemID ZoneItemID) {
 func (_player player) AddEquipmentSet(equipmentSetID EquipmentSetID) {
 	player := _player.player.engine.Player(_player.player.ID)
 	if player.player.OperationKind == OperationKindDelete {
+		player.player.engine.recordError("AddEquipmentSet", ElementKindPlayer, int(_player.player.ID), ErrElementDoesNotExist)
 		return
 	}
 	if player.player.engine.EquipmentSet(equipmentSetID).equipmentSet.OperationKind == OperationKindDelete {
+		player.player.engine.recordError("AddEquipmentSet", ElementKindEquipmentSet, int(equipmentSetID), ErrElementDoesNotExist)
 		return
 	}
 	ref := player.player.engine.createPlayerEquipmentSetRef(equipmentSetID, player.player.ID)
@@ -151,9 +179,11 @@ func (_player player) AddEquipmentSet(equipmentSetID EquipmentSetID) {
 func (_equipmentSet equipmentSet) AddEquipment(itemID ItemID) {
 	equipmentSet := _equipmentSet.equipmentSet.engine.EquipmentSet(_equipmentSet.equipmentSet.ID)
 	if equipmentSet.equipmentSet.OperationKind == OperationKindDelete {
+		equipmentSet.equipmentSet.engine.recordError("AddEquipment", ElementKindEquipmentSet, int(_equipmentSet.equipmentSet.ID), ErrElementDoesNotExist)
 		return
 	}
 	if equipmentSet.equipmentSet.engine.Item(itemID).item.OperationKind == OperationKindDelete {
+		equipmentSet.equipmentSet.engine.recordError("AddEquipment", ElementKindItem, int(itemID), ErrElementDoesNotExist)
 		return
 	}
 	ref := equipmentSet.equipmentSet.engine.createEquipmentSetEquipmentRef(itemID, equipmentSet.equipmentSet.ID)
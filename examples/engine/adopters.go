@@ -0,0 +1,461 @@
+package state
+
+func (engine *Engine) setGearScorePath(gearScoreID GearScoreID, p path, extendWithID bool) {
+	gearScore := engine.GearScore(gearScoreID).gearScore
+	gearScore.HasParent = len(p) > 1
+	gearScore.path = p
+	if extendWithID {
+		gearScore.path = gearScore.path.id(int(gearScore.ID))
+	}
+	gearScore.Path = gearScore.path.toJSONPath()
+	gearScore.OperationKind = OperationKindUpdate
+	engine.Patch.GearScore[gearScore.ID] = gearScore
+}
+
+func (engine *Engine) detachGearScore(gearScore gearScoreCore) bool {
+	return !gearScore.HasParent
+}
+
+func (engine *Engine) setItemPath(itemID ItemID, p path, extendWithID bool) {
+	item := engine.Item(itemID).item
+	item.HasParent = len(p) > 1
+	item.path = p
+	if extendWithID {
+		item.path = item.path.id(int(item.ID))
+	}
+	item.Path = item.path.toJSONPath()
+	item.OperationKind = OperationKindUpdate
+	engine.Patch.Item[item.ID] = item
+	engine.setGearScorePath(item.GearScore, item.path.gearScore(), false)
+	engine.setAnyOfPlayer_PositionPath(item.Origin, item.path.origin(), false)
+}
+
+func (engine *Engine) detachItem(item itemCore) bool {
+	if !item.HasParent {
+		return true
+	}
+	if item.path[len(item.path)-1] < 0 {
+		return false
+	}
+	parentElement, ok := engine.ElementByPath(item.path[:len(item.path)-2].toJSONPath())
+	if !ok {
+		return false
+	}
+	switch {
+	case parentElement.Kind() == ElementKindPlayer && item.path[len(item.path)-2] == itemsIdentifier:
+		parent := parentElement.Player().player
+		var items []ItemID
+		for _, itemID := range parent.Items {
+			if itemID != item.ID {
+				items = append(items, itemID)
+			}
+		}
+		parent.Items = items
+		parent.OperationKind = OperationKindUpdate
+		engine.Patch.Player[parent.ID] = parent
+		return true
+	case parentElement.Kind() == ElementKindZone && item.path[len(item.path)-2] == interactablesIdentifier:
+		// children of anyOf slices live in containers which would be left behind
+		return false
+	}
+	return false
+}
+
+// wasItemDetached is true if the item was adopted away from
+// the slice at the given path within the current tick
+func (engine *Engine) wasItemDetached(itemID ItemID, slicePath path) bool {
+	item, ok := engine.Patch.Item[itemID]
+	return ok && item.OperationKind != OperationKindDelete && !item.path.equals(slicePath.id(int(item.ID)))
+}
+
+func (_item item) AdoptGearScore(gearScoreID GearScoreID) gearScore {
+	item := _item.item.engine.Item(_item.item.ID)
+	child := item.item.engine.GearScore(gearScoreID)
+	if item.item.OperationKind == OperationKindDelete {
+		item.item.engine.recordError("AdoptGearScore", ElementKindItem, int(_item.item.ID), ErrElementDoesNotExist)
+		return child
+	}
+	if child.gearScore.OperationKind == OperationKindDelete {
+		item.item.engine.recordError("AdoptGearScore", ElementKindGearScore, int(gearScoreID), ErrElementDoesNotExist)
+		return child
+	}
+	if item.item.path.isWithin(child.gearScore.path) {
+		item.item.engine.recordError("AdoptGearScore", ElementKindGearScore, int(gearScoreID), ErrElementIsAncestor)
+		return child
+	}
+	if !item.item.engine.detachGearScore(child.gearScore) {
+		item.item.engine.recordError("AdoptGearScore", ElementKindGearScore, int(gearScoreID), ErrElementNotDetachable)
+		return child
+	}
+	item = item.item.engine.Item(item.item.ID)
+	item.item.engine.setGearScorePath(item.item.GearScore, newPath(gearScoreIdentifier), true)
+	item.item.engine.deleteGearScore(item.item.GearScore)
+	item.item.engine.setGearScorePath(child.gearScore.ID, item.item.path.gearScore(), false)
+	item.item.GearScore = child.gearScore.ID
+	item.item.OperationKind = OperationKindUpdate
+	item.item.engine.Patch.Item[item.item.ID] = item.item
+	return item.item.engine.GearScore(gearScoreID)
+}
+
+func (engine *Engine) setPlayerPath(playerID PlayerID, p path, extendWithID bool) {
+	player := engine.Player(playerID).player
+	player.HasParent = len(p) > 1
+	player.path = p
+	if extendWithID {
+		player.path = player.path.id(int(player.ID))
+	}
+	player.Path = player.path.toJSONPath()
+	player.OperationKind = OperationKindUpdate
+	engine.Patch.Player[player.ID] = player
+	engine.setGearScorePath(player.GearScore, player.path.gearScore(), false)
+	for _, itemID := range player.Items {
+		engine.setItemPath(itemID, player.path.items(), true)
+	}
+	engine.setPositionPath(player.Position, player.path.position(), false)
+}
+
+func (engine *Engine) detachPlayer(player playerCore) bool {
+	if !player.HasParent {
+		return true
+	}
+	if player.path[len(player.path)-1] < 0 {
+		return false
+	}
+	parentElement, ok := engine.ElementByPath(player.path[:len(player.path)-2].toJSONPath())
+	if !ok {
+		return false
+	}
+	switch {
+	case parentElement.Kind() == ElementKindZone && player.path[len(player.path)-2] == playersIdentifier:
+		parent := parentElement.Zone().zone
+		var players []PlayerID
+		for _, playerID := range parent.Players {
+			if playerID != player.ID {
+				players = append(players, playerID)
+			}
+		}
+		parent.Players = players
+		parent.OperationKind = OperationKindUpdate
+		engine.Patch.Zone[parent.ID] = parent
+		return true
+	case parentElement.Kind() == ElementKindZone && player.path[len(player.path)-2] == interactablesIdentifier:
+		// children of anyOf slices live in containers which would be left behind
+		return false
+	}
+	return false
+}
+
+// wasPlayerDetached is true if the player was adopted away from
+// the slice at the given path within the current tick
+func (engine *Engine) wasPlayerDetached(playerID PlayerID, slicePath path) bool {
+	player, ok := engine.Patch.Player[playerID]
+	return ok && player.OperationKind != OperationKindDelete && !player.path.equals(slicePath.id(int(player.ID)))
+}
+
+func (_player player) AdoptGearScore(gearScoreID GearScoreID) gearScore {
+	player := _player.player.engine.Player(_player.player.ID)
+	child := player.player.engine.GearScore(gearScoreID)
+	if player.player.OperationKind == OperationKindDelete {
+		player.player.engine.recordError("AdoptGearScore", ElementKindPlayer, int(_player.player.ID), ErrElementDoesNotExist)
+		return child
+	}
+	if child.gearScore.OperationKind == OperationKindDelete {
+		player.player.engine.recordError("AdoptGearScore", ElementKindGearScore, int(gearScoreID), ErrElementDoesNotExist)
+		return child
+	}
+	if player.player.path.isWithin(child.gearScore.path) {
+		player.player.engine.recordError("AdoptGearScore", ElementKindGearScore, int(gearScoreID), ErrElementIsAncestor)
+		return child
+	}
+	if !player.player.engine.detachGearScore(child.gearScore) {
+		player.player.engine.recordError("AdoptGearScore", ElementKindGearScore, int(gearScoreID), ErrElementNotDetachable)
+		return child
+	}
+	player = player.player.engine.Player(player.player.ID)
+	player.player.engine.setGearScorePath(player.player.GearScore, newPath(gearScoreIdentifier), true)
+	player.player.engine.deleteGearScore(player.player.GearScore)
+	player.player.engine.setGearScorePath(child.gearScore.ID, player.player.path.gearScore(), false)
+	player.player.GearScore = child.gearScore.ID
+	player.player.OperationKind = OperationKindUpdate
+	player.player.engine.Patch.Player[player.player.ID] = player.player
+	return player.player.engine.GearScore(gearScoreID)
+}
+
+func (_player player) AdoptItem(itemID ItemID) item {
+	player := _player.player.engine.Player(_player.player.ID)
+	child := player.player.engine.Item(itemID)
+	if player.player.OperationKind == OperationKindDelete {
+		player.player.engine.recordError("AdoptItem", ElementKindPlayer, int(_player.player.ID), ErrElementDoesNotExist)
+		return child
+	}
+	if child.item.OperationKind == OperationKindDelete {
+		player.player.engine.recordError("AdoptItem", ElementKindItem, int(itemID), ErrElementDoesNotExist)
+		return child
+	}
+	if player.player.path.isWithin(child.item.path) {
+		player.player.engine.recordError("AdoptItem", ElementKindItem, int(itemID), ErrElementIsAncestor)
+		return child
+	}
+	if !player.player.engine.detachItem(child.item) {
+		player.player.engine.recordError("AdoptItem", ElementKindItem, int(itemID), ErrElementNotDetachable)
+		return child
+	}
+	player = player.player.engine.Player(player.player.ID)
+	player.player.engine.setItemPath(child.item.ID, player.player.path.items(), true)
+	player.player.Items = append(player.player.Items, child.item.ID)
+	player.player.OperationKind = OperationKindUpdate
+	player.player.engine.Patch.Player[player.player.ID] = player.player
+	return player.player.engine.Item(itemID)
+}
+
+func (_player player) AdoptPosition(positionID PositionID) position {
+	player := _player.player.engine.Player(_player.player.ID)
+	child := player.player.engine.Position(positionID)
+	if player.player.OperationKind == OperationKindDelete {
+		player.player.engine.recordError("AdoptPosition", ElementKindPlayer, int(_player.player.ID), ErrElementDoesNotExist)
+		return child
+	}
+	if child.position.OperationKind == OperationKindDelete {
+		player.player.engine.recordError("AdoptPosition", ElementKindPosition, int(positionID), ErrElementDoesNotExist)
+		return child
+	}
+	if player.player.path.isWithin(child.position.path) {
+		player.player.engine.recordError("AdoptPosition", ElementKindPosition, int(positionID), ErrElementIsAncestor)
+		return child
+	}
+	if !player.player.engine.detachPosition(child.position) {
+		player.player.engine.recordError("AdoptPosition", ElementKindPosition, int(positionID), ErrElementNotDetachable)
+		return child
+	}
+	player = player.player.engine.Player(player.player.ID)
+	player.player.engine.setPositionPath(player.player.Position, newPath(positionIdentifier), true)
+	player.player.engine.deletePosition(player.player.Position)
+	player.player.engine.setPositionPath(child.position.ID, player.player.path.position(), false)
+	player.player.Position = child.position.ID
+	player.player.OperationKind = OperationKindUpdate
+	player.player.engine.Patch.Player[player.player.ID] = player.player
+	return player.player.engine.Position(positionID)
+}
+
+func (engine *Engine) setPositionPath(positionID PositionID, p path, extendWithID bool) {
+	position := engine.Position(positionID).position
+	position.HasParent = len(p) > 1
+	position.path = p
+	if extendWithID {
+		position.path = position.path.id(int(position.ID))
+	}
+	position.Path = position.path.toJSONPath()
+	position.OperationKind = OperationKindUpdate
+	engine.Patch.Position[position.ID] = position
+}
+
+func (engine *Engine) detachPosition(position positionCore) bool {
+	return !position.HasParent
+}
+
+func (_zone zone) AdoptBoss(playerID PlayerID) player {
+	zone := _zone.zone.engine.Zone(_zone.zone.ID)
+	child := zone.zone.engine.Player(playerID)
+	if zone.zone.OperationKind == OperationKindDelete {
+		zone.zone.engine.recordError("AdoptBoss", ElementKindZone, int(_zone.zone.ID), ErrElementDoesNotExist)
+		return child
+	}
+	if child.player.OperationKind == OperationKindDelete {
+		zone.zone.engine.recordError("AdoptBoss", ElementKindPlayer, int(playerID), ErrElementDoesNotExist)
+		return child
+	}
+	if zone.zone.path.isWithin(child.player.path) {
+		zone.zone.engine.recordError("AdoptBoss", ElementKindPlayer, int(playerID), ErrElementIsAncestor)
+		return child
+	}
+	if !zone.zone.engine.detachPlayer(child.player) {
+		zone.zone.engine.recordError("AdoptBoss", ElementKindPlayer, int(playerID), ErrElementNotDetachable)
+		return child
+	}
+	zone = zone.zone.engine.Zone(zone.zone.ID)
+	if zone.zone.Boss != 0 {
+		zone.zone.engine.setPlayerPath(zone.zone.Boss, newPath(playerIdentifier), true)
+		zone.zone.engine.deletePlayer(zone.zone.Boss)
+	}
+	zone.zone.engine.setPlayerPath(child.player.ID, zone.zone.path.boss(), false)
+	zone.zone.Boss = child.player.ID
+	zone.zone.OperationKind = OperationKindUpdate
+	zone.zone.engine.Patch.Zone[zone.zone.ID] = zone.zone
+	return zone.zone.engine.Player(playerID)
+}
+
+func (_zone zone) AdoptItem(zoneItemID ZoneItemID) zoneItem {
+	zone := _zone.zone.engine.Zone(_zone.zone.ID)
+	child := zone.zone.engine.ZoneItem(zoneItemID)
+	if zone.zone.OperationKind == OperationKindDelete {
+		zone.zone.engine.recordError("AdoptItem", ElementKindZone, int(_zone.zone.ID), ErrElementDoesNotExist)
+		return child
+	}
+	if child.zoneItem.OperationKind == OperationKindDelete {
+		zone.zone.engine.recordError("AdoptItem", ElementKindZoneItem, int(zoneItemID), ErrElementDoesNotExist)
+		return child
+	}
+	if zone.zone.path.isWithin(child.zoneItem.path) {
+		zone.zone.engine.recordError("AdoptItem", ElementKindZoneItem, int(zoneItemID), ErrElementIsAncestor)
+		return child
+	}
+	if !zone.zone.engine.detachZoneItem(child.zoneItem) {
+		zone.zone.engine.recordError("AdoptItem", ElementKindZoneItem, int(zoneItemID), ErrElementNotDetachable)
+		return child
+	}
+	zone = zone.zone.engine.Zone(zone.zone.ID)
+	zone.zone.engine.setZoneItemPath(child.zoneItem.ID, zone.zone.path.items(), true)
+	zone.zone.Items = append(zone.zone.Items, child.zoneItem.ID)
+	zone.zone.OperationKind = OperationKindUpdate
+	zone.zone.engine.Patch.Zone[zone.zone.ID] = zone.zone
+	return zone.zone.engine.ZoneItem(zoneItemID)
+}
+
+func (_zone zone) AdoptPlayer(playerID PlayerID) player {
+	zone := _zone.zone.engine.Zone(_zone.zone.ID)
+	child := zone.zone.engine.Player(playerID)
+	if zone.zone.OperationKind == OperationKindDelete {
+		zone.zone.engine.recordError("AdoptPlayer", ElementKindZone, int(_zone.zone.ID), ErrElementDoesNotExist)
+		return child
+	}
+	if child.player.OperationKind == OperationKindDelete {
+		zone.zone.engine.recordError("AdoptPlayer", ElementKindPlayer, int(playerID), ErrElementDoesNotExist)
+		return child
+	}
+	if zone.zone.path.isWithin(child.player.path) {
+		zone.zone.engine.recordError("AdoptPlayer", ElementKindPlayer, int(playerID), ErrElementIsAncestor)
+		return child
+	}
+	if !zone.zone.engine.detachPlayer(child.player) {
+		zone.zone.engine.recordError("AdoptPlayer", ElementKindPlayer, int(playerID), ErrElementNotDetachable)
+		return child
+	}
+	zone = zone.zone.engine.Zone(zone.zone.ID)
+	zone.zone.engine.setPlayerPath(child.player.ID, zone.zone.path.players(), true)
+	zone.zone.Players = append(zone.zone.Players, child.player.ID)
+	zone.zone.OperationKind = OperationKindUpdate
+	zone.zone.engine.Patch.Zone[zone.zone.ID] = zone.zone
+	return zone.zone.engine.Player(playerID)
+}
+
+func (engine *Engine) setZoneItemPath(zoneItemID ZoneItemID, p path, extendWithID bool) {
+	zoneItem := engine.ZoneItem(zoneItemID).zoneItem
+	zoneItem.HasParent = len(p) > 1
+	zoneItem.path = p
+	if extendWithID {
+		zoneItem.path = zoneItem.path.id(int(zoneItem.ID))
+	}
+	zoneItem.Path = zoneItem.path.toJSONPath()
+	zoneItem.OperationKind = OperationKindUpdate
+	engine.Patch.ZoneItem[zoneItem.ID] = zoneItem
+	engine.setItemPath(zoneItem.Item, zoneItem.path.item(), false)
+	engine.setPositionPath(zoneItem.Position, zoneItem.path.position(), false)
+}
+
+func (engine *Engine) detachZoneItem(zoneItem zoneItemCore) bool {
+	if !zoneItem.HasParent {
+		return true
+	}
+	if zoneItem.path[len(zoneItem.path)-1] < 0 {
+		return false
+	}
+	parentElement, ok := engine.ElementByPath(zoneItem.path[:len(zoneItem.path)-2].toJSONPath())
+	if !ok {
+		return false
+	}
+	switch {
+	case parentElement.Kind() == ElementKindZone && zoneItem.path[len(zoneItem.path)-2] == itemsIdentifier:
+		parent := parentElement.Zone().zone
+		var items []ZoneItemID
+		for _, zoneItemID := range parent.Items {
+			if zoneItemID != zoneItem.ID {
+				items = append(items, zoneItemID)
+			}
+		}
+		parent.Items = items
+		parent.OperationKind = OperationKindUpdate
+		engine.Patch.Zone[parent.ID] = parent
+		return true
+	case parentElement.Kind() == ElementKindZone && zoneItem.path[len(zoneItem.path)-2] == interactablesIdentifier:
+		// children of anyOf slices live in containers which would be left behind
+		return false
+	}
+	return false
+}
+
+// wasZoneItemDetached is true if the zoneItem was adopted away from
+// the slice at the given path within the current tick
+func (engine *Engine) wasZoneItemDetached(zoneItemID ZoneItemID, slicePath path) bool {
+	zoneItem, ok := engine.Patch.ZoneItem[zoneItemID]
+	return ok && zoneItem.OperationKind != OperationKindDelete && !zoneItem.path.equals(slicePath.id(int(zoneItem.ID)))
+}
+
+func (_zoneItem zoneItem) AdoptItem(itemID ItemID) item {
+	zoneItem := _zoneItem.zoneItem.engine.ZoneItem(_zoneItem.zoneItem.ID)
+	child := zoneItem.zoneItem.engine.Item(itemID)
+	if zoneItem.zoneItem.OperationKind == OperationKindDelete {
+		zoneItem.zoneItem.engine.recordError("AdoptItem", ElementKindZoneItem, int(_zoneItem.zoneItem.ID), ErrElementDoesNotExist)
+		return child
+	}
+	if child.item.OperationKind == OperationKindDelete {
+		zoneItem.zoneItem.engine.recordError("AdoptItem", ElementKindItem, int(itemID), ErrElementDoesNotExist)
+		return child
+	}
+	if zoneItem.zoneItem.path.isWithin(child.item.path) {
+		zoneItem.zoneItem.engine.recordError("AdoptItem", ElementKindItem, int(itemID), ErrElementIsAncestor)
+		return child
+	}
+	if !zoneItem.zoneItem.engine.detachItem(child.item) {
+		zoneItem.zoneItem.engine.recordError("AdoptItem", ElementKindItem, int(itemID), ErrElementNotDetachable)
+		return child
+	}
+	zoneItem = zoneItem.zoneItem.engine.ZoneItem(zoneItem.zoneItem.ID)
+	zoneItem.zoneItem.engine.setItemPath(zoneItem.zoneItem.Item, newPath(itemIdentifier), true)
+	zoneItem.zoneItem.engine.deleteItem(zoneItem.zoneItem.Item)
+	zoneItem.zoneItem.engine.setItemPath(child.item.ID, zoneItem.zoneItem.path.item(), false)
+	zoneItem.zoneItem.Item = child.item.ID
+	zoneItem.zoneItem.OperationKind = OperationKindUpdate
+	zoneItem.zoneItem.engine.Patch.ZoneItem[zoneItem.zoneItem.ID] = zoneItem.zoneItem
+	return zoneItem.zoneItem.engine.Item(itemID)
+}
+
+func (_zoneItem zoneItem) AdoptPosition(positionID PositionID) position {
+	zoneItem := _zoneItem.zoneItem.engine.ZoneItem(_zoneItem.zoneItem.ID)
+	child := zoneItem.zoneItem.engine.Position(positionID)
+	if zoneItem.zoneItem.OperationKind == OperationKindDelete {
+		zoneItem.zoneItem.engine.recordError("AdoptPosition", ElementKindZoneItem, int(_zoneItem.zoneItem.ID), ErrElementDoesNotExist)
+		return child
+	}
+	if child.position.OperationKind == OperationKindDelete {
+		zoneItem.zoneItem.engine.recordError("AdoptPosition", ElementKindPosition, int(positionID), ErrElementDoesNotExist)
+		return child
+	}
+	if zoneItem.zoneItem.path.isWithin(child.position.path) {
+		zoneItem.zoneItem.engine.recordError("AdoptPosition", ElementKindPosition, int(positionID), ErrElementIsAncestor)
+		return child
+	}
+	if !zoneItem.zoneItem.engine.detachPosition(child.position) {
+		zoneItem.zoneItem.engine.recordError("AdoptPosition", ElementKindPosition, int(positionID), ErrElementNotDetachable)
+		return child
+	}
+	zoneItem = zoneItem.zoneItem.engine.ZoneItem(zoneItem.zoneItem.ID)
+	zoneItem.zoneItem.engine.setPositionPath(zoneItem.zoneItem.Position, newPath(positionIdentifier), true)
+	zoneItem.zoneItem.engine.deletePosition(zoneItem.zoneItem.Position)
+	zoneItem.zoneItem.engine.setPositionPath(child.position.ID, zoneItem.zoneItem.path.position(), false)
+	zoneItem.zoneItem.Position = child.position.ID
+	zoneItem.zoneItem.OperationKind = OperationKindUpdate
+	zoneItem.zoneItem.engine.Patch.ZoneItem[zoneItem.zoneItem.ID] = zoneItem.zoneItem
+	return zoneItem.zoneItem.engine.Position(positionID)
+}
+
+func (engine *Engine) setAnyOfPlayer_PositionPath(anyOfPlayer_PositionID AnyOfPlayer_PositionID, childElementPath path, extendWithID bool) {
+	any := engine.anyOfPlayer_Position(anyOfPlayer_PositionID).anyOfPlayer_Position
+	any.ChildElementPath = childElementPath
+	engine.Patch.AnyOfPlayer_Position[any.ID] = any
+	switch any.ElementKind {
+	case ElementKindPlayer:
+		engine.setPlayerPath(any.Player, childElementPath, extendWithID)
+	case ElementKindPosition:
+		engine.setPositionPath(any.Position, childElementPath, extendWithID)
+	}
+}
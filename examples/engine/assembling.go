@@ -113,8 +113,16 @@ func (engine *Engine) assembleEquipmentSet(equipmentSetID EquipmentSetID, check
 		}
 	}
 
+	if len(equipmentSetData.Equipment) > 1 && (config.forceInclude || wereEquipmentSetEquipmentRefIDsReordered(engine.State.EquipmentSet[equipmentSetData.ID].Equipment, engine.Patch.EquipmentSet[equipmentSetData.ID].Equipment)) {
+		equipmentSet.EquipmentOrder = equipmentSetData.equipmentKeys()
+	}
+
 	equipmentSet.ID = equipmentSetData.ID
 	equipmentSet.OperationKind = equipmentSetData.OperationKind
+	equipmentSet.Cooldown = equipmentSetData.Cooldown
+	equipmentSet.CreatedAt = equipmentSetData.CreatedAt
+	equipmentSet.ExternalID = equipmentSetData.ExternalID
+	equipmentSet.Icon = equipmentSetData.Icon
 	equipmentSet.Name = equipmentSetData.Name
 
 	if config.forceInclude {
@@ -279,6 +287,10 @@ func (engine *Engine) assemblePlayer(playerID PlayerID, check *recursionCheck, c
 		}
 	}
 
+	if len(playerData.EquipmentSets) > 1 && (config.forceInclude || werePlayerEquipmentSetRefIDsReordered(engine.State.Player[playerData.ID].EquipmentSets, engine.Patch.Player[playerData.ID].EquipmentSets)) {
+		player.EquipmentSetsOrder = playerData.equipmentSetsKeys()
+	}
+
 	if treeGearScore, include, childHasUpdated := engine.assembleGearScore(playerData.GearScore, check, config); include {
 		if childHasUpdated {
 			hasUpdated = true
@@ -298,7 +310,22 @@ func (engine *Engine) assemblePlayer(playerID PlayerID, check *recursionCheck, c
 		}
 	}
 
+	if len(playerData.GuildMembers) > 1 && (config.forceInclude || werePlayerGuildMemberRefIDsReordered(engine.State.Player[playerData.ID].GuildMembers, engine.Patch.Player[playerData.ID].GuildMembers)) {
+		player.GuildMembersOrder = playerData.guildMembersKeys()
+	}
+
 	for _, itemID := range mergeItemIDs(engine.State.Player[playerData.ID].Items, engine.Patch.Player[playerData.ID].Items) {
+		// the child was adopted by another parent, so it is only removed from this one
+		if engine.wasItemDetached(itemID, playerData.path.items()) {
+			if !config.forceInclude {
+				if player.Items == nil {
+					player.Items = make(map[ItemID]Item)
+				}
+				player.Items[itemID] = Item{ID: itemID, OperationKind: OperationKindDelete}
+				hasUpdated = true
+			}
+			continue
+		}
 		if treeItem, include, childHasUpdated := engine.assembleItem(itemID, check, config); include {
 			if childHasUpdated {
 				hasUpdated = true
@@ -310,6 +337,10 @@ func (engine *Engine) assemblePlayer(playerID PlayerID, check *recursionCheck, c
 		}
 	}
 
+	if len(playerData.Items) > 1 && (config.forceInclude || wereItemIDsReordered(engine.State.Player[playerData.ID].Items, engine.Patch.Player[playerData.ID].Items)) {
+		player.ItemsOrder = playerData.itemsKeys()
+	}
+
 	if treePosition, include, childHasUpdated := engine.assemblePosition(playerData.Position, check, config); include {
 		if childHasUpdated {
 			hasUpdated = true
@@ -336,6 +367,10 @@ func (engine *Engine) assemblePlayer(playerID PlayerID, check *recursionCheck, c
 		}
 	}
 
+	if len(playerData.TargetedBy) > 1 && (config.forceInclude || werePlayerTargetedByRefIDsReordered(engine.State.Player[playerData.ID].TargetedBy, engine.Patch.Player[playerData.ID].TargetedBy)) {
+		player.TargetedByOrder = playerData.targetedByKeys()
+	}
+
 	player.ID = playerData.ID
 	player.OperationKind = playerData.OperationKind
 
@@ -371,6 +406,21 @@ func (engine *Engine) assembleZone(zoneID ZoneID, check *recursionCheck, config
 
 	var zone Zone
 
+	bossID := zoneData.Boss
+	// an unset boss is absent from the tree, only the patch it was unset in
+	// assembles the previous boss from the state to carry its deletion
+	if bossID == 0 && !config.forceInclude {
+		bossID = engine.State.Zone[zoneData.ID].Boss
+	}
+	if bossID != 0 {
+		if treePlayer, include, childHasUpdated := engine.assemblePlayer(bossID, check, config); include {
+			if childHasUpdated {
+				hasUpdated = true
+			}
+			zone.Boss = &treePlayer
+		}
+	}
+
 	for _, anyOfItem_Player_ZoneItemID := range mergeAnyOfItem_Player_ZoneItemIDs(engine.State.Zone[zoneData.ID].Interactables, engine.Patch.Zone[zoneData.ID].Interactables) {
 		anyOfItem_Player_ZoneItemContainer := engine.anyOfItem_Player_ZoneItem(anyOfItem_Player_ZoneItemID).anyOfItem_Player_ZoneItem
 		if anyOfItem_Player_ZoneItemContainer.ElementKind == ElementKindItem {
@@ -409,7 +459,22 @@ func (engine *Engine) assembleZone(zoneID ZoneID, check *recursionCheck, config
 		}
 	}
 
+	if len(zoneData.Interactables) > 1 && (config.forceInclude || wereAnyOfItem_Player_ZoneItemIDsReordered(engine.State.Zone[zoneData.ID].Interactables, engine.Patch.Zone[zoneData.ID].Interactables)) {
+		zone.InteractablesOrder = zoneData.interactablesKeys()
+	}
+
 	for _, zoneItemID := range mergeZoneItemIDs(engine.State.Zone[zoneData.ID].Items, engine.Patch.Zone[zoneData.ID].Items) {
+		// the child was adopted by another parent, so it is only removed from this one
+		if engine.wasZoneItemDetached(zoneItemID, zoneData.path.items()) {
+			if !config.forceInclude {
+				if zone.Items == nil {
+					zone.Items = make(map[ZoneItemID]ZoneItem)
+				}
+				zone.Items[zoneItemID] = ZoneItem{ID: zoneItemID, OperationKind: OperationKindDelete}
+				hasUpdated = true
+			}
+			continue
+		}
 		if treeZoneItem, include, childHasUpdated := engine.assembleZoneItem(zoneItemID, check, config); include {
 			if childHasUpdated {
 				hasUpdated = true
@@ -421,7 +486,22 @@ func (engine *Engine) assembleZone(zoneID ZoneID, check *recursionCheck, config
 		}
 	}
 
+	if len(zoneData.Items) > 1 && (config.forceInclude || wereZoneItemIDsReordered(engine.State.Zone[zoneData.ID].Items, engine.Patch.Zone[zoneData.ID].Items)) {
+		zone.ItemsOrder = zoneData.itemsKeys()
+	}
+
 	for _, playerID := range mergePlayerIDs(engine.State.Zone[zoneData.ID].Players, engine.Patch.Zone[zoneData.ID].Players) {
+		// the child was adopted by another parent, so it is only removed from this one
+		if engine.wasPlayerDetached(playerID, zoneData.path.players()) {
+			if !config.forceInclude {
+				if zone.Players == nil {
+					zone.Players = make(map[PlayerID]Player)
+				}
+				zone.Players[playerID] = Player{ID: playerID, OperationKind: OperationKindDelete}
+				hasUpdated = true
+			}
+			continue
+		}
 		if treePlayer, include, childHasUpdated := engine.assemblePlayer(playerID, check, config); include {
 			if childHasUpdated {
 				hasUpdated = true
@@ -433,6 +513,10 @@ func (engine *Engine) assembleZone(zoneID ZoneID, check *recursionCheck, config
 		}
 	}
 
+	if len(zoneData.Players) > 1 && (config.forceInclude || werePlayerIDsReordered(engine.State.Zone[zoneData.ID].Players, engine.Patch.Zone[zoneData.ID].Players)) {
+		zone.PlayersOrder = zoneData.playersKeys()
+	}
+
 	zone.ID = zoneData.ID
 	zone.OperationKind = zoneData.OperationKind
 	zone.Tags = zoneData.Tags
@@ -1048,6 +1132,13 @@ func (engine *Engine) assembleTree(assembleEntireTree bool) Tree {
 	for _, gearScoreData := range engine.State.GearScore {
 		if !gearScoreData.HasParent {
 			if _, ok := engine.Tree.GearScore[gearScoreData.ID]; !ok {
+				// the element was adopted, so it is only removed from the root
+				if engine.Patch.GearScore[gearScoreData.ID].HasParent {
+					if !assembleEntireTree {
+						engine.Tree.GearScore[gearScoreData.ID] = GearScore{ID: gearScoreData.ID, OperationKind: OperationKindDelete}
+					}
+					continue
+				}
 				gearScore, include, _ := engine.assembleGearScore(gearScoreData.ID, nil, config)
 				if include {
 					engine.Tree.GearScore[gearScoreData.ID] = gearScore
@@ -1058,6 +1149,13 @@ func (engine *Engine) assembleTree(assembleEntireTree bool) Tree {
 	for _, itemData := range engine.State.Item {
 		if !itemData.HasParent {
 			if _, ok := engine.Tree.Item[itemData.ID]; !ok {
+				// the element was adopted, so it is only removed from the root
+				if engine.Patch.Item[itemData.ID].HasParent {
+					if !assembleEntireTree {
+						engine.Tree.Item[itemData.ID] = Item{ID: itemData.ID, OperationKind: OperationKindDelete}
+					}
+					continue
+				}
 				item, include, _ := engine.assembleItem(itemData.ID, nil, config)
 				if include {
 					engine.Tree.Item[itemData.ID] = item
@@ -1068,6 +1166,13 @@ func (engine *Engine) assembleTree(assembleEntireTree bool) Tree {
 	for _, playerData := range engine.State.Player {
 		if !playerData.HasParent {
 			if _, ok := engine.Tree.Player[playerData.ID]; !ok {
+				// the element was adopted, so it is only removed from the root
+				if engine.Patch.Player[playerData.ID].HasParent {
+					if !assembleEntireTree {
+						engine.Tree.Player[playerData.ID] = Player{ID: playerData.ID, OperationKind: OperationKindDelete}
+					}
+					continue
+				}
 				player, include, _ := engine.assemblePlayer(playerData.ID, nil, config)
 				if include {
 					engine.Tree.Player[playerData.ID] = player
@@ -1078,6 +1183,13 @@ func (engine *Engine) assembleTree(assembleEntireTree bool) Tree {
 	for _, positionData := range engine.State.Position {
 		if !positionData.HasParent {
 			if _, ok := engine.Tree.Position[positionData.ID]; !ok {
+				// the element was adopted, so it is only removed from the root
+				if engine.Patch.Position[positionData.ID].HasParent {
+					if !assembleEntireTree {
+						engine.Tree.Position[positionData.ID] = Position{ID: positionData.ID, OperationKind: OperationKindDelete}
+					}
+					continue
+				}
 				position, include, _ := engine.assemblePosition(positionData.ID, nil, config)
 				if include {
 					engine.Tree.Position[positionData.ID] = position
@@ -1098,6 +1210,13 @@ func (engine *Engine) assembleTree(assembleEntireTree bool) Tree {
 	for _, zoneItemData := range engine.State.ZoneItem {
 		if !zoneItemData.HasParent {
 			if _, ok := engine.Tree.ZoneItem[zoneItemData.ID]; !ok {
+				// the element was adopted, so it is only removed from the root
+				if engine.Patch.ZoneItem[zoneItemData.ID].HasParent {
+					if !assembleEntireTree {
+						engine.Tree.ZoneItem[zoneItemData.ID] = ZoneItem{ID: zoneItemData.ID, OperationKind: OperationKindDelete}
+					}
+					continue
+				}
 				zoneItem, include, _ := engine.assembleZoneItem(zoneItemData.ID, nil, config)
 				if include {
 					engine.Tree.ZoneItem[zoneItemData.ID] = zoneItem
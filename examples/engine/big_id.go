@@ -0,0 +1,30 @@
+package state
+
+import (
+	"strconv"
+)
+
+// BigID is a 64 bit ID which is encoded as a string so clients
+// which read numbers as float64 (e.g. JavaScript) receive it exactly
+type BigID uint64
+
+func (id BigID) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(strconv.FormatUint(uint64(id), 10))), nil
+}
+
+// UnmarshalJSON leaves the ID untouched for null
+func (id *BigID) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	unquoted, err := strconv.Unquote(string(data))
+	if err != nil {
+		return err
+	}
+	value, err := strconv.ParseUint(unquoted, 10, 64)
+	if err != nil {
+		return err
+	}
+	*id = BigID(value)
+	return nil
+}
@@ -0,0 +1,172 @@
+package state
+
+func (_equipmentSet equipmentSet) Clone() equipmentSet {
+	equipmentSet := _equipmentSet.equipmentSet.engine.EquipmentSet(_equipmentSet.equipmentSet.ID)
+	if equipmentSet.equipmentSet.OperationKind == OperationKindDelete {
+		equipmentSet.equipmentSet.engine.recordError("Clone", ElementKindEquipmentSet, int(_equipmentSet.equipmentSet.ID), ErrElementDoesNotExist)
+		return equipmentSet
+	}
+	clone := equipmentSet.equipmentSet.engine.CreateEquipmentSet()
+	equipmentSet.equipmentSet.engine.copyEquipmentSet(clone, equipmentSet)
+	return clone
+}
+
+func (engine *Engine) copyEquipmentSet(dst, src equipmentSet) {
+	dst.SetCooldown(src.Cooldown())
+	dst.SetCreatedAt(src.CreatedAt())
+	for _, equipment := range src.Equipment() {
+		dst.AddEquipment(equipment.ID())
+	}
+	dst.SetExternalID(src.ExternalID())
+	dst.SetIcon(src.Icon())
+	dst.SetName(src.Name())
+}
+
+func (_gearScore gearScore) Clone() gearScore {
+	gearScore := _gearScore.gearScore.engine.GearScore(_gearScore.gearScore.ID)
+	if gearScore.gearScore.OperationKind == OperationKindDelete {
+		gearScore.gearScore.engine.recordError("Clone", ElementKindGearScore, int(_gearScore.gearScore.ID), ErrElementDoesNotExist)
+		return gearScore
+	}
+	clone := gearScore.gearScore.engine.CreateGearScore()
+	gearScore.gearScore.engine.copyGearScore(clone, gearScore)
+	return clone
+}
+
+func (engine *Engine) copyGearScore(dst, src gearScore) {
+	dst.SetLevel(src.Level())
+	dst.SetScore(src.Score())
+}
+
+func (_item item) Clone() item {
+	item := _item.item.engine.Item(_item.item.ID)
+	if item.item.OperationKind == OperationKindDelete {
+		item.item.engine.recordError("Clone", ElementKindItem, int(_item.item.ID), ErrElementDoesNotExist)
+		return item
+	}
+	clone := item.item.engine.CreateItem()
+	item.item.engine.copyItem(clone, item)
+	return clone
+}
+
+func (engine *Engine) copyItem(dst, src item) {
+	if boundTo, ok := src.BoundTo(); ok {
+		dst.SetBoundTo(boundTo.ID())
+	}
+	engine.copyGearScore(dst.GearScore(), src.GearScore())
+	dst.SetName(src.Name())
+	origin := src.Origin()
+	switch origin.Kind() {
+	case ElementKindPlayer:
+		engine.copyPlayer(dst.Origin().SetPlayer(), origin.Player())
+	case ElementKindPosition:
+		engine.copyPosition(dst.Origin().SetPosition(), origin.Position())
+	}
+}
+
+func (_player player) Clone() player {
+	player := _player.player.engine.Player(_player.player.ID)
+	if player.player.OperationKind == OperationKindDelete {
+		player.player.engine.recordError("Clone", ElementKindPlayer, int(_player.player.ID), ErrElementDoesNotExist)
+		return player
+	}
+	clone := player.player.engine.CreatePlayer()
+	player.player.engine.copyPlayer(clone, player)
+	return clone
+}
+
+func (engine *Engine) copyPlayer(dst, src player) {
+	for _, equipmentSet := range src.EquipmentSets() {
+		dst.AddEquipmentSet(equipmentSet.ID())
+	}
+	engine.copyGearScore(dst.GearScore(), src.GearScore())
+	for _, guildMember := range src.GuildMembers() {
+		dst.AddGuildMember(guildMember.ID())
+	}
+	for _, item := range src.Items() {
+		engine.copyItem(dst.AddItem(), item)
+	}
+	engine.copyPosition(dst.Position(), src.Position())
+	if target, ok := src.Target(); ok {
+		switch target.Get().Kind() {
+		case ElementKindPlayer:
+			dst.SetTargetPlayer(target.Get().Player().ID())
+		case ElementKindZoneItem:
+			dst.SetTargetZoneItem(target.Get().ZoneItem().ID())
+		}
+	}
+	for _, targetedBy := range src.TargetedBy() {
+		switch targetedBy.Get().Kind() {
+		case ElementKindPlayer:
+			dst.AddTargetedByPlayer(targetedBy.Get().Player().ID())
+		case ElementKindZoneItem:
+			dst.AddTargetedByZoneItem(targetedBy.Get().ZoneItem().ID())
+		}
+	}
+}
+
+func (_position position) Clone() position {
+	position := _position.position.engine.Position(_position.position.ID)
+	if position.position.OperationKind == OperationKindDelete {
+		position.position.engine.recordError("Clone", ElementKindPosition, int(_position.position.ID), ErrElementDoesNotExist)
+		return position
+	}
+	clone := position.position.engine.CreatePosition()
+	position.position.engine.copyPosition(clone, position)
+	return clone
+}
+
+func (engine *Engine) copyPosition(dst, src position) {
+	dst.SetX(src.X())
+	dst.SetY(src.Y())
+}
+
+func (_zone zone) Clone() zone {
+	zone := _zone.zone.engine.Zone(_zone.zone.ID)
+	if zone.zone.OperationKind == OperationKindDelete {
+		zone.zone.engine.recordError("Clone", ElementKindZone, int(_zone.zone.ID), ErrElementDoesNotExist)
+		return zone
+	}
+	clone := zone.zone.engine.CreateZone()
+	zone.zone.engine.copyZone(clone, zone)
+	return clone
+}
+
+func (engine *Engine) copyZone(dst, src zone) {
+	if boss, ok := src.Boss(); ok {
+		engine.copyPlayer(dst.SetBoss(), boss)
+	}
+	for _, interactable := range src.Interactables() {
+		switch interactable.Kind() {
+		case ElementKindItem:
+			engine.copyItem(dst.AddInteractableItem(), interactable.Item())
+		case ElementKindPlayer:
+			engine.copyPlayer(dst.AddInteractablePlayer(), interactable.Player())
+		case ElementKindZoneItem:
+			engine.copyZoneItem(dst.AddInteractableZoneItem(), interactable.ZoneItem())
+		}
+	}
+	for _, item := range src.Items() {
+		engine.copyZoneItem(dst.AddItem(), item)
+	}
+	for _, player := range src.Players() {
+		engine.copyPlayer(dst.AddPlayer(), player)
+	}
+	dst.AddTags(src.Tags()...)
+}
+
+func (_zoneItem zoneItem) Clone() zoneItem {
+	zoneItem := _zoneItem.zoneItem.engine.ZoneItem(_zoneItem.zoneItem.ID)
+	if zoneItem.zoneItem.OperationKind == OperationKindDelete {
+		zoneItem.zoneItem.engine.recordError("Clone", ElementKindZoneItem, int(_zoneItem.zoneItem.ID), ErrElementDoesNotExist)
+		return zoneItem
+	}
+	clone := zoneItem.zoneItem.engine.CreateZoneItem()
+	zoneItem.zoneItem.engine.copyZoneItem(clone, zoneItem)
+	return clone
+}
+
+func (engine *Engine) copyZoneItem(dst, src zoneItem) {
+	engine.copyItem(dst.Item(), src.Item())
+	engine.copyPosition(dst.Position(), src.Position())
+}
@@ -8,13 +8,13 @@ func (engine *Engine) createEquipmentSet(p path, extendWithID bool) equipmentSet
 	var element equipmentSetCore
 	element.engine = engine
 	element.ID = EquipmentSetID(engine.GenerateID())
-	element.OperationKind = OperationKindUpdate
 	element.HasParent = len(p) > 1
 	element.path = p
 	if extendWithID {
 		element.path = element.path.id(int(element.ID))
 	}
 	element.Path = element.path.toJSONPath()
+	element.OperationKind = OperationKindUpdate
 	engine.Patch.EquipmentSet[element.ID] = element
 	return equipmentSet{equipmentSet: element}
 }
@@ -27,14 +27,15 @@ func (engine *Engine) createGearScore(p path, extendWithID bool) gearScore {
 	var element gearScoreCore
 	element.engine = engine
 	element.ID = GearScoreID(engine.GenerateID())
-	element.OperationKind = OperationKindUpdate
 	element.HasParent = len(p) > 1
 	element.path = p
 	if extendWithID {
 		element.path = element.path.id(int(element.ID))
 	}
 	element.Path = element.path.toJSONPath()
+	element.OperationKind = OperationKindUpdate
 	engine.Patch.GearScore[element.ID] = element
+	engine.indexGearScoreScore(element.ID, element.Score)
 	return gearScore{gearScore: element}
 }
 
@@ -46,13 +47,13 @@ func (engine *Engine) createPosition(p path, extendWithID bool) position {
 	var element positionCore
 	element.engine = engine
 	element.ID = PositionID(engine.GenerateID())
-	element.OperationKind = OperationKindUpdate
 	element.HasParent = len(p) > 1
 	element.path = p
 	if extendWithID {
 		element.path = element.path.id(int(element.ID))
 	}
 	element.Path = element.path.toJSONPath()
+	element.OperationKind = OperationKindUpdate
 	engine.Patch.Position[element.ID] = element
 	return position{position: element}
 }
@@ -65,18 +66,19 @@ func (engine *Engine) createItem(p path, extendWithID bool) item {
 	var element itemCore
 	element.engine = engine
 	element.ID = ItemID(engine.GenerateID())
-	elementGearScore := engine.createGearScore(p.gearScore(), false)
-	element.GearScore = elementGearScore.gearScore.ID
-	elementOrigin := engine.createAnyOfPlayer_Position(true, p.origin())
-	element.Origin = elementOrigin.anyOfPlayer_Position.ID
-	element.OperationKind = OperationKindUpdate
 	element.HasParent = len(p) > 1
 	element.path = p
 	if extendWithID {
 		element.path = element.path.id(int(element.ID))
 	}
 	element.Path = element.path.toJSONPath()
+	elementGearScore := engine.createGearScore(element.path.gearScore(), false)
+	element.GearScore = elementGearScore.gearScore.ID
+	elementOrigin := engine.createAnyOfPlayer_Position(true, element.path.origin())
+	element.Origin = elementOrigin.anyOfPlayer_Position.ID
+	element.OperationKind = OperationKindUpdate
 	engine.Patch.Item[element.ID] = element
+	engine.indexItemName(element.ID, element.Name)
 	return item{item: element}
 }
 
@@ -88,17 +90,17 @@ func (engine *Engine) createZoneItem(p path, extendWithID bool) zoneItem {
 	var element zoneItemCore
 	element.engine = engine
 	element.ID = ZoneItemID(engine.GenerateID())
-	elementItem := engine.createItem(p.item(), false)
-	element.Item = elementItem.item.ID
-	elementPosition := engine.createPosition(p.position(), false)
-	element.Position = elementPosition.position.ID
-	element.OperationKind = OperationKindUpdate
 	element.HasParent = len(p) > 1
 	element.path = p
 	if extendWithID {
 		element.path = element.path.id(int(element.ID))
 	}
 	element.Path = element.path.toJSONPath()
+	elementItem := engine.createItem(element.path.item(), false)
+	element.Item = elementItem.item.ID
+	elementPosition := engine.createPosition(element.path.position(), false)
+	element.Position = elementPosition.position.ID
+	element.OperationKind = OperationKindUpdate
 	engine.Patch.ZoneItem[element.ID] = element
 	return zoneItem{zoneItem: element}
 }
@@ -111,17 +113,17 @@ func (engine *Engine) createPlayer(p path, extendWithID bool) player {
 	var element playerCore
 	element.engine = engine
 	element.ID = PlayerID(engine.GenerateID())
-	elementGearScore := engine.createGearScore(p.gearScore(), false)
-	element.GearScore = elementGearScore.gearScore.ID
-	elementPosition := engine.createPosition(p.position(), false)
-	element.Position = elementPosition.position.ID
-	element.OperationKind = OperationKindUpdate
 	element.HasParent = len(p) > 1
 	element.path = p
 	if extendWithID {
 		element.path = element.path.id(int(element.ID))
 	}
 	element.Path = element.path.toJSONPath()
+	elementGearScore := engine.createGearScore(element.path.gearScore(), false)
+	element.GearScore = elementGearScore.gearScore.ID
+	elementPosition := engine.createPosition(element.path.position(), false)
+	element.Position = elementPosition.position.ID
+	element.OperationKind = OperationKindUpdate
 	engine.Patch.Player[element.ID] = element
 	return player{player: element}
 }
@@ -134,13 +136,13 @@ func (engine *Engine) createZone(p path, extendWithID bool) zone {
 	var element zoneCore
 	element.engine = engine
 	element.ID = ZoneID(engine.GenerateID())
-	element.OperationKind = OperationKindUpdate
 	element.HasParent = len(p) > 1
 	element.path = p
 	if extendWithID {
 		element.path = element.path.id(int(element.ID))
 	}
 	element.Path = element.path.toJSONPath()
+	element.OperationKind = OperationKindUpdate
 	engine.Patch.Zone[element.ID] = element
 	return zone{zone: element}
 }
@@ -2,7 +2,12 @@ package state
 
 func (engine *Engine) DeletePlayer(playerID PlayerID) {
 	player := engine.Player(playerID).player
+	if player.OperationKind == OperationKindDelete {
+		engine.recordError("DeletePlayer", ElementKindPlayer, int(playerID), ErrElementDoesNotExist)
+		return
+	}
 	if player.HasParent {
+		engine.recordError("DeletePlayer", ElementKindPlayer, int(playerID), ErrElementHasParent)
 		return
 	}
 	engine.deletePlayer(playerID)
@@ -38,13 +43,19 @@ func (engine *Engine) deletePlayer(playerID PlayerID) {
 
 func (engine *Engine) DeleteGearScore(gearScoreID GearScoreID) {
 	gearScore := engine.GearScore(gearScoreID).gearScore
+	if gearScore.OperationKind == OperationKindDelete {
+		engine.recordError("DeleteGearScore", ElementKindGearScore, int(gearScoreID), ErrElementDoesNotExist)
+		return
+	}
 	if gearScore.HasParent {
+		engine.recordError("DeleteGearScore", ElementKindGearScore, int(gearScoreID), ErrElementHasParent)
 		return
 	}
 	engine.deleteGearScore(gearScoreID)
 }
 func (engine *Engine) deleteGearScore(gearScoreID GearScoreID) {
 	gearScore := engine.GearScore(gearScoreID).gearScore
+	engine.unindexGearScoreScore(gearScoreID, gearScore.Score)
 	if _, ok := engine.State.GearScore[gearScoreID]; ok {
 		gearScore.OperationKind = OperationKindDelete
 		engine.Patch.GearScore[gearScore.ID] = gearScore
@@ -55,7 +66,12 @@ func (engine *Engine) deleteGearScore(gearScoreID GearScoreID) {
 
 func (engine *Engine) DeletePosition(positionID PositionID) {
 	position := engine.Position(positionID).position
+	if position.OperationKind == OperationKindDelete {
+		engine.recordError("DeletePosition", ElementKindPosition, int(positionID), ErrElementDoesNotExist)
+		return
+	}
 	if position.HasParent {
+		engine.recordError("DeletePosition", ElementKindPosition, int(positionID), ErrElementHasParent)
 		return
 	}
 	engine.deletePosition(positionID)
@@ -72,13 +88,19 @@ func (engine *Engine) deletePosition(positionID PositionID) {
 
 func (engine *Engine) DeleteItem(itemID ItemID) {
 	item := engine.Item(itemID).item
+	if item.OperationKind == OperationKindDelete {
+		engine.recordError("DeleteItem", ElementKindItem, int(itemID), ErrElementDoesNotExist)
+		return
+	}
 	if item.HasParent {
+		engine.recordError("DeleteItem", ElementKindItem, int(itemID), ErrElementHasParent)
 		return
 	}
 	engine.deleteItem(itemID)
 }
 func (engine *Engine) deleteItem(itemID ItemID) {
 	item := engine.Item(itemID).item
+	engine.unindexItemName(itemID, item.Name)
 	engine.dereferenceEquipmentSetEquipmentRefs(itemID)
 	engine.deleteItemBoundToRef(item.BoundTo)
 	engine.deleteGearScore(item.GearScore)
@@ -93,7 +115,12 @@ func (engine *Engine) deleteItem(itemID ItemID) {
 
 func (engine *Engine) DeleteZoneItem(zoneItemID ZoneItemID) {
 	zoneItem := engine.ZoneItem(zoneItemID).zoneItem
+	if zoneItem.OperationKind == OperationKindDelete {
+		engine.recordError("DeleteZoneItem", ElementKindZoneItem, int(zoneItemID), ErrElementDoesNotExist)
+		return
+	}
 	if zoneItem.HasParent {
+		engine.recordError("DeleteZoneItem", ElementKindZoneItem, int(zoneItemID), ErrElementHasParent)
 		return
 	}
 	engine.deleteZoneItem(zoneItemID)
@@ -113,10 +140,18 @@ func (engine *Engine) deleteZoneItem(zoneItemID ZoneItemID) {
 }
 
 func (engine *Engine) DeleteZone(zoneID ZoneID) {
+	zone := engine.Zone(zoneID).zone
+	if zone.OperationKind == OperationKindDelete {
+		engine.recordError("DeleteZone", ElementKindZone, int(zoneID), ErrElementDoesNotExist)
+		return
+	}
 	engine.deleteZone(zoneID)
 }
 func (engine *Engine) deleteZone(zoneID ZoneID) {
 	zone := engine.Zone(zoneID).zone
+	if zone.Boss != 0 {
+		engine.deletePlayer(zone.Boss)
+	}
 	for _, interactableID := range zone.Interactables {
 		engine.deleteAnyOfItem_Player_ZoneItem(interactableID, true)
 	}
@@ -135,6 +170,11 @@ func (engine *Engine) deleteZone(zoneID ZoneID) {
 }
 
 func (engine *Engine) DeleteEquipmentSet(equipmentSetID EquipmentSetID) {
+	equipmentSet := engine.EquipmentSet(equipmentSetID).equipmentSet
+	if equipmentSet.OperationKind == OperationKindDelete {
+		engine.recordError("DeleteEquipmentSet", ElementKindEquipmentSet, int(equipmentSetID), ErrElementDoesNotExist)
+		return
+	}
 	engine.deleteEquipmentSet(equipmentSetID)
 }
 func (engine *Engine) deleteEquipmentSet(equipmentSetID EquipmentSetID) {
@@ -0,0 +1,28 @@
+package state
+
+import (
+	"strconv"
+	"time"
+)
+
+// Duration is a time.Duration which is encoded as milliseconds, precision below
+// a millisecond is truncated when encoded, so a set value of 1.5ms is sent as 1
+type Duration time.Duration
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.FormatInt(time.Duration(d).Milliseconds(), 10)), nil
+}
+
+// UnmarshalJSON accepts fractional milliseconds and
+// leaves the duration untouched for null
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	milliseconds, err := strconv.ParseFloat(string(data), 64)
+	if err != nil {
+		return err
+	}
+	*d = Duration(milliseconds * float64(time.Millisecond))
+	return nil
+}
@@ -0,0 +1,159 @@
+package state
+
+import (
+	"strconv"
+	"strings"
+)
+
+type AnyElement struct {
+	kind   ElementKind
+	id     int
+	engine *Engine
+}
+
+func (element AnyElement) Kind() ElementKind {
+	return element.kind
+}
+
+func (element AnyElement) EquipmentSet() equipmentSet {
+	return element.engine.EquipmentSet(EquipmentSetID(element.id))
+}
+
+func (element AnyElement) GearScore() gearScore {
+	return element.engine.GearScore(GearScoreID(element.id))
+}
+
+func (element AnyElement) Item() item {
+	return element.engine.Item(ItemID(element.id))
+}
+
+func (element AnyElement) Player() player {
+	return element.engine.Player(PlayerID(element.id))
+}
+
+func (element AnyElement) Position() position {
+	return element.engine.Position(PositionID(element.id))
+}
+
+func (element AnyElement) Zone() zone {
+	return element.engine.Zone(ZoneID(element.id))
+}
+
+func (element AnyElement) ZoneItem() zoneItem {
+	return element.engine.ZoneItem(ZoneItemID(element.id))
+}
+
+func (element AnyElement) path() string {
+	switch element.kind {
+	case ElementKindEquipmentSet:
+		return element.EquipmentSet().Path()
+	case ElementKindGearScore:
+		return element.GearScore().Path()
+	case ElementKindItem:
+		return element.Item().Path()
+	case ElementKindPlayer:
+		return element.Player().Path()
+	case ElementKindPosition:
+		return element.Position().Path()
+	case ElementKindZone:
+		return element.Zone().Path()
+	case ElementKindZoneItem:
+		return element.ZoneItem().Path()
+	}
+	return ""
+}
+
+func (engine *Engine) elementByID(id int) (AnyElement, bool) {
+	if engine.EquipmentSet(EquipmentSetID(id)).equipmentSet.OperationKind != OperationKindDelete {
+		return AnyElement{engine: engine, id: id, kind: ElementKindEquipmentSet}, true
+	}
+	if engine.GearScore(GearScoreID(id)).gearScore.OperationKind != OperationKindDelete {
+		return AnyElement{engine: engine, id: id, kind: ElementKindGearScore}, true
+	}
+	if engine.Item(ItemID(id)).item.OperationKind != OperationKindDelete {
+		return AnyElement{engine: engine, id: id, kind: ElementKindItem}, true
+	}
+	if engine.Player(PlayerID(id)).player.OperationKind != OperationKindDelete {
+		return AnyElement{engine: engine, id: id, kind: ElementKindPlayer}, true
+	}
+	if engine.Position(PositionID(id)).position.OperationKind != OperationKindDelete {
+		return AnyElement{engine: engine, id: id, kind: ElementKindPosition}, true
+	}
+	if engine.Zone(ZoneID(id)).zone.OperationKind != OperationKindDelete {
+		return AnyElement{engine: engine, id: id, kind: ElementKindZone}, true
+	}
+	if engine.ZoneItem(ZoneItemID(id)).zoneItem.OperationKind != OperationKindDelete {
+		return AnyElement{engine: engine, id: id, kind: ElementKindZoneItem}, true
+	}
+	return AnyElement{}, false
+}
+
+func (element AnyElement) child(identifier string) (AnyElement, bool) {
+	switch element.kind {
+	case ElementKindItem:
+		item := element.Item()
+		switch identifier {
+		case "gearScore":
+			return AnyElement{engine: element.engine, id: int(item.GearScore().ID()), kind: ElementKindGearScore}, true
+		case "origin":
+			origin := item.Origin()
+			switch origin.Kind() {
+			case ElementKindPlayer:
+				return AnyElement{engine: element.engine, id: int(origin.Player().ID()), kind: ElementKindPlayer}, true
+			case ElementKindPosition:
+				return AnyElement{engine: element.engine, id: int(origin.Position().ID()), kind: ElementKindPosition}, true
+			}
+		}
+	case ElementKindPlayer:
+		player := element.Player()
+		switch identifier {
+		case "gearScore":
+			return AnyElement{engine: element.engine, id: int(player.GearScore().ID()), kind: ElementKindGearScore}, true
+		case "position":
+			return AnyElement{engine: element.engine, id: int(player.Position().ID()), kind: ElementKindPosition}, true
+		}
+	case ElementKindZone:
+		zone := element.Zone()
+		switch identifier {
+		case "boss":
+			if boss, ok := zone.Boss(); ok {
+				return AnyElement{engine: element.engine, id: int(boss.ID()), kind: ElementKindPlayer}, true
+			}
+		}
+	case ElementKindZoneItem:
+		zoneItem := element.ZoneItem()
+		switch identifier {
+		case "item":
+			return AnyElement{engine: element.engine, id: int(zoneItem.Item().ID()), kind: ElementKindItem}, true
+		case "position":
+			return AnyElement{engine: element.engine, id: int(zoneItem.Position().ID()), kind: ElementKindPosition}, true
+		}
+	}
+	return AnyElement{}, false
+}
+
+func (engine *Engine) ElementByPath(jsonPath string) (AnyElement, bool) {
+	segments := strings.Split(strings.NewReplacer("[", ".", "]", "").Replace(strings.TrimPrefix(jsonPath, "$.")), ".")
+	lastIDIndex := -1
+	var id int
+	for i, segment := range segments {
+		if segmentID, err := strconv.Atoi(segment); err == nil {
+			lastIDIndex = i
+			id = segmentID
+		}
+	}
+	if lastIDIndex == -1 {
+		return AnyElement{}, false
+	}
+	element, ok := engine.elementByID(id)
+	for _, identifier := range segments[lastIDIndex+1:] {
+		if !ok {
+			break
+		}
+		element, ok = element.child(identifier)
+	}
+	if !ok || element.path() != jsonPath {
+		return AnyElement{}, false
+	}
+	return element, true
+}
@@ -0,0 +1,31 @@
+package state
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrElementDoesNotExist = errors.New("element does not exist")
+
+var ErrElementHasParent = errors.New("element has a parent")
+
+var ErrElementNotDetachable = errors.New("element cannot be detached from its parent")
+
+var ErrElementIsAncestor = errors.New("element is an ancestor of the adopting element")
+
+var ErrElementNotInSlice = errors.New("element is not in slice")
+
+func (engine *Engine) EnableStrictMode() {
+	engine.strict = true
+}
+
+func (engine *Engine) Errors() []error {
+	return engine.errs
+}
+
+func (engine *Engine) recordError(operation string, kind ElementKind, id int, err error) {
+	if !engine.strict {
+		return
+	}
+	engine.errs = append(engine.errs, fmt.Errorf("%s: %s %d: %w", operation, kind, id, err))
+}
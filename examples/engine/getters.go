@@ -1,5 +1,7 @@
 package state
 
+import "time"
+
 func (engine *Engine) EveryPlayer() []player {
 	playerIDs := engine.allPlayerIDs()
 	var players []player
@@ -26,6 +28,15 @@ func (engine *Engine) Player(playerID PlayerID) player {
 	return player{player: playerCore{OperationKind: OperationKindDelete, engine: engine}}
 }
 
+func (engine *Engine) PlayerExists(playerID PlayerID) bool {
+	return engine.Player(playerID).player.OperationKind != OperationKindDelete
+}
+
+func (engine *Engine) LookupPlayer(playerID PlayerID) (player, bool) {
+	player := engine.Player(playerID)
+	return player, player.player.OperationKind != OperationKindDelete
+}
+
 func (_player player) ID() PlayerID {
 	return _player.player.ID
 }
@@ -111,6 +122,15 @@ func (engine *Engine) GearScore(gearScoreID GearScoreID) gearScore {
 	return gearScore{gearScore: gearScoreCore{OperationKind: OperationKindDelete, engine: engine}}
 }
 
+func (engine *Engine) GearScoreExists(gearScoreID GearScoreID) bool {
+	return engine.GearScore(gearScoreID).gearScore.OperationKind != OperationKindDelete
+}
+
+func (engine *Engine) LookupGearScore(gearScoreID GearScoreID) (gearScore, bool) {
+	gearScore := engine.GearScore(gearScoreID)
+	return gearScore, gearScore.gearScore.OperationKind != OperationKindDelete
+}
+
 func (_gearScore gearScore) ID() GearScoreID {
 	return _gearScore.gearScore.ID
 }
@@ -155,6 +175,15 @@ func (engine *Engine) Item(itemID ItemID) item {
 	return item{item: itemCore{OperationKind: OperationKindDelete, engine: engine}}
 }
 
+func (engine *Engine) ItemExists(itemID ItemID) bool {
+	return engine.Item(itemID).item.OperationKind != OperationKindDelete
+}
+
+func (engine *Engine) LookupItem(itemID ItemID) (item, bool) {
+	item := engine.Item(itemID)
+	return item, item.item.OperationKind != OperationKindDelete
+}
+
 func (_item item) ID() ItemID {
 	return _item.item.ID
 }
@@ -208,6 +237,15 @@ func (engine *Engine) Position(positionID PositionID) position {
 	return position{position: positionCore{OperationKind: OperationKindDelete, engine: engine}}
 }
 
+func (engine *Engine) PositionExists(positionID PositionID) bool {
+	return engine.Position(positionID).position.OperationKind != OperationKindDelete
+}
+
+func (engine *Engine) LookupPosition(positionID PositionID) (position, bool) {
+	position := engine.Position(positionID)
+	return position, position.position.OperationKind != OperationKindDelete
+}
+
 func (_position position) ID() PositionID {
 	return _position.position.ID
 }
@@ -252,6 +290,15 @@ func (engine *Engine) ZoneItem(zoneItemID ZoneItemID) zoneItem {
 	return zoneItem{zoneItem: zoneItemCore{OperationKind: OperationKindDelete, engine: engine}}
 }
 
+func (engine *Engine) ZoneItemExists(zoneItemID ZoneItemID) bool {
+	return engine.ZoneItem(zoneItemID).zoneItem.OperationKind != OperationKindDelete
+}
+
+func (engine *Engine) LookupZoneItem(zoneItemID ZoneItemID) (zoneItem, bool) {
+	zoneItem := engine.ZoneItem(zoneItemID)
+	return zoneItem, zoneItem.zoneItem.OperationKind != OperationKindDelete
+}
+
 func (_zoneItem zoneItem) ID() ZoneItemID {
 	return _zoneItem.zoneItem.ID
 }
@@ -293,6 +340,15 @@ func (engine *Engine) Zone(zoneID ZoneID) zone {
 	return zone{zone: zoneCore{OperationKind: OperationKindDelete, engine: engine}}
 }
 
+func (engine *Engine) ZoneExists(zoneID ZoneID) bool {
+	return engine.Zone(zoneID).zone.OperationKind != OperationKindDelete
+}
+
+func (engine *Engine) LookupZone(zoneID ZoneID) (zone, bool) {
+	zone := engine.Zone(zoneID)
+	return zone, zone.zone.OperationKind != OperationKindDelete
+}
+
 func (_zone zone) ID() ZoneID {
 	return _zone.zone.ID
 }
@@ -301,6 +357,11 @@ func (_zone zone) Path() string {
 	return _zone.zone.Path
 }
 
+func (_zone zone) Boss() (player, bool) {
+	zone := _zone.zone.engine.Zone(_zone.zone.ID)
+	return zone.zone.engine.Player(zone.zone.Boss), zone.zone.Boss != 0
+}
+
 func (_zone zone) Players() []player {
 	zone := _zone.zone.engine.Zone(_zone.zone.ID)
 	var players []player
@@ -396,6 +457,15 @@ func (engine *Engine) EquipmentSet(equipmentSetID EquipmentSetID) equipmentSet {
 	return equipmentSet{equipmentSet: equipmentSetCore{OperationKind: OperationKindDelete, engine: engine}}
 }
 
+func (engine *Engine) EquipmentSetExists(equipmentSetID EquipmentSetID) bool {
+	return engine.EquipmentSet(equipmentSetID).equipmentSet.OperationKind != OperationKindDelete
+}
+
+func (engine *Engine) LookupEquipmentSet(equipmentSetID EquipmentSetID) (equipmentSet, bool) {
+	equipmentSet := engine.EquipmentSet(equipmentSetID)
+	return equipmentSet, equipmentSet.equipmentSet.OperationKind != OperationKindDelete
+}
+
 func (_equipmentSet equipmentSet) ID() EquipmentSetID {
 	return _equipmentSet.equipmentSet.ID
 }
@@ -409,6 +479,26 @@ func (_equipmentSet equipmentSet) Name() string {
 	return equipmentSet.equipmentSet.Name
 }
 
+func (_equipmentSet equipmentSet) CreatedAt() time.Time {
+	equipmentSet := _equipmentSet.equipmentSet.engine.EquipmentSet(_equipmentSet.equipmentSet.ID)
+	return equipmentSet.equipmentSet.CreatedAt
+}
+
+func (_equipmentSet equipmentSet) Cooldown() Duration {
+	equipmentSet := _equipmentSet.equipmentSet.engine.EquipmentSet(_equipmentSet.equipmentSet.ID)
+	return equipmentSet.equipmentSet.Cooldown
+}
+
+func (_equipmentSet equipmentSet) ExternalID() BigID {
+	equipmentSet := _equipmentSet.equipmentSet.engine.EquipmentSet(_equipmentSet.equipmentSet.ID)
+	return equipmentSet.equipmentSet.ExternalID
+}
+
+func (_equipmentSet equipmentSet) Icon() []byte {
+	equipmentSet := _equipmentSet.equipmentSet.engine.EquipmentSet(_equipmentSet.equipmentSet.ID)
+	return append([]byte(nil), equipmentSet.equipmentSet.Icon...)
+}
+
 func (_equipmentSet equipmentSet) Equipment() []equipmentSetEquipmentRef {
 	equipmentSet := _equipmentSet.equipmentSet.engine.EquipmentSet(_equipmentSet.equipmentSet.ID)
 	var equipment []equipmentSetEquipmentRef
@@ -1,5 +1,7 @@
 package state
 
+import "sort"
+
 func deduplicateZoneItemIDs(a []ZoneItemID, b []ZoneItemID) []ZoneItemID {
 
 	check := zoneItemCheckPool.Get().(map[ZoneItemID]bool)
@@ -310,6 +312,11 @@ func (engine Engine) allEquipmentSetIDs() []EquipmentSetID {
 		patchEquipmentSetIDs = append(patchEquipmentSetIDs, equipmentSetID)
 	}
 	dedupedIDs := deduplicateEquipmentSetIDs(stateEquipmentSetIDs, patchEquipmentSetIDs)
+	if engine.ordered {
+		sort.Slice(dedupedIDs, func(i, j int) bool {
+			return dedupedIDs[i] < dedupedIDs[j]
+		})
+	}
 
 	equipmentSetIDSlicePool.Put(stateEquipmentSetIDs)
 	equipmentSetIDSlicePool.Put(patchEquipmentSetIDs)
@@ -327,6 +334,11 @@ func (engine Engine) allGearScoreIDs() []GearScoreID {
 		patchGearScoreIDs = append(patchGearScoreIDs, gearScoreID)
 	}
 	dedupedIDs := deduplicateGearScoreIDs(stateGearScoreIDs, patchGearScoreIDs)
+	if engine.ordered {
+		sort.Slice(dedupedIDs, func(i, j int) bool {
+			return dedupedIDs[i] < dedupedIDs[j]
+		})
+	}
 
 	gearScoreIDSlicePool.Put(stateGearScoreIDs)
 	gearScoreIDSlicePool.Put(patchGearScoreIDs)
@@ -344,6 +356,11 @@ func (engine Engine) allItemIDs() []ItemID {
 		patchItemIDs = append(patchItemIDs, itemID)
 	}
 	dedupedIDs := deduplicateItemIDs(stateItemIDs, patchItemIDs)
+	if engine.ordered {
+		sort.Slice(dedupedIDs, func(i, j int) bool {
+			return dedupedIDs[i] < dedupedIDs[j]
+		})
+	}
 
 	itemIDSlicePool.Put(stateItemIDs)
 	itemIDSlicePool.Put(patchItemIDs)
@@ -361,6 +378,11 @@ func (engine Engine) allPositionIDs() []PositionID {
 		patchPositionIDs = append(patchPositionIDs, positionID)
 	}
 	dedupedIDs := deduplicatePositionIDs(statePositionIDs, patchPositionIDs)
+	if engine.ordered {
+		sort.Slice(dedupedIDs, func(i, j int) bool {
+			return dedupedIDs[i] < dedupedIDs[j]
+		})
+	}
 
 	positionIDSlicePool.Put(statePositionIDs)
 	positionIDSlicePool.Put(patchPositionIDs)
@@ -378,6 +400,11 @@ func (engine Engine) allZoneIDs() []ZoneID {
 		patchZoneIDs = append(patchZoneIDs, zoneID)
 	}
 	dedupedIDs := deduplicateZoneIDs(stateZoneIDs, patchZoneIDs)
+	if engine.ordered {
+		sort.Slice(dedupedIDs, func(i, j int) bool {
+			return dedupedIDs[i] < dedupedIDs[j]
+		})
+	}
 
 	zoneIDSlicePool.Put(stateZoneIDs)
 	zoneIDSlicePool.Put(patchZoneIDs)
@@ -395,6 +422,11 @@ func (engine Engine) allZoneItemIDs() []ZoneItemID {
 		patchZoneItemIDs = append(patchZoneItemIDs, zoneItemID)
 	}
 	dedupedIDs := deduplicateZoneItemIDs(stateZoneItemIDs, patchZoneItemIDs)
+	if engine.ordered {
+		sort.Slice(dedupedIDs, func(i, j int) bool {
+			return dedupedIDs[i] < dedupedIDs[j]
+		})
+	}
 
 	zoneItemIDSlicePool.Put(stateZoneItemIDs)
 	zoneItemIDSlicePool.Put(patchZoneItemIDs)
@@ -412,6 +444,11 @@ func (engine Engine) allPlayerIDs() []PlayerID {
 		patchPlayerIDs = append(patchPlayerIDs, playerID)
 	}
 	dedupedIDs := deduplicatePlayerIDs(statePlayerIDs, patchPlayerIDs)
+	if engine.ordered {
+		sort.Slice(dedupedIDs, func(i, j int) bool {
+			return dedupedIDs[i] < dedupedIDs[j]
+		})
+	}
 
 	playerIDSlicePool.Put(statePlayerIDs)
 	playerIDSlicePool.Put(patchPlayerIDs)
@@ -429,6 +466,11 @@ func (engine Engine) allPlayerTargetedByRefIDs() []PlayerTargetedByRefID {
 		patchPlayerTargetedByRefIDs = append(patchPlayerTargetedByRefIDs, playerTargetedByRefID)
 	}
 	dedupedIDs := deduplicatePlayerTargetedByRefIDs(statePlayerTargetedByRefIDs, patchPlayerTargetedByRefIDs)
+	if engine.ordered {
+		sort.Slice(dedupedIDs, func(i, j int) bool {
+			return dedupedIDs[i] < dedupedIDs[j]
+		})
+	}
 
 	playerTargetedByRefIDSlicePool.Put(statePlayerTargetedByRefIDs)
 	playerTargetedByRefIDSlicePool.Put(patchPlayerTargetedByRefIDs)
@@ -446,6 +488,11 @@ func (engine Engine) allPlayerTargetRefIDs() []PlayerTargetRefID {
 		patchPlayerTargetRefIDs = append(patchPlayerTargetRefIDs, playerTargetRefID)
 	}
 	dedupedIDs := deduplicatePlayerTargetRefIDs(statePlayerTargetRefIDs, patchPlayerTargetRefIDs)
+	if engine.ordered {
+		sort.Slice(dedupedIDs, func(i, j int) bool {
+			return dedupedIDs[i] < dedupedIDs[j]
+		})
+	}
 
 	playerTargetRefIDSlicePool.Put(statePlayerTargetRefIDs)
 	playerTargetRefIDSlicePool.Put(patchPlayerTargetRefIDs)
@@ -463,6 +510,11 @@ func (engine Engine) allItemBoundToRefIDs() []ItemBoundToRefID {
 		patchItemBoundToRefIDs = append(patchItemBoundToRefIDs, itemBoundToRefID)
 	}
 	dedupedIDs := deduplicateItemBoundToRefIDs(stateItemBoundToRefIDs, patchItemBoundToRefIDs)
+	if engine.ordered {
+		sort.Slice(dedupedIDs, func(i, j int) bool {
+			return dedupedIDs[i] < dedupedIDs[j]
+		})
+	}
 
 	itemBoundToRefIDSlicePool.Put(stateItemBoundToRefIDs)
 	itemBoundToRefIDSlicePool.Put(patchItemBoundToRefIDs)
@@ -480,6 +532,11 @@ func (engine Engine) allPlayerGuildMemberRefIDs() []PlayerGuildMemberRefID {
 		patchPlayerGuildMemberRefIDs = append(patchPlayerGuildMemberRefIDs, playerGuildMemberRefID)
 	}
 	dedupedIDs := deduplicatePlayerGuildMemberRefIDs(statePlayerGuildMemberRefIDs, patchPlayerGuildMemberRefIDs)
+	if engine.ordered {
+		sort.Slice(dedupedIDs, func(i, j int) bool {
+			return dedupedIDs[i] < dedupedIDs[j]
+		})
+	}
 
 	playerGuildMemberRefIDSlicePool.Put(statePlayerGuildMemberRefIDs)
 	playerGuildMemberRefIDSlicePool.Put(patchPlayerGuildMemberRefIDs)
@@ -497,6 +554,11 @@ func (engine Engine) allPlayerEquipmentSetRefIDs() []PlayerEquipmentSetRefID {
 		patchPlayerEquipmentSetRefIDs = append(patchPlayerEquipmentSetRefIDs, playerEquipmentSetRefID)
 	}
 	dedupedIDs := deduplicatePlayerEquipmentSetRefIDs(statePlayerEquipmentSetRefIDs, patchPlayerEquipmentSetRefIDs)
+	if engine.ordered {
+		sort.Slice(dedupedIDs, func(i, j int) bool {
+			return dedupedIDs[i] < dedupedIDs[j]
+		})
+	}
 
 	playerEquipmentSetRefIDSlicePool.Put(statePlayerEquipmentSetRefIDs)
 	playerEquipmentSetRefIDSlicePool.Put(patchPlayerEquipmentSetRefIDs)
@@ -514,6 +576,11 @@ func (engine Engine) allEquipmentSetEquipmentRefIDs() []EquipmentSetEquipmentRef
 		patchEquipmentSetEquipmentRefIDs = append(patchEquipmentSetEquipmentRefIDs, equipmentSetEquipmentRefID)
 	}
 	dedupedIDs := deduplicateEquipmentSetEquipmentRefIDs(stateEquipmentSetEquipmentRefIDs, patchEquipmentSetEquipmentRefIDs)
+	if engine.ordered {
+		sort.Slice(dedupedIDs, func(i, j int) bool {
+			return dedupedIDs[i] < dedupedIDs[j]
+		})
+	}
 
 	equipmentSetEquipmentRefIDSlicePool.Put(stateEquipmentSetEquipmentRefIDs)
 	equipmentSetEquipmentRefIDSlicePool.Put(patchEquipmentSetEquipmentRefIDs)
@@ -540,6 +607,44 @@ func mergeGearScoreIDs(currentIDs, nextIDs []GearScoreID) []GearScoreID {
 	return ids
 }
 
+func wereGearScoreIDsReordered(currentIDs, nextIDs []GearScoreID) bool {
+	var j int
+
+	for _, currentID := range currentIDs {
+		if len(nextIDs) <= j || currentID != nextIDs[j] {
+			continue
+		}
+		j += 1
+	}
+
+	if len(nextIDs[j:]) > 1 {
+		return true
+	}
+
+	for _, nextID := range nextIDs[j:] {
+		for _, currentID := range currentIDs {
+			if nextID == currentID {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func moveGearScoreID(ids []GearScoreID, from, to int) []GearScoreID {
+	if to < 0 {
+		to = 0
+	}
+	if to > len(ids)-1 {
+		to = len(ids) - 1
+	}
+	movedIDs := make([]GearScoreID, 0, len(ids))
+	movedIDs = append(movedIDs, ids[:from]...)
+	movedIDs = append(movedIDs, ids[from+1:]...)
+	return append(movedIDs[:to], append([]GearScoreID{ids[from]}, movedIDs[to:]...)...)
+}
+
 func mergeItemIDs(currentIDs, nextIDs []ItemID) []ItemID {
 	ids := make([]ItemID, len(currentIDs))
 	copy(ids, currentIDs)
@@ -559,6 +664,44 @@ func mergeItemIDs(currentIDs, nextIDs []ItemID) []ItemID {
 	return ids
 }
 
+func wereItemIDsReordered(currentIDs, nextIDs []ItemID) bool {
+	var j int
+
+	for _, currentID := range currentIDs {
+		if len(nextIDs) <= j || currentID != nextIDs[j] {
+			continue
+		}
+		j += 1
+	}
+
+	if len(nextIDs[j:]) > 1 {
+		return true
+	}
+
+	for _, nextID := range nextIDs[j:] {
+		for _, currentID := range currentIDs {
+			if nextID == currentID {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func moveItemID(ids []ItemID, from, to int) []ItemID {
+	if to < 0 {
+		to = 0
+	}
+	if to > len(ids)-1 {
+		to = len(ids) - 1
+	}
+	movedIDs := make([]ItemID, 0, len(ids))
+	movedIDs = append(movedIDs, ids[:from]...)
+	movedIDs = append(movedIDs, ids[from+1:]...)
+	return append(movedIDs[:to], append([]ItemID{ids[from]}, movedIDs[to:]...)...)
+}
+
 func mergePlayerIDs(currentIDs, nextIDs []PlayerID) []PlayerID {
 	ids := make([]PlayerID, len(currentIDs))
 	copy(ids, currentIDs)
@@ -578,6 +721,44 @@ func mergePlayerIDs(currentIDs, nextIDs []PlayerID) []PlayerID {
 	return ids
 }
 
+func werePlayerIDsReordered(currentIDs, nextIDs []PlayerID) bool {
+	var j int
+
+	for _, currentID := range currentIDs {
+		if len(nextIDs) <= j || currentID != nextIDs[j] {
+			continue
+		}
+		j += 1
+	}
+
+	if len(nextIDs[j:]) > 1 {
+		return true
+	}
+
+	for _, nextID := range nextIDs[j:] {
+		for _, currentID := range currentIDs {
+			if nextID == currentID {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func movePlayerID(ids []PlayerID, from, to int) []PlayerID {
+	if to < 0 {
+		to = 0
+	}
+	if to > len(ids)-1 {
+		to = len(ids) - 1
+	}
+	movedIDs := make([]PlayerID, 0, len(ids))
+	movedIDs = append(movedIDs, ids[:from]...)
+	movedIDs = append(movedIDs, ids[from+1:]...)
+	return append(movedIDs[:to], append([]PlayerID{ids[from]}, movedIDs[to:]...)...)
+}
+
 func mergePositionIDs(currentIDs, nextIDs []PositionID) []PositionID {
 	ids := make([]PositionID, len(currentIDs))
 	copy(ids, currentIDs)
@@ -597,6 +778,44 @@ func mergePositionIDs(currentIDs, nextIDs []PositionID) []PositionID {
 	return ids
 }
 
+func werePositionIDsReordered(currentIDs, nextIDs []PositionID) bool {
+	var j int
+
+	for _, currentID := range currentIDs {
+		if len(nextIDs) <= j || currentID != nextIDs[j] {
+			continue
+		}
+		j += 1
+	}
+
+	if len(nextIDs[j:]) > 1 {
+		return true
+	}
+
+	for _, nextID := range nextIDs[j:] {
+		for _, currentID := range currentIDs {
+			if nextID == currentID {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func movePositionID(ids []PositionID, from, to int) []PositionID {
+	if to < 0 {
+		to = 0
+	}
+	if to > len(ids)-1 {
+		to = len(ids) - 1
+	}
+	movedIDs := make([]PositionID, 0, len(ids))
+	movedIDs = append(movedIDs, ids[:from]...)
+	movedIDs = append(movedIDs, ids[from+1:]...)
+	return append(movedIDs[:to], append([]PositionID{ids[from]}, movedIDs[to:]...)...)
+}
+
 func mergeZoneIDs(currentIDs, nextIDs []ZoneID) []ZoneID {
 	ids := make([]ZoneID, len(currentIDs))
 	copy(ids, currentIDs)
@@ -616,6 +835,44 @@ func mergeZoneIDs(currentIDs, nextIDs []ZoneID) []ZoneID {
 	return ids
 }
 
+func wereZoneIDsReordered(currentIDs, nextIDs []ZoneID) bool {
+	var j int
+
+	for _, currentID := range currentIDs {
+		if len(nextIDs) <= j || currentID != nextIDs[j] {
+			continue
+		}
+		j += 1
+	}
+
+	if len(nextIDs[j:]) > 1 {
+		return true
+	}
+
+	for _, nextID := range nextIDs[j:] {
+		for _, currentID := range currentIDs {
+			if nextID == currentID {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func moveZoneID(ids []ZoneID, from, to int) []ZoneID {
+	if to < 0 {
+		to = 0
+	}
+	if to > len(ids)-1 {
+		to = len(ids) - 1
+	}
+	movedIDs := make([]ZoneID, 0, len(ids))
+	movedIDs = append(movedIDs, ids[:from]...)
+	movedIDs = append(movedIDs, ids[from+1:]...)
+	return append(movedIDs[:to], append([]ZoneID{ids[from]}, movedIDs[to:]...)...)
+}
+
 func mergeZoneItemIDs(currentIDs, nextIDs []ZoneItemID) []ZoneItemID {
 	ids := make([]ZoneItemID, len(currentIDs))
 	copy(ids, currentIDs)
@@ -635,6 +892,44 @@ func mergeZoneItemIDs(currentIDs, nextIDs []ZoneItemID) []ZoneItemID {
 	return ids
 }
 
+func wereZoneItemIDsReordered(currentIDs, nextIDs []ZoneItemID) bool {
+	var j int
+
+	for _, currentID := range currentIDs {
+		if len(nextIDs) <= j || currentID != nextIDs[j] {
+			continue
+		}
+		j += 1
+	}
+
+	if len(nextIDs[j:]) > 1 {
+		return true
+	}
+
+	for _, nextID := range nextIDs[j:] {
+		for _, currentID := range currentIDs {
+			if nextID == currentID {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func moveZoneItemID(ids []ZoneItemID, from, to int) []ZoneItemID {
+	if to < 0 {
+		to = 0
+	}
+	if to > len(ids)-1 {
+		to = len(ids) - 1
+	}
+	movedIDs := make([]ZoneItemID, 0, len(ids))
+	movedIDs = append(movedIDs, ids[:from]...)
+	movedIDs = append(movedIDs, ids[from+1:]...)
+	return append(movedIDs[:to], append([]ZoneItemID{ids[from]}, movedIDs[to:]...)...)
+}
+
 func mergeEquipmentSetIDs(currentIDs, nextIDs []EquipmentSetID) []EquipmentSetID {
 	ids := make([]EquipmentSetID, len(currentIDs))
 	copy(ids, currentIDs)
@@ -654,6 +949,44 @@ func mergeEquipmentSetIDs(currentIDs, nextIDs []EquipmentSetID) []EquipmentSetID
 	return ids
 }
 
+func wereEquipmentSetIDsReordered(currentIDs, nextIDs []EquipmentSetID) bool {
+	var j int
+
+	for _, currentID := range currentIDs {
+		if len(nextIDs) <= j || currentID != nextIDs[j] {
+			continue
+		}
+		j += 1
+	}
+
+	if len(nextIDs[j:]) > 1 {
+		return true
+	}
+
+	for _, nextID := range nextIDs[j:] {
+		for _, currentID := range currentIDs {
+			if nextID == currentID {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func moveEquipmentSetID(ids []EquipmentSetID, from, to int) []EquipmentSetID {
+	if to < 0 {
+		to = 0
+	}
+	if to > len(ids)-1 {
+		to = len(ids) - 1
+	}
+	movedIDs := make([]EquipmentSetID, 0, len(ids))
+	movedIDs = append(movedIDs, ids[:from]...)
+	movedIDs = append(movedIDs, ids[from+1:]...)
+	return append(movedIDs[:to], append([]EquipmentSetID{ids[from]}, movedIDs[to:]...)...)
+}
+
 func mergeItemBoundToRefIDs(currentIDs, nextIDs []ItemBoundToRefID) []ItemBoundToRefID {
 	ids := make([]ItemBoundToRefID, len(currentIDs))
 	copy(ids, currentIDs)
@@ -673,6 +1006,44 @@ func mergeItemBoundToRefIDs(currentIDs, nextIDs []ItemBoundToRefID) []ItemBoundT
 	return ids
 }
 
+func wereItemBoundToRefIDsReordered(currentIDs, nextIDs []ItemBoundToRefID) bool {
+	var j int
+
+	for _, currentID := range currentIDs {
+		if len(nextIDs) <= j || currentID != nextIDs[j] {
+			continue
+		}
+		j += 1
+	}
+
+	if len(nextIDs[j:]) > 1 {
+		return true
+	}
+
+	for _, nextID := range nextIDs[j:] {
+		for _, currentID := range currentIDs {
+			if nextID == currentID {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func moveItemBoundToRefID(ids []ItemBoundToRefID, from, to int) []ItemBoundToRefID {
+	if to < 0 {
+		to = 0
+	}
+	if to > len(ids)-1 {
+		to = len(ids) - 1
+	}
+	movedIDs := make([]ItemBoundToRefID, 0, len(ids))
+	movedIDs = append(movedIDs, ids[:from]...)
+	movedIDs = append(movedIDs, ids[from+1:]...)
+	return append(movedIDs[:to], append([]ItemBoundToRefID{ids[from]}, movedIDs[to:]...)...)
+}
+
 func mergeEquipmentSetEquipmentRefIDs(currentIDs, nextIDs []EquipmentSetEquipmentRefID) []EquipmentSetEquipmentRefID {
 	ids := make([]EquipmentSetEquipmentRefID, len(currentIDs))
 	copy(ids, currentIDs)
@@ -692,6 +1063,44 @@ func mergeEquipmentSetEquipmentRefIDs(currentIDs, nextIDs []EquipmentSetEquipmen
 	return ids
 }
 
+func wereEquipmentSetEquipmentRefIDsReordered(currentIDs, nextIDs []EquipmentSetEquipmentRefID) bool {
+	var j int
+
+	for _, currentID := range currentIDs {
+		if len(nextIDs) <= j || currentID != nextIDs[j] {
+			continue
+		}
+		j += 1
+	}
+
+	if len(nextIDs[j:]) > 1 {
+		return true
+	}
+
+	for _, nextID := range nextIDs[j:] {
+		for _, currentID := range currentIDs {
+			if nextID == currentID {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func moveEquipmentSetEquipmentRefID(ids []EquipmentSetEquipmentRefID, from, to int) []EquipmentSetEquipmentRefID {
+	if to < 0 {
+		to = 0
+	}
+	if to > len(ids)-1 {
+		to = len(ids) - 1
+	}
+	movedIDs := make([]EquipmentSetEquipmentRefID, 0, len(ids))
+	movedIDs = append(movedIDs, ids[:from]...)
+	movedIDs = append(movedIDs, ids[from+1:]...)
+	return append(movedIDs[:to], append([]EquipmentSetEquipmentRefID{ids[from]}, movedIDs[to:]...)...)
+}
+
 func mergePlayerGuildMemberRefIDs(currentIDs, nextIDs []PlayerGuildMemberRefID) []PlayerGuildMemberRefID {
 	ids := make([]PlayerGuildMemberRefID, len(currentIDs))
 	copy(ids, currentIDs)
@@ -711,6 +1120,44 @@ func mergePlayerGuildMemberRefIDs(currentIDs, nextIDs []PlayerGuildMemberRefID)
 	return ids
 }
 
+func werePlayerGuildMemberRefIDsReordered(currentIDs, nextIDs []PlayerGuildMemberRefID) bool {
+	var j int
+
+	for _, currentID := range currentIDs {
+		if len(nextIDs) <= j || currentID != nextIDs[j] {
+			continue
+		}
+		j += 1
+	}
+
+	if len(nextIDs[j:]) > 1 {
+		return true
+	}
+
+	for _, nextID := range nextIDs[j:] {
+		for _, currentID := range currentIDs {
+			if nextID == currentID {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func movePlayerGuildMemberRefID(ids []PlayerGuildMemberRefID, from, to int) []PlayerGuildMemberRefID {
+	if to < 0 {
+		to = 0
+	}
+	if to > len(ids)-1 {
+		to = len(ids) - 1
+	}
+	movedIDs := make([]PlayerGuildMemberRefID, 0, len(ids))
+	movedIDs = append(movedIDs, ids[:from]...)
+	movedIDs = append(movedIDs, ids[from+1:]...)
+	return append(movedIDs[:to], append([]PlayerGuildMemberRefID{ids[from]}, movedIDs[to:]...)...)
+}
+
 func mergePlayerTargetedByRefIDs(currentIDs, nextIDs []PlayerTargetedByRefID) []PlayerTargetedByRefID {
 	ids := make([]PlayerTargetedByRefID, len(currentIDs))
 	copy(ids, currentIDs)
@@ -730,6 +1177,44 @@ func mergePlayerTargetedByRefIDs(currentIDs, nextIDs []PlayerTargetedByRefID) []
 	return ids
 }
 
+func werePlayerTargetedByRefIDsReordered(currentIDs, nextIDs []PlayerTargetedByRefID) bool {
+	var j int
+
+	for _, currentID := range currentIDs {
+		if len(nextIDs) <= j || currentID != nextIDs[j] {
+			continue
+		}
+		j += 1
+	}
+
+	if len(nextIDs[j:]) > 1 {
+		return true
+	}
+
+	for _, nextID := range nextIDs[j:] {
+		for _, currentID := range currentIDs {
+			if nextID == currentID {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func movePlayerTargetedByRefID(ids []PlayerTargetedByRefID, from, to int) []PlayerTargetedByRefID {
+	if to < 0 {
+		to = 0
+	}
+	if to > len(ids)-1 {
+		to = len(ids) - 1
+	}
+	movedIDs := make([]PlayerTargetedByRefID, 0, len(ids))
+	movedIDs = append(movedIDs, ids[:from]...)
+	movedIDs = append(movedIDs, ids[from+1:]...)
+	return append(movedIDs[:to], append([]PlayerTargetedByRefID{ids[from]}, movedIDs[to:]...)...)
+}
+
 func mergePlayerTargetRefIDs(currentIDs, nextIDs []PlayerTargetRefID) []PlayerTargetRefID {
 	ids := make([]PlayerTargetRefID, len(currentIDs))
 	copy(ids, currentIDs)
@@ -749,6 +1234,44 @@ func mergePlayerTargetRefIDs(currentIDs, nextIDs []PlayerTargetRefID) []PlayerTa
 	return ids
 }
 
+func werePlayerTargetRefIDsReordered(currentIDs, nextIDs []PlayerTargetRefID) bool {
+	var j int
+
+	for _, currentID := range currentIDs {
+		if len(nextIDs) <= j || currentID != nextIDs[j] {
+			continue
+		}
+		j += 1
+	}
+
+	if len(nextIDs[j:]) > 1 {
+		return true
+	}
+
+	for _, nextID := range nextIDs[j:] {
+		for _, currentID := range currentIDs {
+			if nextID == currentID {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func movePlayerTargetRefID(ids []PlayerTargetRefID, from, to int) []PlayerTargetRefID {
+	if to < 0 {
+		to = 0
+	}
+	if to > len(ids)-1 {
+		to = len(ids) - 1
+	}
+	movedIDs := make([]PlayerTargetRefID, 0, len(ids))
+	movedIDs = append(movedIDs, ids[:from]...)
+	movedIDs = append(movedIDs, ids[from+1:]...)
+	return append(movedIDs[:to], append([]PlayerTargetRefID{ids[from]}, movedIDs[to:]...)...)
+}
+
 func mergePlayerEquipmentSetRefIDs(currentIDs, nextIDs []PlayerEquipmentSetRefID) []PlayerEquipmentSetRefID {
 	ids := make([]PlayerEquipmentSetRefID, len(currentIDs))
 	copy(ids, currentIDs)
@@ -768,6 +1291,44 @@ func mergePlayerEquipmentSetRefIDs(currentIDs, nextIDs []PlayerEquipmentSetRefID
 	return ids
 }
 
+func werePlayerEquipmentSetRefIDsReordered(currentIDs, nextIDs []PlayerEquipmentSetRefID) bool {
+	var j int
+
+	for _, currentID := range currentIDs {
+		if len(nextIDs) <= j || currentID != nextIDs[j] {
+			continue
+		}
+		j += 1
+	}
+
+	if len(nextIDs[j:]) > 1 {
+		return true
+	}
+
+	for _, nextID := range nextIDs[j:] {
+		for _, currentID := range currentIDs {
+			if nextID == currentID {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func movePlayerEquipmentSetRefID(ids []PlayerEquipmentSetRefID, from, to int) []PlayerEquipmentSetRefID {
+	if to < 0 {
+		to = 0
+	}
+	if to > len(ids)-1 {
+		to = len(ids) - 1
+	}
+	movedIDs := make([]PlayerEquipmentSetRefID, 0, len(ids))
+	movedIDs = append(movedIDs, ids[:from]...)
+	movedIDs = append(movedIDs, ids[from+1:]...)
+	return append(movedIDs[:to], append([]PlayerEquipmentSetRefID{ids[from]}, movedIDs[to:]...)...)
+}
+
 func mergeAnyOfPlayer_ZoneItemIDs(currentIDs, nextIDs []AnyOfPlayer_ZoneItemID) []AnyOfPlayer_ZoneItemID {
 	ids := make([]AnyOfPlayer_ZoneItemID, len(currentIDs))
 	copy(ids, currentIDs)
@@ -787,6 +1348,44 @@ func mergeAnyOfPlayer_ZoneItemIDs(currentIDs, nextIDs []AnyOfPlayer_ZoneItemID)
 	return ids
 }
 
+func wereAnyOfPlayer_ZoneItemIDsReordered(currentIDs, nextIDs []AnyOfPlayer_ZoneItemID) bool {
+	var j int
+
+	for _, currentID := range currentIDs {
+		if len(nextIDs) <= j || currentID != nextIDs[j] {
+			continue
+		}
+		j += 1
+	}
+
+	if len(nextIDs[j:]) > 1 {
+		return true
+	}
+
+	for _, nextID := range nextIDs[j:] {
+		for _, currentID := range currentIDs {
+			if nextID == currentID {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func moveAnyOfPlayer_ZoneItemID(ids []AnyOfPlayer_ZoneItemID, from, to int) []AnyOfPlayer_ZoneItemID {
+	if to < 0 {
+		to = 0
+	}
+	if to > len(ids)-1 {
+		to = len(ids) - 1
+	}
+	movedIDs := make([]AnyOfPlayer_ZoneItemID, 0, len(ids))
+	movedIDs = append(movedIDs, ids[:from]...)
+	movedIDs = append(movedIDs, ids[from+1:]...)
+	return append(movedIDs[:to], append([]AnyOfPlayer_ZoneItemID{ids[from]}, movedIDs[to:]...)...)
+}
+
 func mergeAnyOfPlayer_PositionIDs(currentIDs, nextIDs []AnyOfPlayer_PositionID) []AnyOfPlayer_PositionID {
 	ids := make([]AnyOfPlayer_PositionID, len(currentIDs))
 	copy(ids, currentIDs)
@@ -806,6 +1405,44 @@ func mergeAnyOfPlayer_PositionIDs(currentIDs, nextIDs []AnyOfPlayer_PositionID)
 	return ids
 }
 
+func wereAnyOfPlayer_PositionIDsReordered(currentIDs, nextIDs []AnyOfPlayer_PositionID) bool {
+	var j int
+
+	for _, currentID := range currentIDs {
+		if len(nextIDs) <= j || currentID != nextIDs[j] {
+			continue
+		}
+		j += 1
+	}
+
+	if len(nextIDs[j:]) > 1 {
+		return true
+	}
+
+	for _, nextID := range nextIDs[j:] {
+		for _, currentID := range currentIDs {
+			if nextID == currentID {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func moveAnyOfPlayer_PositionID(ids []AnyOfPlayer_PositionID, from, to int) []AnyOfPlayer_PositionID {
+	if to < 0 {
+		to = 0
+	}
+	if to > len(ids)-1 {
+		to = len(ids) - 1
+	}
+	movedIDs := make([]AnyOfPlayer_PositionID, 0, len(ids))
+	movedIDs = append(movedIDs, ids[:from]...)
+	movedIDs = append(movedIDs, ids[from+1:]...)
+	return append(movedIDs[:to], append([]AnyOfPlayer_PositionID{ids[from]}, movedIDs[to:]...)...)
+}
+
 func mergeAnyOfItem_Player_ZoneItemIDs(currentIDs, nextIDs []AnyOfItem_Player_ZoneItemID) []AnyOfItem_Player_ZoneItemID {
 	ids := make([]AnyOfItem_Player_ZoneItemID, len(currentIDs))
 	copy(ids, currentIDs)
@@ -824,3 +1461,41 @@ func mergeAnyOfItem_Player_ZoneItemIDs(currentIDs, nextIDs []AnyOfItem_Player_Zo
 
 	return ids
 }
+
+func wereAnyOfItem_Player_ZoneItemIDsReordered(currentIDs, nextIDs []AnyOfItem_Player_ZoneItemID) bool {
+	var j int
+
+	for _, currentID := range currentIDs {
+		if len(nextIDs) <= j || currentID != nextIDs[j] {
+			continue
+		}
+		j += 1
+	}
+
+	if len(nextIDs[j:]) > 1 {
+		return true
+	}
+
+	for _, nextID := range nextIDs[j:] {
+		for _, currentID := range currentIDs {
+			if nextID == currentID {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func moveAnyOfItem_Player_ZoneItemID(ids []AnyOfItem_Player_ZoneItemID, from, to int) []AnyOfItem_Player_ZoneItemID {
+	if to < 0 {
+		to = 0
+	}
+	if to > len(ids)-1 {
+		to = len(ids) - 1
+	}
+	movedIDs := make([]AnyOfItem_Player_ZoneItemID, 0, len(ids))
+	movedIDs = append(movedIDs, ids[:from]...)
+	movedIDs = append(movedIDs, ids[from+1:]...)
+	return append(movedIDs[:to], append([]AnyOfItem_Player_ZoneItemID{ids[from]}, movedIDs[to:]...)...)
+}
@@ -0,0 +1,463 @@
+package state
+
+import "sort"
+
+type listeners struct {
+	registered          bool
+	emitted             bool
+	equipmentSetCreated []func(equipmentSet)
+	equipmentSetUpdated []func(old, new equipmentSet)
+	equipmentSetDeleted []func(equipmentSet)
+	gearScoreCreated    []func(gearScore)
+	gearScoreUpdated    []func(old, new gearScore)
+	gearScoreDeleted    []func(gearScore)
+	itemCreated         []func(item)
+	itemUpdated         []func(old, new item)
+	itemDeleted         []func(item)
+	playerCreated       []func(player)
+	playerUpdated       []func(old, new player)
+	playerDeleted       []func(player)
+	positionCreated     []func(position)
+	positionUpdated     []func(old, new position)
+	positionDeleted     []func(position)
+	zoneCreated         []func(zone)
+	zoneUpdated         []func(old, new zone)
+	zoneDeleted         []func(zone)
+	zoneItemCreated     []func(zoneItem)
+	zoneItemUpdated     []func(old, new zoneItem)
+	zoneItemDeleted     []func(zoneItem)
+}
+
+func (engine *Engine) OnEquipmentSetCreated(listener func(equipmentSet)) {
+	engine.listeners.registered = true
+	engine.listeners.equipmentSetCreated = append(engine.listeners.equipmentSetCreated, listener)
+}
+
+func (engine *Engine) OnEquipmentSetUpdated(listener func(old, new equipmentSet)) {
+	engine.listeners.registered = true
+	engine.listeners.equipmentSetUpdated = append(engine.listeners.equipmentSetUpdated, listener)
+}
+
+func (engine *Engine) OnEquipmentSetDeleted(listener func(equipmentSet)) {
+	engine.listeners.registered = true
+	engine.listeners.equipmentSetDeleted = append(engine.listeners.equipmentSetDeleted, listener)
+}
+
+func (engine *Engine) OnGearScoreCreated(listener func(gearScore)) {
+	engine.listeners.registered = true
+	engine.listeners.gearScoreCreated = append(engine.listeners.gearScoreCreated, listener)
+}
+
+func (engine *Engine) OnGearScoreUpdated(listener func(old, new gearScore)) {
+	engine.listeners.registered = true
+	engine.listeners.gearScoreUpdated = append(engine.listeners.gearScoreUpdated, listener)
+}
+
+func (engine *Engine) OnGearScoreDeleted(listener func(gearScore)) {
+	engine.listeners.registered = true
+	engine.listeners.gearScoreDeleted = append(engine.listeners.gearScoreDeleted, listener)
+}
+
+func (engine *Engine) OnItemCreated(listener func(item)) {
+	engine.listeners.registered = true
+	engine.listeners.itemCreated = append(engine.listeners.itemCreated, listener)
+}
+
+func (engine *Engine) OnItemUpdated(listener func(old, new item)) {
+	engine.listeners.registered = true
+	engine.listeners.itemUpdated = append(engine.listeners.itemUpdated, listener)
+}
+
+func (engine *Engine) OnItemDeleted(listener func(item)) {
+	engine.listeners.registered = true
+	engine.listeners.itemDeleted = append(engine.listeners.itemDeleted, listener)
+}
+
+func (engine *Engine) OnPlayerCreated(listener func(player)) {
+	engine.listeners.registered = true
+	engine.listeners.playerCreated = append(engine.listeners.playerCreated, listener)
+}
+
+func (engine *Engine) OnPlayerUpdated(listener func(old, new player)) {
+	engine.listeners.registered = true
+	engine.listeners.playerUpdated = append(engine.listeners.playerUpdated, listener)
+}
+
+func (engine *Engine) OnPlayerDeleted(listener func(player)) {
+	engine.listeners.registered = true
+	engine.listeners.playerDeleted = append(engine.listeners.playerDeleted, listener)
+}
+
+func (engine *Engine) OnPositionCreated(listener func(position)) {
+	engine.listeners.registered = true
+	engine.listeners.positionCreated = append(engine.listeners.positionCreated, listener)
+}
+
+func (engine *Engine) OnPositionUpdated(listener func(old, new position)) {
+	engine.listeners.registered = true
+	engine.listeners.positionUpdated = append(engine.listeners.positionUpdated, listener)
+}
+
+func (engine *Engine) OnPositionDeleted(listener func(position)) {
+	engine.listeners.registered = true
+	engine.listeners.positionDeleted = append(engine.listeners.positionDeleted, listener)
+}
+
+func (engine *Engine) OnZoneCreated(listener func(zone)) {
+	engine.listeners.registered = true
+	engine.listeners.zoneCreated = append(engine.listeners.zoneCreated, listener)
+}
+
+func (engine *Engine) OnZoneUpdated(listener func(old, new zone)) {
+	engine.listeners.registered = true
+	engine.listeners.zoneUpdated = append(engine.listeners.zoneUpdated, listener)
+}
+
+func (engine *Engine) OnZoneDeleted(listener func(zone)) {
+	engine.listeners.registered = true
+	engine.listeners.zoneDeleted = append(engine.listeners.zoneDeleted, listener)
+}
+
+func (engine *Engine) OnZoneItemCreated(listener func(zoneItem)) {
+	engine.listeners.registered = true
+	engine.listeners.zoneItemCreated = append(engine.listeners.zoneItemCreated, listener)
+}
+
+func (engine *Engine) OnZoneItemUpdated(listener func(old, new zoneItem)) {
+	engine.listeners.registered = true
+	engine.listeners.zoneItemUpdated = append(engine.listeners.zoneItemUpdated, listener)
+}
+
+func (engine *Engine) OnZoneItemDeleted(listener func(zoneItem)) {
+	engine.listeners.registered = true
+	engine.listeners.zoneItemDeleted = append(engine.listeners.zoneItemDeleted, listener)
+}
+
+// emitChanges calls the listeners with the changes of the patch ordered by kind and ID,
+// it only emits once per patch as the server already emits before assembling the patch
+func (engine *Engine) emitChanges() {
+	if engine.listeners.emitted || !engine.listeners.registered {
+		return
+	}
+	engine.listeners.emitted = true
+	previous := &Engine{Patch: newState(), State: engine.State}
+	var emits []func()
+	emits = engine.collectEquipmentSetChanges(emits, previous)
+	emits = engine.collectGearScoreChanges(emits, previous)
+	emits = engine.collectItemChanges(emits, previous)
+	emits = engine.collectPlayerChanges(emits, previous)
+	emits = engine.collectPositionChanges(emits, previous)
+	emits = engine.collectZoneChanges(emits, previous)
+	emits = engine.collectZoneItemChanges(emits, previous)
+	for _, emit := range emits {
+		emit()
+	}
+}
+
+func (engine *Engine) collectEquipmentSetChanges(emits []func(), previous *Engine) []func() {
+	equipmentSetIDs := make([]EquipmentSetID, 0, len(engine.Patch.EquipmentSet))
+	for equipmentSetID := range engine.Patch.EquipmentSet {
+		equipmentSetIDs = append(equipmentSetIDs, equipmentSetID)
+	}
+	sort.Slice(equipmentSetIDs, func(i, j int) bool {
+		return equipmentSetIDs[i] < equipmentSetIDs[j]
+	})
+	for _, equipmentSetID := range equipmentSetIDs {
+		equipmentSetData := engine.Patch.EquipmentSet[equipmentSetID]
+		current := equipmentSet{equipmentSet: equipmentSetData}
+		oldData, existed := engine.State.EquipmentSet[equipmentSetData.ID]
+		if equipmentSetData.OperationKind == OperationKindDelete {
+			for _, listener := range engine.listeners.equipmentSetDeleted {
+				listener := listener
+				emits = append(emits, func() {
+					listener(current)
+				})
+			}
+			continue
+		}
+		if !existed {
+			for _, listener := range engine.listeners.equipmentSetCreated {
+				listener := listener
+				emits = append(emits, func() {
+					listener(current)
+				})
+			}
+			continue
+		}
+		if equipmentSetData.OperationKind == OperationKindUpdate {
+			oldData.engine = previous
+			old := equipmentSet{equipmentSet: oldData}
+			for _, listener := range engine.listeners.equipmentSetUpdated {
+				listener := listener
+				emits = append(emits, func() {
+					listener(old, current)
+				})
+			}
+		}
+	}
+	return emits
+}
+
+func (engine *Engine) collectGearScoreChanges(emits []func(), previous *Engine) []func() {
+	gearScoreIDs := make([]GearScoreID, 0, len(engine.Patch.GearScore))
+	for gearScoreID := range engine.Patch.GearScore {
+		gearScoreIDs = append(gearScoreIDs, gearScoreID)
+	}
+	sort.Slice(gearScoreIDs, func(i, j int) bool {
+		return gearScoreIDs[i] < gearScoreIDs[j]
+	})
+	for _, gearScoreID := range gearScoreIDs {
+		gearScoreData := engine.Patch.GearScore[gearScoreID]
+		current := gearScore{gearScore: gearScoreData}
+		oldData, existed := engine.State.GearScore[gearScoreData.ID]
+		if gearScoreData.OperationKind == OperationKindDelete {
+			for _, listener := range engine.listeners.gearScoreDeleted {
+				listener := listener
+				emits = append(emits, func() {
+					listener(current)
+				})
+			}
+			continue
+		}
+		if !existed {
+			for _, listener := range engine.listeners.gearScoreCreated {
+				listener := listener
+				emits = append(emits, func() {
+					listener(current)
+				})
+			}
+			continue
+		}
+		if gearScoreData.OperationKind == OperationKindUpdate {
+			oldData.engine = previous
+			old := gearScore{gearScore: oldData}
+			for _, listener := range engine.listeners.gearScoreUpdated {
+				listener := listener
+				emits = append(emits, func() {
+					listener(old, current)
+				})
+			}
+		}
+	}
+	return emits
+}
+
+func (engine *Engine) collectItemChanges(emits []func(), previous *Engine) []func() {
+	itemIDs := make([]ItemID, 0, len(engine.Patch.Item))
+	for itemID := range engine.Patch.Item {
+		itemIDs = append(itemIDs, itemID)
+	}
+	sort.Slice(itemIDs, func(i, j int) bool {
+		return itemIDs[i] < itemIDs[j]
+	})
+	for _, itemID := range itemIDs {
+		itemData := engine.Patch.Item[itemID]
+		current := item{item: itemData}
+		oldData, existed := engine.State.Item[itemData.ID]
+		if itemData.OperationKind == OperationKindDelete {
+			for _, listener := range engine.listeners.itemDeleted {
+				listener := listener
+				emits = append(emits, func() {
+					listener(current)
+				})
+			}
+			continue
+		}
+		if !existed {
+			for _, listener := range engine.listeners.itemCreated {
+				listener := listener
+				emits = append(emits, func() {
+					listener(current)
+				})
+			}
+			continue
+		}
+		if itemData.OperationKind == OperationKindUpdate {
+			oldData.engine = previous
+			old := item{item: oldData}
+			for _, listener := range engine.listeners.itemUpdated {
+				listener := listener
+				emits = append(emits, func() {
+					listener(old, current)
+				})
+			}
+		}
+	}
+	return emits
+}
+
+func (engine *Engine) collectPlayerChanges(emits []func(), previous *Engine) []func() {
+	playerIDs := make([]PlayerID, 0, len(engine.Patch.Player))
+	for playerID := range engine.Patch.Player {
+		playerIDs = append(playerIDs, playerID)
+	}
+	sort.Slice(playerIDs, func(i, j int) bool {
+		return playerIDs[i] < playerIDs[j]
+	})
+	for _, playerID := range playerIDs {
+		playerData := engine.Patch.Player[playerID]
+		current := player{player: playerData}
+		oldData, existed := engine.State.Player[playerData.ID]
+		if playerData.OperationKind == OperationKindDelete {
+			for _, listener := range engine.listeners.playerDeleted {
+				listener := listener
+				emits = append(emits, func() {
+					listener(current)
+				})
+			}
+			continue
+		}
+		if !existed {
+			for _, listener := range engine.listeners.playerCreated {
+				listener := listener
+				emits = append(emits, func() {
+					listener(current)
+				})
+			}
+			continue
+		}
+		if playerData.OperationKind == OperationKindUpdate {
+			oldData.engine = previous
+			old := player{player: oldData}
+			for _, listener := range engine.listeners.playerUpdated {
+				listener := listener
+				emits = append(emits, func() {
+					listener(old, current)
+				})
+			}
+		}
+	}
+	return emits
+}
+
+func (engine *Engine) collectPositionChanges(emits []func(), previous *Engine) []func() {
+	positionIDs := make([]PositionID, 0, len(engine.Patch.Position))
+	for positionID := range engine.Patch.Position {
+		positionIDs = append(positionIDs, positionID)
+	}
+	sort.Slice(positionIDs, func(i, j int) bool {
+		return positionIDs[i] < positionIDs[j]
+	})
+	for _, positionID := range positionIDs {
+		positionData := engine.Patch.Position[positionID]
+		current := position{position: positionData}
+		oldData, existed := engine.State.Position[positionData.ID]
+		if positionData.OperationKind == OperationKindDelete {
+			for _, listener := range engine.listeners.positionDeleted {
+				listener := listener
+				emits = append(emits, func() {
+					listener(current)
+				})
+			}
+			continue
+		}
+		if !existed {
+			for _, listener := range engine.listeners.positionCreated {
+				listener := listener
+				emits = append(emits, func() {
+					listener(current)
+				})
+			}
+			continue
+		}
+		if positionData.OperationKind == OperationKindUpdate {
+			oldData.engine = previous
+			old := position{position: oldData}
+			for _, listener := range engine.listeners.positionUpdated {
+				listener := listener
+				emits = append(emits, func() {
+					listener(old, current)
+				})
+			}
+		}
+	}
+	return emits
+}
+
+func (engine *Engine) collectZoneChanges(emits []func(), previous *Engine) []func() {
+	zoneIDs := make([]ZoneID, 0, len(engine.Patch.Zone))
+	for zoneID := range engine.Patch.Zone {
+		zoneIDs = append(zoneIDs, zoneID)
+	}
+	sort.Slice(zoneIDs, func(i, j int) bool {
+		return zoneIDs[i] < zoneIDs[j]
+	})
+	for _, zoneID := range zoneIDs {
+		zoneData := engine.Patch.Zone[zoneID]
+		current := zone{zone: zoneData}
+		oldData, existed := engine.State.Zone[zoneData.ID]
+		if zoneData.OperationKind == OperationKindDelete {
+			for _, listener := range engine.listeners.zoneDeleted {
+				listener := listener
+				emits = append(emits, func() {
+					listener(current)
+				})
+			}
+			continue
+		}
+		if !existed {
+			for _, listener := range engine.listeners.zoneCreated {
+				listener := listener
+				emits = append(emits, func() {
+					listener(current)
+				})
+			}
+			continue
+		}
+		if zoneData.OperationKind == OperationKindUpdate {
+			oldData.engine = previous
+			old := zone{zone: oldData}
+			for _, listener := range engine.listeners.zoneUpdated {
+				listener := listener
+				emits = append(emits, func() {
+					listener(old, current)
+				})
+			}
+		}
+	}
+	return emits
+}
+
+func (engine *Engine) collectZoneItemChanges(emits []func(), previous *Engine) []func() {
+	zoneItemIDs := make([]ZoneItemID, 0, len(engine.Patch.ZoneItem))
+	for zoneItemID := range engine.Patch.ZoneItem {
+		zoneItemIDs = append(zoneItemIDs, zoneItemID)
+	}
+	sort.Slice(zoneItemIDs, func(i, j int) bool {
+		return zoneItemIDs[i] < zoneItemIDs[j]
+	})
+	for _, zoneItemID := range zoneItemIDs {
+		zoneItemData := engine.Patch.ZoneItem[zoneItemID]
+		current := zoneItem{zoneItem: zoneItemData}
+		oldData, existed := engine.State.ZoneItem[zoneItemData.ID]
+		if zoneItemData.OperationKind == OperationKindDelete {
+			for _, listener := range engine.listeners.zoneItemDeleted {
+				listener := listener
+				emits = append(emits, func() {
+					listener(current)
+				})
+			}
+			continue
+		}
+		if !existed {
+			for _, listener := range engine.listeners.zoneItemCreated {
+				listener := listener
+				emits = append(emits, func() {
+					listener(current)
+				})
+			}
+			continue
+		}
+		if zoneItemData.OperationKind == OperationKindUpdate {
+			oldData.engine = previous
+			old := zoneItem{zoneItem: oldData}
+			for _, listener := range engine.listeners.zoneItemUpdated {
+				listener := listener
+				emits = append(emits, func() {
+					listener(old, current)
+				})
+			}
+		}
+	}
+	return emits
+}
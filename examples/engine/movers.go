@@ -0,0 +1,580 @@
+package state
+
+func (_any anyOfPlayer_PositionCore) childID() int {
+	switch _any.ElementKind {
+	case ElementKindPlayer:
+		return int(_any.Player)
+	case ElementKindPosition:
+		return int(_any.Position)
+	}
+	return 0
+}
+
+func (_any anyOfPlayer_ZoneItemCore) childID() int {
+	switch _any.ElementKind {
+	case ElementKindPlayer:
+		return int(_any.Player)
+	case ElementKindZoneItem:
+		return int(_any.ZoneItem)
+	}
+	return 0
+}
+
+func (_any anyOfItem_Player_ZoneItemCore) childID() int {
+	switch _any.ElementKind {
+	case ElementKindItem:
+		return int(_any.Item)
+	case ElementKindPlayer:
+		return int(_any.Player)
+	case ElementKindZoneItem:
+		return int(_any.ZoneItem)
+	}
+	return 0
+}
+
+func (_equipmentSet equipmentSetCore) equipmentKeys() []ItemID {
+	keys := make([]ItemID, 0, len(_equipmentSet.Equipment))
+	for _, id := range _equipmentSet.Equipment {
+		keys = append(keys, _equipmentSet.engine.equipmentSetEquipmentRef(id).equipmentSetEquipmentRef.ReferencedElementID)
+	}
+	return keys
+}
+
+func (_equipmentSet equipmentSetCore) indexOfEquipment(itemID ItemID) int {
+	for i, key := range _equipmentSet.equipmentKeys() {
+		if key == itemID {
+			return i
+		}
+	}
+	return -1
+}
+
+func (_equipmentSet equipmentSet) InsertEquipmentAt(index int, itemID ItemID) {
+	length := len(_equipmentSet.equipmentSet.engine.EquipmentSet(_equipmentSet.equipmentSet.ID).equipmentSet.Equipment)
+	_equipmentSet.AddEquipment(itemID)
+	equipmentSet := _equipmentSet.equipmentSet.engine.EquipmentSet(_equipmentSet.equipmentSet.ID)
+	if len(equipmentSet.equipmentSet.Equipment) == length {
+		return
+	}
+	equipmentSet.equipmentSet.Equipment = moveEquipmentSetEquipmentRefID(equipmentSet.equipmentSet.Equipment, length, index)
+	equipmentSet.equipmentSet.engine.Patch.EquipmentSet[equipmentSet.equipmentSet.ID] = equipmentSet.equipmentSet
+}
+
+func (_equipmentSet equipmentSet) MoveEquipment(itemID ItemID, index int) equipmentSet {
+	equipmentSet := _equipmentSet.equipmentSet.engine.EquipmentSet(_equipmentSet.equipmentSet.ID)
+	if equipmentSet.equipmentSet.OperationKind == OperationKindDelete {
+		equipmentSet.equipmentSet.engine.recordError("MoveEquipment", ElementKindEquipmentSet, int(_equipmentSet.equipmentSet.ID), ErrElementDoesNotExist)
+		return equipmentSet
+	}
+	from := equipmentSet.equipmentSet.indexOfEquipment(itemID)
+	if from == -1 {
+		equipmentSet.equipmentSet.engine.recordError("MoveEquipment", ElementKindEquipmentSet, int(_equipmentSet.equipmentSet.ID), ErrElementNotInSlice)
+		return equipmentSet
+	}
+	equipmentSet.equipmentSet.Equipment = moveEquipmentSetEquipmentRefID(equipmentSet.equipmentSet.Equipment, from, index)
+	equipmentSet.equipmentSet.OperationKind = OperationKindUpdate
+	equipmentSet.equipmentSet.engine.Patch.EquipmentSet[equipmentSet.equipmentSet.ID] = equipmentSet.equipmentSet
+	return equipmentSet
+}
+
+func (_equipmentSet equipmentSet) SwapEquipment(a, b ItemID) equipmentSet {
+	equipmentSet := _equipmentSet.equipmentSet.engine.EquipmentSet(_equipmentSet.equipmentSet.ID)
+	if equipmentSet.equipmentSet.OperationKind == OperationKindDelete {
+		equipmentSet.equipmentSet.engine.recordError("SwapEquipment", ElementKindEquipmentSet, int(_equipmentSet.equipmentSet.ID), ErrElementDoesNotExist)
+		return equipmentSet
+	}
+	i, j := equipmentSet.equipmentSet.indexOfEquipment(a), equipmentSet.equipmentSet.indexOfEquipment(b)
+	if i == -1 || j == -1 {
+		equipmentSet.equipmentSet.engine.recordError("SwapEquipment", ElementKindEquipmentSet, int(_equipmentSet.equipmentSet.ID), ErrElementNotInSlice)
+		return equipmentSet
+	}
+	ids := make([]EquipmentSetEquipmentRefID, len(equipmentSet.equipmentSet.Equipment))
+	copy(ids, equipmentSet.equipmentSet.Equipment)
+	ids[i], ids[j] = ids[j], ids[i]
+	equipmentSet.equipmentSet.Equipment = ids
+	equipmentSet.equipmentSet.OperationKind = OperationKindUpdate
+	equipmentSet.equipmentSet.engine.Patch.EquipmentSet[equipmentSet.equipmentSet.ID] = equipmentSet.equipmentSet
+	return equipmentSet
+}
+
+func (_player playerCore) equipmentSetsKeys() []EquipmentSetID {
+	keys := make([]EquipmentSetID, 0, len(_player.EquipmentSets))
+	for _, id := range _player.EquipmentSets {
+		keys = append(keys, _player.engine.playerEquipmentSetRef(id).playerEquipmentSetRef.ReferencedElementID)
+	}
+	return keys
+}
+
+func (_player playerCore) indexOfEquipmentSet(equipmentSetID EquipmentSetID) int {
+	for i, key := range _player.equipmentSetsKeys() {
+		if key == equipmentSetID {
+			return i
+		}
+	}
+	return -1
+}
+
+func (_player player) InsertEquipmentSetAt(index int, equipmentSetID EquipmentSetID) {
+	length := len(_player.player.engine.Player(_player.player.ID).player.EquipmentSets)
+	_player.AddEquipmentSet(equipmentSetID)
+	player := _player.player.engine.Player(_player.player.ID)
+	if len(player.player.EquipmentSets) == length {
+		return
+	}
+	player.player.EquipmentSets = movePlayerEquipmentSetRefID(player.player.EquipmentSets, length, index)
+	player.player.engine.Patch.Player[player.player.ID] = player.player
+}
+
+func (_player player) MoveEquipmentSet(equipmentSetID EquipmentSetID, index int) player {
+	player := _player.player.engine.Player(_player.player.ID)
+	if player.player.OperationKind == OperationKindDelete {
+		player.player.engine.recordError("MoveEquipmentSet", ElementKindPlayer, int(_player.player.ID), ErrElementDoesNotExist)
+		return player
+	}
+	from := player.player.indexOfEquipmentSet(equipmentSetID)
+	if from == -1 {
+		player.player.engine.recordError("MoveEquipmentSet", ElementKindPlayer, int(_player.player.ID), ErrElementNotInSlice)
+		return player
+	}
+	player.player.EquipmentSets = movePlayerEquipmentSetRefID(player.player.EquipmentSets, from, index)
+	player.player.OperationKind = OperationKindUpdate
+	player.player.engine.Patch.Player[player.player.ID] = player.player
+	return player
+}
+
+func (_player player) SwapEquipmentSets(a, b EquipmentSetID) player {
+	player := _player.player.engine.Player(_player.player.ID)
+	if player.player.OperationKind == OperationKindDelete {
+		player.player.engine.recordError("SwapEquipmentSets", ElementKindPlayer, int(_player.player.ID), ErrElementDoesNotExist)
+		return player
+	}
+	i, j := player.player.indexOfEquipmentSet(a), player.player.indexOfEquipmentSet(b)
+	if i == -1 || j == -1 {
+		player.player.engine.recordError("SwapEquipmentSets", ElementKindPlayer, int(_player.player.ID), ErrElementNotInSlice)
+		return player
+	}
+	ids := make([]PlayerEquipmentSetRefID, len(player.player.EquipmentSets))
+	copy(ids, player.player.EquipmentSets)
+	ids[i], ids[j] = ids[j], ids[i]
+	player.player.EquipmentSets = ids
+	player.player.OperationKind = OperationKindUpdate
+	player.player.engine.Patch.Player[player.player.ID] = player.player
+	return player
+}
+
+func (_player playerCore) guildMembersKeys() []PlayerID {
+	keys := make([]PlayerID, 0, len(_player.GuildMembers))
+	for _, id := range _player.GuildMembers {
+		keys = append(keys, _player.engine.playerGuildMemberRef(id).playerGuildMemberRef.ReferencedElementID)
+	}
+	return keys
+}
+
+func (_player playerCore) indexOfGuildMember(playerID PlayerID) int {
+	for i, key := range _player.guildMembersKeys() {
+		if key == playerID {
+			return i
+		}
+	}
+	return -1
+}
+
+func (_player player) InsertGuildMemberAt(index int, playerID PlayerID) {
+	length := len(_player.player.engine.Player(_player.player.ID).player.GuildMembers)
+	_player.AddGuildMember(playerID)
+	player := _player.player.engine.Player(_player.player.ID)
+	if len(player.player.GuildMembers) == length {
+		return
+	}
+	player.player.GuildMembers = movePlayerGuildMemberRefID(player.player.GuildMembers, length, index)
+	player.player.engine.Patch.Player[player.player.ID] = player.player
+}
+
+func (_player player) MoveGuildMember(playerID PlayerID, index int) player {
+	player := _player.player.engine.Player(_player.player.ID)
+	if player.player.OperationKind == OperationKindDelete {
+		player.player.engine.recordError("MoveGuildMember", ElementKindPlayer, int(_player.player.ID), ErrElementDoesNotExist)
+		return player
+	}
+	from := player.player.indexOfGuildMember(playerID)
+	if from == -1 {
+		player.player.engine.recordError("MoveGuildMember", ElementKindPlayer, int(_player.player.ID), ErrElementNotInSlice)
+		return player
+	}
+	player.player.GuildMembers = movePlayerGuildMemberRefID(player.player.GuildMembers, from, index)
+	player.player.OperationKind = OperationKindUpdate
+	player.player.engine.Patch.Player[player.player.ID] = player.player
+	return player
+}
+
+func (_player player) SwapGuildMembers(a, b PlayerID) player {
+	player := _player.player.engine.Player(_player.player.ID)
+	if player.player.OperationKind == OperationKindDelete {
+		player.player.engine.recordError("SwapGuildMembers", ElementKindPlayer, int(_player.player.ID), ErrElementDoesNotExist)
+		return player
+	}
+	i, j := player.player.indexOfGuildMember(a), player.player.indexOfGuildMember(b)
+	if i == -1 || j == -1 {
+		player.player.engine.recordError("SwapGuildMembers", ElementKindPlayer, int(_player.player.ID), ErrElementNotInSlice)
+		return player
+	}
+	ids := make([]PlayerGuildMemberRefID, len(player.player.GuildMembers))
+	copy(ids, player.player.GuildMembers)
+	ids[i], ids[j] = ids[j], ids[i]
+	player.player.GuildMembers = ids
+	player.player.OperationKind = OperationKindUpdate
+	player.player.engine.Patch.Player[player.player.ID] = player.player
+	return player
+}
+
+func (_player playerCore) itemsKeys() []ItemID {
+	return _player.Items
+}
+
+func (_player playerCore) indexOfItem(itemID ItemID) int {
+	for i, key := range _player.itemsKeys() {
+		if key == itemID {
+			return i
+		}
+	}
+	return -1
+}
+
+func (_player player) InsertItemAt(index int) item {
+	length := len(_player.player.engine.Player(_player.player.ID).player.Items)
+	item := _player.AddItem()
+	player := _player.player.engine.Player(_player.player.ID)
+	if len(player.player.Items) == length {
+		return item
+	}
+	player.player.Items = moveItemID(player.player.Items, length, index)
+	player.player.engine.Patch.Player[player.player.ID] = player.player
+	return item
+}
+
+func (_player player) MoveItem(itemID ItemID, index int) player {
+	player := _player.player.engine.Player(_player.player.ID)
+	if player.player.OperationKind == OperationKindDelete {
+		player.player.engine.recordError("MoveItem", ElementKindPlayer, int(_player.player.ID), ErrElementDoesNotExist)
+		return player
+	}
+	from := player.player.indexOfItem(itemID)
+	if from == -1 {
+		player.player.engine.recordError("MoveItem", ElementKindPlayer, int(_player.player.ID), ErrElementNotInSlice)
+		return player
+	}
+	player.player.Items = moveItemID(player.player.Items, from, index)
+	player.player.OperationKind = OperationKindUpdate
+	player.player.engine.Patch.Player[player.player.ID] = player.player
+	return player
+}
+
+func (_player player) SwapItems(a, b ItemID) player {
+	player := _player.player.engine.Player(_player.player.ID)
+	if player.player.OperationKind == OperationKindDelete {
+		player.player.engine.recordError("SwapItems", ElementKindPlayer, int(_player.player.ID), ErrElementDoesNotExist)
+		return player
+	}
+	i, j := player.player.indexOfItem(a), player.player.indexOfItem(b)
+	if i == -1 || j == -1 {
+		player.player.engine.recordError("SwapItems", ElementKindPlayer, int(_player.player.ID), ErrElementNotInSlice)
+		return player
+	}
+	ids := make([]ItemID, len(player.player.Items))
+	copy(ids, player.player.Items)
+	ids[i], ids[j] = ids[j], ids[i]
+	player.player.Items = ids
+	player.player.OperationKind = OperationKindUpdate
+	player.player.engine.Patch.Player[player.player.ID] = player.player
+	return player
+}
+
+func (_player playerCore) targetedByKeys() []int {
+	keys := make([]int, 0, len(_player.TargetedBy))
+	for _, id := range _player.TargetedBy {
+		keys = append(keys, _player.engine.anyOfPlayer_ZoneItem(_player.engine.playerTargetedByRef(id).playerTargetedByRef.ReferencedElementID).anyOfPlayer_ZoneItem.childID())
+	}
+	return keys
+}
+
+func (_player playerCore) indexOfTargetedBy(targetedByID int) int {
+	for i, key := range _player.targetedByKeys() {
+		if key == targetedByID {
+			return i
+		}
+	}
+	return -1
+}
+
+func (_player player) InsertTargetedByPlayerAt(index int, playerID PlayerID) {
+	length := len(_player.player.engine.Player(_player.player.ID).player.TargetedBy)
+	_player.AddTargetedByPlayer(playerID)
+	player := _player.player.engine.Player(_player.player.ID)
+	if len(player.player.TargetedBy) == length {
+		return
+	}
+	player.player.TargetedBy = movePlayerTargetedByRefID(player.player.TargetedBy, length, index)
+	player.player.engine.Patch.Player[player.player.ID] = player.player
+}
+
+func (_player player) InsertTargetedByZoneItemAt(index int, zoneItemID ZoneItemID) {
+	length := len(_player.player.engine.Player(_player.player.ID).player.TargetedBy)
+	_player.AddTargetedByZoneItem(zoneItemID)
+	player := _player.player.engine.Player(_player.player.ID)
+	if len(player.player.TargetedBy) == length {
+		return
+	}
+	player.player.TargetedBy = movePlayerTargetedByRefID(player.player.TargetedBy, length, index)
+	player.player.engine.Patch.Player[player.player.ID] = player.player
+}
+
+func (_player player) MoveTargetedBy(targetedByID int, index int) player {
+	player := _player.player.engine.Player(_player.player.ID)
+	if player.player.OperationKind == OperationKindDelete {
+		player.player.engine.recordError("MoveTargetedBy", ElementKindPlayer, int(_player.player.ID), ErrElementDoesNotExist)
+		return player
+	}
+	from := player.player.indexOfTargetedBy(targetedByID)
+	if from == -1 {
+		player.player.engine.recordError("MoveTargetedBy", ElementKindPlayer, int(_player.player.ID), ErrElementNotInSlice)
+		return player
+	}
+	player.player.TargetedBy = movePlayerTargetedByRefID(player.player.TargetedBy, from, index)
+	player.player.OperationKind = OperationKindUpdate
+	player.player.engine.Patch.Player[player.player.ID] = player.player
+	return player
+}
+
+func (_player player) SwapTargetedBy(a, b int) player {
+	player := _player.player.engine.Player(_player.player.ID)
+	if player.player.OperationKind == OperationKindDelete {
+		player.player.engine.recordError("SwapTargetedBy", ElementKindPlayer, int(_player.player.ID), ErrElementDoesNotExist)
+		return player
+	}
+	i, j := player.player.indexOfTargetedBy(a), player.player.indexOfTargetedBy(b)
+	if i == -1 || j == -1 {
+		player.player.engine.recordError("SwapTargetedBy", ElementKindPlayer, int(_player.player.ID), ErrElementNotInSlice)
+		return player
+	}
+	ids := make([]PlayerTargetedByRefID, len(player.player.TargetedBy))
+	copy(ids, player.player.TargetedBy)
+	ids[i], ids[j] = ids[j], ids[i]
+	player.player.TargetedBy = ids
+	player.player.OperationKind = OperationKindUpdate
+	player.player.engine.Patch.Player[player.player.ID] = player.player
+	return player
+}
+
+func (_zone zoneCore) interactablesKeys() []int {
+	keys := make([]int, 0, len(_zone.Interactables))
+	for _, id := range _zone.Interactables {
+		keys = append(keys, _zone.engine.anyOfItem_Player_ZoneItem(id).anyOfItem_Player_ZoneItem.childID())
+	}
+	return keys
+}
+
+func (_zone zoneCore) indexOfInteractable(interactableID int) int {
+	for i, key := range _zone.interactablesKeys() {
+		if key == interactableID {
+			return i
+		}
+	}
+	return -1
+}
+
+func (_zone zone) InsertInteractableItemAt(index int) item {
+	length := len(_zone.zone.engine.Zone(_zone.zone.ID).zone.Interactables)
+	item := _zone.AddInteractableItem()
+	zone := _zone.zone.engine.Zone(_zone.zone.ID)
+	if len(zone.zone.Interactables) == length {
+		return item
+	}
+	zone.zone.Interactables = moveAnyOfItem_Player_ZoneItemID(zone.zone.Interactables, length, index)
+	zone.zone.engine.Patch.Zone[zone.zone.ID] = zone.zone
+	return item
+}
+
+func (_zone zone) InsertInteractablePlayerAt(index int) player {
+	length := len(_zone.zone.engine.Zone(_zone.zone.ID).zone.Interactables)
+	player := _zone.AddInteractablePlayer()
+	zone := _zone.zone.engine.Zone(_zone.zone.ID)
+	if len(zone.zone.Interactables) == length {
+		return player
+	}
+	zone.zone.Interactables = moveAnyOfItem_Player_ZoneItemID(zone.zone.Interactables, length, index)
+	zone.zone.engine.Patch.Zone[zone.zone.ID] = zone.zone
+	return player
+}
+
+func (_zone zone) InsertInteractableZoneItemAt(index int) zoneItem {
+	length := len(_zone.zone.engine.Zone(_zone.zone.ID).zone.Interactables)
+	zoneItem := _zone.AddInteractableZoneItem()
+	zone := _zone.zone.engine.Zone(_zone.zone.ID)
+	if len(zone.zone.Interactables) == length {
+		return zoneItem
+	}
+	zone.zone.Interactables = moveAnyOfItem_Player_ZoneItemID(zone.zone.Interactables, length, index)
+	zone.zone.engine.Patch.Zone[zone.zone.ID] = zone.zone
+	return zoneItem
+}
+
+func (_zone zone) MoveInteractable(interactableID int, index int) zone {
+	zone := _zone.zone.engine.Zone(_zone.zone.ID)
+	if zone.zone.OperationKind == OperationKindDelete {
+		zone.zone.engine.recordError("MoveInteractable", ElementKindZone, int(_zone.zone.ID), ErrElementDoesNotExist)
+		return zone
+	}
+	from := zone.zone.indexOfInteractable(interactableID)
+	if from == -1 {
+		zone.zone.engine.recordError("MoveInteractable", ElementKindZone, int(_zone.zone.ID), ErrElementNotInSlice)
+		return zone
+	}
+	zone.zone.Interactables = moveAnyOfItem_Player_ZoneItemID(zone.zone.Interactables, from, index)
+	zone.zone.OperationKind = OperationKindUpdate
+	zone.zone.engine.Patch.Zone[zone.zone.ID] = zone.zone
+	return zone
+}
+
+func (_zone zone) SwapInteractables(a, b int) zone {
+	zone := _zone.zone.engine.Zone(_zone.zone.ID)
+	if zone.zone.OperationKind == OperationKindDelete {
+		zone.zone.engine.recordError("SwapInteractables", ElementKindZone, int(_zone.zone.ID), ErrElementDoesNotExist)
+		return zone
+	}
+	i, j := zone.zone.indexOfInteractable(a), zone.zone.indexOfInteractable(b)
+	if i == -1 || j == -1 {
+		zone.zone.engine.recordError("SwapInteractables", ElementKindZone, int(_zone.zone.ID), ErrElementNotInSlice)
+		return zone
+	}
+	ids := make([]AnyOfItem_Player_ZoneItemID, len(zone.zone.Interactables))
+	copy(ids, zone.zone.Interactables)
+	ids[i], ids[j] = ids[j], ids[i]
+	zone.zone.Interactables = ids
+	zone.zone.OperationKind = OperationKindUpdate
+	zone.zone.engine.Patch.Zone[zone.zone.ID] = zone.zone
+	return zone
+}
+
+func (_zone zoneCore) itemsKeys() []ZoneItemID {
+	return _zone.Items
+}
+
+func (_zone zoneCore) indexOfItem(zoneItemID ZoneItemID) int {
+	for i, key := range _zone.itemsKeys() {
+		if key == zoneItemID {
+			return i
+		}
+	}
+	return -1
+}
+
+func (_zone zone) InsertItemAt(index int) zoneItem {
+	length := len(_zone.zone.engine.Zone(_zone.zone.ID).zone.Items)
+	zoneItem := _zone.AddItem()
+	zone := _zone.zone.engine.Zone(_zone.zone.ID)
+	if len(zone.zone.Items) == length {
+		return zoneItem
+	}
+	zone.zone.Items = moveZoneItemID(zone.zone.Items, length, index)
+	zone.zone.engine.Patch.Zone[zone.zone.ID] = zone.zone
+	return zoneItem
+}
+
+func (_zone zone) MoveItem(zoneItemID ZoneItemID, index int) zone {
+	zone := _zone.zone.engine.Zone(_zone.zone.ID)
+	if zone.zone.OperationKind == OperationKindDelete {
+		zone.zone.engine.recordError("MoveItem", ElementKindZone, int(_zone.zone.ID), ErrElementDoesNotExist)
+		return zone
+	}
+	from := zone.zone.indexOfItem(zoneItemID)
+	if from == -1 {
+		zone.zone.engine.recordError("MoveItem", ElementKindZone, int(_zone.zone.ID), ErrElementNotInSlice)
+		return zone
+	}
+	zone.zone.Items = moveZoneItemID(zone.zone.Items, from, index)
+	zone.zone.OperationKind = OperationKindUpdate
+	zone.zone.engine.Patch.Zone[zone.zone.ID] = zone.zone
+	return zone
+}
+
+func (_zone zone) SwapItems(a, b ZoneItemID) zone {
+	zone := _zone.zone.engine.Zone(_zone.zone.ID)
+	if zone.zone.OperationKind == OperationKindDelete {
+		zone.zone.engine.recordError("SwapItems", ElementKindZone, int(_zone.zone.ID), ErrElementDoesNotExist)
+		return zone
+	}
+	i, j := zone.zone.indexOfItem(a), zone.zone.indexOfItem(b)
+	if i == -1 || j == -1 {
+		zone.zone.engine.recordError("SwapItems", ElementKindZone, int(_zone.zone.ID), ErrElementNotInSlice)
+		return zone
+	}
+	ids := make([]ZoneItemID, len(zone.zone.Items))
+	copy(ids, zone.zone.Items)
+	ids[i], ids[j] = ids[j], ids[i]
+	zone.zone.Items = ids
+	zone.zone.OperationKind = OperationKindUpdate
+	zone.zone.engine.Patch.Zone[zone.zone.ID] = zone.zone
+	return zone
+}
+
+func (_zone zoneCore) playersKeys() []PlayerID {
+	return _zone.Players
+}
+
+func (_zone zoneCore) indexOfPlayer(playerID PlayerID) int {
+	for i, key := range _zone.playersKeys() {
+		if key == playerID {
+			return i
+		}
+	}
+	return -1
+}
+
+func (_zone zone) InsertPlayerAt(index int) player {
+	length := len(_zone.zone.engine.Zone(_zone.zone.ID).zone.Players)
+	player := _zone.AddPlayer()
+	zone := _zone.zone.engine.Zone(_zone.zone.ID)
+	if len(zone.zone.Players) == length {
+		return player
+	}
+	zone.zone.Players = movePlayerID(zone.zone.Players, length, index)
+	zone.zone.engine.Patch.Zone[zone.zone.ID] = zone.zone
+	return player
+}
+
+func (_zone zone) MovePlayer(playerID PlayerID, index int) zone {
+	zone := _zone.zone.engine.Zone(_zone.zone.ID)
+	if zone.zone.OperationKind == OperationKindDelete {
+		zone.zone.engine.recordError("MovePlayer", ElementKindZone, int(_zone.zone.ID), ErrElementDoesNotExist)
+		return zone
+	}
+	from := zone.zone.indexOfPlayer(playerID)
+	if from == -1 {
+		zone.zone.engine.recordError("MovePlayer", ElementKindZone, int(_zone.zone.ID), ErrElementNotInSlice)
+		return zone
+	}
+	zone.zone.Players = movePlayerID(zone.zone.Players, from, index)
+	zone.zone.OperationKind = OperationKindUpdate
+	zone.zone.engine.Patch.Zone[zone.zone.ID] = zone.zone
+	return zone
+}
+
+func (_zone zone) SwapPlayers(a, b PlayerID) zone {
+	zone := _zone.zone.engine.Zone(_zone.zone.ID)
+	if zone.zone.OperationKind == OperationKindDelete {
+		zone.zone.engine.recordError("SwapPlayers", ElementKindZone, int(_zone.zone.ID), ErrElementDoesNotExist)
+		return zone
+	}
+	i, j := zone.zone.indexOfPlayer(a), zone.zone.indexOfPlayer(b)
+	if i == -1 || j == -1 {
+		zone.zone.engine.recordError("SwapPlayers", ElementKindZone, int(_zone.zone.ID), ErrElementNotInSlice)
+		return zone
+	}
+	ids := make([]PlayerID, len(zone.zone.Players))
+	copy(ids, zone.zone.Players)
+	ids[i], ids[j] = ids[j], ids[i]
+	zone.zone.Players = ids
+	zone.zone.OperationKind = OperationKindUpdate
+	zone.zone.engine.Patch.Zone[zone.zone.ID] = zone.zone
+	return zone
+}
@@ -0,0 +1,37 @@
+package state
+
+func (_zone zone) SetBoss() player {
+	zone := _zone.zone.engine.Zone(_zone.zone.ID)
+	if zone.zone.OperationKind == OperationKindDelete {
+		zone.zone.engine.recordError("SetBoss", ElementKindZone, int(_zone.zone.ID), ErrElementDoesNotExist)
+		return player{player: playerCore{OperationKind: OperationKindDelete, engine: zone.zone.engine}}
+	}
+	if zone.zone.Boss != 0 {
+		return zone.zone.engine.Player(zone.zone.Boss)
+	}
+	player := zone.zone.engine.createPlayer(zone.zone.path.boss(), false)
+	zone.zone.Boss = player.player.ID
+	zone.zone.OperationKind = OperationKindUpdate
+	zone.zone.engine.Patch.Zone[zone.zone.ID] = zone.zone
+	return player
+}
+
+func (_zone zone) UnsetBoss() {
+	zone := _zone.zone.engine.Zone(_zone.zone.ID)
+	if zone.zone.OperationKind == OperationKindDelete {
+		zone.zone.engine.recordError("UnsetBoss", ElementKindZone, int(_zone.zone.ID), ErrElementDoesNotExist)
+		return
+	}
+	if zone.zone.Boss == 0 {
+		return
+	}
+	zone.zone.engine.deletePlayer(zone.zone.Boss)
+	zone.zone.Boss = 0
+	zone.zone.OperationKind = OperationKindUpdate
+	zone.zone.engine.Patch.Zone[zone.zone.ID] = zone.zone
+}
+
+func (_zone zone) HasBoss() bool {
+	zone := _zone.zone.engine.Zone(_zone.zone.ID)
+	return zone.zone.Boss != 0
+}
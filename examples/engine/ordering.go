@@ -0,0 +1,412 @@
+package state
+
+import (
+	"encoding/json"
+	"sort"
+
+	"github.com/mailru/easyjson"
+	"github.com/mailru/easyjson/jwriter"
+)
+
+// sortedMapKeys is set on the writer of ordered engines, the maps of
+// the tree pass it on to each other and write their IDs in ascending order
+const sortedMapKeys jwriter.Flags = 1 << 16
+
+// marshalTree is the only way to marshal ordered trees,
+// Tree.MarshalJSON does not know about the engine
+func (engine *Engine) marshalTree(tree Tree) ([]byte, error) {
+	out := jwriter.Writer{}
+	if engine.ordered {
+		out.Flags = sortedMapKeys
+	}
+	tree.MarshalEasyJSON(&out)
+	return out.Buffer.BuildBytes(), out.Error
+}
+
+type equipmentSetMap map[EquipmentSetID]EquipmentSet
+
+func (m equipmentSetMap) keys(flags jwriter.Flags) []EquipmentSetID {
+	ids := make([]EquipmentSetID, 0, len(m))
+	for id := range m {
+		ids = append(ids, id)
+	}
+	if flags&sortedMapKeys != 0 {
+		sort.Slice(ids, func(i, j int) bool {
+			return ids[i] < ids[j]
+		})
+	}
+	return ids
+}
+
+func (m equipmentSetMap) MarshalEasyJSON(out *jwriter.Writer) {
+	if m == nil && out.Flags&jwriter.NilMapAsEmpty == 0 {
+		out.RawString("null")
+		return
+	}
+	out.RawByte('{')
+	for i, id := range m.keys(out.Flags) {
+		if i > 0 {
+			out.RawByte(',')
+		}
+		out.IntStr(int(id))
+		out.RawByte(':')
+		m[id].MarshalEasyJSON(out)
+	}
+	out.RawByte('}')
+}
+
+type gearScoreMap map[GearScoreID]GearScore
+
+func (m gearScoreMap) keys(flags jwriter.Flags) []GearScoreID {
+	ids := make([]GearScoreID, 0, len(m))
+	for id := range m {
+		ids = append(ids, id)
+	}
+	if flags&sortedMapKeys != 0 {
+		sort.Slice(ids, func(i, j int) bool {
+			return ids[i] < ids[j]
+		})
+	}
+	return ids
+}
+
+func (m gearScoreMap) MarshalEasyJSON(out *jwriter.Writer) {
+	if m == nil && out.Flags&jwriter.NilMapAsEmpty == 0 {
+		out.RawString("null")
+		return
+	}
+	out.RawByte('{')
+	for i, id := range m.keys(out.Flags) {
+		if i > 0 {
+			out.RawByte(',')
+		}
+		out.IntStr(int(id))
+		out.RawByte(':')
+		m[id].MarshalEasyJSON(out)
+	}
+	out.RawByte('}')
+}
+
+type itemMap map[ItemID]Item
+
+func (m itemMap) keys(flags jwriter.Flags) []ItemID {
+	ids := make([]ItemID, 0, len(m))
+	for id := range m {
+		ids = append(ids, id)
+	}
+	if flags&sortedMapKeys != 0 {
+		sort.Slice(ids, func(i, j int) bool {
+			return ids[i] < ids[j]
+		})
+	}
+	return ids
+}
+
+func (m itemMap) MarshalEasyJSON(out *jwriter.Writer) {
+	if m == nil && out.Flags&jwriter.NilMapAsEmpty == 0 {
+		out.RawString("null")
+		return
+	}
+	out.RawByte('{')
+	for i, id := range m.keys(out.Flags) {
+		if i > 0 {
+			out.RawByte(',')
+		}
+		out.IntStr(int(id))
+		out.RawByte(':')
+		m[id].MarshalEasyJSON(out)
+	}
+	out.RawByte('}')
+}
+
+type playerMap map[PlayerID]Player
+
+func (m playerMap) keys(flags jwriter.Flags) []PlayerID {
+	ids := make([]PlayerID, 0, len(m))
+	for id := range m {
+		ids = append(ids, id)
+	}
+	if flags&sortedMapKeys != 0 {
+		sort.Slice(ids, func(i, j int) bool {
+			return ids[i] < ids[j]
+		})
+	}
+	return ids
+}
+
+func (m playerMap) MarshalEasyJSON(out *jwriter.Writer) {
+	if m == nil && out.Flags&jwriter.NilMapAsEmpty == 0 {
+		out.RawString("null")
+		return
+	}
+	out.RawByte('{')
+	for i, id := range m.keys(out.Flags) {
+		if i > 0 {
+			out.RawByte(',')
+		}
+		out.IntStr(int(id))
+		out.RawByte(':')
+		m[id].MarshalEasyJSON(out)
+	}
+	out.RawByte('}')
+}
+
+type positionMap map[PositionID]Position
+
+func (m positionMap) keys(flags jwriter.Flags) []PositionID {
+	ids := make([]PositionID, 0, len(m))
+	for id := range m {
+		ids = append(ids, id)
+	}
+	if flags&sortedMapKeys != 0 {
+		sort.Slice(ids, func(i, j int) bool {
+			return ids[i] < ids[j]
+		})
+	}
+	return ids
+}
+
+func (m positionMap) MarshalEasyJSON(out *jwriter.Writer) {
+	if m == nil && out.Flags&jwriter.NilMapAsEmpty == 0 {
+		out.RawString("null")
+		return
+	}
+	out.RawByte('{')
+	for i, id := range m.keys(out.Flags) {
+		if i > 0 {
+			out.RawByte(',')
+		}
+		out.IntStr(int(id))
+		out.RawByte(':')
+		m[id].MarshalEasyJSON(out)
+	}
+	out.RawByte('}')
+}
+
+type zoneMap map[ZoneID]Zone
+
+func (m zoneMap) keys(flags jwriter.Flags) []ZoneID {
+	ids := make([]ZoneID, 0, len(m))
+	for id := range m {
+		ids = append(ids, id)
+	}
+	if flags&sortedMapKeys != 0 {
+		sort.Slice(ids, func(i, j int) bool {
+			return ids[i] < ids[j]
+		})
+	}
+	return ids
+}
+
+func (m zoneMap) MarshalEasyJSON(out *jwriter.Writer) {
+	if m == nil && out.Flags&jwriter.NilMapAsEmpty == 0 {
+		out.RawString("null")
+		return
+	}
+	out.RawByte('{')
+	for i, id := range m.keys(out.Flags) {
+		if i > 0 {
+			out.RawByte(',')
+		}
+		out.IntStr(int(id))
+		out.RawByte(':')
+		m[id].MarshalEasyJSON(out)
+	}
+	out.RawByte('}')
+}
+
+type zoneItemMap map[ZoneItemID]ZoneItem
+
+func (m zoneItemMap) keys(flags jwriter.Flags) []ZoneItemID {
+	ids := make([]ZoneItemID, 0, len(m))
+	for id := range m {
+		ids = append(ids, id)
+	}
+	if flags&sortedMapKeys != 0 {
+		sort.Slice(ids, func(i, j int) bool {
+			return ids[i] < ids[j]
+		})
+	}
+	return ids
+}
+
+func (m zoneItemMap) MarshalEasyJSON(out *jwriter.Writer) {
+	if m == nil && out.Flags&jwriter.NilMapAsEmpty == 0 {
+		out.RawString("null")
+		return
+	}
+	out.RawByte('{')
+	for i, id := range m.keys(out.Flags) {
+		if i > 0 {
+			out.RawByte(',')
+		}
+		out.IntStr(int(id))
+		out.RawByte(':')
+		m[id].MarshalEasyJSON(out)
+	}
+	out.RawByte('}')
+}
+
+type itemReferenceMap map[ItemID]ItemReference
+
+func (m itemReferenceMap) keys(flags jwriter.Flags) []ItemID {
+	ids := make([]ItemID, 0, len(m))
+	for id := range m {
+		ids = append(ids, id)
+	}
+	if flags&sortedMapKeys != 0 {
+		sort.Slice(ids, func(i, j int) bool {
+			return ids[i] < ids[j]
+		})
+	}
+	return ids
+}
+
+func (m itemReferenceMap) MarshalEasyJSON(out *jwriter.Writer) {
+	if m == nil && out.Flags&jwriter.NilMapAsEmpty == 0 {
+		out.RawString("null")
+		return
+	}
+	out.RawByte('{')
+	for i, id := range m.keys(out.Flags) {
+		if i > 0 {
+			out.RawByte(',')
+		}
+		out.IntStr(int(id))
+		out.RawByte(':')
+		m[id].MarshalEasyJSON(out)
+	}
+	out.RawByte('}')
+}
+
+type equipmentSetReferenceMap map[EquipmentSetID]EquipmentSetReference
+
+func (m equipmentSetReferenceMap) keys(flags jwriter.Flags) []EquipmentSetID {
+	ids := make([]EquipmentSetID, 0, len(m))
+	for id := range m {
+		ids = append(ids, id)
+	}
+	if flags&sortedMapKeys != 0 {
+		sort.Slice(ids, func(i, j int) bool {
+			return ids[i] < ids[j]
+		})
+	}
+	return ids
+}
+
+func (m equipmentSetReferenceMap) MarshalEasyJSON(out *jwriter.Writer) {
+	if m == nil && out.Flags&jwriter.NilMapAsEmpty == 0 {
+		out.RawString("null")
+		return
+	}
+	out.RawByte('{')
+	for i, id := range m.keys(out.Flags) {
+		if i > 0 {
+			out.RawByte(',')
+		}
+		out.IntStr(int(id))
+		out.RawByte(':')
+		m[id].MarshalEasyJSON(out)
+	}
+	out.RawByte('}')
+}
+
+type playerReferenceMap map[PlayerID]PlayerReference
+
+func (m playerReferenceMap) keys(flags jwriter.Flags) []PlayerID {
+	ids := make([]PlayerID, 0, len(m))
+	for id := range m {
+		ids = append(ids, id)
+	}
+	if flags&sortedMapKeys != 0 {
+		sort.Slice(ids, func(i, j int) bool {
+			return ids[i] < ids[j]
+		})
+	}
+	return ids
+}
+
+func (m playerReferenceMap) MarshalEasyJSON(out *jwriter.Writer) {
+	if m == nil && out.Flags&jwriter.NilMapAsEmpty == 0 {
+		out.RawString("null")
+		return
+	}
+	out.RawByte('{')
+	for i, id := range m.keys(out.Flags) {
+		if i > 0 {
+			out.RawByte(',')
+		}
+		out.IntStr(int(id))
+		out.RawByte(':')
+		m[id].MarshalEasyJSON(out)
+	}
+	out.RawByte('}')
+}
+
+type anyOfPlayer_ZoneItemReferenceMap map[int]AnyOfPlayer_ZoneItemReference
+
+func (m anyOfPlayer_ZoneItemReferenceMap) keys(flags jwriter.Flags) []int {
+	ids := make([]int, 0, len(m))
+	for id := range m {
+		ids = append(ids, id)
+	}
+	if flags&sortedMapKeys != 0 {
+		sort.Slice(ids, func(i, j int) bool {
+			return ids[i] < ids[j]
+		})
+	}
+	return ids
+}
+
+func (m anyOfPlayer_ZoneItemReferenceMap) MarshalEasyJSON(out *jwriter.Writer) {
+	if m == nil && out.Flags&jwriter.NilMapAsEmpty == 0 {
+		out.RawString("null")
+		return
+	}
+	out.RawByte('{')
+	for i, id := range m.keys(out.Flags) {
+		if i > 0 {
+			out.RawByte(',')
+		}
+		out.IntStr(id)
+		out.RawByte(':')
+		m[id].MarshalEasyJSON(out)
+	}
+	out.RawByte('}')
+}
+
+type anyOfItem_Player_ZoneItemMap map[int]interface{}
+
+func (m anyOfItem_Player_ZoneItemMap) keys(flags jwriter.Flags) []int {
+	ids := make([]int, 0, len(m))
+	for id := range m {
+		ids = append(ids, id)
+	}
+	if flags&sortedMapKeys != 0 {
+		sort.Slice(ids, func(i, j int) bool {
+			return ids[i] < ids[j]
+		})
+	}
+	return ids
+}
+
+func (m anyOfItem_Player_ZoneItemMap) MarshalEasyJSON(out *jwriter.Writer) {
+	if m == nil && out.Flags&jwriter.NilMapAsEmpty == 0 {
+		out.RawString("null")
+		return
+	}
+	out.RawByte('{')
+	for i, id := range m.keys(out.Flags) {
+		if i > 0 {
+			out.RawByte(',')
+		}
+		out.IntStr(id)
+		out.RawByte(':')
+		if marshaler, ok := m[id].(easyjson.Marshaler); ok {
+			marshaler.MarshalEasyJSON(out)
+		} else {
+			out.Raw(json.Marshal(m[id]))
+		}
+	}
+	out.RawByte('}')
+}
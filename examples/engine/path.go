@@ -11,9 +11,10 @@ const (
 	itemsIdentifier         int = -6
 	positionIdentifier      int = -7
 	zoneIdentifier          int = -8
-	interactablesIdentifier int = -9
-	playersIdentifier       int = -10
-	zoneItemIdentifier      int = -11
+	bossIdentifier          int = -9
+	interactablesIdentifier int = -10
+	playersIdentifier       int = -11
+	zoneItemIdentifier      int = -12
 )
 
 type path []int
@@ -85,6 +86,13 @@ func (p path) zone() path {
 	return newPath
 }
 
+func (p path) boss() path {
+	newPath := make([]int, len(p), len(p)+1)
+	copy(newPath, p)
+	newPath = append(newPath, bossIdentifier)
+	return newPath
+}
+
 func (p path) zoneItem() path {
 	newPath := make([]int, len(p), len(p)+1)
 	copy(newPath, p)
@@ -120,6 +128,20 @@ func (p path) equals(parentPath path) bool {
 	return true
 }
 
+func (p path) isWithin(ancestorPath path) bool {
+	if len(p) < len(ancestorPath) {
+		return false
+	}
+
+	for i, segment := range ancestorPath {
+		if segment != p[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
 func (p path) toJSONPath() string {
 	jsonPath := "$"
 
@@ -154,6 +176,8 @@ func pathIdentifierToString(identifier int) string {
 		return "position"
 	case zoneIdentifier:
 		return "zone"
+	case bossIdentifier:
+		return "boss"
 	case interactablesIdentifier:
 		return "interactables"
 	case playersIdentifier:
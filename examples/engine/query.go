@@ -0,0 +1,474 @@
+package state
+
+import (
+	"sort"
+)
+
+type index struct {
+	gearScoreScore map[int]map[GearScoreID]bool
+	itemName       map[string]map[ItemID]bool
+}
+
+func newIndex() index {
+	return index{
+		gearScoreScore: make(map[int]map[GearScoreID]bool),
+		itemName:       make(map[string]map[ItemID]bool),
+	}
+}
+
+func (engine *Engine) indexGearScoreScore(gearScoreID GearScoreID, value int) {
+	gearScoreIDs, ok := engine.index.gearScoreScore[value]
+	if !ok {
+		gearScoreIDs = make(map[GearScoreID]bool)
+		engine.index.gearScoreScore[value] = gearScoreIDs
+	}
+	gearScoreIDs[gearScoreID] = true
+}
+
+func (engine *Engine) unindexGearScoreScore(gearScoreID GearScoreID, value int) {
+	gearScoreIDs := engine.index.gearScoreScore[value]
+	delete(gearScoreIDs, gearScoreID)
+	if len(gearScoreIDs) == 0 {
+		delete(engine.index.gearScoreScore, value)
+	}
+}
+
+func (engine *Engine) indexItemName(itemID ItemID, value string) {
+	itemIDs, ok := engine.index.itemName[value]
+	if !ok {
+		itemIDs = make(map[ItemID]bool)
+		engine.index.itemName[value] = itemIDs
+	}
+	itemIDs[itemID] = true
+}
+
+func (engine *Engine) unindexItemName(itemID ItemID, value string) {
+	itemIDs := engine.index.itemName[value]
+	delete(itemIDs, itemID)
+	if len(itemIDs) == 0 {
+		delete(engine.index.itemName, value)
+	}
+}
+
+type equipmentSetQuery struct {
+	engine          *Engine
+	equipmentSetIDs map[EquipmentSetID]bool
+	narrowed        bool
+	filters         []func(equipmentSet) bool
+}
+
+func (engine *Engine) QueryEquipmentSets() equipmentSetQuery {
+	return equipmentSetQuery{engine: engine}
+}
+
+func (query equipmentSetQuery) Where(filter func(equipmentSet) bool) equipmentSetQuery {
+	filters := make([]func(equipmentSet) bool, len(query.filters), len(query.filters)+1)
+	copy(filters, query.filters)
+	query.filters = append(filters, filter)
+	return query
+}
+
+func (query equipmentSetQuery) matches(equipmentSet equipmentSet) bool {
+	if equipmentSet.equipmentSet.OperationKind == OperationKindDelete {
+		return false
+	}
+	for _, filter := range query.filters {
+		if !filter(equipmentSet) {
+			return false
+		}
+	}
+	return true
+}
+
+func (query equipmentSetQuery) All() []equipmentSet {
+	var equipmentSets []equipmentSet
+	if query.narrowed {
+		for equipmentSetID := range query.equipmentSetIDs {
+			equipmentSet := query.engine.EquipmentSet(equipmentSetID)
+			if query.matches(equipmentSet) {
+				equipmentSets = append(equipmentSets, equipmentSet)
+			}
+		}
+		if query.engine.ordered {
+			sort.Slice(equipmentSets, func(i, j int) bool {
+				return equipmentSets[i].equipmentSet.ID < equipmentSets[j].equipmentSet.ID
+			})
+		}
+		return equipmentSets
+	}
+	equipmentSetIDs := query.engine.allEquipmentSetIDs()
+	for _, equipmentSetID := range equipmentSetIDs {
+		equipmentSet := query.engine.EquipmentSet(equipmentSetID)
+		if query.matches(equipmentSet) {
+			equipmentSets = append(equipmentSets, equipmentSet)
+		}
+	}
+	equipmentSetIDSlicePool.Put(equipmentSetIDs)
+	return equipmentSets
+}
+
+type gearScoreQuery struct {
+	engine       *Engine
+	gearScoreIDs map[GearScoreID]bool
+	narrowed     bool
+	filters      []func(gearScore) bool
+}
+
+func (engine *Engine) QueryGearScores() gearScoreQuery {
+	return gearScoreQuery{engine: engine}
+}
+
+func (query gearScoreQuery) Where(filter func(gearScore) bool) gearScoreQuery {
+	filters := make([]func(gearScore) bool, len(query.filters), len(query.filters)+1)
+	copy(filters, query.filters)
+	query.filters = append(filters, filter)
+	return query
+}
+
+func (query gearScoreQuery) WhereScore(score int) gearScoreQuery {
+	gearScoreIDs := make(map[GearScoreID]bool)
+	for gearScoreID := range query.engine.index.gearScoreScore[score] {
+		if !query.narrowed || query.gearScoreIDs[gearScoreID] {
+			gearScoreIDs[gearScoreID] = true
+		}
+	}
+	query.gearScoreIDs = gearScoreIDs
+	query.narrowed = true
+	return query
+}
+
+func (query gearScoreQuery) matches(gearScore gearScore) bool {
+	if gearScore.gearScore.OperationKind == OperationKindDelete {
+		return false
+	}
+	for _, filter := range query.filters {
+		if !filter(gearScore) {
+			return false
+		}
+	}
+	return true
+}
+
+func (query gearScoreQuery) All() []gearScore {
+	var gearScores []gearScore
+	if query.narrowed {
+		for gearScoreID := range query.gearScoreIDs {
+			gearScore := query.engine.GearScore(gearScoreID)
+			if query.matches(gearScore) {
+				gearScores = append(gearScores, gearScore)
+			}
+		}
+		if query.engine.ordered {
+			sort.Slice(gearScores, func(i, j int) bool {
+				return gearScores[i].gearScore.ID < gearScores[j].gearScore.ID
+			})
+		}
+		return gearScores
+	}
+	gearScoreIDs := query.engine.allGearScoreIDs()
+	for _, gearScoreID := range gearScoreIDs {
+		gearScore := query.engine.GearScore(gearScoreID)
+		if query.matches(gearScore) {
+			gearScores = append(gearScores, gearScore)
+		}
+	}
+	gearScoreIDSlicePool.Put(gearScoreIDs)
+	return gearScores
+}
+
+type itemQuery struct {
+	engine   *Engine
+	itemIDs  map[ItemID]bool
+	narrowed bool
+	filters  []func(item) bool
+}
+
+func (engine *Engine) QueryItems() itemQuery {
+	return itemQuery{engine: engine}
+}
+
+func (query itemQuery) Where(filter func(item) bool) itemQuery {
+	filters := make([]func(item) bool, len(query.filters), len(query.filters)+1)
+	copy(filters, query.filters)
+	query.filters = append(filters, filter)
+	return query
+}
+
+func (query itemQuery) WhereName(name string) itemQuery {
+	itemIDs := make(map[ItemID]bool)
+	for itemID := range query.engine.index.itemName[name] {
+		if !query.narrowed || query.itemIDs[itemID] {
+			itemIDs[itemID] = true
+		}
+	}
+	query.itemIDs = itemIDs
+	query.narrowed = true
+	return query
+}
+
+func (query itemQuery) matches(item item) bool {
+	if item.item.OperationKind == OperationKindDelete {
+		return false
+	}
+	for _, filter := range query.filters {
+		if !filter(item) {
+			return false
+		}
+	}
+	return true
+}
+
+func (query itemQuery) All() []item {
+	var items []item
+	if query.narrowed {
+		for itemID := range query.itemIDs {
+			item := query.engine.Item(itemID)
+			if query.matches(item) {
+				items = append(items, item)
+			}
+		}
+		if query.engine.ordered {
+			sort.Slice(items, func(i, j int) bool {
+				return items[i].item.ID < items[j].item.ID
+			})
+		}
+		return items
+	}
+	itemIDs := query.engine.allItemIDs()
+	for _, itemID := range itemIDs {
+		item := query.engine.Item(itemID)
+		if query.matches(item) {
+			items = append(items, item)
+		}
+	}
+	itemIDSlicePool.Put(itemIDs)
+	return items
+}
+
+type playerQuery struct {
+	engine    *Engine
+	playerIDs map[PlayerID]bool
+	narrowed  bool
+	filters   []func(player) bool
+}
+
+func (engine *Engine) QueryPlayers() playerQuery {
+	return playerQuery{engine: engine}
+}
+
+func (query playerQuery) Where(filter func(player) bool) playerQuery {
+	filters := make([]func(player) bool, len(query.filters), len(query.filters)+1)
+	copy(filters, query.filters)
+	query.filters = append(filters, filter)
+	return query
+}
+
+func (query playerQuery) matches(player player) bool {
+	if player.player.OperationKind == OperationKindDelete {
+		return false
+	}
+	for _, filter := range query.filters {
+		if !filter(player) {
+			return false
+		}
+	}
+	return true
+}
+
+func (query playerQuery) All() []player {
+	var players []player
+	if query.narrowed {
+		for playerID := range query.playerIDs {
+			player := query.engine.Player(playerID)
+			if query.matches(player) {
+				players = append(players, player)
+			}
+		}
+		if query.engine.ordered {
+			sort.Slice(players, func(i, j int) bool {
+				return players[i].player.ID < players[j].player.ID
+			})
+		}
+		return players
+	}
+	playerIDs := query.engine.allPlayerIDs()
+	for _, playerID := range playerIDs {
+		player := query.engine.Player(playerID)
+		if query.matches(player) {
+			players = append(players, player)
+		}
+	}
+	playerIDSlicePool.Put(playerIDs)
+	return players
+}
+
+type positionQuery struct {
+	engine      *Engine
+	positionIDs map[PositionID]bool
+	narrowed    bool
+	filters     []func(position) bool
+}
+
+func (engine *Engine) QueryPositions() positionQuery {
+	return positionQuery{engine: engine}
+}
+
+func (query positionQuery) Where(filter func(position) bool) positionQuery {
+	filters := make([]func(position) bool, len(query.filters), len(query.filters)+1)
+	copy(filters, query.filters)
+	query.filters = append(filters, filter)
+	return query
+}
+
+func (query positionQuery) matches(position position) bool {
+	if position.position.OperationKind == OperationKindDelete {
+		return false
+	}
+	for _, filter := range query.filters {
+		if !filter(position) {
+			return false
+		}
+	}
+	return true
+}
+
+func (query positionQuery) All() []position {
+	var positions []position
+	if query.narrowed {
+		for positionID := range query.positionIDs {
+			position := query.engine.Position(positionID)
+			if query.matches(position) {
+				positions = append(positions, position)
+			}
+		}
+		if query.engine.ordered {
+			sort.Slice(positions, func(i, j int) bool {
+				return positions[i].position.ID < positions[j].position.ID
+			})
+		}
+		return positions
+	}
+	positionIDs := query.engine.allPositionIDs()
+	for _, positionID := range positionIDs {
+		position := query.engine.Position(positionID)
+		if query.matches(position) {
+			positions = append(positions, position)
+		}
+	}
+	positionIDSlicePool.Put(positionIDs)
+	return positions
+}
+
+type zoneQuery struct {
+	engine   *Engine
+	zoneIDs  map[ZoneID]bool
+	narrowed bool
+	filters  []func(zone) bool
+}
+
+func (engine *Engine) QueryZones() zoneQuery {
+	return zoneQuery{engine: engine}
+}
+
+func (query zoneQuery) Where(filter func(zone) bool) zoneQuery {
+	filters := make([]func(zone) bool, len(query.filters), len(query.filters)+1)
+	copy(filters, query.filters)
+	query.filters = append(filters, filter)
+	return query
+}
+
+func (query zoneQuery) matches(zone zone) bool {
+	if zone.zone.OperationKind == OperationKindDelete {
+		return false
+	}
+	for _, filter := range query.filters {
+		if !filter(zone) {
+			return false
+		}
+	}
+	return true
+}
+
+func (query zoneQuery) All() []zone {
+	var zones []zone
+	if query.narrowed {
+		for zoneID := range query.zoneIDs {
+			zone := query.engine.Zone(zoneID)
+			if query.matches(zone) {
+				zones = append(zones, zone)
+			}
+		}
+		if query.engine.ordered {
+			sort.Slice(zones, func(i, j int) bool {
+				return zones[i].zone.ID < zones[j].zone.ID
+			})
+		}
+		return zones
+	}
+	zoneIDs := query.engine.allZoneIDs()
+	for _, zoneID := range zoneIDs {
+		zone := query.engine.Zone(zoneID)
+		if query.matches(zone) {
+			zones = append(zones, zone)
+		}
+	}
+	zoneIDSlicePool.Put(zoneIDs)
+	return zones
+}
+
+type zoneItemQuery struct {
+	engine      *Engine
+	zoneItemIDs map[ZoneItemID]bool
+	narrowed    bool
+	filters     []func(zoneItem) bool
+}
+
+func (engine *Engine) QueryZoneItems() zoneItemQuery {
+	return zoneItemQuery{engine: engine}
+}
+
+func (query zoneItemQuery) Where(filter func(zoneItem) bool) zoneItemQuery {
+	filters := make([]func(zoneItem) bool, len(query.filters), len(query.filters)+1)
+	copy(filters, query.filters)
+	query.filters = append(filters, filter)
+	return query
+}
+
+func (query zoneItemQuery) matches(zoneItem zoneItem) bool {
+	if zoneItem.zoneItem.OperationKind == OperationKindDelete {
+		return false
+	}
+	for _, filter := range query.filters {
+		if !filter(zoneItem) {
+			return false
+		}
+	}
+	return true
+}
+
+func (query zoneItemQuery) All() []zoneItem {
+	var zoneItems []zoneItem
+	if query.narrowed {
+		for zoneItemID := range query.zoneItemIDs {
+			zoneItem := query.engine.ZoneItem(zoneItemID)
+			if query.matches(zoneItem) {
+				zoneItems = append(zoneItems, zoneItem)
+			}
+		}
+		if query.engine.ordered {
+			sort.Slice(zoneItems, func(i, j int) bool {
+				return zoneItems[i].zoneItem.ID < zoneItems[j].zoneItem.ID
+			})
+		}
+		return zoneItems
+	}
+	zoneItemIDs := query.engine.allZoneItemIDs()
+	for _, zoneItemID := range zoneItemIDs {
+		zoneItem := query.engine.ZoneItem(zoneItemID)
+		if query.matches(zoneItem) {
+			zoneItems = append(zoneItems, zoneItem)
+		}
+	}
+	zoneItemIDSlicePool.Put(zoneItemIDs)
+	return zoneItems
+}
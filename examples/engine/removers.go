@@ -3,6 +3,7 @@ package state
 func (_zone zone) RemovePlayers(playersToRemove ...PlayerID) zone {
 	zone := _zone.zone.engine.Zone(_zone.zone.ID)
 	if zone.zone.OperationKind == OperationKindDelete {
+		zone.zone.engine.recordError("RemovePlayers", ElementKindZone, int(_zone.zone.ID), ErrElementDoesNotExist)
 		return zone
 	}
 	var wereElementsAltered bool
@@ -33,6 +34,7 @@ func (_zone zone) RemovePlayers(playersToRemove ...PlayerID) zone {
 func (_zone zone) RemoveItems(itemsToRemove ...ZoneItemID) zone {
 	zone := _zone.zone.engine.Zone(_zone.zone.ID)
 	if zone.zone.OperationKind == OperationKindDelete {
+		zone.zone.engine.recordError("RemoveItems", ElementKindZone, int(_zone.zone.ID), ErrElementDoesNotExist)
 		return zone
 	}
 	var wereElementsAltered bool
@@ -63,6 +65,7 @@ func (_zone zone) RemoveItems(itemsToRemove ...ZoneItemID) zone {
 func (_zone zone) RemoveInteractablesItem(itemsToRemove ...ItemID) zone {
 	zone := _zone.zone.engine.Zone(_zone.zone.ID)
 	if zone.zone.OperationKind == OperationKindDelete {
+		zone.zone.engine.recordError("RemoveInteractablesItem", ElementKindZone, int(_zone.zone.ID), ErrElementDoesNotExist)
 		return zone
 	}
 	var wereElementsAltered bool
@@ -95,6 +98,7 @@ func (_zone zone) RemoveInteractablesItem(itemsToRemove ...ItemID) zone {
 func (_zone zone) RemoveInteractablesPlayer(playersToRemove ...PlayerID) zone {
 	zone := _zone.zone.engine.Zone(_zone.zone.ID)
 	if zone.zone.OperationKind == OperationKindDelete {
+		zone.zone.engine.recordError("RemoveInteractablesPlayer", ElementKindZone, int(_zone.zone.ID), ErrElementDoesNotExist)
 		return zone
 	}
 	var wereElementsAltered bool
@@ -127,6 +131,7 @@ func (_zone zone) RemoveInteractablesPlayer(playersToRemove ...PlayerID) zone {
 func (_zone zone) RemoveInteractablesZoneItem(zoneItemsToRemove ...ZoneItemID) zone {
 	zone := _zone.zone.engine.Zone(_zone.zone.ID)
 	if zone.zone.OperationKind == OperationKindDelete {
+		zone.zone.engine.recordError("RemoveInteractablesZoneItem", ElementKindZone, int(_zone.zone.ID), ErrElementDoesNotExist)
 		return zone
 	}
 	var wereElementsAltered bool
@@ -159,6 +164,7 @@ func (_zone zone) RemoveInteractablesZoneItem(zoneItemsToRemove ...ZoneItemID) z
 func (_player player) RemoveItems(itemsToRemove ...ItemID) player {
 	player := _player.player.engine.Player(_player.player.ID)
 	if player.player.OperationKind == OperationKindDelete {
+		player.player.engine.recordError("RemoveItems", ElementKindPlayer, int(_player.player.ID), ErrElementDoesNotExist)
 		return player
 	}
 	var wereElementsAltered bool
@@ -189,6 +195,7 @@ func (_player player) RemoveItems(itemsToRemove ...ItemID) player {
 func (_player player) RemoveEquipmentSets(equipmentSetsToRemove ...EquipmentSetID) player {
 	player := _player.player.engine.Player(_player.player.ID)
 	if player.player.OperationKind == OperationKindDelete {
+		player.player.engine.recordError("RemoveEquipmentSets", ElementKindPlayer, int(_player.player.ID), ErrElementDoesNotExist)
 		return player
 	}
 	var wereElementsAltered bool
@@ -220,6 +227,7 @@ func (_player player) RemoveEquipmentSets(equipmentSetsToRemove ...EquipmentSetI
 func (_player player) RemoveGuildMembers(guildMembersToRemove ...PlayerID) player {
 	player := _player.player.engine.Player(_player.player.ID)
 	if player.player.OperationKind == OperationKindDelete {
+		player.player.engine.recordError("RemoveGuildMembers", ElementKindPlayer, int(_player.player.ID), ErrElementDoesNotExist)
 		return player
 	}
 	var wereElementsAltered bool
@@ -251,6 +259,7 @@ func (_player player) RemoveGuildMembers(guildMembersToRemove ...PlayerID) playe
 func (_player player) RemoveTargetedByZoneItem(zoneItemsToRemove ...ZoneItemID) player {
 	player := _player.player.engine.Player(_player.player.ID)
 	if player.player.OperationKind == OperationKindDelete {
+		player.player.engine.recordError("RemoveTargetedByZoneItem", ElementKindPlayer, int(_player.player.ID), ErrElementDoesNotExist)
 		return player
 	}
 	var wereElementsAltered bool
@@ -283,6 +292,7 @@ func (_player player) RemoveTargetedByZoneItem(zoneItemsToRemove ...ZoneItemID)
 func (_player player) RemoveTargetedByPlayer(playersToRemove ...PlayerID) player {
 	player := _player.player.engine.Player(_player.player.ID)
 	if player.player.OperationKind == OperationKindDelete {
+		player.player.engine.recordError("RemoveTargetedByPlayer", ElementKindPlayer, int(_player.player.ID), ErrElementDoesNotExist)
 		return player
 	}
 	var wereElementsAltered bool
@@ -315,6 +325,7 @@ func (_player player) RemoveTargetedByPlayer(playersToRemove ...PlayerID) player
 func (_zone zone) RemoveTags(tagsToRemove ...string) zone {
 	zone := _zone.zone.engine.Zone(_zone.zone.ID)
 	if zone.zone.OperationKind == OperationKindDelete {
+		zone.zone.engine.recordError("RemoveTags", ElementKindZone, int(_zone.zone.ID), ErrElementDoesNotExist)
 		return zone
 	}
 	var wereElementsAltered bool
@@ -344,6 +355,7 @@ func (_zone zone) RemoveTags(tagsToRemove ...string) zone {
 func (_equipmentSet equipmentSet) RemoveEquipment(equipmentToRemove ...ItemID) equipmentSet {
 	equipmentSet := _equipmentSet.equipmentSet.engine.EquipmentSet(_equipmentSet.equipmentSet.ID)
 	if equipmentSet.equipmentSet.OperationKind == OperationKindDelete {
+		equipmentSet.equipmentSet.engine.recordError("RemoveEquipment", ElementKindEquipmentSet, int(_equipmentSet.equipmentSet.ID), ErrElementDoesNotExist)
 		return equipmentSet
 	}
 	var wereElementsAltered bool
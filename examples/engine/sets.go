@@ -0,0 +1,30 @@
+package state
+
+func (_player player) HasGuildMember(playerID PlayerID) bool {
+	player := _player.player.engine.Player(_player.player.ID)
+	return player.player.indexOfGuildMember(playerID) != -1
+}
+
+func (_player player) LenGuildMembers() int {
+	player := _player.player.engine.Player(_player.player.ID)
+	return len(player.player.GuildMembers)
+}
+
+func (_zone zoneCore) indexOfTag(tag string) int {
+	for i, element := range _zone.Tags {
+		if element == tag {
+			return i
+		}
+	}
+	return -1
+}
+
+func (_zone zone) HasTag(tag string) bool {
+	zone := _zone.zone.engine.Zone(_zone.zone.ID)
+	return zone.zone.indexOfTag(tag) != -1
+}
+
+func (_zone zone) LenTags() int {
+	zone := _zone.zone.engine.Zone(_zone.zone.ID)
+	return len(zone.zone.Tags)
+}
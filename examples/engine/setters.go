@@ -1,8 +1,11 @@
 package state
 
+import "time"
+
 func (_gearScore gearScore) SetLevel(newLevel int) gearScore {
 	gearScore := _gearScore.gearScore.engine.GearScore(_gearScore.gearScore.ID)
 	if gearScore.gearScore.OperationKind == OperationKindDelete {
+		gearScore.gearScore.engine.recordError("SetLevel", ElementKindGearScore, int(_gearScore.gearScore.ID), ErrElementDoesNotExist)
 		return gearScore
 	}
 	gearScore.gearScore.Level = newLevel
@@ -14,9 +17,12 @@ func (_gearScore gearScore) SetLevel(newLevel int) gearScore {
 func (_gearScore gearScore) SetScore(newScore int) gearScore {
 	gearScore := _gearScore.gearScore.engine.GearScore(_gearScore.gearScore.ID)
 	if gearScore.gearScore.OperationKind == OperationKindDelete {
+		gearScore.gearScore.engine.recordError("SetScore", ElementKindGearScore, int(_gearScore.gearScore.ID), ErrElementDoesNotExist)
 		return gearScore
 	}
+	gearScore.gearScore.engine.unindexGearScoreScore(gearScore.gearScore.ID, gearScore.gearScore.Score)
 	gearScore.gearScore.Score = newScore
+	gearScore.gearScore.engine.indexGearScoreScore(gearScore.gearScore.ID, newScore)
 	gearScore.gearScore.OperationKind = OperationKindUpdate
 	gearScore.gearScore.engine.Patch.GearScore[gearScore.gearScore.ID] = gearScore.gearScore
 	return gearScore
@@ -25,6 +31,7 @@ func (_gearScore gearScore) SetScore(newScore int) gearScore {
 func (_position position) SetX(newX float64) position {
 	position := _position.position.engine.Position(_position.position.ID)
 	if position.position.OperationKind == OperationKindDelete {
+		position.position.engine.recordError("SetX", ElementKindPosition, int(_position.position.ID), ErrElementDoesNotExist)
 		return position
 	}
 	position.position.X = newX
@@ -36,6 +43,7 @@ func (_position position) SetX(newX float64) position {
 func (_position position) SetY(newY float64) position {
 	position := _position.position.engine.Position(_position.position.ID)
 	if position.position.OperationKind == OperationKindDelete {
+		position.position.engine.recordError("SetY", ElementKindPosition, int(_position.position.ID), ErrElementDoesNotExist)
 		return position
 	}
 	position.position.Y = newY
@@ -47,9 +55,12 @@ func (_position position) SetY(newY float64) position {
 func (_item item) SetName(newName string) item {
 	item := _item.item.engine.Item(_item.item.ID)
 	if item.item.OperationKind == OperationKindDelete {
+		item.item.engine.recordError("SetName", ElementKindItem, int(_item.item.ID), ErrElementDoesNotExist)
 		return item
 	}
+	item.item.engine.unindexItemName(item.item.ID, item.item.Name)
 	item.item.Name = newName
+	item.item.engine.indexItemName(item.item.ID, newName)
 	item.item.OperationKind = OperationKindUpdate
 	item.item.engine.Patch.Item[item.item.ID] = item.item
 	return item
@@ -58,9 +69,11 @@ func (_item item) SetName(newName string) item {
 func (_item item) SetBoundTo(playerID PlayerID) item {
 	item := _item.item.engine.Item(_item.item.ID)
 	if item.item.OperationKind == OperationKindDelete {
+		item.item.engine.recordError("SetBoundTo", ElementKindItem, int(_item.item.ID), ErrElementDoesNotExist)
 		return item
 	}
 	if item.item.engine.Player(playerID).player.OperationKind == OperationKindDelete {
+		item.item.engine.recordError("SetBoundTo", ElementKindPlayer, int(playerID), ErrElementDoesNotExist)
 		return item
 	}
 	if item.item.BoundTo != 0 {
@@ -76,6 +89,7 @@ func (_item item) SetBoundTo(playerID PlayerID) item {
 func (_equipmentSet equipmentSet) SetName(newName string) equipmentSet {
 	equipmentSet := _equipmentSet.equipmentSet.engine.EquipmentSet(_equipmentSet.equipmentSet.ID)
 	if equipmentSet.equipmentSet.OperationKind == OperationKindDelete {
+		equipmentSet.equipmentSet.engine.recordError("SetName", ElementKindEquipmentSet, int(_equipmentSet.equipmentSet.ID), ErrElementDoesNotExist)
 		return equipmentSet
 	}
 	equipmentSet.equipmentSet.Name = newName
@@ -84,12 +98,62 @@ func (_equipmentSet equipmentSet) SetName(newName string) equipmentSet {
 	return equipmentSet
 }
 
+func (_equipmentSet equipmentSet) SetCreatedAt(newCreatedAt time.Time) equipmentSet {
+	equipmentSet := _equipmentSet.equipmentSet.engine.EquipmentSet(_equipmentSet.equipmentSet.ID)
+	if equipmentSet.equipmentSet.OperationKind == OperationKindDelete {
+		equipmentSet.equipmentSet.engine.recordError("SetCreatedAt", ElementKindEquipmentSet, int(_equipmentSet.equipmentSet.ID), ErrElementDoesNotExist)
+		return equipmentSet
+	}
+	equipmentSet.equipmentSet.CreatedAt = newCreatedAt
+	equipmentSet.equipmentSet.OperationKind = OperationKindUpdate
+	equipmentSet.equipmentSet.engine.Patch.EquipmentSet[equipmentSet.equipmentSet.ID] = equipmentSet.equipmentSet
+	return equipmentSet
+}
+
+func (_equipmentSet equipmentSet) SetCooldown(newCooldown Duration) equipmentSet {
+	equipmentSet := _equipmentSet.equipmentSet.engine.EquipmentSet(_equipmentSet.equipmentSet.ID)
+	if equipmentSet.equipmentSet.OperationKind == OperationKindDelete {
+		equipmentSet.equipmentSet.engine.recordError("SetCooldown", ElementKindEquipmentSet, int(_equipmentSet.equipmentSet.ID), ErrElementDoesNotExist)
+		return equipmentSet
+	}
+	equipmentSet.equipmentSet.Cooldown = newCooldown
+	equipmentSet.equipmentSet.OperationKind = OperationKindUpdate
+	equipmentSet.equipmentSet.engine.Patch.EquipmentSet[equipmentSet.equipmentSet.ID] = equipmentSet.equipmentSet
+	return equipmentSet
+}
+
+func (_equipmentSet equipmentSet) SetExternalID(newExternalID BigID) equipmentSet {
+	equipmentSet := _equipmentSet.equipmentSet.engine.EquipmentSet(_equipmentSet.equipmentSet.ID)
+	if equipmentSet.equipmentSet.OperationKind == OperationKindDelete {
+		equipmentSet.equipmentSet.engine.recordError("SetExternalID", ElementKindEquipmentSet, int(_equipmentSet.equipmentSet.ID), ErrElementDoesNotExist)
+		return equipmentSet
+	}
+	equipmentSet.equipmentSet.ExternalID = newExternalID
+	equipmentSet.equipmentSet.OperationKind = OperationKindUpdate
+	equipmentSet.equipmentSet.engine.Patch.EquipmentSet[equipmentSet.equipmentSet.ID] = equipmentSet.equipmentSet
+	return equipmentSet
+}
+
+func (_equipmentSet equipmentSet) SetIcon(newIcon []byte) equipmentSet {
+	equipmentSet := _equipmentSet.equipmentSet.engine.EquipmentSet(_equipmentSet.equipmentSet.ID)
+	if equipmentSet.equipmentSet.OperationKind == OperationKindDelete {
+		equipmentSet.equipmentSet.engine.recordError("SetIcon", ElementKindEquipmentSet, int(_equipmentSet.equipmentSet.ID), ErrElementDoesNotExist)
+		return equipmentSet
+	}
+	equipmentSet.equipmentSet.Icon = append([]byte(nil), newIcon...)
+	equipmentSet.equipmentSet.OperationKind = OperationKindUpdate
+	equipmentSet.equipmentSet.engine.Patch.EquipmentSet[equipmentSet.equipmentSet.ID] = equipmentSet.equipmentSet
+	return equipmentSet
+}
+
 func (_player player) SetTargetPlayer(playerID PlayerID) player {
 	player := _player.player.engine.Player(_player.player.ID)
 	if player.player.OperationKind == OperationKindDelete {
+		player.player.engine.recordError("SetTargetPlayer", ElementKindPlayer, int(_player.player.ID), ErrElementDoesNotExist)
 		return player
 	}
 	if player.player.engine.Player(playerID).player.OperationKind == OperationKindDelete {
+		player.player.engine.recordError("SetTargetPlayer", ElementKindPlayer, int(playerID), ErrElementDoesNotExist)
 		return player
 	}
 	if player.player.Target != 0 {
@@ -107,9 +171,11 @@ func (_player player) SetTargetPlayer(playerID PlayerID) player {
 func (_player player) SetTargetZoneItem(zoneItemID ZoneItemID) player {
 	player := _player.player.engine.Player(_player.player.ID)
 	if player.player.OperationKind == OperationKindDelete {
+		player.player.engine.recordError("SetTargetZoneItem", ElementKindPlayer, int(_player.player.ID), ErrElementDoesNotExist)
 		return player
 	}
 	if player.player.engine.ZoneItem(zoneItemID).zoneItem.OperationKind == OperationKindDelete {
+		player.player.engine.recordError("SetTargetZoneItem", ElementKindZoneItem, int(zoneItemID), ErrElementDoesNotExist)
 		return player
 	}
 	if player.player.Target != 0 {
@@ -1,5 +1,7 @@
 package state
 
+import "time"
+
 type EquipmentSetID int
 type GearScoreID int
 type ItemID int
@@ -59,6 +61,7 @@ func newState() State {
 
 type zoneCore struct {
 	ID            ZoneID                        `json:"id"`
+	Boss          PlayerID                      `json:"boss"`
 	Interactables []AnyOfItem_Player_ZoneItemID `json:"interactables"`
 	Items         []ZoneItemID                  `json:"items"`
 	Players       []PlayerID                    `json:"players"`
@@ -72,6 +75,8 @@ type zoneCore struct {
 
 type zone struct{ zone zoneCore }
 
+type ZoneElement = zone
+
 type zoneItemCore struct {
 	ID            ZoneItemID    `json:"id"`
 	Item          ItemID        `json:"item"`
@@ -85,6 +90,8 @@ type zoneItemCore struct {
 
 type zoneItem struct{ zoneItem zoneItemCore }
 
+type ZoneItemElement = zoneItem
+
 type itemCore struct {
 	ID            ItemID                 `json:"id"`
 	BoundTo       ItemBoundToRefID       `json:"boundTo"`
@@ -100,6 +107,8 @@ type itemCore struct {
 
 type item struct{ item itemCore }
 
+type ItemElement = item
+
 type playerCore struct {
 	ID            PlayerID                  `json:"id"`
 	EquipmentSets []PlayerEquipmentSetRefID `json:"equipmentSets"`
@@ -118,6 +127,8 @@ type playerCore struct {
 
 type player struct{ player playerCore }
 
+type PlayerElement = player
+
 type gearScoreCore struct {
 	ID            GearScoreID   `json:"id"`
 	Level         int           `json:"level"`
@@ -131,6 +142,8 @@ type gearScoreCore struct {
 
 type gearScore struct{ gearScore gearScoreCore }
 
+type GearScoreElement = gearScore
+
 type positionCore struct {
 	ID            PositionID    `json:"id"`
 	X             float64       `json:"x"`
@@ -144,9 +157,15 @@ type positionCore struct {
 
 type position struct{ position positionCore }
 
+type PositionElement = position
+
 type equipmentSetCore struct {
 	ID            EquipmentSetID               `json:"id"`
+	Cooldown      Duration                     `json:"cooldown"`
+	CreatedAt     time.Time                    `json:"createdAt"`
 	Equipment     []EquipmentSetEquipmentRefID `json:"equipment"`
+	ExternalID    BigID                        `json:"externalID"`
+	Icon          []byte                       `json:"icon"`
 	Name          string                       `json:"name"`
 	OperationKind OperationKind                `json:"operationKind"`
 	HasParent     bool                         `json:"hasParent"`
@@ -157,6 +176,8 @@ type equipmentSetCore struct {
 
 type equipmentSet struct{ equipmentSet equipmentSetCore }
 
+type EquipmentSetElement = equipmentSet
+
 type itemBoundToRefCore struct {
 	ID                  ItemBoundToRefID `json:"id"`
 	ParentID            ItemID           `json:"parentID"`
@@ -15,6 +15,12 @@ type Engine struct {
 	assembleCache             assembleCache
 	forceIncludeAssembleCache assembleCache
 	IDgen                     int
+	index                     index
+	listeners                 listeners
+	strict                    bool
+	errs                      []error
+	ordered                   bool
+	queueEvent                func(message []byte, clientIDs map[string]bool) error
 }
 
 func newEngine() *Engine {
@@ -25,6 +31,7 @@ func newEngine() *Engine {
 		Tree:                      newTree(),
 		assembleCache:             newAssembleCache(),
 		forceIncludeAssembleCache: newAssembleCache(),
+		index:                     newIndex(),
 	}
 }
 
@@ -35,6 +42,8 @@ func (engine *Engine) GenerateID() int {
 }
 
 func (engine *Engine) UpdateState() {
+	engine.emitChanges()
+	engine.listeners.emitted = false
 	for _, equipmentSet := range engine.Patch.EquipmentSet {
 		if equipmentSet.OperationKind == OperationKindDelete {
 			delete(engine.State.EquipmentSet, equipmentSet.ID)
@@ -212,4 +221,6 @@ func (engine *Engine) UpdateState() {
 	for key := range engine.Patch.AnyOfItem_Player_ZoneItem {
 		delete(engine.Patch.AnyOfItem_Player_ZoneItem, key)
 	}
+
+	engine.errs = nil
 }
@@ -248,3 +248,50 @@ func BenchmarkUpdateState(b *testing.B) {
 		engine.UpdateState()
 	}
 }
+
+func benchTestEveryElement(b *testing.B, ordered bool) {
+	engine := newEngine()
+	engine.ordered = ordered
+	for i := 0; i < benchTestNumberOfZones; i++ {
+		setUpRealisticZoneForBenchmarkExample(engine)
+	}
+	engine.UpdateState()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = engine.EveryItem()
+		_ = engine.EveryPlayer()
+		_ = engine.EveryEquipmentSet()
+	}
+}
+
+func BenchmarkEveryElement(b *testing.B) {
+	benchTestEveryElement(b, false)
+}
+
+func BenchmarkEveryElementOrdered(b *testing.B) {
+	benchTestEveryElement(b, true)
+}
+
+func benchTestMarshalTree(b *testing.B, ordered bool) {
+	engine := newEngine()
+	engine.ordered = ordered
+	for i := 0; i < benchTestNumberOfZones; i++ {
+		setUpRealisticZoneForBenchmarkExample(engine)
+	}
+	engine.UpdateState()
+	tree := engine.assembleTree(true)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = engine.marshalTree(tree)
+	}
+}
+
+func BenchmarkMarshalTree(b *testing.B) {
+	benchTestMarshalTree(b, false)
+}
+
+func BenchmarkMarshalTreeOrdered(b *testing.B) {
+	benchTestMarshalTree(b, true)
+}
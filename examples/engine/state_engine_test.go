@@ -1,7 +1,11 @@
 package state
 
 import (
+	"errors"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/jobergner/backent-cli/testutils"
 
@@ -277,6 +281,718 @@ func newTreeTest(define func(*Engine, *Tree), onFail func(errText string)) {
 	}
 }
 
+func TestQuery(t *testing.T) {
+	t.Run("finds elements by indexed field", func(t *testing.T) {
+		se := newEngine()
+		player := se.CreatePlayer()
+		player.AddItem().SetName("sword")
+		se.CreateItem().SetName("sword")
+		se.CreateItem().SetName("shield")
+		assert.Equal(t, 2, len(se.QueryItems().WhereName("sword").All()))
+		assert.Equal(t, 0, len(se.QueryItems().WhereName("axe").All()))
+	})
+	t.Run("updates index when field is set", func(t *testing.T) {
+		se := newEngine()
+		item := se.CreateItem().SetName("sword")
+		se.UpdateState()
+		item.SetName("axe")
+		assert.Equal(t, 0, len(se.QueryItems().WhereName("sword").All()))
+		assert.Equal(t, item.ID(), se.QueryItems().WhereName("axe").All()[0].ID())
+	})
+	t.Run("indexes zero values of created elements", func(t *testing.T) {
+		se := newEngine()
+		se.CreateItem()
+		assert.Equal(t, 1, len(se.QueryItems().WhereName("").All()))
+	})
+	t.Run("excludes deleted elements", func(t *testing.T) {
+		se := newEngine()
+		item := se.CreateItem().SetName("sword")
+		se.UpdateState()
+		se.DeleteItem(item.ID())
+		assert.Equal(t, 0, len(se.QueryItems().WhereName("sword").All()))
+		assert.Equal(t, 0, len(se.QueryItems().All()))
+	})
+	t.Run("combines index with filters", func(t *testing.T) {
+		se := newEngine()
+		se.CreateGearScore().SetScore(50).SetLevel(1)
+		se.CreateGearScore().SetScore(50).SetLevel(2)
+		se.CreateGearScore().SetScore(60).SetLevel(2)
+		levelTwo := func(gearScore gearScore) bool { return gearScore.Level() == 2 }
+		assert.Equal(t, 1, len(se.QueryGearScores().WhereScore(50).Where(levelTwo).All()))
+		assert.Equal(t, 2, len(se.QueryGearScores().Where(levelTwo).All()))
+		assert.Equal(t, 0, len(se.QueryGearScores().WhereScore(50).WhereScore(60).All()))
+	})
+	t.Run("does not share filters between derived queries", func(t *testing.T) {
+		se := newEngine()
+		se.CreateGearScore().SetLevel(1)
+		se.CreateGearScore().SetLevel(2)
+		query := se.QueryGearScores().Where(func(gearScore gearScore) bool { return gearScore.Level() > 0 })
+		levelOne := query.Where(func(gearScore gearScore) bool { return gearScore.Level() == 1 })
+		levelTwo := query.Where(func(gearScore gearScore) bool { return gearScore.Level() == 2 })
+		assert.Equal(t, 1, len(levelOne.All()))
+		assert.Equal(t, 1, len(levelTwo.All()))
+		assert.Equal(t, 2, len(query.All()))
+	})
+}
+
+func TestListeners(t *testing.T) {
+	t.Run("emits created, updated and deleted elements on UpdateState", func(t *testing.T) {
+		se := newEngine()
+		var created, deleted []ItemID
+		var updatedFrom, updatedTo []string
+		se.OnItemCreated(func(item item) { created = append(created, item.ID()) })
+		se.OnItemUpdated(func(old, new item) {
+			updatedFrom = append(updatedFrom, old.Name())
+			updatedTo = append(updatedTo, new.Name())
+		})
+		se.OnItemDeleted(func(item item) { deleted = append(deleted, item.ID()) })
+
+		item := se.CreateItem().SetName("sword")
+		assert.Empty(t, created)
+		se.UpdateState()
+		assert.Equal(t, []ItemID{item.ID()}, created)
+		assert.Empty(t, updatedTo)
+
+		item.SetName("axe")
+		se.UpdateState()
+		assert.Equal(t, []string{"sword"}, updatedFrom)
+		assert.Equal(t, []string{"axe"}, updatedTo)
+
+		se.DeleteItem(item.ID())
+		se.UpdateState()
+		assert.Equal(t, []ItemID{item.ID()}, deleted)
+		assert.Equal(t, 1, len(created))
+		assert.Equal(t, 1, len(updatedTo))
+	})
+	t.Run("emits nothing for elements created and deleted before UpdateState", func(t *testing.T) {
+		se := newEngine()
+		var emitted int
+		se.OnItemCreated(func(item item) { emitted++ })
+		se.OnItemDeleted(func(item item) { emitted++ })
+		item := se.CreateItem()
+		se.DeleteItem(item.ID())
+		se.UpdateState()
+		assert.Equal(t, 0, emitted)
+	})
+	t.Run("emits child elements", func(t *testing.T) {
+		se := newEngine()
+		var positions int
+		se.OnPositionCreated(func(position position) { positions++ })
+		se.CreatePlayer()
+		se.UpdateState()
+		assert.Equal(t, 1, positions)
+	})
+	t.Run("includes changes made by listeners in the update", func(t *testing.T) {
+		se := newEngine()
+		var created int
+		se.OnPlayerCreated(func(player player) {
+			created++
+			player.AddItem().SetName("starter")
+		})
+		player := se.CreatePlayer()
+		se.UpdateState()
+		assert.Equal(t, 1, created)
+		assert.Equal(t, 0, len(se.Patch.Item))
+		assert.Equal(t, "starter", se.Player(player.ID()).Items()[0].Name())
+	})
+	t.Run("emits elements ordered by ID only once per patch", func(t *testing.T) {
+		se := newEngine()
+		var created []ItemID
+		se.OnItemCreated(func(item item) { created = append(created, item.ID()) })
+		var expected []ItemID
+		for i := 0; i < 20; i++ {
+			expected = append(expected, se.CreateItem().ID())
+		}
+		se.emitChanges()
+		se.UpdateState()
+		assert.Equal(t, expected, created)
+
+		se.CreateItem()
+		se.UpdateState()
+		assert.Equal(t, 21, len(created))
+	})
+}
+
+func TestElementByPath(t *testing.T) {
+	t.Run("resolves root, slice and nested elements", func(t *testing.T) {
+		se := newEngine()
+		player := se.CreatePlayer()
+		item := player.AddItem().SetName("sword")
+		se.UpdateState()
+
+		element, ok := se.ElementByPath(player.Path())
+		assert.True(t, ok)
+		assert.Equal(t, ElementKindPlayer, element.Kind())
+		assert.Equal(t, player.ID(), element.Player().ID())
+
+		element, ok = se.ElementByPath(item.Path())
+		assert.True(t, ok)
+		assert.Equal(t, ElementKindItem, element.Kind())
+		assert.Equal(t, "sword", element.Item().Name())
+
+		element, ok = se.ElementByPath(item.GearScore().Path())
+		assert.True(t, ok)
+		assert.Equal(t, ElementKindGearScore, element.Kind())
+		assert.Equal(t, item.GearScore().ID(), element.GearScore().ID())
+	})
+	t.Run("resolves elements of anyOf fields", func(t *testing.T) {
+		se := newEngine()
+		item := se.CreateItem()
+		origin := item.Origin().SetPosition()
+
+		element, ok := se.ElementByPath(origin.Path())
+		assert.True(t, ok)
+		assert.Equal(t, ElementKindPosition, element.Kind())
+		assert.Equal(t, origin.ID(), element.Position().ID())
+	})
+	t.Run("rejects unknown, malformed and deleted paths", func(t *testing.T) {
+		se := newEngine()
+		player := se.CreatePlayer()
+		item := player.AddItem()
+
+		_, ok := se.ElementByPath("$.player.999")
+		assert.False(t, ok)
+		_, ok = se.ElementByPath("$.player")
+		assert.False(t, ok)
+		_, ok = se.ElementByPath("$.zone.1.items[" + strconv.Itoa(int(item.ID())) + "]")
+		assert.False(t, ok)
+		_, ok = se.ElementByPath(player.Path() + ".unknown")
+		assert.False(t, ok)
+
+		path := item.Path()
+		player.RemoveItems(item.ID())
+		_, ok = se.ElementByPath(path)
+		assert.False(t, ok)
+	})
+}
+
+func TestClone(t *testing.T) {
+	t.Run("deep copies an element with new IDs", func(t *testing.T) {
+		se := newEngine()
+		guildMember := se.CreatePlayer()
+		player := se.CreatePlayer()
+		player.GearScore().SetLevel(3)
+		player.Position().SetX(1.5)
+		player.AddGuildMember(guildMember.ID())
+		item := player.AddItem().SetName("sword")
+		item.Origin().SetPosition().SetY(2)
+		se.UpdateState()
+
+		clone := player.Clone()
+		assert.NotEqual(t, player.ID(), clone.ID())
+		assert.Equal(t, 3, clone.GearScore().Level())
+		assert.NotEqual(t, player.GearScore().ID(), clone.GearScore().ID())
+		assert.Equal(t, 1.5, clone.Position().X())
+		assert.Equal(t, guildMember.ID(), clone.GuildMembers()[0].ID())
+		assert.Equal(t, 1, len(clone.Items()))
+		clonedItem := clone.Items()[0]
+		assert.NotEqual(t, item.ID(), clonedItem.ID())
+		assert.Equal(t, "sword", clonedItem.Name())
+		assert.Equal(t, ElementKindPosition, clonedItem.Origin().Kind())
+		assert.Equal(t, 2.0, clonedItem.Origin().Position().Y())
+		assert.Equal(t, clone.Path()+".items["+strconv.Itoa(int(clonedItem.ID()))+"]", clonedItem.Path())
+		assert.Equal(t, 1, len(player.Items()))
+	})
+	t.Run("clones child elements as root elements", func(t *testing.T) {
+		se := newEngine()
+		item := se.CreatePlayer().AddItem().SetName("sword")
+
+		clone := item.Clone()
+		assert.False(t, clone.item.HasParent)
+		assert.Equal(t, "$.item."+strconv.Itoa(int(clone.ID())), clone.Path())
+		assert.Equal(t, "sword", clone.Name())
+	})
+}
+
+func TestAdopt(t *testing.T) {
+	t.Run("moves a child between slices keeping its ID", func(t *testing.T) {
+		se := newEngine()
+		player1 := se.CreatePlayer()
+		player2 := se.CreatePlayer()
+		item := player1.AddItem().SetName("sword")
+		se.UpdateState()
+
+		adopted := player2.AdoptItem(item.ID())
+		assert.Equal(t, item.ID(), adopted.ID())
+		assert.Equal(t, 0, len(player1.Items()))
+		assert.Equal(t, 1, len(player2.Items()))
+		assert.Equal(t, player2.Path()+".items["+strconv.Itoa(int(item.ID()))+"]", adopted.Path())
+		assert.Equal(t, adopted.Path()+".gearScore", adopted.GearScore().Path())
+		assert.Equal(t, OperationKindUpdate, se.Patch.Player[player1.ID()].OperationKind)
+		assert.Equal(t, OperationKindUpdate, se.Patch.Item[item.ID()].OperationKind)
+	})
+	t.Run("replaces the child of a non-slice field", func(t *testing.T) {
+		se := newEngine()
+		player := se.CreatePlayer()
+		item := player.AddItem().SetName("sword")
+		zoneItem := se.CreateZoneItem()
+		replacedItemID := zoneItem.Item().ID()
+
+		adopted := zoneItem.AdoptItem(item.ID())
+		assert.Equal(t, item.ID(), zoneItem.Item().ID())
+		assert.Equal(t, zoneItem.Path()+".item", adopted.Path())
+		assert.Equal(t, OperationKindDelete, se.Item(replacedItemID).item.OperationKind)
+		assert.Equal(t, 0, len(player.Items()))
+	})
+	t.Run("adopts root elements", func(t *testing.T) {
+		se := newEngine()
+		zone := se.CreateZone()
+		player := se.CreatePlayer()
+
+		zone.AdoptPlayer(player.ID())
+		assert.True(t, se.Player(player.ID()).player.HasParent)
+		assert.Equal(t, 0, len(se.EveryPlayer()))
+		assert.Equal(t, player.ID(), zone.Players()[0].ID())
+	})
+	t.Run("refuses children of non-slice fields and cycles", func(t *testing.T) {
+		se := newEngine()
+		zone := se.CreateZone()
+		player := zone.AddPlayer()
+		gearScore := player.GearScore()
+		zoneItem := se.CreateZoneItem()
+
+		item := zoneItem.Item()
+		se.CreatePlayer().AdoptItem(item.ID())
+		assert.Equal(t, item.ID(), zoneItem.Item().ID())
+
+		se.CreateItem().AdoptGearScore(gearScore.ID())
+		assert.Equal(t, gearScore.ID(), player.GearScore().ID())
+
+		item = player.AddItem()
+		item.Origin().Player().AdoptItem(item.ID())
+		assert.Equal(t, player.Path()+".items["+strconv.Itoa(int(item.ID()))+"]", se.Item(item.ID()).Path())
+	})
+}
+
+func TestStrictMode(t *testing.T) {
+	t.Run("records mutations which had no effect", func(t *testing.T) {
+		se := newEngine()
+		se.EnableStrictMode()
+		player := se.CreatePlayer()
+		item := player.AddItem()
+		se.DeletePlayer(player.ID())
+
+		player.SetTargetPlayer(player.ID())
+		se.DeleteItem(item.ID())
+		se.DeletePlayer(player.ID())
+		errs := se.Errors()
+		assert.Equal(t, 3, len(errs))
+		assert.True(t, errors.Is(errs[0], ErrElementDoesNotExist))
+		assert.True(t, errors.Is(errs[1], ErrElementDoesNotExist))
+		assert.True(t, errors.Is(errs[2], ErrElementDoesNotExist))
+		assert.Equal(t, "SetTargetPlayer: Player 1: element does not exist", errs[0].Error())
+
+		se.UpdateState()
+		assert.Equal(t, 0, len(se.Errors()))
+	})
+	t.Run("records refused deletions and adoptions", func(t *testing.T) {
+		se := newEngine()
+		se.EnableStrictMode()
+		player := se.CreatePlayer()
+		item := player.AddItem()
+		zoneItem := se.CreateZoneItem()
+
+		se.DeleteItem(item.ID())
+		player.AdoptItem(zoneItem.Item().ID())
+		errs := se.Errors()
+		assert.Equal(t, 2, len(errs))
+		assert.True(t, errors.Is(errs[0], ErrElementHasParent))
+		assert.True(t, errors.Is(errs[1], ErrElementNotDetachable))
+	})
+	t.Run("records nothing when not enabled", func(t *testing.T) {
+		se := newEngine()
+		player := se.CreatePlayer()
+		se.DeletePlayer(player.ID())
+		player.AddItem()
+		assert.Equal(t, 0, len(se.Errors()))
+	})
+}
+
+func TestLookup(t *testing.T) {
+	t.Run("reports whether elements exist", func(t *testing.T) {
+		se := newEngine()
+		player := se.CreatePlayer()
+		_, ok := se.LookupPlayer(player.ID())
+		assert.True(t, ok)
+		assert.True(t, se.PlayerExists(player.ID()))
+
+		se.DeletePlayer(player.ID())
+		_, ok = se.LookupPlayer(player.ID())
+		assert.False(t, ok)
+		assert.False(t, se.PlayerExists(player.ID()))
+		assert.False(t, se.ItemExists(ItemID(999)))
+	})
+}
+
+func TestOrdering(t *testing.T) {
+	t.Run("returns elements sorted by ID", func(t *testing.T) {
+		se := newEngine()
+		se.ordered = true
+		for i := 0; i < 50; i++ {
+			se.CreatePlayer()
+		}
+		se.UpdateState()
+		for i := 0; i < 50; i++ {
+			se.CreatePlayer()
+		}
+
+		players := se.EveryPlayer()
+		assert.Equal(t, 100, len(players))
+		for i := 1; i < len(players); i++ {
+			assert.Less(t, int(players[i-1].ID()), int(players[i].ID()))
+		}
+	})
+	t.Run("returns elements of narrowed queries sorted by ID", func(t *testing.T) {
+		se := newEngine()
+		se.ordered = true
+		for i := 0; i < 50; i++ {
+			se.CreateItem().SetName("sword")
+		}
+
+		items := se.QueryItems().WhereName("sword").All()
+		assert.Equal(t, 50, len(items))
+		for i := 1; i < len(items); i++ {
+			assert.Less(t, int(items[i-1].ID()), int(items[i].ID()))
+		}
+	})
+	t.Run("marshals trees with keys sorted by ID", func(t *testing.T) {
+		se := newEngine()
+		se.ordered = true
+		player := se.CreatePlayer()
+		for i := 0; i < 20; i++ {
+			player.AddItem()
+		}
+
+		treeBytes, err := se.marshalTree(se.assembleTree(false))
+		assert.NoError(t, err)
+		var previousIndex int
+		for _, item := range player.Items() {
+			index := strings.Index(string(treeBytes), `"`+strconv.Itoa(int(item.ID()))+`":{"id":`)
+			assert.Less(t, previousIndex, index)
+			previousIndex = index
+		}
+	})
+	t.Run("keeps the order of fields", func(t *testing.T) {
+		se := newEngine()
+		se.CreatePlayer().AddItem().SetName("sword")
+		tree := se.assembleTree(false)
+		expected, err := tree.MarshalJSON()
+		assert.NoError(t, err)
+
+		se.ordered = true
+		actual, err := se.marshalTree(tree)
+		assert.NoError(t, err)
+		assert.Equal(t, string(expected), string(actual))
+	})
+	t.Run("marshals the same tree as unordered engines", func(t *testing.T) {
+		se := newEngine()
+		zone := se.CreateZone()
+		for i := 0; i < 10; i++ {
+			player := zone.AddInteractablePlayer()
+			player.AddGuildMember(se.CreatePlayer().ID())
+			player.AddTargetedByZoneItem(zone.AddItem().ID())
+			zone.AddInteractableItem()
+		}
+		tree := se.assembleTree(false)
+		expected, err := se.marshalTree(tree)
+		assert.NoError(t, err)
+
+		se.ordered = true
+		actual, err := se.marshalTree(tree)
+		assert.NoError(t, err)
+		assert.JSONEq(t, string(expected), string(actual))
+	})
+}
+
+func TestMovers(t *testing.T) {
+	t.Run("inserts elements at index", func(t *testing.T) {
+		se := newEngine()
+		player := se.CreatePlayer()
+		item1 := player.AddItem()
+		item2 := player.AddItem()
+		item3 := player.InsertItemAt(1)
+		item4 := player.InsertItemAt(10)
+		assert.Equal(t, []ItemID{item1.ID(), item3.ID(), item2.ID(), item4.ID()}, itemIDs(player.Items()))
+	})
+	t.Run("moves and swaps elements", func(t *testing.T) {
+		se := newEngine()
+		player := se.CreatePlayer()
+		item1 := player.AddItem()
+		item2 := player.AddItem()
+		item3 := player.AddItem()
+		player.MoveItem(item3.ID(), 0)
+		assert.Equal(t, []ItemID{item3.ID(), item1.ID(), item2.ID()}, itemIDs(player.Items()))
+		player.SwapItems(item1.ID(), item3.ID())
+		assert.Equal(t, []ItemID{item1.ID(), item3.ID(), item2.ID()}, itemIDs(player.Items()))
+	})
+	t.Run("orders references and any kinds by referenced element", func(t *testing.T) {
+		se := newEngine()
+		player := se.CreatePlayer()
+		member1 := se.CreatePlayer()
+		member2 := se.CreatePlayer()
+		player.AddGuildMember(member1.ID())
+		player.InsertGuildMemberAt(0, member2.ID())
+		assert.Equal(t, member2.ID(), player.GuildMembers()[0].ID())
+
+		zone := se.CreateZone()
+		interactableItem := zone.AddInteractableItem()
+		interactablePlayer := zone.AddInteractablePlayer()
+		zone.MoveInteractable(int(interactablePlayer.ID()), 0)
+		assert.Equal(t, int(interactablePlayer.ID()), int(zone.Interactables()[0].Player().ID()))
+		assert.Equal(t, int(interactableItem.ID()), int(zone.Interactables()[1].Item().ID()))
+	})
+	t.Run("includes order in patch tree when reordered", func(t *testing.T) {
+		se := newEngine()
+		player := se.CreatePlayer()
+		item1 := player.AddItem()
+		item2 := player.AddItem()
+		se.UpdateState()
+
+		player.SwapItems(item1.ID(), item2.ID())
+		tree := se.assembleTree(false)
+		assert.Equal(t, []ItemID{item2.ID(), item1.ID()}, tree.Player[player.ID()].ItemsOrder)
+		se.UpdateState()
+
+		player.AddItem()
+		tree = se.assembleTree(false)
+		assert.Nil(t, tree.Player[player.ID()].ItemsOrder)
+	})
+	t.Run("records elements which are not in slice", func(t *testing.T) {
+		se := newEngine()
+		se.EnableStrictMode()
+		player := se.CreatePlayer()
+		item := player.AddItem()
+		player.MoveItem(ItemID(999), 0)
+		player.SwapItems(item.ID(), ItemID(999))
+		errs := se.Errors()
+		assert.Equal(t, 2, len(errs))
+		assert.True(t, errors.Is(errs[0], ErrElementNotInSlice))
+		assert.True(t, errors.Is(errs[1], ErrElementNotInSlice))
+	})
+}
+
+func itemIDs(items []item) []ItemID {
+	ids := make([]ItemID, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, item.ID())
+	}
+	return ids
+}
+
+func TestSets(t *testing.T) {
+	t.Run("adds values only once", func(t *testing.T) {
+		se := newEngine()
+		zone := se.CreateZone()
+		zone.AddTags("a", "b", "a")
+		zone.AddTags("b", "c")
+		assert.Equal(t, []string{"a", "b", "c"}, zone.Tags())
+		assert.Equal(t, 3, zone.LenTags())
+		assert.True(t, zone.HasTag("c"))
+		zone.RemoveTags("c")
+		assert.False(t, zone.HasTag("c"))
+	})
+	t.Run("leaves the patch untouched when no value is added", func(t *testing.T) {
+		se := newEngine()
+		zone := se.CreateZone()
+		zone.AddTags("a")
+		se.UpdateState()
+		zone.AddTags("a", "a")
+		_, ok := se.Patch.Zone[zone.ID()]
+		assert.False(t, ok)
+		zone.AddTags("a", "b")
+		assert.Equal(t, OperationKindUpdate, se.Patch.Zone[zone.ID()].OperationKind)
+	})
+	t.Run("references elements only once", func(t *testing.T) {
+		se := newEngine()
+		player := se.CreatePlayer()
+		member := se.CreatePlayer()
+		player.AddGuildMember(member.ID())
+		player.AddGuildMember(member.ID())
+		player.InsertGuildMemberAt(0, member.ID())
+		assert.Equal(t, 1, player.LenGuildMembers())
+		assert.True(t, player.HasGuildMember(member.ID()))
+		assert.False(t, player.HasGuildMember(player.ID()))
+
+		se.DeletePlayer(member.ID())
+		assert.False(t, player.HasGuildMember(member.ID()))
+		assert.Equal(t, 0, player.LenGuildMembers())
+	})
+}
+
+func TestOptionals(t *testing.T) {
+	t.Run("creates the child only when set", func(t *testing.T) {
+		se := newEngine()
+		zone := se.CreateZone()
+		assert.False(t, zone.HasBoss())
+		_, ok := zone.Boss()
+		assert.False(t, ok)
+		assert.Equal(t, 0, len(se.Patch.Player))
+
+		boss := zone.SetBoss()
+		assert.True(t, zone.HasBoss())
+		assert.Equal(t, boss.ID(), zone.SetBoss().ID())
+		assert.Equal(t, zone.Path()+".boss", boss.Path())
+		assert.Equal(t, 0, len(se.EveryPlayer()))
+
+		element, ok := se.ElementByPath(boss.Path())
+		assert.True(t, ok)
+		assert.Equal(t, boss.ID(), element.Player().ID())
+	})
+	t.Run("deletes the child when unset", func(t *testing.T) {
+		se := newEngine()
+		zone := se.CreateZone()
+		boss := zone.SetBoss()
+		item := boss.AddItem()
+		se.UpdateState()
+
+		path := boss.Path()
+		zone.UnsetBoss()
+		assert.False(t, zone.HasBoss())
+		assert.Equal(t, OperationKindDelete, se.Patch.Player[boss.ID()].OperationKind)
+		assert.Equal(t, OperationKindDelete, se.Patch.Item[item.ID()].OperationKind)
+		_, ok := se.ElementByPath(path)
+		assert.False(t, ok)
+
+		tree := se.assembleTree(false)
+		assert.Equal(t, OperationKindDelete, tree.Zone[zone.ID()].Boss.OperationKind)
+
+		se.UpdateState()
+		tree = se.assembleTree(true)
+		assert.Nil(t, tree.Zone[zone.ID()].Boss)
+	})
+	t.Run("leaves an unset child out of the tree", func(t *testing.T) {
+		se := newEngine()
+		zone := se.CreateZone()
+		zone.SetBoss()
+		zone.UnsetBoss()
+
+		tree := se.assembleTree(false)
+		assert.Nil(t, tree.Zone[zone.ID()].Boss)
+		tree = se.assembleTree(true)
+		assert.Nil(t, tree.Zone[zone.ID()].Boss)
+
+		se.UpdateState()
+		zone.SetBoss()
+		se.UpdateState()
+		zone.UnsetBoss()
+		tree = se.assembleTree(true)
+		assert.Nil(t, tree.Zone[zone.ID()].Boss)
+	})
+	t.Run("deletes the child with its parent", func(t *testing.T) {
+		se := newEngine()
+		zone := se.CreateZone()
+		boss := zone.SetBoss()
+		se.DeleteZone(zone.ID())
+		assert.False(t, se.PlayerExists(boss.ID()))
+	})
+	t.Run("adopts and clones the child", func(t *testing.T) {
+		se := newEngine()
+		zone := se.CreateZone()
+		player := zone.AddPlayer()
+		player.AddItem().SetName("sword")
+
+		zone.AdoptBoss(player.ID())
+		boss, ok := zone.Boss()
+		assert.True(t, ok)
+		assert.Equal(t, player.ID(), boss.ID())
+		assert.Equal(t, 0, len(zone.Players()))
+
+		clonedBoss, ok := zone.Clone().Boss()
+		assert.True(t, ok)
+		assert.NotEqual(t, boss.ID(), clonedBoss.ID())
+		assert.Equal(t, "sword", clonedBoss.Items()[0].Name())
+	})
+}
+
+func TestTimeDurationBytes(t *testing.T) {
+	t.Run("encodes time as RFC 3339, duration as milliseconds and bytes as base64", func(t *testing.T) {
+		se := newEngine()
+		createdAt := time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC)
+		equipmentSet := se.CreateEquipmentSet()
+		equipmentSet.SetCreatedAt(createdAt)
+		equipmentSet.SetCooldown(Duration(1500 * time.Millisecond))
+		equipmentSet.SetIcon([]byte("icon"))
+
+		actual, err := se.assembleTree(false).EquipmentSet[equipmentSet.ID()].MarshalJSON()
+		assert.Nil(t, err)
+		assert.Contains(t, string(actual), `"createdAt":"2021-03-04T05:06:07Z"`)
+		assert.Contains(t, string(actual), `"cooldown":1500`)
+		assert.Contains(t, string(actual), `"icon":"aWNvbg=="`)
+
+		var decoded EquipmentSet
+		assert.Nil(t, decoded.UnmarshalJSON(actual))
+		assert.True(t, createdAt.Equal(decoded.CreatedAt))
+		assert.Equal(t, Duration(1500*time.Millisecond), decoded.Cooldown)
+		assert.Equal(t, []byte("icon"), decoded.Icon)
+	})
+	t.Run("copies bytes when setting and getting", func(t *testing.T) {
+		se := newEngine()
+		equipmentSet := se.CreateEquipmentSet()
+		icon := []byte("icon")
+		equipmentSet.SetIcon(icon)
+		icon[0] = 'x'
+		assert.Equal(t, []byte("icon"), equipmentSet.Icon())
+
+		equipmentSet.Icon()[0] = 'x'
+		assert.Equal(t, []byte("icon"), equipmentSet.Icon())
+	})
+	t.Run("clones time, duration and bytes", func(t *testing.T) {
+		se := newEngine()
+		createdAt := time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC)
+		equipmentSet := se.CreateEquipmentSet()
+		equipmentSet.SetCreatedAt(createdAt)
+		equipmentSet.SetCooldown(Duration(time.Second))
+		equipmentSet.SetIcon([]byte("icon"))
+
+		clone := equipmentSet.Clone()
+		assert.True(t, createdAt.Equal(clone.CreatedAt()))
+		assert.Equal(t, Duration(time.Second), clone.Cooldown())
+		assert.Equal(t, []byte("icon"), clone.Icon())
+	})
+	t.Run("decodes null and fractional milliseconds as duration", func(t *testing.T) {
+		cooldown := Duration(time.Second)
+		assert.Nil(t, cooldown.UnmarshalJSON([]byte("null")))
+		assert.Equal(t, Duration(time.Second), cooldown)
+
+		assert.Nil(t, cooldown.UnmarshalJSON([]byte("1.5")))
+		assert.Equal(t, Duration(1500*time.Microsecond), cooldown)
+
+		encoded, err := cooldown.MarshalJSON()
+		assert.Nil(t, err)
+		assert.Equal(t, "1", string(encoded))
+	})
+}
+
+func TestBigID(t *testing.T) {
+	t.Run("encodes big IDs as strings", func(t *testing.T) {
+		se := newEngine()
+		equipmentSet := se.CreateEquipmentSet()
+		equipmentSet.SetExternalID(BigID(9007199254740993))
+
+		actual, err := se.assembleTree(false).EquipmentSet[equipmentSet.ID()].MarshalJSON()
+		assert.Nil(t, err)
+		assert.Contains(t, string(actual), `"externalID":"9007199254740993"`)
+
+		var decoded EquipmentSet
+		assert.Nil(t, decoded.UnmarshalJSON(actual))
+		assert.Equal(t, BigID(9007199254740993), decoded.ExternalID)
+	})
+	t.Run("decodes null and rejects numbers", func(t *testing.T) {
+		externalID := BigID(1)
+		assert.Nil(t, externalID.UnmarshalJSON([]byte("null")))
+		assert.Equal(t, BigID(1), externalID)
+
+		assert.NotNil(t, externalID.UnmarshalJSON([]byte("9007199254740993")))
+	})
+	t.Run("clones big IDs", func(t *testing.T) {
+		se := newEngine()
+		equipmentSet := se.CreateEquipmentSet()
+		equipmentSet.SetExternalID(BigID(18446744073709551615))
+
+		assert.Equal(t, BigID(18446744073709551615), equipmentSet.Clone().ExternalID())
+	})
+}
+
 func TestTree(t *testing.T) {
 	t.Run("assembles elements in a tree", func(t *testing.T) {
 		newTreeTest(
@@ -314,6 +1030,7 @@ func TestTree(t *testing.T) {
 								},
 							},
 						},
+						PlayersOrder:  []PlayerID{player1.ID(), player2.ID()},
 						OperationKind: OperationKindUpdate,
 					},
 				}
@@ -620,7 +1337,6 @@ func TestTree(t *testing.T) {
 		newTreeTest(
 			func(se *Engine, expectedTree *Tree) {
 				player := se.CreatePlayer()
-				player.AddGuildMember(player.ID())
 
 				se.UpdateState()
 
@@ -630,13 +1346,6 @@ func TestTree(t *testing.T) {
 					player.ID(): {
 						ID: player.ID(),
 						GuildMembers: map[PlayerID]PlayerReference{
-							player.ID(): {
-								OperationKind:        OperationKindUnchanged,
-								ElementID:            player.ID(),
-								ElementKind:          ElementKindPlayer,
-								ReferencedDataStatus: ReferencedDataModified,
-								ElementPath:          newPath(playerIdentifier).id(int(player.ID())).toJSONPath(),
-							},
 							player.ID(): {
 								OperationKind:        OperationKindUpdate,
 								ElementID:            player.ID(),
@@ -655,13 +1364,6 @@ func TestTree(t *testing.T) {
 										OperationKind: OperationKindUnchanged,
 									},
 									GuildMembers: map[PlayerID]PlayerReference{
-										player.ID(): {
-											OperationKind:        OperationKindUnchanged,
-											ElementID:            player.ID(),
-											ElementKind:          ElementKindPlayer,
-											ReferencedDataStatus: ReferencedDataUnchanged, // TODO: should be modified, but won't fix for now
-											ElementPath:          newPath(playerIdentifier).id(int(player.ID())).toJSONPath(),
-										},
 										player.ID(): {
 											OperationKind:        OperationKindUpdate,
 											ElementID:            player.ID(),
@@ -1177,6 +1879,7 @@ func TestTree(t *testing.T) {
 								},
 							},
 						},
+						InteractablesOrder: []int{int(item.ID()), int(zoneItem.ID())},
 					},
 				}
 			},
@@ -1,5 +1,7 @@
 package state
 
+import "time"
+
 type ReferencedDataStatus string
 
 const (
@@ -20,24 +22,24 @@ const (
 )
 
 type Tree struct {
-	EquipmentSet map[EquipmentSetID]EquipmentSet `json:"equipmentSet"`
-	GearScore    map[GearScoreID]GearScore       `json:"gearScore"`
-	Item         map[ItemID]Item                 `json:"item"`
-	Player       map[PlayerID]Player             `json:"player"`
-	Position     map[PositionID]Position         `json:"position"`
-	Zone         map[ZoneID]Zone                 `json:"zone"`
-	ZoneItem     map[ZoneItemID]ZoneItem         `json:"zoneItem"`
+	EquipmentSet equipmentSetMap `json:"equipmentSet"`
+	GearScore    gearScoreMap    `json:"gearScore"`
+	Item         itemMap         `json:"item"`
+	Player       playerMap       `json:"player"`
+	Position     positionMap     `json:"position"`
+	Zone         zoneMap         `json:"zone"`
+	ZoneItem     zoneItemMap     `json:"zoneItem"`
 }
 
 func newTree() Tree {
 	return Tree{
-		EquipmentSet: make(map[EquipmentSetID]EquipmentSet),
-		GearScore:    make(map[GearScoreID]GearScore),
-		Item:         make(map[ItemID]Item),
-		Player:       make(map[PlayerID]Player),
-		Position:     make(map[PositionID]Position),
-		Zone:         make(map[ZoneID]Zone),
-		ZoneItem:     make(map[ZoneItemID]ZoneItem),
+		EquipmentSet: make(equipmentSetMap),
+		GearScore:    make(gearScoreMap),
+		Item:         make(itemMap),
+		Player:       make(playerMap),
+		Position:     make(positionMap),
+		Zone:         make(zoneMap),
+		ZoneItem:     make(zoneItemMap),
 	}
 }
 
@@ -74,10 +76,15 @@ type ItemReference struct {
 }
 
 type EquipmentSet struct {
-	ID            EquipmentSetID           `json:"id"`
-	Equipment     map[ItemID]ItemReference `json:"equipment"`
-	Name          string                   `json:"name"`
-	OperationKind OperationKind            `json:"operationKind"`
+	ID             EquipmentSetID   `json:"id"`
+	Cooldown       Duration         `json:"cooldown"`
+	CreatedAt      time.Time        `json:"createdAt"`
+	Equipment      itemReferenceMap `json:"equipment"`
+	EquipmentOrder []ItemID         `json:"equipmentOrder,omitempty"`
+	ExternalID     BigID            `json:"externalID"`
+	Icon           []byte           `json:"icon"`
+	Name           string           `json:"name"`
+	OperationKind  OperationKind    `json:"operationKind"`
 }
 type EquipmentSetReference struct {
 	OperationKind        OperationKind        `json:"operationKind"`
@@ -119,15 +126,19 @@ type GearScoreReference struct {
 }
 
 type Player struct {
-	ID            PlayerID                                 `json:"id"`
-	EquipmentSets map[EquipmentSetID]EquipmentSetReference `json:"equipmentSets"`
-	GearScore     *GearScore                               `json:"gearScore"`
-	GuildMembers  map[PlayerID]PlayerReference             `json:"guildMembers"`
-	Items         map[ItemID]Item                          `json:"items"`
-	Position      *Position                                `json:"position"`
-	Target        *AnyOfPlayer_ZoneItemReference           `json:"target"`
-	TargetedBy    map[int]AnyOfPlayer_ZoneItemReference    `json:"targetedBy"`
-	OperationKind OperationKind                            `json:"operationKind"`
+	ID                 PlayerID                         `json:"id"`
+	EquipmentSets      equipmentSetReferenceMap         `json:"equipmentSets"`
+	EquipmentSetsOrder []EquipmentSetID                 `json:"equipmentSetsOrder,omitempty"`
+	GearScore          *GearScore                       `json:"gearScore"`
+	GuildMembers       playerReferenceMap               `json:"guildMembers"`
+	GuildMembersOrder  []PlayerID                       `json:"guildMembersOrder,omitempty"`
+	Items              itemMap                          `json:"items"`
+	ItemsOrder         []ItemID                         `json:"itemsOrder,omitempty"`
+	Position           *Position                        `json:"position"`
+	Target             *AnyOfPlayer_ZoneItemReference   `json:"target"`
+	TargetedBy         anyOfPlayer_ZoneItemReferenceMap `json:"targetedBy"`
+	TargetedByOrder    []int                            `json:"targetedByOrder,omitempty"`
+	OperationKind      OperationKind                    `json:"operationKind"`
 }
 type PlayerReference struct {
 	OperationKind        OperationKind        `json:"operationKind"`
@@ -139,12 +150,16 @@ type PlayerReference struct {
 }
 
 type Zone struct {
-	ID            ZoneID                  `json:"id"`
-	Interactables map[int]interface{}     `json:"interactables"`
-	Items         map[ZoneItemID]ZoneItem `json:"items"`
-	Players       map[PlayerID]Player     `json:"players"`
-	Tags          []string                `json:"tags"`
-	OperationKind OperationKind           `json:"operationKind"`
+	ID                 ZoneID                       `json:"id"`
+	Boss               *Player                      `json:"boss"`
+	Interactables      anyOfItem_Player_ZoneItemMap `json:"interactables"`
+	InteractablesOrder []int                        `json:"interactablesOrder,omitempty"`
+	Items              zoneItemMap                  `json:"items"`
+	ItemsOrder         []ZoneItemID                 `json:"itemsOrder,omitempty"`
+	Players            playerMap                    `json:"players"`
+	PlayersOrder       []PlayerID                   `json:"playersOrder,omitempty"`
+	Tags               []string                     `json:"tags"`
+	OperationKind      OperationKind                `json:"operationKind"`
 }
 type ZoneReference struct {
 	OperationKind        OperationKind        `json:"operationKind"`
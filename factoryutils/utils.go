@@ -20,6 +20,10 @@ import (
 // can have the coherent adder method of "AddPiece"
 var Singular func(string) string = pluralize.NewClient().Singular
 
+// pluralizeClient.Plural is used to find the plural of type names
+// eg. for writing query constructors like "QueryItems"
+var Plural func(string) string = pluralize.NewClient().Plural
+
 func ForEachParamInAction(action ast.Action, fn func(param ast.Field) *jen.Statement) *jen.Statement {
 	var statements jen.Statement
 	action.RangeParams(func(field ast.Field) {
@@ -38,6 +42,15 @@ func ForEachResponseValueInAction(action ast.Action, fn func(param ast.Field) *j
 	return &statements
 }
 
+func ForEachFieldInEvent(event ast.Event, fn func(field ast.Field) *jen.Statement) *jen.Statement {
+	var statements jen.Statement
+	event.RangeFields(func(field ast.Field) {
+		statements = append(statements, fn(field))
+		statements = append(statements, jen.Line())
+	})
+	return &statements
+}
+
 func ForEachFieldInType(configType ast.ConfigType, fn func(field ast.Field) *jen.Statement) *jen.Statement {
 	var statements jen.Statement
 	configType.RangeFields(func(field ast.Field) {
@@ -101,6 +114,15 @@ func ForEachActionInAST(config *ast.AST, fn func(action ast.Action) *jen.Stateme
 	return &statements
 }
 
+func ForEachEventInAST(config *ast.AST, fn func(event ast.Event) *jen.Statement) *jen.Statement {
+	var statements jen.Statement
+	config.RangeEvents(func(event ast.Event) {
+		statements = append(statements, fn(event))
+		statements = append(statements, jen.Line())
+	})
+	return &statements
+}
+
 func ForEachFieldValueComparison(field ast.Field, comparator jen.Statement, fn func(configType *ast.ConfigType) *jen.Statement) *jen.Statement {
 	var statements jen.Statement
 	first := true
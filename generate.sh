@@ -12,5 +12,5 @@ decltostring -input ./examples/application/server/ -output ./serverfactory/strin
 decltostring -input ./examples/engine/ -output ./enginefactory/stringified_state_engine_decls.go -package enginefactory -exclude "test|easyjson";
 
 # required for running integration tests
-go run . -out=integrationtest/state/ generate;
+go run . -ordered -out=integrationtest/state/ generate;
 
@@ -77,7 +77,7 @@ func scanDeclsInDir(directoryPath string, forceIncludeFileNames ...string) ([]as
 			return nil, err
 		}
 
-		f, err := parser.ParseFile(token.NewFileSet(), "", content, 0)
+		f, err := parser.ParseFile(token.NewFileSet(), "", content, parser.ParseComments)
 		if err != nil {
 			return nil, err
 		}
@@ -103,10 +103,31 @@ func readImportedServerExampleFiles() string {
 	}
 
 	buf := bytes.Buffer{}
-	printer.Fprint(&buf, token.NewFileSet(), decls)
+	for _, decl := range decls {
+		// comments get lost when printing the declarations,
+		// so directives for easyjson need to be written manually
+		if hasEasyjsonSkipDirective(decl) {
+			buf.WriteString("// easyjson:skip\n")
+		}
+		printer.Fprint(&buf, token.NewFileSet(), decl)
+		buf.WriteString("\n\n")
+	}
 	return buf.String()
 }
 
+func hasEasyjsonSkipDirective(decl ast.Decl) bool {
+	genDecl, ok := decl.(*ast.GenDecl)
+	if !ok || genDecl.Doc == nil {
+		return false
+	}
+	for _, comment := range genDecl.Doc.List {
+		if strings.Contains(comment.Text, "easyjson:skip") {
+			return true
+		}
+	}
+	return false
+}
+
 func generateImportDecl(engineOnly bool) string {
 	importDecl := &ast.GenDecl{
 		Tok: token.IMPORT,
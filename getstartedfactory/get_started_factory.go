@@ -22,7 +22,7 @@ func newGetStartedFactory(config *ast.AST) *GetStartedFactory {
 }
 
 func WriteGetStarted(moduleName string, useExample bool, stateConfigData, actionsConfigData, responsesConfigData map[interface{}]interface{}) string {
-	config := ast.Parse(stateConfigData, actionsConfigData, responsesConfigData)
+	config := ast.Parse(stateConfigData, actionsConfigData, responsesConfigData, map[interface{}]interface{}{}, map[interface{}]interface{}{})
 
 	if useExample {
 		g := newGetStartedFactory(config).
@@ -63,17 +63,17 @@ func (g *GetStartedFactory) writeMainFunc() *GetStartedFactory {
 
 	decls.File.Var().Id("sideEffects").Op("=").Id("state").Dot("SideEffects").Values(Dict{
 		Id("OnDeploy"):    Func().Params(Id("engine").Id("*state.Engine")).Block(),
-		Id("OnFrameTick"): Func().Params(Id("engine").Id("*state.Engine")).Block(),
+		Id("OnFrameTick"): Func().Params(Id("engine").Id("*state.Engine"), Id("tick").Id("state").Dot("TickInfo")).Block(),
 	}).Line()
 
 	decls.File.Var().Id("actions").Op("=").Id("state").Dot("Actions").Values(
 		Line().Add(
 			ForEachActionInAST(g.config, func(action ast.Action) *Statement {
 				if action.Response == nil {
-					return Id(Title(action.Name)).Op(":").Func().Params(Id("params").Id("state").Dot(Title(action.Name)+"Params"), Id("engine").Id("*state.Engine")).Block().Id(",")
+					return Id(Title(action.Name)).Op(":").Func().Params(Id("params").Id("state").Dot(Title(action.Name)+"Params"), Id("engine").Id("*state.Engine"), Id("client").Id("state").Dot("Identity")).Block().Id(",")
 				}
 				responseName := Id("state").Dot(Title(action.Name) + "Response")
-				return Id(Title(action.Name)).Op(":").Func().Params(Id("params").Id("state").Dot(Title(action.Name)+"Params"), Id("engine").Id("*state.Engine")).Add(responseName).Block(
+				return Id(Title(action.Name)).Op(":").Func().Params(Id("params").Id("state").Dot(Title(action.Name)+"Params"), Id("engine").Id("*state.Engine"), Id("client").Id("state").Dot("Identity")).Add(responseName).Block(
 					Return(responseName).Values(),
 				).Id(",")
 			}),
@@ -100,18 +100,18 @@ var sideEffects = state.SideEffects{
 		engine.CreateNpc().SetName("Scorpid Worker")
 		engine.CreatePlayer().SetName("Thralltheorc")
 	},
-	OnFrameTick: func(engine *state.Engine) {},
+	OnFrameTick: func(engine *state.Engine, tick state.TickInfo) {},
 }
 
 var actions = state.Actions{
-	AddFriend: func(params state.AddFriendParams, engine *state.Engine) state.AddFriendResponse {
+	AddFriend: func(params state.AddFriendParams, engine *state.Engine, client state.Identity) state.AddFriendResponse {
 		player := engine.Player(params.Player)
 		player.AddFriendsList(params.NewFriend)
 		return state.AddFriendResponse{
 			NewNumberOfFriends: len(player.FriendsList()),
 		}
 	},
-	AddItemToPlayer: func(params state.AddItemToPlayerParams, engine *state.Engine) state.AddItemToPlayerResponse {
+	AddItemToPlayer: func(params state.AddItemToPlayerParams, engine *state.Engine, client state.Identity) state.AddItemToPlayerResponse {
 		player := engine.Player(params.Player)
 		item := player.AddItem().SetName(params.ItemName)
 		item.SetFirstLootedBy(player.ID())
@@ -119,24 +119,24 @@ var actions = state.Actions{
 			ItemPath: item.Path(),
 		}
 	},
-	CreatePlayer: func(params state.CreatePlayerParams, engine *state.Engine) state.CreatePlayerResponse {
+	CreatePlayer: func(params state.CreatePlayerParams, engine *state.Engine, client state.Identity) state.CreatePlayerResponse {
 		player := engine.CreatePlayer().SetName(params.Name)
 		return state.CreatePlayerResponse{
 			PlayerPath: player.Path(),
 		}
 	},
-	DeletePlayer: func(params state.DeletePlayerParams, engine *state.Engine) {
+	DeletePlayer: func(params state.DeletePlayerParams, engine *state.Engine, client state.Identity) {
 		engine.DeletePlayer(params.Player)
 	},
-	MoveNpc: func(params state.MoveNpcParams, engine *state.Engine) {
+	MoveNpc: func(params state.MoveNpcParams, engine *state.Engine, client state.Identity) {
 		npc := engine.Npc(params.Npc)
 		npc.Location().SetX(params.NewX).SetY(params.NewY)
 	},
-	MovePlayer: func(params state.MovePlayerParams, engine *state.Engine) {
+	MovePlayer: func(params state.MovePlayerParams, engine *state.Engine, client state.Identity) {
 		player := engine.Player(params.Player)
 		player.Location().SetX(params.NewX).SetY(params.NewY)
 	},
-	PlayerLeaveCombat: func(params state.PlayerLeaveCombatParams, engine *state.Engine) state.PlayerLeaveCombatResponse {
+	PlayerLeaveCombat: func(params state.PlayerLeaveCombatParams, engine *state.Engine, client state.Identity) state.PlayerLeaveCombatResponse {
 		player := engine.Player(params.Player)
 		inCombatRef, isSet := player.InCombatWith()
 		if isSet {
@@ -146,15 +146,15 @@ var actions = state.Actions{
 			CombatWon: true,
 		}
 	},
-	RemoveFriend: func(params state.RemoveFriendParams, engine *state.Engine) {
+	RemoveFriend: func(params state.RemoveFriendParams, engine *state.Engine, client state.Identity) {
 		player := engine.Player(params.Player)
 		player.RemoveFriendsList(params.FriendToRemove)
 	},
-	RemoveItemFromPlayer: func(params state.RemoveItemFromPlayerParams, engine *state.Engine) {
+	RemoveItemFromPlayer: func(params state.RemoveItemFromPlayerParams, engine *state.Engine, client state.Identity) {
 		player := engine.Player(params.Player)
 		player.RemoveItems(params.Item)
 	},
-	SetPlayerCombat: func(params state.SetPlayerCombatParams, engine *state.Engine) state.SetPlayerCombatResponse {
+	SetPlayerCombat: func(params state.SetPlayerCombatParams, engine *state.Engine, client state.Identity) state.SetPlayerCombatResponse {
 		player := engine.Player(params.Player)
 		if state.ElementKind(params.EnemyKind) == state.ElementKindNpc {
 			enemyNpc := engine.Npc(state.NpcID(params.EnemyID))
@@ -14,18 +14,29 @@ export const numericTypes = [
   "float64",
   "complex64",
   "complex128",
+  // durations are sent as milliseconds
+  "duration",
 ];
 
-export const textTypes = ["string", "byte", "rune", "[]byte"];
+// big IDs are sent as strings so they don't lose precision
+export const textTypes = ["string", "byte", "rune", "[]byte", "time", "bytes", "bigID"];
 
 export const defualtValuePerValue = (value) => {
   if (value.startsWith("[]")) {
     return []
   }
+  if (value === "bigID") {
+    return "0";
+  }
   if (numericTypes.includes(value) || value.endsWith("ID")) {
     return 0;
   }
+  if (value === "time") {
+    // times are sent as RFC 3339 strings
+    return "0001-01-01T00:00:00Z";
+  }
   if (textTypes.includes(value)) {
+    // bytes are sent as base64 strings, which are empty for no bytes
     return "";
   }
   return false;
@@ -20,8 +20,11 @@ const numericTypes = [
   "float64",
   "complex64",
   "complex128",
+  // durations are sent as milliseconds
+  "duration",
 ];
-const textTypes = ["string", "byte", "rune", "[]byte"];
+// big IDs are sent as strings so they don't lose precision
+const textTypes = ["string", "byte", "rune", "[]byte", "time", "bytes", "bigID"];
 
 
 const evalInput = (key, value, setFormContent, currentFormContent, omitLabel) => {
@@ -0,0 +1,216 @@
+package integrationtest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jobergner/backent-cli/integrationtest/state"
+	"github.com/stretchr/testify/assert"
+	"nhooyr.io/websocket"
+	"nhooyr.io/websocket/wsjson"
+)
+
+const adminToken = "secret"
+
+func adminAuthenticate(r *http.Request) error {
+	if r.Header.Get("Authorization") != "Bearer "+adminToken {
+		return errors.New("invalid admin token")
+	}
+	return nil
+}
+
+func adminRequest(t *testing.T, method, url string, body []byte) *http.Response {
+	req, err := http.NewRequest(method, url, bytes.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", "Bearer "+adminToken)
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return res
+}
+
+type adminClient struct {
+	ID               string `json:"id"`
+	Status           string `json:"status"`
+	BufferedMessages int    `json:"bufferedMessages"`
+	BufferSize       int    `json:"bufferSize"`
+}
+
+func TestAdmin(t *testing.T) {
+	t.Run("is not served without AdminAuthenticate", func(t *testing.T) {
+		server := state.NewServer(state.Options{FPS: 100, Logger: state.NopLogger()})
+		httpServer := httptest.NewServer(server)
+		defer httpServer.Close()
+		defer server.Shutdown(context.Background())
+
+		// the request falls through to the home page
+		res := adminRequest(t, http.MethodGet, httpServer.URL+"/admin/clients", nil)
+		assert.NotEqual(t, "application/json", res.Header.Get("Content-Type"))
+	})
+
+	t.Run("rejects unauthenticated requests", func(t *testing.T) {
+		server := state.NewServer(state.Options{FPS: 100, AdminAuthenticate: adminAuthenticate, Logger: state.NopLogger()})
+		httpServer := httptest.NewServer(server)
+		defer httpServer.Close()
+		defer server.Shutdown(context.Background())
+
+		res, err := http.Get(httpServer.URL + "/admin/clients")
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, http.StatusUnauthorized, res.StatusCode)
+	})
+
+	t.Run("lists, kicks and acts for clients", func(t *testing.T) {
+		moves := make(chan state.Identity, 1)
+		server := state.NewServer(state.Options{
+			Actions: state.Actions{
+				MovePlayer: func(params state.MovePlayerParams, engine *state.Engine, client state.Identity) {
+					moves <- client
+				},
+			},
+			FPS:               100,
+			AdminAuthenticate: adminAuthenticate,
+			Logger:            state.NopLogger(),
+		})
+		httpServer := httptest.NewServer(server)
+		defer httpServer.Close()
+		defer server.Shutdown(context.Background())
+
+		ctx := context.Background()
+		wsURL := "ws" + strings.TrimPrefix(httpServer.URL, "http") + "/ws"
+		c, _, err := websocket.Dial(ctx, wsURL, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer c.Close(websocket.StatusNormalClosure, "")
+
+		var serverResponse state.Message
+		err = wsjson.Read(ctx, c, &serverResponse)
+		assert.NoError(t, err)
+		assert.Equal(t, state.MessageKindCurrentState, serverResponse.Kind)
+
+		res := adminRequest(t, http.MethodGet, httpServer.URL+"/admin/clients", nil)
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		var clients []adminClient
+		err = json.NewDecoder(res.Body).Decode(&clients)
+		assert.NoError(t, err)
+		if !assert.Len(t, clients, 1) {
+			return
+		}
+		assert.Equal(t, "connected", clients[0].Status)
+		assert.NotZero(t, clients[0].BufferSize)
+		clientID := clients[0].ID
+
+		params, err := state.MovePlayerParams{ChangeX: 1}.MarshalJSON()
+		if err != nil {
+			t.Fatal(err)
+		}
+		action, err := state.Message{Kind: state.MessageKindAction_movePlayer, Content: params}.MarshalJSON()
+		if err != nil {
+			t.Fatal(err)
+		}
+		res = adminRequest(t, http.MethodPost, httpServer.URL+"/admin/action?client="+clientID, action)
+		assert.Equal(t, http.StatusAccepted, res.StatusCode)
+		select {
+		case identity := <-moves:
+			assert.Equal(t, clientID, identity.ClientID)
+		case <-time.After(time.Second):
+			t.Fatal("injected action was not processed")
+		}
+
+		res = adminRequest(t, http.MethodPost, httpServer.URL+"/admin/kick?client=unknown", nil)
+		assert.Equal(t, http.StatusNotFound, res.StatusCode)
+		res = adminRequest(t, http.MethodPost, httpServer.URL+"/admin/kick?client="+clientID, nil)
+		assert.Equal(t, http.StatusNoContent, res.StatusCode)
+
+		for {
+			_, _, err = c.Read(ctx)
+			if err != nil {
+				break
+			}
+		}
+		assert.Equal(t, websocket.StatusPolicyViolation, websocket.CloseStatus(err))
+		assert.Contains(t, err.Error(), "kicked by admin")
+	})
+
+	t.Run("pauses, steps and resumes the tick loop", func(t *testing.T) {
+		clock := state.NewManualClock(time.Unix(0, 0))
+		ticks := make(chan state.TickInfo, 10)
+		server := state.NewServer(state.Options{
+			SideEffects: state.SideEffects{
+				OnFrameTick: func(engine *state.Engine, tick state.TickInfo) {
+					ticks <- tick
+				},
+			},
+			FPS:               10,
+			Clock:             clock,
+			AdminAuthenticate: adminAuthenticate,
+			Logger:            state.NopLogger(),
+		})
+		httpServer := httptest.NewServer(server)
+		defer httpServer.Close()
+		defer server.Shutdown(context.Background())
+
+		clock.Advance(100 * time.Millisecond)
+		assert.Equal(t, 1, (<-ticks).Number)
+
+		res := adminRequest(t, http.MethodPost, httpServer.URL+"/admin/step", nil)
+		assert.Equal(t, http.StatusConflict, res.StatusCode)
+
+		res = adminRequest(t, http.MethodPost, httpServer.URL+"/admin/pause", nil)
+		assert.Equal(t, http.StatusNoContent, res.StatusCode)
+		clock.Advance(300 * time.Millisecond)
+		select {
+		case <-ticks:
+			t.Fatal("paused tick loop processed a tick")
+		case <-time.After(50 * time.Millisecond):
+		}
+
+		res = adminRequest(t, http.MethodPost, httpServer.URL+"/admin/step", nil)
+		assert.Equal(t, http.StatusNoContent, res.StatusCode)
+		assert.Equal(t, 2, (<-ticks).Number)
+
+		res = adminRequest(t, http.MethodPost, httpServer.URL+"/admin/resume", nil)
+		assert.Equal(t, http.StatusNoContent, res.StatusCode)
+		clock.Advance(100 * time.Millisecond)
+		assert.Equal(t, 3, (<-ticks).Number)
+	})
+
+	t.Run("downloads a snapshot", func(t *testing.T) {
+		server := state.NewServer(state.Options{
+			SideEffects: state.SideEffects{
+				OnDeploy: func(engine *state.Engine) {
+					engine.CreateItem().SetName("snapshotted")
+				},
+			},
+			FPS:               100,
+			AdminAuthenticate: adminAuthenticate,
+			Logger:            state.NopLogger(),
+		})
+		httpServer := httptest.NewServer(server)
+		defer httpServer.Close()
+		defer server.Shutdown(context.Background())
+
+		res := adminRequest(t, http.MethodGet, httpServer.URL+"/admin/snapshot", nil)
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Contains(t, res.Header.Get("Content-Disposition"), "attachment")
+		body, err := ioutil.ReadAll(res.Body)
+		assert.NoError(t, err)
+		assert.Contains(t, string(body), `"name":"snapshotted"`)
+
+		res = adminRequest(t, http.MethodPost, httpServer.URL+"/admin/snapshot", nil)
+		assert.Equal(t, http.StatusMethodNotAllowed, res.StatusCode)
+	})
+}
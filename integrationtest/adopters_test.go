@@ -0,0 +1,122 @@
+package integrationtest
+
+import (
+	"testing"
+
+	"github.com/jobergner/backent-cli/integrationtest/state"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCloneAndAdopt(t *testing.T) {
+	var playerID state.PlayerID
+	var zoneItemID state.ZoneItemID
+	room := state.NewTestRoom(state.Options{
+		SideEffects: state.SideEffects{
+			OnDeploy: func(engine *state.Engine) {
+				player := engine.CreatePlayer()
+				player.AddItem().SetName("sword")
+				playerID = player.ID()
+				zoneItemID = engine.CreateZone().AddItem().ID()
+			},
+		},
+		Actions: state.Actions{
+			AddItemToPlayer: func(params state.AddItemToPlayerParams, engine *state.Engine, client state.Identity) state.AddItemToPlayerResponse {
+				item := engine.Item(params.Item)
+				item.Clone().SetName(params.NewName)
+				engine.ZoneItem(zoneItemID).AdoptItem(item.ID())
+				return state.AddItemToPlayerResponse{}
+			},
+		},
+	})
+	client := room.Connect(state.Identity{})
+	room.Tick()
+
+	item := room.Engine().Player(playerID).Items()[0]
+	client.Send(state.MessageKindAction_addItemToPlayer, state.AddItemToPlayerParams{Item: item.ID(), NewName: "copy"})
+	room.Tick()
+
+	engine := room.Engine()
+	assert.Equal(t, 0, len(engine.Player(playerID).Items()))
+	dropped := engine.ZoneItem(zoneItemID).Item()
+	assert.Equal(t, item.ID(), dropped.ID())
+	assert.Equal(t, "sword", dropped.Name())
+	assert.Equal(t, engine.ZoneItem(zoneItemID).Path()+".item", dropped.Path())
+	assert.Equal(t, 1, len(engine.QueryItems().WhereName("copy").All()))
+}
+
+func TestAdoptPatches(t *testing.T) {
+	var playerID state.PlayerID
+	var zoneID state.ZoneID
+	var zoneItemID state.ZoneItemID
+	var adopt func(engine *state.Engine, itemID state.ItemID)
+	room := state.NewTestRoom(state.Options{
+		SideEffects: state.SideEffects{
+			OnDeploy: func(engine *state.Engine) {
+				player := engine.CreatePlayer()
+				player.AddItem().SetName("sword")
+				playerID = player.ID()
+				zone := engine.CreateZone()
+				zone.AddInteractableItem().SetName("lever")
+				zoneID = zone.ID()
+				zoneItemID = zone.AddItem().ID()
+			},
+		},
+		Actions: state.Actions{
+			AddItemToPlayer: func(params state.AddItemToPlayerParams, engine *state.Engine, client state.Identity) state.AddItemToPlayerResponse {
+				adopt(engine, params.Item)
+				return state.AddItemToPlayerResponse{}
+			},
+		},
+	})
+	client := room.Connect(state.Identity{})
+	room.Tick()
+	client.Messages()
+
+	adoptInAction := func(itemID state.ItemID) []state.Message {
+		client.Send(state.MessageKindAction_addItemToPlayer, state.AddItemToPlayerParams{Item: itemID})
+		room.Tick()
+		return client.Messages()
+	}
+
+	t.Run("removes the child from its previous parent and deletes the replaced child", func(t *testing.T) {
+		adopt = func(engine *state.Engine, itemID state.ItemID) {
+			engine.ZoneItem(zoneItemID).AdoptItem(itemID)
+		}
+		messages := adoptInAction(room.Engine().Player(playerID).Items()[0].ID())
+		assert.Equal(t, []state.MessageKind{state.MessageKindAction_addItemToPlayer, state.MessageKindUpdate}, messageKinds(messages))
+		expected := `{"item":{"19":{"id":19,"gearScore":{"id":20,"operationKind":"DELETE"},"origin":{"id":22,"gearScore":{"id":23,"operationKind":"DELETE"},"position":{"id":24,"operationKind":"DELETE"},"operationKind":"DELETE"},"operationKind":"DELETE"}},"player":{"1":{"id":1,"items":{"4":{"id":4,"operationKind":"DELETE"}},"operationKind":"UPDATE"}},"zone":{"10":{"id":10,"items":{"18":{"id":18,"item":{"id":4,"gearScore":{"id":5,"operationKind":"UPDATE"},"name":"sword","origin":{"id":7,"gearScore":{"id":8,"operationKind":"UPDATE"},"position":{"id":9,"operationKind":"UPDATE"},"operationKind":"UPDATE"},"operationKind":"UPDATE"},"operationKind":"UPDATE"}},"operationKind":"UNCHANGED"}}}`
+		assert.Equal(t, expected, string(messages[1].Content))
+	})
+
+	t.Run("removes adopted root elements from the root", func(t *testing.T) {
+		itemID := room.Engine().CreateItem().SetName("shield").ID()
+		room.Tick()
+		client.Messages()
+
+		adopt = func(engine *state.Engine, itemID state.ItemID) {
+			engine.Player(playerID).AdoptItem(itemID)
+		}
+		messages := adoptInAction(itemID)
+		assert.Equal(t, []state.MessageKind{state.MessageKindAction_addItemToPlayer, state.MessageKindUpdate}, messageKinds(messages))
+		assert.Contains(t, string(messages[1].Content), `"item":{"26":{"id":26,"operationKind":"DELETE"}}`)
+		assert.Contains(t, string(messages[1].Content), `"player":{"1":{"id":1,"items":{"26":{"id":26,"gearScore":{"id":27,"operationKind":"UPDATE"},"name":"shield"`)
+	})
+
+	t.Run("rejects children of anyOf slices", func(t *testing.T) {
+		lever := room.Engine().Zone(zoneID).Interactables()[0].Item()
+		adopt = func(engine *state.Engine, itemID state.ItemID) {
+			engine.Player(playerID).AdoptItem(itemID)
+		}
+		messages := adoptInAction(lever.ID())
+		assert.Equal(t, []state.MessageKind{state.MessageKindAction_addItemToPlayer}, messageKinds(messages))
+		assert.Equal(t, lever.Path(), room.Engine().Item(lever.ID()).Path())
+	})
+
+	t.Run("leaves no removals in the current state", func(t *testing.T) {
+		lateClient := room.Connect(state.Identity{})
+		room.Tick()
+		messages := lateClient.Messages()
+		assert.Equal(t, []state.MessageKind{state.MessageKindCurrentState}, messageKinds(messages))
+		assert.NotContains(t, string(messages[0].Content), `DELETE`)
+	})
+}
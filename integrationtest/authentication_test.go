@@ -0,0 +1,80 @@
+package integrationtest
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/jobergner/backent-cli/integrationtest/state"
+	"github.com/stretchr/testify/assert"
+	"nhooyr.io/websocket"
+	"nhooyr.io/websocket/wsjson"
+)
+
+const validToken = "secret-token"
+
+func authenticateByToken(r *http.Request) (state.Identity, error) {
+	token := r.URL.Query().Get("token")
+	if token != validToken {
+		return state.Identity{}, fmt.Errorf("invalid token %q", token)
+	}
+	return state.Identity{UserID: "user-" + token}, nil
+}
+
+func TestAuthentication(t *testing.T) {
+	connectedIdentities := make(chan state.Identity, 1)
+
+	server := state.NewServer(state.Options{
+		Actions: state.Actions{
+			AddItemToPlayer: func(params state.AddItemToPlayerParams, engine *state.Engine, client state.Identity) state.AddItemToPlayerResponse {
+				return state.AddItemToPlayerResponse{PlayerPath: client.UserID}
+			},
+		},
+		SideEffects: state.SideEffects{
+			OnClientConnect: func(engine *state.Engine, client state.Identity) {
+				connectedIdentities <- client
+			},
+		},
+		FPS:          100,
+		Authenticate: authenticateByToken,
+		Logger:       state.NopLogger(),
+	})
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+	defer server.Shutdown(context.Background())
+
+	wsURL := "ws" + strings.TrimPrefix(httpServer.URL, "http") + "/ws"
+	ctx := context.Background()
+
+	t.Run("rejects clients with invalid token", func(t *testing.T) {
+		_, resp, err := websocket.Dial(ctx, wsURL+"?token=wrong", nil)
+		assert.Error(t, err)
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	})
+
+	t.Run("passes identity to side effects and actions", func(t *testing.T) {
+		c, _, err := websocket.Dial(ctx, wsURL+"?token="+validToken, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer c.Close(websocket.StatusNormalClosure, "")
+
+		identity := <-connectedIdentities
+		assert.Equal(t, "user-"+validToken, identity.UserID)
+		assert.NotEmpty(t, identity.ClientID)
+
+		var serverResponse state.Message
+		err = wsjson.Read(ctx, c, &serverResponse)
+		assert.NoError(t, err)
+		assert.Equal(t, state.MessageKindCurrentState, serverResponse.Kind)
+
+		sendActionAddItemToPlayer(ctx, c)
+		err = wsjson.Read(ctx, c, &serverResponse)
+		assert.NoError(t, err)
+		assert.Equal(t, state.MessageKindAction_addItemToPlayer, serverResponse.Kind)
+		assert.Equal(t, `{"playerPath":"user-secret-token"}`, string(serverResponse.Content))
+	})
+}
@@ -0,0 +1,173 @@
+package integrationtest
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jobergner/backent-cli/integrationtest/state"
+	"github.com/stretchr/testify/assert"
+	"nhooyr.io/websocket"
+	"nhooyr.io/websocket/wsjson"
+)
+
+// largePatchSideEffects change a long name every tick so a client
+// which does not read quickly fills up all buffers
+func largePatchSideEffects() state.SideEffects {
+	var itemID state.ItemID
+	var tick int
+	return state.SideEffects{
+		OnDeploy: func(e *state.Engine) {
+			itemID = e.CreateItem().ID()
+		},
+		OnFrameTick: func(e *state.Engine, _ state.TickInfo) {
+			tick++
+			e.Item(itemID).SetName(strings.Repeat(string(rune('a'+tick%26)), 1<<16))
+		},
+	}
+}
+
+func dialBackpressureServer(t *testing.T, policy state.BackpressurePolicy) (*state.Server, *websocket.Conn, func()) {
+	server := state.NewServer(state.Options{
+		SideEffects: largePatchSideEffects(),
+		FPS:         1000,
+		Logger:      state.NopLogger(),
+		Backpressure: state.BackpressureOptions{
+			Policy:           policy,
+			ClientBufferSize: 1,
+		},
+	})
+	httpServer := httptest.NewServer(server)
+
+	ctx := context.Background()
+	c, _, err := websocket.Dial(ctx, "ws"+strings.TrimPrefix(httpServer.URL, "http")+"/ws", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.SetReadLimit(1 << 24)
+
+	var serverResponse state.Message
+	err = wsjson.Read(ctx, c, &serverResponse)
+	assert.NoError(t, err)
+	assert.Equal(t, state.MessageKindCurrentState, serverResponse.Kind)
+
+	return server, c, func() {
+		c.Close(websocket.StatusNormalClosure, "")
+		server.Shutdown(ctx)
+		httpServer.Close()
+	}
+}
+
+// waitForMetrics polls the server's metrics while the client is not reading
+func waitForMetrics(t *testing.T, server *state.Server, condition func(state.BackpressureMetrics) bool) {
+	deadline := time.Now().Add(10 * time.Second)
+	for !condition(server.BackpressureMetrics()) {
+		if time.Now().After(deadline) {
+			t.Fatalf("backpressure metrics not reached: %+v", server.BackpressureMetrics())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestBackpressure(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("drops slow clients", func(t *testing.T) {
+		server, _, teardown := dialBackpressureServer(t, state.BackpressureDrop)
+		defer teardown()
+
+		waitForMetrics(t, server, func(m state.BackpressureMetrics) bool {
+			return m.ClientsDropped == 1
+		})
+		assert.Zero(t, server.BackpressureMetrics().PatchesCoalesced)
+	})
+
+	t.Run("coalesces patches for slow clients", func(t *testing.T) {
+		server, c, teardown := dialBackpressureServer(t, state.BackpressureCoalesce)
+		defer teardown()
+
+		waitForMetrics(t, server, func(m state.BackpressureMetrics) bool {
+			return m.PatchesCoalesced > 1
+		})
+
+		readCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+		for i := 0; i < 100; i++ {
+			var serverResponse state.Message
+			err := wsjson.Read(readCtx, c, &serverResponse)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, state.MessageKindUpdate, serverResponse.Kind)
+		}
+		assert.Zero(t, server.BackpressureMetrics().ClientsDropped)
+	})
+
+	t.Run("resyncs slow clients", func(t *testing.T) {
+		server, c, teardown := dialBackpressureServer(t, state.BackpressureResync)
+		defer teardown()
+
+		waitForMetrics(t, server, func(m state.BackpressureMetrics) bool {
+			return m.PatchesSkipped > 1
+		})
+
+		readCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+		for {
+			var serverResponse state.Message
+			err := wsjson.Read(readCtx, c, &serverResponse)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if serverResponse.Kind == state.MessageKindCurrentState {
+				break
+			}
+		}
+		waitForMetrics(t, server, func(m state.BackpressureMetrics) bool {
+			return m.ClientsResynced > 0
+		})
+		assert.Zero(t, server.BackpressureMetrics().ClientsDropped)
+	})
+	t.Run("merges coalesced patches by operation kind", func(t *testing.T) {
+		var swordID, shieldID state.ItemID
+		room := state.NewTestRoom(state.Options{
+			Backpressure: state.BackpressureOptions{
+				Policy:           state.BackpressureCoalesce,
+				ClientBufferSize: 1,
+			},
+			Actions: state.Actions{
+				// every response fills the client's buffer so the patch of the tick is coalesced
+				AddItemToPlayer: func(params state.AddItemToPlayerParams, engine *state.Engine, client state.Identity) state.AddItemToPlayerResponse {
+					if swordID == 0 {
+						swordID = engine.CreateItem().SetName("sword").ID()
+						shieldID = engine.CreateItem().SetName("shield").ID()
+					} else {
+						// the sword becomes UNCHANGED while its gear score is updated
+						engine.Item(swordID).GearScore().SetScore(1<<53 + 1)
+						engine.DeleteItem(shieldID)
+					}
+					return state.AddItemToPlayerResponse{}
+				},
+			},
+		})
+		client := room.Connect(state.Identity{})
+		room.Tick()
+		client.Messages()
+
+		client.Send(state.MessageKindAction_addItemToPlayer, state.AddItemToPlayerParams{})
+		room.Tick()
+		assert.Equal(t, []state.MessageKind{state.MessageKindAction_addItemToPlayer}, messageKinds(client.Messages()))
+
+		client.Send(state.MessageKindAction_addItemToPlayer, state.AddItemToPlayerParams{})
+		room.Tick()
+		assert.Equal(t, []state.MessageKind{state.MessageKindAction_addItemToPlayer}, messageKinds(client.Messages()))
+
+		room.Tick()
+		messages := client.Messages()
+		assert.Equal(t, []state.MessageKind{state.MessageKindUpdate}, messageKinds(messages))
+		expected := `{"item":{"1":{"gearScore":{"id":2,"operationKind":"UPDATE","score":9007199254740993},"id":1,"name":"sword","operationKind":"UPDATE","origin":{"gearScore":{"id":5,"operationKind":"UPDATE"},"id":4,"operationKind":"UPDATE","position":{"id":6,"operationKind":"UPDATE"}}},"7":{"gearScore":{"id":8,"operationKind":"DELETE"},"id":7,"name":"shield","operationKind":"DELETE","origin":{"gearScore":{"id":11,"operationKind":"DELETE"},"id":10,"operationKind":"DELETE","position":{"id":12,"operationKind":"DELETE"}}}}}`
+		assert.Equal(t, expected, string(messages[0].Content))
+	})
+}
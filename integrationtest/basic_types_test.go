@@ -0,0 +1,33 @@
+package integrationtest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jobergner/backent-cli/integrationtest/state"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimeDurationBytesBigID(t *testing.T) {
+	createdAt := time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC)
+	var equipmentSetID state.EquipmentSetID
+	room := state.NewTestRoom(state.Options{
+		SideEffects: state.SideEffects{
+			OnDeploy: func(engine *state.Engine) {
+				equipmentSet := engine.CreateEquipmentSet()
+				equipmentSet.SetCreatedAt(createdAt)
+				equipmentSet.SetCooldown(state.Duration(2 * time.Second))
+				equipmentSet.SetIcon([]byte{1, 2, 3})
+				equipmentSet.SetExternalID(state.BigID(9007199254740993))
+				equipmentSetID = equipmentSet.ID()
+			},
+		},
+	})
+	room.Tick()
+
+	equipmentSet := room.Engine().EquipmentSet(equipmentSetID)
+	assert.True(t, createdAt.Equal(equipmentSet.CreatedAt()))
+	assert.Equal(t, state.Duration(2*time.Second), equipmentSet.Cooldown())
+	assert.Equal(t, []byte{1, 2, 3}, equipmentSet.Icon())
+	assert.Equal(t, state.BigID(9007199254740993), equipmentSet.ExternalID())
+}
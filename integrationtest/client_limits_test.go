@@ -0,0 +1,118 @@
+package integrationtest
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/jobergner/backent-cli/integrationtest/state"
+	"github.com/stretchr/testify/assert"
+	"nhooyr.io/websocket"
+	"nhooyr.io/websocket/wsjson"
+)
+
+func dialClientLimitsServer(t *testing.T, limits state.ClientLimits) (*websocket.Conn, func()) {
+	server := state.NewServer(state.Options{
+		Actions:      actions,
+		FPS:          100,
+		ClientLimits: limits,
+		Logger:       state.NopLogger(),
+	})
+	httpServer := httptest.NewServer(server)
+
+	ctx := context.Background()
+	c, _, err := websocket.Dial(ctx, "ws"+strings.TrimPrefix(httpServer.URL, "http")+"/ws", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var serverResponse state.Message
+	err = wsjson.Read(ctx, c, &serverResponse)
+	assert.NoError(t, err)
+	assert.Equal(t, state.MessageKindCurrentState, serverResponse.Kind)
+
+	return c, func() {
+		c.Close(websocket.StatusNormalClosure, "")
+		server.Shutdown(ctx)
+		httpServer.Close()
+	}
+}
+
+func TestClientLimits(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("reports oversized messages", func(t *testing.T) {
+		c, teardown := dialClientLimitsServer(t, state.ClientLimits{MaxMessageBytes: 10})
+		defer teardown()
+
+		sendActionAddItemToPlayer(ctx, c)
+
+		var serverResponse state.Message
+		err := wsjson.Read(ctx, c, &serverResponse)
+		assert.NoError(t, err)
+		assert.Equal(t, state.MessageKindError, serverResponse.Kind)
+		assert.Equal(t, "client limit violated: message exceeds 10 bytes", string(serverResponse.Content))
+	})
+
+	t.Run("reports oversized messages above the default read limit", func(t *testing.T) {
+		c, teardown := dialClientLimitsServer(t, state.ClientLimits{MaxMessageBytes: 40000})
+		defer teardown()
+
+		err := c.Write(ctx, websocket.MessageText, []byte(strings.Repeat("a", 40001)))
+		assert.NoError(t, err)
+
+		var serverResponse state.Message
+		err = wsjson.Read(ctx, c, &serverResponse)
+		assert.NoError(t, err)
+		assert.Equal(t, state.MessageKindError, serverResponse.Kind)
+		assert.Equal(t, "client limit violated: message exceeds 40000 bytes", string(serverResponse.Content))
+	})
+
+	t.Run("disconnects clients after repeatedly exceeding the rate limit", func(t *testing.T) {
+		c, teardown := dialClientLimitsServer(t, state.ClientLimits{ActionsPerSecond: 0.001, ActionBurst: 1, MaxViolations: 1})
+		defer teardown()
+
+		sendActionUnknownKind(ctx, c)
+		sendActionUnknownKind(ctx, c)
+		sendActionUnknownKind(ctx, c)
+
+		var errorContents []string
+		var err error
+		for {
+			var serverResponse state.Message
+			err = wsjson.Read(ctx, c, &serverResponse)
+			if err != nil {
+				break
+			}
+			if serverResponse.Kind == state.MessageKindError {
+				errorContents = append(errorContents, string(serverResponse.Content))
+			}
+		}
+
+		assert.Equal(t, websocket.StatusPolicyViolation, websocket.CloseStatus(err))
+		assert.Contains(t, errorContents, "unknown message kind whoami")
+		assert.Contains(t, errorContents, "client limit violated: more than 0.001 actions per second")
+	})
+
+	t.Run("limits actions per tick", func(t *testing.T) {
+		c, teardown := dialClientLimitsServer(t, state.ClientLimits{MaxPendingActions: 1})
+		defer teardown()
+
+		for i := 0; i < 20; i++ {
+			sendActionUnknownKind(ctx, c)
+		}
+
+		for {
+			var serverResponse state.Message
+			err := wsjson.Read(ctx, c, &serverResponse)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if serverResponse.Kind == state.MessageKindError && strings.HasPrefix(string(serverResponse.Content), "client limit violated") {
+				assert.Equal(t, "client limit violated: more than 1 actions per tick", string(serverResponse.Content))
+				break
+			}
+		}
+	})
+}
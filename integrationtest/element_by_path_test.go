@@ -0,0 +1,46 @@
+package integrationtest
+
+import (
+	"testing"
+
+	"github.com/jobergner/backent-cli/integrationtest/state"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestElementByPath(t *testing.T) {
+	var itemPath, gearScorePath string
+	var resolved []state.ElementKind
+	room := state.NewTestRoom(state.Options{
+		SideEffects: state.SideEffects{
+			OnDeploy: func(engine *state.Engine) {
+				item := engine.CreatePlayer().AddItem()
+				itemPath, gearScorePath = item.Path(), item.GearScore().Path()
+			},
+		},
+		Actions: state.Actions{
+			// the path is sent as the new name for lack of a string param
+			AddItemToPlayer: func(params state.AddItemToPlayerParams, engine *state.Engine, client state.Identity) state.AddItemToPlayerResponse {
+				element, ok := engine.ElementByPath(params.NewName)
+				if !ok {
+					return state.AddItemToPlayerResponse{}
+				}
+				resolved = append(resolved, element.Kind())
+				if element.Kind() == state.ElementKindItem {
+					element.Item().SetName("resolved")
+				}
+				return state.AddItemToPlayerResponse{PlayerPath: params.NewName}
+			},
+		},
+	})
+	client := room.Connect(state.Identity{})
+	room.Tick()
+
+	client.Send(state.MessageKindAction_addItemToPlayer, state.AddItemToPlayerParams{NewName: itemPath})
+	client.Send(state.MessageKindAction_addItemToPlayer, state.AddItemToPlayerParams{NewName: gearScorePath})
+	client.Send(state.MessageKindAction_addItemToPlayer, state.AddItemToPlayerParams{NewName: "$.player.999"})
+	room.Tick()
+
+	assert.Equal(t, []state.ElementKind{state.ElementKindItem, state.ElementKindGearScore}, resolved)
+	messages := client.Messages()
+	assert.Contains(t, string(messages[len(messages)-1].Content), `"name":"resolved"`)
+}
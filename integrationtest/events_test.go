@@ -0,0 +1,125 @@
+package integrationtest
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/jobergner/backent-cli/integrationtest/state"
+	"github.com/stretchr/testify/assert"
+	"nhooyr.io/websocket"
+	"nhooyr.io/websocket/wsjson"
+)
+
+// readUntilEvent skips all messages which are not events
+func readUntilEvent(t *testing.T, ctx context.Context, c *websocket.Conn) state.Message {
+	for {
+		var serverResponse state.Message
+		err := wsjson.Read(ctx, c, &serverResponse)
+		if err != nil {
+			t.Fatal(err)
+		}
+		switch serverResponse.Kind {
+		case state.MessageKindEvent_chatMessage, state.MessageKindEvent_matchEnded:
+			return serverResponse
+		}
+	}
+}
+
+func TestEvents(t *testing.T) {
+	connectedIdentities := make(chan state.Identity, 2)
+
+	var server *state.Server
+	server = state.NewServer(state.Options{
+		Actions: state.Actions{
+			AddItemToPlayer: func(params state.AddItemToPlayerParams, engine *state.Engine, client state.Identity) state.AddItemToPlayerResponse {
+				engine.BroadcastEvent(state.MatchEndedEvent{Winners: []state.PlayerID{1}})
+				return state.AddItemToPlayerResponse{}
+			},
+		},
+		SideEffects: state.SideEffects{
+			OnClientConnect: func(engine *state.Engine, client state.Identity) {
+				connectedIdentities <- client
+			},
+		},
+		FPS:    100,
+		Logger: state.NopLogger(),
+	})
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+	defer server.Shutdown(context.Background())
+
+	ctx := context.Background()
+	wsURL := "ws" + strings.TrimPrefix(httpServer.URL, "http") + "/ws"
+
+	c1, _, err := websocket.Dial(ctx, wsURL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c1.Close(websocket.StatusNormalClosure, "")
+	identity1 := <-connectedIdentities
+
+	c2, _, err := websocket.Dial(ctx, wsURL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c2.Close(websocket.StatusNormalClosure, "")
+	<-connectedIdentities
+
+	t.Run("sends events to single clients", func(t *testing.T) {
+		err := server.SendEventToClient(identity1.ClientID, state.ChatMessageEvent{Author: 1, Text: "hello"})
+		assert.NoError(t, err)
+		// broadcast from within an action
+		sendActionAddItemToPlayer(ctx, c2)
+
+		event := readUntilEvent(t, ctx, c1)
+		assert.Equal(t, state.MessageKindEvent_chatMessage, event.Kind)
+		assert.Equal(t, `{"author":1,"text":"hello"}`, string(event.Content))
+	})
+
+	t.Run("broadcasts events to all clients", func(t *testing.T) {
+		event := readUntilEvent(t, ctx, c1)
+		assert.Equal(t, state.MessageKindEvent_matchEnded, event.Kind)
+		assert.Equal(t, `{"winners":[1]}`, string(event.Content))
+
+		// the chat message was only sent to the first client
+		event = readUntilEvent(t, ctx, c2)
+		assert.Equal(t, state.MessageKindEvent_matchEnded, event.Kind)
+	})
+	t.Run("sends events to clients once they received the current state", func(t *testing.T) {
+		room := state.NewTestRoom(state.Options{
+			Backpressure: state.BackpressureOptions{
+				Policy:           state.BackpressureResync,
+				ClientBufferSize: 2,
+			},
+			Actions: state.Actions{
+				AddItemToPlayer: func(params state.AddItemToPlayerParams, engine *state.Engine, client state.Identity) state.AddItemToPlayerResponse {
+					engine.CreateItem()
+					engine.SendEventToClient(client.ClientID, state.ChatMessageEvent{Text: params.NewName})
+					return state.AddItemToPlayerResponse{}
+				},
+			},
+		})
+		client := room.Connect(state.Identity{})
+		room.Tick()
+		client.Messages()
+
+		// the responses fill the buffer, so the client awaits a resync instead of receiving the patch
+		client.Send(state.MessageKindAction_addItemToPlayer, state.AddItemToPlayerParams{NewName: "hello"})
+		client.Send(state.MessageKindAction_addItemToPlayer, state.AddItemToPlayerParams{NewName: "world"})
+		room.Tick()
+		assert.Equal(t, []state.MessageKind{state.MessageKindAction_addItemToPlayer, state.MessageKindAction_addItemToPlayer}, messageKinds(client.Messages()))
+
+		// the event which does not fit into the buffer anymore is sent with the next tick
+		room.Tick()
+		messages := client.Messages()
+		assert.Equal(t, []state.MessageKind{state.MessageKindCurrentState, state.MessageKindEvent_chatMessage}, messageKinds(messages))
+		assert.Equal(t, `{"text":"hello"}`, string(messages[1].Content))
+
+		room.Tick()
+		messages = client.Messages()
+		assert.Equal(t, []state.MessageKind{state.MessageKindEvent_chatMessage}, messageKinds(messages))
+		assert.Equal(t, `{"text":"world"}`, string(messages[0].Content))
+	})
+}
@@ -0,0 +1,92 @@
+package integrationtest
+
+import (
+	"testing"
+
+	"github.com/jobergner/backent-cli/integrationtest/state"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListeners(t *testing.T) {
+	var created []string
+	var renamed [][2]string
+	var deleted int
+	room := state.NewTestRoom(state.Options{
+		SideEffects: state.SideEffects{
+			OnDeploy: func(engine *state.Engine) {
+				engine.OnItemCreated(func(item state.ItemElement) {
+					created = append(created, item.Name())
+				})
+				engine.OnItemUpdated(func(old, new state.ItemElement) {
+					renamed = append(renamed, [2]string{old.Name(), new.Name()})
+				})
+				engine.OnItemDeleted(func(item state.ItemElement) {
+					deleted++
+				})
+			},
+		},
+		Actions: state.Actions{
+			AddItemToPlayer: func(params state.AddItemToPlayerParams, engine *state.Engine, client state.Identity) state.AddItemToPlayerResponse {
+				items := engine.QueryItems().Where(func(item state.ItemElement) bool { return item.Name() != "" }).All()
+				if len(items) == 0 {
+					engine.CreateItem().SetName(params.NewName)
+				}
+				for _, item := range items {
+					if params.NewName == "" {
+						engine.DeleteItem(item.ID())
+						continue
+					}
+					item.SetName(params.NewName)
+				}
+				return state.AddItemToPlayerResponse{}
+			},
+		},
+	})
+	client := room.Connect(state.Identity{})
+	room.Tick()
+
+	client.Send(state.MessageKindAction_addItemToPlayer, state.AddItemToPlayerParams{NewName: "sword"})
+	room.Tick()
+	assert.Equal(t, []string{"sword"}, created)
+	assert.Empty(t, renamed)
+
+	client.Send(state.MessageKindAction_addItemToPlayer, state.AddItemToPlayerParams{NewName: "axe"})
+	room.Tick()
+	assert.Equal(t, [][2]string{{"sword", "axe"}}, renamed)
+
+	client.Send(state.MessageKindAction_addItemToPlayer, state.AddItemToPlayerParams{})
+	room.Tick()
+	assert.Equal(t, 1, deleted)
+}
+
+func TestListenerChangesArePublished(t *testing.T) {
+	room := state.NewTestRoom(state.Options{
+		SideEffects: state.SideEffects{
+			OnDeploy: func(engine *state.Engine) {
+				engine.OnPlayerCreated(func(player state.PlayerElement) {
+					engine.CreateZone()
+				})
+			},
+		},
+		Actions: state.Actions{
+			AddItemToPlayer: func(params state.AddItemToPlayerParams, engine *state.Engine, client state.Identity) state.AddItemToPlayerResponse {
+				engine.CreatePlayer()
+				return state.AddItemToPlayerResponse{}
+			},
+		},
+	})
+	client := room.Connect(state.Identity{})
+	room.Tick()
+	client.Messages()
+
+	client.Send(state.MessageKindAction_addItemToPlayer, state.AddItemToPlayerParams{})
+	room.Tick()
+	messages := client.Messages()
+	assert.Equal(t, []state.MessageKind{state.MessageKindAction_addItemToPlayer, state.MessageKindUpdate}, messageKinds(messages))
+	assert.Contains(t, string(messages[1].Content), `"player":{"1":`)
+	assert.Contains(t, string(messages[1].Content), `"zone":{"4":{"id":4,"operationKind":"UPDATE"}}`)
+	assert.Equal(t, 1, len(room.Engine().EveryZone()))
+
+	room.Tick()
+	assert.Empty(t, client.Messages())
+}
@@ -0,0 +1,104 @@
+package integrationtest
+
+import (
+	"bytes"
+	"context"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jobergner/backent-cli/integrationtest/state"
+	"github.com/stretchr/testify/assert"
+	"nhooyr.io/websocket"
+	"nhooyr.io/websocket/wsjson"
+)
+
+type logEntry struct {
+	level  state.LogLevel
+	msg    string
+	fields map[string]interface{}
+}
+
+type recordingLogger struct {
+	mu      sync.Mutex
+	entries []logEntry
+}
+
+func (l *recordingLogger) Log(level state.LogLevel, msg string, fields ...state.LogField) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	entry := logEntry{level, msg, make(map[string]interface{})}
+	for _, field := range fields {
+		entry.fields[field.Key] = field.Value
+	}
+	l.entries = append(l.entries, entry)
+}
+
+func (l *recordingLogger) find(msg string) (logEntry, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, entry := range l.entries {
+		if entry.msg == msg {
+			return entry, true
+		}
+	}
+	return logEntry{}, false
+}
+
+func TestLogger(t *testing.T) {
+	t.Run("adds structured fields to entries", func(t *testing.T) {
+		logger := &recordingLogger{}
+		server := state.NewServer(state.Options{
+			Actions: actions,
+			FPS:     100,
+			Logger:  logger,
+		})
+		httpServer := httptest.NewServer(server)
+		defer httpServer.Close()
+		defer server.Shutdown(context.Background())
+
+		ctx := context.Background()
+		c, _, err := websocket.Dial(ctx, "ws"+strings.TrimPrefix(httpServer.URL, "http")+"/ws", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var serverResponse state.Message
+		err = wsjson.Read(ctx, c, &serverResponse)
+		assert.NoError(t, err)
+
+		sendActionUnknownKind(ctx, c)
+		err = wsjson.Read(ctx, c, &serverResponse)
+		assert.NoError(t, err)
+		c.Close(websocket.StatusNormalClosure, "")
+
+		var entry logEntry
+		assert.Eventually(t, func() bool {
+			var ok bool
+			entry, ok = logger.find("unregistering client")
+			return ok
+		}, time.Second, 10*time.Millisecond)
+		assert.Equal(t, state.LogLevelInfo, entry.level)
+		assert.NotEmpty(t, entry.fields["room"])
+		assert.NotEmpty(t, entry.fields["client"])
+		assert.NotZero(t, entry.fields["tick"])
+
+		entry, ok := logger.find("error processing client message")
+		assert.True(t, ok)
+		assert.Equal(t, state.LogLevelWarn, entry.level)
+		assert.Equal(t, state.MessageKind("whoami"), entry.fields["action"])
+	})
+
+	t.Run("writes entries above minimum level", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := state.NewStdLogger(&buf, state.LogLevelWarn)
+
+		logger.Log(state.LogLevelInfo, "client connected")
+		logger.Log(state.LogLevelWarn, "client violated limit", state.LogField{Key: "client", Value: "abc"}, state.LogField{Key: "tick", Value: 3})
+
+		assert.NotContains(t, buf.String(), "client connected")
+		assert.True(t, strings.HasSuffix(buf.String(), "WARN client violated limit client=abc tick=3\n"))
+	})
+}
@@ -118,7 +118,7 @@ func TestIntegration(t *testing.T) {
 	if err != nil {
 		t.Error("expected inspection result to be unmarshallable, but it was not")
 	}
-	assert.Equal(t, 3, len(m))
+	assert.Equal(t, 5, len(m))
 
 	resp, err = http.Get("http://localhost:3496/state")
 	if err != nil {
@@ -143,14 +143,15 @@ const expectedConfig = `{
       "equipmentSets": "[]*equipmentSet",
       "gearScore": "gearScore",
       "position": "position",
-      "guildMembers": "[]*player",
+      "guildMembers": "set<*player>",
       "target": "*anyOf<player,zoneItem>",
       "targetedBy": "[]*anyOf<player,zoneItem>"
     },
     "zone": {
       "items": "[]zoneItem",
       "players": "[]player",
-      "tags": "[]string",
+      "boss": "?player",
+      "tags": "set<string>",
       "interactables": "[]anyOf<item,player,zoneItem>"
     },
     "zoneItem": {
@@ -173,6 +174,10 @@ const expectedConfig = `{
     },
     "equipmentSet": {
       "name": "string",
+      "createdAt": "time",
+      "cooldown": "duration",
+      "icon": "bytes",
+      "externalID": "bigID",
       "equipment": "[]*item"
     }
   },
@@ -197,6 +202,19 @@ const expectedConfig = `{
     "spawnZoneItems": {
       "newZoneItemPaths": "string"
     }
+  },
+  "events": {
+    "chatMessage": {
+      "author": "playerID",
+      "text": "string"
+    },
+    "matchEnded": {
+      "winners": "[]playerID"
+    }
+  },
+  "indexes": {
+    "gearScore": ["score"],
+    "item": ["name"]
   }
 }
 `
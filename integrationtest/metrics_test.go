@@ -0,0 +1,76 @@
+package integrationtest
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jobergner/backent-cli/integrationtest/state"
+	"github.com/stretchr/testify/assert"
+	"nhooyr.io/websocket"
+	"nhooyr.io/websocket/wsjson"
+)
+
+func TestMetrics(t *testing.T) {
+	server := state.NewServer(state.Options{
+		Actions:     actions,
+		SideEffects: sideEffects,
+		FPS:         100,
+		Logger:      state.NopLogger(),
+	})
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+	defer server.Shutdown(context.Background())
+
+	ctx := context.Background()
+	c, _, err := websocket.Dial(ctx, "ws"+strings.TrimPrefix(httpServer.URL, "http")+"/ws", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close(websocket.StatusNormalClosure, "")
+
+	var serverResponse state.Message
+	err = wsjson.Read(ctx, c, &serverResponse)
+	assert.NoError(t, err)
+	assert.Equal(t, state.MessageKindCurrentState, serverResponse.Kind)
+
+	sendActionAddItemToPlayer(ctx, c)
+	for serverResponse.Kind != state.MessageKindAction_addItemToPlayer {
+		err = wsjson.Read(ctx, c, &serverResponse)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	// the room status is updated at the end of each tick
+	time.Sleep(50 * time.Millisecond)
+
+	resp, err := http.Get(httpServer.URL + "/metrics")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	assert.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/plain; version=0.0.4", resp.Header.Get("Content-Type"))
+
+	metrics := string(body)
+	assert.Contains(t, metrics, "# TYPE backent_tick_duration_seconds histogram\n")
+	assert.Contains(t, metrics, "backent_tick_duration_seconds_bucket{le=\"+Inf\"}")
+	assert.Contains(t, metrics, "backent_patch_bytes_count")
+	assert.Contains(t, metrics, "backent_connected_clients 1\n")
+	assert.Contains(t, metrics, "backent_incoming_clients 0\n")
+	assert.Contains(t, metrics, "backent_actions_total{action=\"addItemToPlayer\"} 1\n")
+	assert.Contains(t, metrics, "backent_actions_total{action=\"movePlayer\"} 0\n")
+	assert.Contains(t, metrics, "backent_action_duration_seconds_count{action=\"addItemToPlayer\"} 1\n")
+	// the item's origin defaults to a player
+	assert.Contains(t, metrics, "backent_elements{kind=\"Player\"} 2\n")
+	assert.Contains(t, metrics, "backent_elements{kind=\"Item\"} 1\n")
+	assert.Contains(t, metrics, "backent_messages_dropped_total{buffer=\"room\"} 0\n")
+	assert.Contains(t, metrics, "backent_clients_dropped_total 0\n")
+}
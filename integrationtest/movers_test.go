@@ -0,0 +1,41 @@
+package integrationtest
+
+import (
+	"testing"
+
+	"github.com/jobergner/backent-cli/integrationtest/state"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMovers(t *testing.T) {
+	var playerID state.PlayerID
+	room := state.NewTestRoom(state.Options{
+		SideEffects: state.SideEffects{
+			OnDeploy: func(engine *state.Engine) {
+				player := engine.CreatePlayer()
+				player.AddItem().SetName("sword")
+				player.AddItem().SetName("shield")
+				playerID = player.ID()
+			},
+		},
+		Actions: state.Actions{
+			MovePlayer: func(params state.MovePlayerParams, engine *state.Engine, client state.Identity) {
+				player := engine.Player(params.Player)
+				items := player.Items()
+				player.SwapItems(items[0].ID(), items[1].ID())
+				player.InsertItemAt(1).SetName("bow")
+			},
+		},
+	})
+	client := room.Connect(state.Identity{})
+	room.Tick()
+
+	client.Send(state.MessageKindAction_movePlayer, state.MovePlayerParams{Player: playerID})
+	room.Tick()
+
+	var names []string
+	for _, item := range room.Engine().Player(playerID).Items() {
+		names = append(names, item.Name())
+	}
+	assert.Equal(t, []string{"shield", "bow", "sword"}, names)
+}
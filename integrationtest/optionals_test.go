@@ -0,0 +1,83 @@
+package integrationtest
+
+import (
+	"testing"
+
+	"github.com/jobergner/backent-cli/integrationtest/state"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOptionals(t *testing.T) {
+	var zoneID, emptyZoneID state.ZoneID
+	room := state.NewTestRoom(state.Options{
+		SideEffects: state.SideEffects{
+			OnDeploy: func(engine *state.Engine) {
+				zone := engine.CreateZone()
+				zone.SetBoss().AddItem().SetName("axe")
+				zoneID = zone.ID()
+				emptyZone := engine.CreateZone()
+				emptyZone.SetBoss()
+				emptyZone.UnsetBoss()
+				emptyZoneID = emptyZone.ID()
+			},
+		},
+	})
+	room.Tick()
+
+	engine := room.Engine()
+	boss, ok := engine.Zone(zoneID).Boss()
+	assert.True(t, ok)
+	assert.Equal(t, "axe", boss.Items()[0].Name())
+	assert.False(t, engine.Zone(emptyZoneID).HasBoss())
+	assert.Equal(t, 0, len(engine.EveryPlayer()))
+}
+
+func TestUnsetOptionals(t *testing.T) {
+	var zoneID state.ZoneID
+	room := state.NewTestRoom(state.Options{
+		SideEffects: state.SideEffects{
+			OnDeploy: func(engine *state.Engine) {
+				zone := engine.CreateZone()
+				zone.SetBoss()
+				zone.UnsetBoss()
+				zoneID = zone.ID()
+			},
+		},
+	})
+	client := room.Connect(state.Identity{})
+	room.Tick()
+
+	t.Run("leaves an unset child out of the current state", func(t *testing.T) {
+		messages := client.Messages()
+		assert.Equal(t, []state.MessageKind{state.MessageKindCurrentState}, messageKinds(messages))
+		assert.Equal(t, `{"zone":{"1":{"id":1,"operationKind":"UNCHANGED"}}}`, string(messages[0].Content))
+	})
+
+	t.Run("leaves a child which was set and unset within one tick out of the patch", func(t *testing.T) {
+		room.Engine().Zone(zoneID).SetBoss()
+		room.Engine().Zone(zoneID).UnsetBoss()
+		room.Tick()
+		messages := client.Messages()
+		assert.Equal(t, []state.MessageKind{state.MessageKindUpdate}, messageKinds(messages))
+		assert.Equal(t, `{"zone":{"1":{"id":1,"operationKind":"UPDATE"}}}`, string(messages[0].Content))
+	})
+
+	t.Run("deletes the child only in the patch it was unset in", func(t *testing.T) {
+		room.Engine().Zone(zoneID).SetBoss()
+		room.Tick()
+		client.Messages()
+
+		room.Engine().Zone(zoneID).UnsetBoss()
+		lateClient := room.Connect(state.Identity{})
+		room.Tick()
+		messages := client.Messages()
+		assert.Equal(t, []state.MessageKind{state.MessageKindUpdate}, messageKinds(messages))
+		assert.Equal(t, `{"zone":{"1":{"id":1,"boss":{"id":8,"gearScore":{"id":9,"operationKind":"DELETE"},"position":{"id":10,"operationKind":"DELETE"},"operationKind":"DELETE"},"operationKind":"UPDATE"}}}`, string(messages[0].Content))
+		messages = lateClient.Messages()
+		assert.Equal(t, []state.MessageKind{state.MessageKindCurrentState}, messageKinds(messages))
+		assert.Equal(t, `{"zone":{"1":{"id":1,"operationKind":"UNCHANGED"}}}`, string(messages[0].Content))
+
+		room.Tick()
+		assert.Equal(t, 0, len(client.Messages()))
+	})
+}
@@ -0,0 +1,27 @@
+package integrationtest
+
+import (
+	"testing"
+
+	"github.com/jobergner/backent-cli/integrationtest/state"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrdering(t *testing.T) {
+	room := state.NewTestRoom(state.Options{
+		SideEffects: state.SideEffects{
+			OnDeploy: func(engine *state.Engine) {
+				for i := 0; i < 50; i++ {
+					engine.CreatePlayer()
+				}
+			},
+		},
+	})
+	room.Tick()
+
+	players := room.Engine().EveryPlayer()
+	assert.Len(t, players, 50)
+	for i := 1; i < len(players); i++ {
+		assert.Less(t, int(players[i-1].ID()), int(players[i].ID()))
+	}
+}
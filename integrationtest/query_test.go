@@ -0,0 +1,38 @@
+package integrationtest
+
+import (
+	"testing"
+
+	"github.com/jobergner/backent-cli/integrationtest/state"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuery(t *testing.T) {
+	var swords, shields int
+	room := state.NewTestRoom(state.Options{
+		SideEffects: state.SideEffects{
+			OnDeploy: func(engine *state.Engine) {
+				engine.CreatePlayer().AddItem().SetName("sword")
+				engine.CreateItem().SetName("shield")
+			},
+		},
+		Actions: state.Actions{
+			AddItemToPlayer: func(params state.AddItemToPlayerParams, engine *state.Engine, client state.Identity) state.AddItemToPlayerResponse {
+				for _, item := range engine.QueryItems().WhereName("shield").All() {
+					item.SetName(params.NewName)
+				}
+				swords = len(engine.QueryItems().WhereName("sword").All())
+				shields = len(engine.QueryItems().WhereName("shield").All())
+				return state.AddItemToPlayerResponse{}
+			},
+		},
+	})
+	client := room.Connect(state.Identity{})
+	room.Tick()
+
+	client.Send(state.MessageKindAction_addItemToPlayer, state.AddItemToPlayerParams{NewName: "sword"})
+	room.Tick()
+
+	assert.Equal(t, 2, swords)
+	assert.Equal(t, 0, shields)
+}
@@ -0,0 +1,106 @@
+package integrationtest
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jobergner/backent-cli/integrationtest/state"
+	"github.com/stretchr/testify/assert"
+	"nhooyr.io/websocket"
+	"nhooyr.io/websocket/wsjson"
+)
+
+func TestScheduler(t *testing.T) {
+	t.Run("runs tick based tasks deterministically", func(t *testing.T) {
+		schedulers := make(chan *state.Scheduler, 1)
+		done := make(chan int)
+		// frames and runs are only accessed on the tick goroutine
+		var frames, runs int
+		var scheduled bool
+		server := state.NewServer(state.Options{
+			SideEffects: state.SideEffects{
+				OnFrameTick: func(engine *state.Engine, tick state.TickInfo) {
+					if !scheduled {
+						select {
+						case scheduler := <-schedulers:
+							scheduler.EveryTicks(2, func(engine *state.Engine) {
+								runs++
+							})
+							scheduled = true
+						default:
+						}
+						return
+					}
+					frames++
+					if frames == 10 {
+						done <- runs
+					}
+				},
+			},
+			FPS:    100,
+			Logger: state.NopLogger(),
+		})
+		defer server.Shutdown(context.Background())
+		schedulers <- server.Scheduler()
+
+		// the task runs every second of the following ten ticks
+		assert.Equal(t, 5, <-done)
+	})
+
+	t.Run("cancels repeating tasks", func(t *testing.T) {
+		server := state.NewServer(state.Options{
+			FPS:    100,
+			Logger: state.NopLogger(),
+		})
+		defer server.Shutdown(context.Background())
+
+		var runs int32
+		task := server.Scheduler().Every(10*time.Millisecond, func(engine *state.Engine) {
+			atomic.AddInt32(&runs, 1)
+		})
+		assert.Eventually(t, func() bool {
+			return atomic.LoadInt32(&runs) >= 3
+		}, time.Second, 10*time.Millisecond)
+
+		task.Cancel()
+		// a run may already be in progress while cancelling
+		runsAfterCancel := atomic.LoadInt32(&runs) + 1
+		time.Sleep(50 * time.Millisecond)
+		assert.LessOrEqual(t, atomic.LoadInt32(&runs), runsAfterCancel)
+	})
+
+	t.Run("publishes changes of tasks with the tick's patch", func(t *testing.T) {
+		server := state.NewServer(state.Options{
+			FPS:    100,
+			Logger: state.NopLogger(),
+		})
+		httpServer := httptest.NewServer(server)
+		defer httpServer.Close()
+		defer server.Shutdown(context.Background())
+
+		ctx := context.Background()
+		c, _, err := websocket.Dial(ctx, "ws"+strings.TrimPrefix(httpServer.URL, "http")+"/ws", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer c.Close(websocket.StatusNormalClosure, "")
+
+		var serverResponse state.Message
+		err = wsjson.Read(ctx, c, &serverResponse)
+		assert.NoError(t, err)
+		assert.Equal(t, state.MessageKindCurrentState, serverResponse.Kind)
+
+		server.Scheduler().After(20*time.Millisecond, func(engine *state.Engine) {
+			engine.CreateItem().SetName("scheduled")
+		})
+
+		err = wsjson.Read(ctx, c, &serverResponse)
+		assert.NoError(t, err)
+		assert.Equal(t, state.MessageKindUpdate, serverResponse.Kind)
+		assert.Contains(t, string(serverResponse.Content), `"name":"scheduled"`)
+	})
+}
@@ -0,0 +1,33 @@
+package integrationtest
+
+import (
+	"testing"
+
+	"github.com/jobergner/backent-cli/integrationtest/state"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSets(t *testing.T) {
+	var zoneID state.ZoneID
+	var playerID state.PlayerID
+	room := state.NewTestRoom(state.Options{
+		SideEffects: state.SideEffects{
+			OnDeploy: func(engine *state.Engine) {
+				zone := engine.CreateZone()
+				zone.AddTags("pvp", "pvp", "night")
+				zoneID = zone.ID()
+				player := engine.CreatePlayer()
+				member := engine.CreatePlayer()
+				player.AddGuildMember(member.ID())
+				player.AddGuildMember(member.ID())
+				playerID = player.ID()
+			},
+		},
+	})
+	room.Tick()
+
+	engine := room.Engine()
+	assert.Equal(t, []string{"pvp", "night"}, engine.Zone(zoneID).Tags())
+	assert.True(t, engine.Zone(zoneID).HasTag("night"))
+	assert.Equal(t, 1, engine.Player(playerID).LenGuildMembers())
+}
@@ -0,0 +1,108 @@
+package integrationtest
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jobergner/backent-cli/integrationtest/state"
+	"github.com/stretchr/testify/assert"
+	"nhooyr.io/websocket"
+	"nhooyr.io/websocket/wsjson"
+)
+
+func TestShutdown(t *testing.T) {
+	t.Run("closes websocket clients with going away and runs OnShutdown", func(t *testing.T) {
+		var shutDown bool
+		server := state.NewServer(state.Options{
+			FPS:    100,
+			Logger: state.NopLogger(),
+			SideEffects: state.SideEffects{
+				OnShutdown: func(engine *state.Engine) {
+					shutDown = true
+				},
+			},
+		})
+		httpServer := httptest.NewServer(server)
+		defer httpServer.Close()
+
+		ctx := context.Background()
+		c, _, err := websocket.Dial(ctx, "ws"+strings.TrimPrefix(httpServer.URL, "http")+"/ws", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var serverResponse state.Message
+		assert.NoError(t, wsjson.Read(ctx, c, &serverResponse))
+		assert.Equal(t, state.MessageKindCurrentState, serverResponse.Kind)
+
+		// the client has to read to complete the closing handshake
+		readErr := make(chan error)
+		go func() {
+			_, _, err := c.Read(ctx)
+			readErr <- err
+		}()
+
+		shutdownCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+		assert.NoError(t, server.Shutdown(shutdownCtx))
+		assert.True(t, shutDown)
+		assert.Equal(t, websocket.StatusGoingAway, websocket.CloseStatus(<-readErr))
+	})
+
+	t.Run("returns once the connected clients are gone", func(t *testing.T) {
+		server := state.NewServer(state.Options{
+			FPS:    100,
+			Logger: state.NopLogger(),
+		})
+		var clientConns []*state.MemoryConnector
+		for i := 0; i < 3; i++ {
+			serverConn, clientConn := state.NewMemoryConnectorPair()
+			_, err := server.Connect(serverConn, state.Identity{})
+			assert.NoError(t, err)
+			clientConns = append(clientConns, clientConn)
+		}
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		assert.NoError(t, server.Shutdown(shutdownCtx))
+
+		for _, clientConn := range clientConns {
+			code, reason, closed := clientConn.CloseStatus()
+			assert.True(t, closed)
+			assert.Equal(t, websocket.StatusGoingAway, code)
+			assert.Equal(t, "server shutting down", reason)
+		}
+
+		serverConn, _ := state.NewMemoryConnectorPair()
+		_, err := server.Connect(serverConn, state.Identity{})
+		assert.Error(t, err)
+	})
+
+	t.Run("flushes responses of actions received before shutting down", func(t *testing.T) {
+		room := state.NewTestRoom(state.Options{
+			Actions: state.Actions{
+				AddItemToPlayer: func(params state.AddItemToPlayerParams, engine *state.Engine, client state.Identity) state.AddItemToPlayerResponse {
+					engine.CreateItem().SetName(params.NewName)
+					return state.AddItemToPlayerResponse{PlayerPath: "$.player"}
+				},
+			},
+		})
+		client := room.Connect(state.Identity{})
+		room.Tick()
+		client.Messages()
+
+		err := client.Send(state.MessageKindAction_addItemToPlayer, state.AddItemToPlayerParams{NewName: "myItem"})
+		assert.NoError(t, err)
+		room.Shutdown()
+
+		messages := client.Messages()
+		assert.Equal(t, []state.MessageKind{state.MessageKindAction_addItemToPlayer, state.MessageKindUpdate}, messageKinds(messages))
+		assert.Equal(t, `{"playerPath":"$.player"}`, string(messages[0].Content))
+		assert.Contains(t, string(messages[1].Content), `"name":"myItem"`)
+		code, _, closed := client.CloseStatus()
+		assert.True(t, closed)
+		assert.Equal(t, websocket.StatusGoingAway, code)
+	})
+}
@@ -0,0 +1,141 @@
+package integrationtest
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jobergner/backent-cli/integrationtest/state"
+	"github.com/stretchr/testify/assert"
+	"nhooyr.io/websocket"
+	"nhooyr.io/websocket/wsjson"
+)
+
+func TestSpectator(t *testing.T) {
+	t.Run("rejects actions of spectators", func(t *testing.T) {
+		var actionCalled bool
+		room := state.NewTestRoom(state.Options{
+			Actions: state.Actions{
+				MovePlayer: func(params state.MovePlayerParams, engine *state.Engine, client state.Identity) {
+					actionCalled = true
+				},
+			},
+		})
+		spectator := room.Connect(state.Identity{Spectator: true})
+		room.Tick()
+		assert.Equal(t, []state.MessageKind{state.MessageKindCurrentState}, messageKinds(spectator.Messages()))
+
+		spectator.Send(state.MessageKindAction_movePlayer, state.MovePlayerParams{})
+		room.Tick()
+
+		messages := spectator.Messages()
+		assert.Equal(t, []state.MessageKind{state.MessageKindError}, messageKinds(messages))
+		assert.Equal(t, "spectators cannot send actions", string(messages[0].Content))
+		assert.False(t, actionCalled)
+	})
+
+	// with 10 FPS and a delay of 300ms spectators are 3 ticks behind
+	newDelayedRoom := func() (*state.TestRoom, state.ItemID) {
+		room := state.NewTestRoom(state.Options{
+			FPS:            10,
+			SpectatorDelay: 300 * time.Millisecond,
+		})
+		itemID := room.Engine().CreateItem().ID()
+		return room, itemID
+	}
+
+	t.Run("serves spectators with a delay", func(t *testing.T) {
+		room, itemID := newDelayedRoom()
+		player := room.Connect(state.Identity{})
+		spectator := room.Connect(state.Identity{Spectator: true})
+
+		room.Ticks(3)
+		assert.Equal(t, []state.MessageKind{state.MessageKindCurrentState}, messageKinds(player.Messages()))
+		assert.Empty(t, spectator.Messages())
+		room.Tick()
+		assert.Equal(t, []state.MessageKind{state.MessageKindCurrentState}, messageKinds(spectator.Messages()))
+
+		room.Engine().Item(itemID).SetName("delayed")
+		room.Tick()
+		assert.Equal(t, []state.MessageKind{state.MessageKindUpdate}, messageKinds(player.Messages()))
+		room.Ticks(2)
+		assert.Empty(t, spectator.Messages())
+		room.Tick()
+
+		messages := spectator.Messages()
+		assert.Equal(t, []state.MessageKind{state.MessageKindUpdate}, messageKinds(messages))
+		assert.Contains(t, string(messages[0].Content), `"name":"delayed"`)
+	})
+
+	t.Run("catches up late spectators with a merged update", func(t *testing.T) {
+		room, itemID := newDelayedRoom()
+		room.Ticks(4)
+		room.Engine().Item(itemID).SetName("first")
+		room.Tick()
+		room.Engine().Item(itemID).SetName("second")
+		room.Tick()
+		room.Ticks(2)
+
+		spectator := room.Connect(state.Identity{Spectator: true})
+		room.Tick()
+
+		messages := spectator.Messages()
+		assert.Equal(t, []state.MessageKind{state.MessageKindCurrentState, state.MessageKindUpdate}, messageKinds(messages))
+		assert.NotContains(t, string(messages[0].Content), `"name":"second"`)
+		assert.Contains(t, string(messages[1].Content), `"name":"second"`)
+	})
+
+	t.Run("keeps elements created before an update in the merged catch up", func(t *testing.T) {
+		room, _ := newDelayedRoom()
+		room.Ticks(4)
+		swordID := room.Engine().CreateItem().SetName("sword").ID()
+		room.Tick()
+		room.Engine().Item(swordID).GearScore().SetScore(1<<53 + 1)
+		room.Tick()
+		room.Ticks(2)
+
+		spectator := room.Connect(state.Identity{Spectator: true})
+		room.Tick()
+
+		messages := spectator.Messages()
+		assert.Equal(t, []state.MessageKind{state.MessageKindCurrentState, state.MessageKindUpdate}, messageKinds(messages))
+		assert.NotContains(t, string(messages[0].Content), `"name":"sword"`)
+		expected := `{"item":{"7":{"gearScore":{"id":8,"operationKind":"UPDATE","score":9007199254740993},"id":7,"name":"sword","operationKind":"UPDATE","origin":{"gearScore":{"id":11,"operationKind":"UPDATE"},"id":10,"operationKind":"UPDATE","position":{"id":12,"operationKind":"UPDATE"}}}}}`
+		assert.Equal(t, expected, string(messages[1].Content))
+	})
+
+	t.Run("connects spectators via the mode parameter", func(t *testing.T) {
+		server := state.NewServer(state.Options{
+			FPS:    100,
+			Logger: state.NopLogger(),
+		})
+		httpServer := httptest.NewServer(server)
+		defer httpServer.Close()
+		defer server.Shutdown(context.Background())
+
+		ctx := context.Background()
+		wsURL := "ws" + strings.TrimPrefix(httpServer.URL, "http") + "/ws"
+		c, _, err := websocket.Dial(ctx, wsURL+"?mode=spectate", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer c.Close(websocket.StatusNormalClosure, "")
+
+		var serverResponse state.Message
+		err = wsjson.Read(ctx, c, &serverResponse)
+		assert.NoError(t, err)
+		assert.Equal(t, state.MessageKindCurrentState, serverResponse.Kind)
+
+		sendActionMovePlayer(ctx, c)
+		err = wsjson.Read(ctx, c, &serverResponse)
+		assert.NoError(t, err)
+		assert.Equal(t, state.MessageKindError, serverResponse.Kind)
+
+		_, res, err := websocket.Dial(ctx, wsURL+"?mode=cheat", nil)
+		assert.Error(t, err)
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+	})
+}
@@ -0,0 +1,126 @@
+package integrationtest
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/jobergner/backent-cli/integrationtest/state"
+	"github.com/stretchr/testify/assert"
+)
+
+type sseEvent struct {
+	event string
+	data  string
+}
+
+func readSSEEvent(t *testing.T, reader *bufio.Reader) sseEvent {
+	var e sseEvent
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			t.Fatal(err)
+		}
+		line = strings.TrimSuffix(line, "\n")
+		switch {
+		case line == "":
+			return e
+		case strings.HasPrefix(line, "event: "):
+			e.event = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			e.data = strings.TrimPrefix(line, "data: ")
+		}
+	}
+}
+
+func readSSEMessage(t *testing.T, reader *bufio.Reader) state.Message {
+	e := readSSEEvent(t, reader)
+	assert.Equal(t, "", e.event)
+	var msg state.Message
+	err := msg.UnmarshalJSON([]byte(e.data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return msg
+}
+
+func TestSSE(t *testing.T) {
+	server := state.NewServer(state.Options{
+		Actions: state.Actions{
+			AddItemToPlayer: func(params state.AddItemToPlayerParams, engine *state.Engine, client state.Identity) state.AddItemToPlayerResponse {
+				engine.CreateItem().SetName(params.NewName)
+				return state.AddItemToPlayerResponse{PlayerPath: "$.player"}
+			},
+		},
+		FPS:    100,
+		Logger: state.NopLogger(),
+	})
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+
+	res, err := http.Get(httpServer.URL + "/sse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	assert.Equal(t, "text/event-stream", res.Header.Get("Content-Type"))
+	reader := bufio.NewReader(res.Body)
+
+	connected := readSSEEvent(t, reader)
+	assert.Equal(t, "connected", connected.event)
+	var connectedContent struct {
+		ClientID string `json:"clientID"`
+	}
+	err = json.Unmarshal([]byte(connected.data), &connectedContent)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, connectedContent.ClientID)
+
+	assert.Equal(t, state.MessageKindCurrentState, readSSEMessage(t, reader).Kind)
+
+	t.Run("accepts posted actions", func(t *testing.T) {
+		params, _ := state.AddItemToPlayerParams{NewName: "myItem"}.MarshalJSON()
+		body, _ := state.Message{Kind: state.MessageKindAction_addItemToPlayer, Content: params}.MarshalJSON()
+		postRes, err := http.Post(httpServer.URL+"/action?client="+connectedContent.ClientID, "application/json", bytes.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		postRes.Body.Close()
+		assert.Equal(t, http.StatusAccepted, postRes.StatusCode)
+
+		response := readSSEMessage(t, reader)
+		assert.Equal(t, state.MessageKindAction_addItemToPlayer, response.Kind)
+		assert.Equal(t, `{"playerPath":"$.player"}`, string(response.Content))
+		update := readSSEMessage(t, reader)
+		assert.Equal(t, state.MessageKindUpdate, update.Kind)
+		assert.Contains(t, string(update.Content), `"name":"myItem"`)
+	})
+
+	t.Run("rejects actions of unknown clients", func(t *testing.T) {
+		postRes, err := http.Post(httpServer.URL+"/action?client=unknown", "application/json", strings.NewReader(`{}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+		postRes.Body.Close()
+		assert.Equal(t, http.StatusNotFound, postRes.StatusCode)
+
+		getRes, err := http.Get(httpServer.URL + "/action")
+		if err != nil {
+			t.Fatal(err)
+		}
+		getRes.Body.Close()
+		assert.Equal(t, http.StatusMethodNotAllowed, getRes.StatusCode)
+	})
+
+	t.Run("sends a close event on shutdown", func(t *testing.T) {
+		go server.Shutdown(context.Background())
+
+		closeEvent := readSSEEvent(t, reader)
+		assert.Equal(t, "close", closeEvent.event)
+		assert.Equal(t, `{"code":1001,"reason":"server shutting down"}`, closeEvent.data)
+	})
+}
@@ -9,19 +9,19 @@ import (
 var playerID state.PlayerID
 
 var actions = state.Actions{
-	AddItemToPlayer: func(a state.AddItemToPlayerParams, e *state.Engine) state.AddItemToPlayerResponse {
+	AddItemToPlayer: func(a state.AddItemToPlayerParams, e *state.Engine, _ state.Identity) state.AddItemToPlayerResponse {
 		log.Println("addItemToPlayer", a)
 		player := e.Player(playerID)
 		item := player.AddItem()
 		item.SetName(a.NewName)
 		return state.AddItemToPlayerResponse{PlayerPath: player.Path()}
 	},
-	MovePlayer: func(p state.MovePlayerParams, e *state.Engine) {
+	MovePlayer: func(p state.MovePlayerParams, e *state.Engine, _ state.Identity) {
 		log.Println("movePlayer", p)
 		playerPosition := e.Player(playerID).Position()
 		playerPosition.SetX(playerPosition.X() + p.ChangeX)
 	},
-	SpawnZoneItems: func(a state.SpawnZoneItemsParams, e *state.Engine) state.SpawnZoneItemsResponse {
+	SpawnZoneItems: func(a state.SpawnZoneItemsParams, e *state.Engine, _ state.Identity) state.SpawnZoneItemsResponse {
 		return state.SpawnZoneItemsResponse{}
 	},
 }
@@ -31,7 +31,7 @@ var sideEffects = state.SideEffects{
 		player := e.CreatePlayer()
 		playerID = player.ID()
 	},
-	OnFrameTick: func(e *state.Engine) {
+	OnFrameTick: func(e *state.Engine, _ state.TickInfo) {
 		playerGearScore := e.Player(playerID).GearScore()
 		playerGearScore.SetLevel(playerGearScore.Level() + 1)
 	},
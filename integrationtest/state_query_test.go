@@ -0,0 +1,149 @@
+package integrationtest
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/jobergner/backent-cli/integrationtest/state"
+	"github.com/stretchr/testify/assert"
+)
+
+func getState(t *testing.T, serverURL string, params url.Values) (int, map[string]map[string]map[string]interface{}) {
+	res, err := http.Get(serverURL + "/state?" + params.Encode())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	assert.Equal(t, "application/json", res.Header.Get("Content-Type"))
+
+	var body map[string]map[string]map[string]interface{}
+	if res.StatusCode == http.StatusOK {
+		err = json.NewDecoder(res.Body).Decode(&body)
+		assert.NoError(t, err)
+	}
+	return res.StatusCode, body
+}
+
+func getStateError(t *testing.T, serverURL string, params url.Values) (int, string) {
+	res, err := http.Get(serverURL + "/state?" + params.Encode())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	assert.Equal(t, "application/json", res.Header.Get("Content-Type"))
+
+	var body struct {
+		Error string `json:"error"`
+	}
+	err = json.NewDecoder(res.Body).Decode(&body)
+	assert.NoError(t, err)
+	return res.StatusCode, body.Error
+}
+
+func TestStateQuery(t *testing.T) {
+	var playerID state.PlayerID
+	var itemID state.ItemID
+	var itemPath string
+	server := state.NewServer(state.Options{
+		SideEffects: state.SideEffects{
+			OnDeploy: func(engine *state.Engine) {
+				player := engine.CreatePlayer()
+				item := player.AddItem().SetName("sword")
+				playerID, itemID, itemPath = player.ID(), item.ID(), item.Path()
+				engine.CreateItem().SetName("shield")
+			},
+		},
+		FPS:    100,
+		Logger: state.NopLogger(),
+	})
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+	defer server.Shutdown(context.Background())
+
+	itemKey := strconv.Itoa(int(itemID))
+	playerKey := strconv.Itoa(int(playerID))
+
+	t.Run("returns the entire tree without parameters", func(t *testing.T) {
+		status, body := getState(t, httpServer.URL, url.Values{})
+		assert.Equal(t, http.StatusOK, status)
+		assert.Len(t, body["player"], 1)
+		assert.Len(t, body["item"], 1)
+	})
+
+	t.Run("filters by kind including child elements", func(t *testing.T) {
+		status, body := getState(t, httpServer.URL, url.Values{"kind": {"Item"}})
+		assert.Equal(t, http.StatusOK, status)
+		assert.Len(t, body, 1)
+		assert.Len(t, body["item"], 2)
+		assert.Equal(t, "sword", body["item"][itemKey]["name"])
+	})
+
+	t.Run("filters by id and path", func(t *testing.T) {
+		status, body := getState(t, httpServer.URL, url.Values{"id": {itemKey}})
+		assert.Equal(t, http.StatusOK, status)
+		assert.Len(t, body["item"], 1)
+		assert.Equal(t, "sword", body["item"][itemKey]["name"])
+
+		status, body = getState(t, httpServer.URL, url.Values{"path": {itemPath}})
+		assert.Equal(t, http.StatusOK, status)
+		assert.Len(t, body["item"], 1)
+		assert.Equal(t, "sword", body["item"][itemKey]["name"])
+	})
+
+	t.Run("limits the depth", func(t *testing.T) {
+		status, body := getState(t, httpServer.URL, url.Values{"id": {playerKey}, "depth": {"0"}})
+		assert.Equal(t, http.StatusOK, status)
+		items := body["player"][playerKey]["items"].(map[string]interface{})
+		assert.Equal(t, map[string]interface{}{"id": float64(itemID)}, items[itemKey])
+
+		status, body = getState(t, httpServer.URL, url.Values{"id": {playerKey}, "depth": {"1"}})
+		assert.Equal(t, http.StatusOK, status)
+		items = body["player"][playerKey]["items"].(map[string]interface{})
+		assert.Equal(t, "sword", items[itemKey].(map[string]interface{})["name"])
+	})
+
+	t.Run("responds with JSON errors", func(t *testing.T) {
+		status, msg := getStateError(t, httpServer.URL, url.Values{"kind": {"Dragon"}})
+		assert.Equal(t, http.StatusBadRequest, status)
+		assert.Equal(t, `unknown kind "Dragon"`, msg)
+
+		status, msg = getStateError(t, httpServer.URL, url.Values{"depth": {"-1"}})
+		assert.Equal(t, http.StatusBadRequest, status)
+		assert.Equal(t, `invalid depth "-1"`, msg)
+
+		status, msg = getStateError(t, httpServer.URL, url.Values{"id": {"999"}})
+		assert.Equal(t, http.StatusNotFound, status)
+		assert.Equal(t, "element not found", msg)
+	})
+}
+
+func TestStateQueryMergesStateAndPatch(t *testing.T) {
+	var storedID, createdID state.ItemID
+	server := state.NewServer(state.Options{
+		SideEffects: state.SideEffects{
+			OnDeploy: func(engine *state.Engine) {
+				storedID = engine.CreateItem().SetName("sword").ID()
+				engine.UpdateState()
+				// the manual clock never ticks so the shield stays in the patch
+				createdID = engine.CreateItem().SetName("shield").ID()
+			},
+		},
+		Clock:  state.NewManualClock(time.Unix(0, 0)),
+		Logger: state.NopLogger(),
+	})
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+	defer server.Shutdown(context.Background())
+
+	status, body := getState(t, httpServer.URL, url.Values{"kind": {"Item"}})
+	assert.Equal(t, http.StatusOK, status)
+	assert.Len(t, body["item"], 2)
+	assert.Equal(t, "sword", body["item"][strconv.Itoa(int(storedID))]["name"])
+	assert.Equal(t, "shield", body["item"][strconv.Itoa(int(createdID))]["name"])
+}
@@ -0,0 +1,48 @@
+package integrationtest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/jobergner/backent-cli/integrationtest/state"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStrictMode(t *testing.T) {
+	var playerID state.PlayerID
+	logger := &recordingLogger{}
+	room := state.NewTestRoom(state.Options{
+		StrictMode: true,
+		Logger:     logger,
+		SideEffects: state.SideEffects{
+			OnDeploy: func(engine *state.Engine) {
+				playerID = engine.CreatePlayer().ID()
+			},
+		},
+		Actions: state.Actions{
+			AddItemToPlayer: func(params state.AddItemToPlayerParams, engine *state.Engine, client state.Identity) state.AddItemToPlayerResponse {
+				engine.DeletePlayer(playerID)
+				engine.Player(playerID).AddItem().SetName(params.NewName)
+				return state.AddItemToPlayerResponse{}
+			},
+		},
+	})
+	client := room.Connect(state.Identity{})
+	room.Tick()
+
+	_, ok := room.Engine().LookupPlayer(playerID)
+	assert.True(t, ok)
+
+	client.Send(state.MessageKindAction_addItemToPlayer, state.AddItemToPlayerParams{NewName: "sword"})
+	room.Tick()
+
+	assert.False(t, room.Engine().PlayerExists(playerID))
+	entry, ok := logger.find("mutation had no effect")
+	if assert.True(t, ok) {
+		assert.Equal(t, state.LogLevelWarn, entry.level)
+		err := entry.fields["error"].(error)
+		assert.True(t, errors.Is(err, state.ErrElementDoesNotExist))
+		assert.Equal(t, "AddItem: Player 1: element does not exist", err.Error())
+	}
+	assert.Empty(t, room.Engine().Errors())
+}
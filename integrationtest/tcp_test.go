@@ -0,0 +1,173 @@
+package integrationtest
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/jobergner/backent-cli/integrationtest/state"
+	"github.com/stretchr/testify/assert"
+)
+
+func writeFrame(t *testing.T, conn net.Conn, msg []byte) {
+	frame := make([]byte, 4+len(msg))
+	binary.BigEndian.PutUint32(frame, uint32(len(msg)))
+	copy(frame[4:], msg)
+	_, err := conn.Write(frame)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func readFrame(t *testing.T, conn net.Conn) state.Message {
+	var header [4]byte
+	_, err := io.ReadFull(conn, header[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	msgBytes := make([]byte, binary.BigEndian.Uint32(header[:]))
+	_, err = io.ReadFull(conn, msgBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var msg state.Message
+	err = msg.UnmarshalJSON(msgBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return msg
+}
+
+func dialTCP(t *testing.T, addr string, handshake string) net.Conn {
+	conn, err := net.Dial("tcp", addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeFrame(t, conn, []byte(handshake))
+	return conn
+}
+
+func TestTCP(t *testing.T) {
+	server := state.NewServer(state.Options{
+		Actions: state.Actions{
+			AddItemToPlayer: func(params state.AddItemToPlayerParams, engine *state.Engine, client state.Identity) state.AddItemToPlayerResponse {
+				engine.CreateItem().SetName(params.NewName)
+				return state.AddItemToPlayerResponse{PlayerPath: client.UserID}
+			},
+		},
+		Authenticate: func(r *http.Request) (state.Identity, error) {
+			if r.Header.Get("Authorization") != "Bearer secret" {
+				return state.Identity{}, errors.New("invalid token")
+			}
+			return state.Identity{UserID: "user-1"}, nil
+		},
+		FPS:    100,
+		Logger: state.NopLogger(),
+	})
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	serveErr := make(chan error)
+	go func() {
+		serveErr <- server.ServeTCP(listener)
+	}()
+
+	conn := dialTCP(t, listener.Addr().String(), `{"headers":{"Authorization":"Bearer secret"}}`)
+	defer conn.Close()
+	assert.Equal(t, state.MessageKindCurrentState, readFrame(t, conn).Kind)
+
+	t.Run("processes actions", func(t *testing.T) {
+		params, _ := state.AddItemToPlayerParams{NewName: "myItem"}.MarshalJSON()
+		msg, _ := state.Message{Kind: state.MessageKindAction_addItemToPlayer, Content: params}.MarshalJSON()
+		writeFrame(t, conn, msg)
+
+		response := readFrame(t, conn)
+		assert.Equal(t, state.MessageKindAction_addItemToPlayer, response.Kind)
+		assert.Equal(t, `{"playerPath":"user-1"}`, string(response.Content))
+		update := readFrame(t, conn)
+		assert.Equal(t, state.MessageKindUpdate, update.Kind)
+		assert.Contains(t, string(update.Content), `"name":"myItem"`)
+	})
+
+	t.Run("rejects unauthenticated clients", func(t *testing.T) {
+		rejectedConn := dialTCP(t, listener.Addr().String(), `{"headers":{"Authorization":"Bearer wrong"}}`)
+		defer rejectedConn.Close()
+
+		closeMsg := readFrame(t, rejectedConn)
+		assert.Equal(t, state.MessageKind("close"), closeMsg.Kind)
+		assert.Equal(t, `{"code":1008,"reason":"authentication failed: invalid token"}`, string(closeMsg.Content))
+	})
+
+	t.Run("closes connections on shutdown", func(t *testing.T) {
+		err := server.Shutdown(context.Background())
+		assert.NoError(t, err)
+
+		closeMsg := readFrame(t, conn)
+		assert.Equal(t, state.MessageKind("close"), closeMsg.Kind)
+		assert.Equal(t, `{"code":1001,"reason":"server shutting down"}`, string(closeMsg.Content))
+		assert.NoError(t, <-serveErr)
+	})
+}
+
+func TestTCPWriteTimeout(t *testing.T) {
+	server := state.NewServer(state.Options{
+		SideEffects:     largePatchSideEffects(),
+		FPS:             1000,
+		Logger:          state.NopLogger(),
+		TCPWriteTimeout: time.Second,
+		// coalescing keeps the client connected while its writer is blocked
+		Backpressure: state.BackpressureOptions{
+			Policy:           state.BackpressureCoalesce,
+			ClientBufferSize: 1,
+		},
+	})
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go server.ServeTCP(listener)
+
+	// the client never reads, so the server's writes block once the socket buffers are full
+	conn := dialTCP(t, listener.Addr().String(), `{}`)
+	defer conn.Close()
+	conn.(*net.TCPConn).SetReadBuffer(4096)
+	waitForMetrics(t, server, func(m state.BackpressureMetrics) bool {
+		return m.PatchesCoalesced > 100
+	})
+	// give the server time to fill the socket buffers
+	time.Sleep(500 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	assert.NoError(t, server.Shutdown(ctx))
+}
+
+func TestTCPMessageLimit(t *testing.T) {
+	server := state.NewServer(state.Options{
+		ClientLimits: state.ClientLimits{MaxMessageBytes: 40000},
+		FPS:          100,
+		Logger:       state.NopLogger(),
+	})
+	defer server.Shutdown(context.Background())
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go server.ServeTCP(listener)
+
+	conn := dialTCP(t, listener.Addr().String(), `{}`)
+	defer conn.Close()
+	assert.Equal(t, state.MessageKindCurrentState, readFrame(t, conn).Kind)
+
+	writeFrame(t, conn, bytes.Repeat([]byte("a"), 40001))
+	response := readFrame(t, conn)
+	assert.Equal(t, state.MessageKindError, response.Kind)
+	assert.Equal(t, "client limit violated: message exceeds 40000 bytes", string(response.Content))
+}
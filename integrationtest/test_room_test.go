@@ -0,0 +1,120 @@
+package integrationtest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jobergner/backent-cli/integrationtest/state"
+	"github.com/stretchr/testify/assert"
+	"nhooyr.io/websocket"
+)
+
+func messageKinds(messages []state.Message) []state.MessageKind {
+	var kinds []state.MessageKind
+	for _, msg := range messages {
+		kinds = append(kinds, msg.Kind)
+	}
+	return kinds
+}
+
+func TestTestRoom(t *testing.T) {
+	t.Run("delivers the current state, responses and patches", func(t *testing.T) {
+		room := state.NewTestRoom(state.Options{
+			Actions: state.Actions{
+				AddItemToPlayer: func(params state.AddItemToPlayerParams, engine *state.Engine, client state.Identity) state.AddItemToPlayerResponse {
+					engine.CreateItem().SetName(params.NewName)
+					return state.AddItemToPlayerResponse{PlayerPath: "$.player"}
+				},
+			},
+		})
+		client := room.Connect(state.Identity{})
+
+		assert.Empty(t, client.Messages())
+		room.Tick()
+		assert.Equal(t, []state.MessageKind{state.MessageKindCurrentState}, messageKinds(client.Messages()))
+
+		err := client.Send(state.MessageKindAction_addItemToPlayer, state.AddItemToPlayerParams{NewName: "myItem"})
+		assert.NoError(t, err)
+		room.Tick()
+
+		messages := client.Messages()
+		assert.Equal(t, []state.MessageKind{state.MessageKindAction_addItemToPlayer, state.MessageKindUpdate}, messageKinds(messages))
+		assert.Equal(t, `{"playerPath":"$.player"}`, string(messages[0].Content))
+		assert.Contains(t, string(messages[1].Content), `"name":"myItem"`)
+	})
+
+	t.Run("processes actions in the order clients connected", func(t *testing.T) {
+		var senders []string
+		room := state.NewTestRoom(state.Options{
+			Actions: state.Actions{
+				MovePlayer: func(params state.MovePlayerParams, engine *state.Engine, client state.Identity) {
+					senders = append(senders, client.ClientID)
+				},
+			},
+		})
+		client1 := room.Connect(state.Identity{})
+		client2 := room.Connect(state.Identity{})
+
+		client2.Send(state.MessageKindAction_movePlayer, state.MovePlayerParams{})
+		client1.Send(state.MessageKindAction_movePlayer, state.MovePlayerParams{})
+		room.Tick()
+
+		assert.Equal(t, []string{client1.ID, client2.ID}, senders)
+	})
+
+	t.Run("runs scheduled tasks and delivers events", func(t *testing.T) {
+		room := state.NewTestRoom(state.Options{FPS: 10})
+		client := room.Connect(state.Identity{})
+		room.Tick()
+		client.Messages()
+
+		room.Scheduler().AfterTicks(2, func(engine *state.Engine) {
+			room.SendEventToClient(client.ID, state.ChatMessageEvent{Text: "hello"})
+		})
+		room.Tick()
+		assert.Empty(t, client.Messages())
+		room.Tick()
+
+		messages := client.Messages()
+		assert.Equal(t, []state.MessageKind{state.MessageKindEvent_chatMessage}, messageKinds(messages))
+		assert.Equal(t, `{"text":"hello"}`, string(messages[0].Content))
+	})
+
+	t.Run("closes connections on shutdown", func(t *testing.T) {
+		var shutDown bool
+		room := state.NewTestRoom(state.Options{
+			SideEffects: state.SideEffects{
+				OnShutdown: func(engine *state.Engine) {
+					shutDown = true
+				},
+			},
+		})
+		client := room.Connect(state.Identity{})
+		room.Tick()
+
+		room.Shutdown()
+		code, reason, closed := client.CloseStatus()
+		assert.True(t, closed)
+		assert.Equal(t, websocket.StatusGoingAway, code)
+		assert.Equal(t, "server shutting down", reason)
+		assert.True(t, shutDown)
+	})
+	t.Run("connects memory connectors to a running server", func(t *testing.T) {
+		server := state.NewServer(state.Options{
+			FPS:    100,
+			Logger: state.NopLogger(),
+		})
+		defer server.Shutdown(context.Background())
+
+		serverConn, clientConn := state.NewMemoryConnectorPair()
+		clientID, err := server.Connect(serverConn, state.Identity{})
+		assert.NoError(t, err)
+		assert.NotEmpty(t, clientID)
+
+		_, msgBytes, err := clientConn.ReadMessage()
+		assert.NoError(t, err)
+		var msg state.Message
+		assert.NoError(t, msg.UnmarshalJSON(msgBytes))
+		assert.Equal(t, state.MessageKindCurrentState, msg.Kind)
+	})
+}
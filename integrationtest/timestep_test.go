@@ -0,0 +1,78 @@
+package integrationtest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jobergner/backent-cli/integrationtest/state"
+	"github.com/stretchr/testify/assert"
+)
+
+// newSteppedServer runs with 10 FPS, so every 100ms of the manual clock is one tick
+func newSteppedServer(timestep state.TimestepOptions, logger state.Logger) (*state.Server, *state.ManualClock, chan state.TickInfo) {
+	clock := state.NewManualClock(time.Unix(0, 0))
+	ticks := make(chan state.TickInfo, 10)
+	server := state.NewServer(state.Options{
+		SideEffects: state.SideEffects{
+			OnFrameTick: func(engine *state.Engine, tick state.TickInfo) {
+				ticks <- tick
+			},
+		},
+		FPS:      10,
+		Timestep: timestep,
+		Clock:    clock,
+		Logger:   logger,
+	})
+	return server, clock, ticks
+}
+
+func TestTimestep(t *testing.T) {
+	t.Run("steps ticks with a manual clock", func(t *testing.T) {
+		server, clock, ticks := newSteppedServer(state.TimestepOptions{}, state.NopLogger())
+		defer server.Shutdown(context.Background())
+
+		clock.Advance(100 * time.Millisecond)
+		assert.Equal(t, state.TickInfo{Number: 1, Delta: 100 * time.Millisecond}, <-ticks)
+		clock.Advance(100 * time.Millisecond)
+		assert.Equal(t, state.TickInfo{Number: 2, Delta: 100 * time.Millisecond}, <-ticks)
+	})
+
+	t.Run("bursts missed ticks", func(t *testing.T) {
+		server, clock, ticks := newSteppedServer(state.TimestepOptions{CatchUp: state.CatchUpBurst}, state.NopLogger())
+		defer server.Shutdown(context.Background())
+
+		clock.Advance(300 * time.Millisecond)
+		assert.Equal(t, state.TickInfo{Number: 1, Delta: 100 * time.Millisecond, Overrun: 200 * time.Millisecond}, <-ticks)
+		assert.Equal(t, state.TickInfo{Number: 2, Delta: 100 * time.Millisecond, Overrun: 100 * time.Millisecond}, <-ticks)
+		assert.Equal(t, state.TickInfo{Number: 3, Delta: 100 * time.Millisecond}, <-ticks)
+	})
+
+	t.Run("skips missed ticks", func(t *testing.T) {
+		server, clock, ticks := newSteppedServer(state.TimestepOptions{CatchUp: state.CatchUpSkip}, state.NopLogger())
+		defer server.Shutdown(context.Background())
+
+		clock.Advance(300 * time.Millisecond)
+		assert.Equal(t, state.TickInfo{Number: 1, Delta: 300 * time.Millisecond, Overrun: 200 * time.Millisecond}, <-ticks)
+		clock.Advance(100 * time.Millisecond)
+		assert.Equal(t, state.TickInfo{Number: 2, Delta: 100 * time.Millisecond}, <-ticks)
+	})
+
+	t.Run("logs ticks skipped beyond the catch up limit", func(t *testing.T) {
+		logger := &recordingLogger{}
+		server, clock, ticks := newSteppedServer(state.TimestepOptions{MaxCatchUpTicks: 2}, logger)
+		defer server.Shutdown(context.Background())
+
+		clock.Advance(500 * time.Millisecond)
+		assert.Equal(t, 1, (<-ticks).Number)
+		assert.Equal(t, 2, (<-ticks).Number)
+
+		entry, ok := logger.find("skipping ticks to catch up")
+		assert.True(t, ok)
+		assert.Equal(t, state.LogLevelWarn, entry.level)
+		assert.Equal(t, 3, entry.fields["skipped_ticks"])
+
+		clock.Advance(100 * time.Millisecond)
+		assert.Equal(t, state.TickInfo{Number: 3, Delta: 100 * time.Millisecond}, <-ticks)
+	})
+}
@@ -11,6 +11,7 @@ const outFile = "state.go"
 
 var configNameFlag = flag.String("config", "./example.config.json", "path of config")
 var engineOnlyFlag = flag.Bool("engine_only", false, "only state")
+var orderedFlag = flag.Bool("ordered", false, "sort elements by ID")
 var outDirName = flag.String("out", "./tmp", "where to write the files to")
 var exampleFlag = flag.Bool("example", false, "when enabled starts example")
 var devModeFlag = flag.Bool("dev", false, "start in dev mode")
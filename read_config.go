@@ -11,11 +11,15 @@ type config struct {
 	State     map[interface{}]interface{} `json:"state"`
 	Actions   map[interface{}]interface{} `json:"actions"`
 	Responses map[interface{}]interface{} `json:"responses"`
+	Events    map[interface{}]interface{} `json:"events"`
+	Indexes   map[interface{}]interface{} `json:"indexes"`
 }
 type jsonConfig struct {
 	State     map[string]interface{} `json:"state"`
 	Actions   map[string]interface{} `json:"actions"`
 	Responses map[string]interface{} `json:"responses"`
+	Events    map[string]interface{} `json:"events"`
+	Indexes   map[string]interface{} `json:"indexes"`
 }
 
 func makeAmbiguous(a map[string]interface{}) map[interface{}]interface{} {
@@ -33,6 +37,10 @@ func makeAmbiguous(a map[string]interface{}) map[interface{}]interface{} {
 			b[k] = tmp
 			continue
 		}
+		if l, ok := v.([]interface{}); ok {
+			b[k] = l
+			continue
+		}
 		if v == nil {
 			b[k] = nil
 			continue
@@ -81,6 +89,8 @@ func useExampleConfig() (*config, []byte, error) {
 		State:     makeAmbiguous(exampleConfig.State),
 		Actions:   makeAmbiguous(exampleConfig.Actions),
 		Responses: makeAmbiguous(exampleConfig.Responses),
+		Events:    makeAmbiguous(exampleConfig.Events),
+		Indexes:   makeAmbiguous(exampleConfig.Indexes),
 	}
 
 	return c, b, nil
@@ -112,6 +122,8 @@ func readConfig() (*config, []byte, error) {
 		State:     makeAmbiguous(jc.State),
 		Actions:   makeAmbiguous(jc.Actions),
 		Responses: makeAmbiguous(jc.Responses),
+		Events:    makeAmbiguous(jc.Events),
+		Indexes:   makeAmbiguous(jc.Indexes),
 	}
 
 	return c, configFile, nil
@@ -37,16 +37,18 @@ func newServerFactory(config *ast.AST) *ServerFactory {
 // WriteServerFrom writes source code for a given ActionsConfig
 func WriteServer(
 	buf *bytes.Buffer,
-	stateConfigData, actionsConfigData, responsesConfigData map[interface{}]interface{},
+	stateConfigData, actionsConfigData, responsesConfigData, eventsConfigData map[interface{}]interface{},
 	configJson []byte,
 ) {
-	config := ast.Parse(stateConfigData, actionsConfigData, responsesConfigData)
+	config := ast.Parse(stateConfigData, actionsConfigData, responsesConfigData, eventsConfigData, map[interface{}]interface{}{})
 	s := newServerFactory(config).
 		writePackageName(). // to be able to format the code without errors
 		writeMessageKinds().
 		writeParameters().
 		writeResponses().
+		writeEvents().
 		writeProcessClientMessage().
+		writeMetrics().
 		writeInspectHandler(configJson)
 
 	err := Format(s.buf)
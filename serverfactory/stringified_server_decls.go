@@ -11,6 +11,8 @@ const _MessageKindAction_addItemToPlayer_type string = `const (
 	MessageKindAction_addItemToPlayer	MessageKind	= "addItemToPlayer"
 	MessageKindAction_movePlayer		MessageKind	= "movePlayer"
 	MessageKindAction_spawnZoneItems	MessageKind	= "spawnZoneItems"
+	MessageKindEvent_chatMessage		MessageKind	= "chatMessage"
+	MessageKindEvent_matchEnded		MessageKind	= "matchEnded"
 )`
 
 const _MovePlayerParams_type string = `type MovePlayerParams struct {
@@ -36,15 +38,34 @@ const _SpawnZoneItemsResponse_type string = `type SpawnZoneItemsResponse struct
 	NewZoneItemPaths []string ` + "`" + `json:"newZoneItemPaths"` + "`" + `
 }`
 
+const _ChatMessageEvent_type string = `type ChatMessageEvent struct {
+	Author	PlayerID	` + "`" + `json:"author"` + "`" + `
+	Text	string		` + "`" + `json:"text"` + "`" + `
+}`
+
+const kind_ChatMessageEvent_func string = `func (e ChatMessageEvent) kind() MessageKind {
+	return MessageKindEvent_chatMessage
+}`
+
+const _MatchEndedEvent_type string = `type MatchEndedEvent struct {
+	Winners []PlayerID ` + "`" + `json:"winners"` + "`" + `
+}`
+
+const kind_MatchEndedEvent_func string = `func (e MatchEndedEvent) kind() MessageKind {
+	return MessageKindEvent_matchEnded
+}`
+
 const _Actions_type string = `type Actions struct {
-	AddItemToPlayer	func(AddItemToPlayerParams, *Engine) AddItemToPlayerResponse
-	MovePlayer	func(MovePlayerParams, *Engine)
-	SpawnZoneItems	func(SpawnZoneItemsParams, *Engine) SpawnZoneItemsResponse
+	AddItemToPlayer	func(AddItemToPlayerParams, *Engine, Identity) AddItemToPlayerResponse
+	MovePlayer	func(MovePlayerParams, *Engine, Identity)
+	SpawnZoneItems	func(SpawnZoneItemsParams, *Engine, Identity) SpawnZoneItemsResponse
 }`
 
 const _SideEffects_type string = `type SideEffects struct {
+	OnClientConnect	func(*Engine, Identity)
 	OnDeploy	func(*Engine)
-	OnFrameTick	func(*Engine)
+	OnFrameTick	func(*Engine, TickInfo)
+	OnShutdown	func(*Engine)
 }`
 
 const processClientMessage_Room_func string = `func (r *Room) processClientMessage(msg Message) (Message, error) {
@@ -58,7 +79,7 @@ const processClientMessage_Room_func string = `func (r *Room) processClientMessa
 		if err != nil {
 			return Message{MessageKindError, messageUnmarshallingError(msg.Content, err), msg.client}, err
 		}
-		res := r.actions.AddItemToPlayer(params, r.state)
+		res := r.actions.AddItemToPlayer(params, r.state, msg.client.identity)
 		resContent, err := res.MarshalJSON()
 		if err != nil {
 			return Message{MessageKindError, responseMarshallingError(msg.Content, err), msg.client}, err
@@ -73,7 +94,7 @@ const processClientMessage_Room_func string = `func (r *Room) processClientMessa
 		if err != nil {
 			return Message{MessageKindError, messageUnmarshallingError(msg.Content, err), msg.client}, err
 		}
-		r.actions.MovePlayer(params, r.state)
+		r.actions.MovePlayer(params, r.state, msg.client.identity)
 		return Message{}, nil
 	case MessageKindAction_spawnZoneItems:
 		if r.actions.SpawnZoneItems == nil {
@@ -84,7 +105,7 @@ const processClientMessage_Room_func string = `func (r *Room) processClientMessa
 		if err != nil {
 			return Message{MessageKindError, messageUnmarshallingError(msg.Content, err), msg.client}, err
 		}
-		res := r.actions.SpawnZoneItems(params, r.state)
+		res := r.actions.SpawnZoneItems(params, r.state, msg.client.identity)
 		resContent, err := res.MarshalJSON()
 		if err != nil {
 			return Message{MessageKindError, responseMarshallingError(msg.Content, err), msg.client}, err
@@ -96,6 +117,12 @@ const processClientMessage_Room_func string = `func (r *Room) processClientMessa
 	return Message{}, nil
 }`
 
+const actionKinds_type string = `var actionKinds = []MessageKind{MessageKindAction_addItemToPlayer, MessageKindAction_movePlayer, MessageKindAction_spawnZoneItems}`
+
+const elementCounts_Room_func string = `func (r *Room) elementCounts() map[ElementKind]int {
+	return map[ElementKind]int{ElementKindEquipmentSet: len(r.state.State.EquipmentSet), ElementKindGearScore: len(r.state.State.GearScore), ElementKindItem: len(r.state.State.Item), ElementKindPlayer: len(r.state.State.Player), ElementKindPosition: len(r.state.State.Position), ElementKindZone: len(r.state.State.Zone), ElementKindZoneItem: len(r.state.State.ZoneItem)}
+}`
+
 const inspectHandler_func string = `func inspectHandler(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Access-Control-Allow-Origin", "*")
 	fmt.Fprintf(w, ` + "`" + `{
@@ -105,14 +132,15 @@ const inspectHandler_func string = `func inspectHandler(w http.ResponseWriter, r
       "equipmentSets": "[]*equipmentSet",
       "gearScore": "gearScore",
       "position": "position",
-      "guildMembers": "[]*player",
+      "guildMembers": "set<*player>",
       "target": "*anyOf<player,zoneItem>",
       "targetedBy": "[]*anyOf<player,zoneItem>"
     },
     "zone": {
       "items": "[]zoneItem",
       "players": "[]player",
-      "tags": "[]string",
+      "boss": "?player",
+      "tags": "set<string>",
       "interactables": "[]anyOf<item,player,zoneItem>"
     },
     "zoneItem": {
@@ -135,6 +163,10 @@ const inspectHandler_func string = `func inspectHandler(w http.ResponseWriter, r
     },
     "equipmentSet": {
       "name": "string",
+      "createdAt": "time",
+      "cooldown": "duration",
+      "icon": "bytes",
+      "externalID": "bigID",
       "equipment": "[]*item"
     }
   },
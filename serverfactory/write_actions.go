@@ -17,7 +17,7 @@ func (s *ServerFactory) writeActions() *ServerFactory {
 			if action.Response == nil {
 				responseName = Empty()
 			}
-			return Id(Title(action.Name)).Func().Params(Id(Title(action.Name)+"Params"), Id("*Engine")).Add(responseName)
+			return Id(Title(action.Name)).Func().Params(Id(Title(action.Name)+"Params"), Id("*Engine"), Id("Identity")).Add(responseName)
 		}),
 	)
 
@@ -0,0 +1,62 @@
+package serverfactory
+
+import (
+	"github.com/jobergner/backent-cli/ast"
+	. "github.com/jobergner/backent-cli/factoryutils"
+
+	. "github.com/dave/jennifer/jen"
+)
+
+func (s *ServerFactory) writeEvents() *ServerFactory {
+	decls := NewDeclSet()
+	s.config.RangeEvents(func(event ast.Event) {
+
+		e := eventWriter{
+			e: event,
+		}
+
+		decls.File.Type().Id(e.name()).Struct(ForEachFieldInEvent(event, func(field ast.Field) *Statement {
+			e.f = &field
+			return Id(e.fieldName()).Id(e.fieldType(s)).Id(e.fieldTag())
+		}))
+
+		decls.File.Func().Params(Id("e").Id(e.name())).Id("kind").Params().Id("MessageKind").Block(
+			Return(Id(e.messageKind())),
+		)
+	})
+
+	decls.Render(s.buf)
+	return s
+}
+
+type eventWriter struct {
+	e ast.Event
+	f *ast.Field
+}
+
+func (e eventWriter) name() string {
+	return Title(e.e.Name) + "Event"
+}
+
+func (e eventWriter) messageKind() string {
+	return "MessageKindEvent_" + e.e.Name
+}
+
+func (e eventWriter) fieldName() string {
+	return Title(e.f.Name)
+}
+
+func (e eventWriter) fieldType(s *ServerFactory) string {
+	var typeName string
+	if e.f.HasSliceValue {
+		typeName += "[]"
+	}
+	if s.isIDTypeOfType(e.f.ValueType().Name) || !e.f.ValueType().IsBasicType {
+		return typeName + Title(e.f.ValueType().Name)
+	}
+	return typeName + e.f.ValueType().Name
+}
+
+func (e eventWriter) fieldTag() string {
+	return "`json:\"" + e.f.Name + "\"`"
+}
@@ -0,0 +1,27 @@
+package serverfactory
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jobergner/backent-cli/testutils"
+)
+
+func TestWriteEvents(t *testing.T) {
+	t.Run("writes events", func(t *testing.T) {
+		sf := newServerFactory(newSimpleASTExample())
+		sf.writeEvents()
+
+		actual := testutils.FormatCode(sf.buf.String())
+		expected := testutils.FormatCode(strings.Join([]string{
+			_ChatMessageEvent_type,
+			kind_ChatMessageEvent_func,
+			_MatchEndedEvent_type,
+			kind_MatchEndedEvent_func,
+		}, "\n"))
+
+		if expected != actual {
+			t.Errorf(testutils.Diff(actual, expected))
+		}
+	})
+}
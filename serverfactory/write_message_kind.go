@@ -14,6 +14,9 @@ func (s *ServerFactory) writeMessageKinds() *ServerFactory {
 		ForEachActionInAST(s.config, func(action ast.Action) *Statement {
 			return Id("MessageKindAction_" + action.Name).Id("MessageKind").Op("=").Lit(action.Name)
 		}),
+		ForEachEventInAST(s.config, func(event ast.Event) *Statement {
+			return Id("MessageKindEvent_" + event.Name).Id("MessageKind").Op("=").Lit(event.Name)
+		}),
 	)
 
 	decls.Render(s.buf)
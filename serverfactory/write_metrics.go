@@ -0,0 +1,29 @@
+package serverfactory
+
+import (
+	"github.com/jobergner/backent-cli/ast"
+	. "github.com/jobergner/backent-cli/factoryutils"
+
+	. "github.com/dave/jennifer/jen"
+)
+
+func (s *ServerFactory) writeMetrics() *ServerFactory {
+	decls := NewDeclSet()
+
+	decls.File.Var().Id("actionKinds").Op("=").Index().Id("MessageKind").Values(
+		ForEachActionInAST(s.config, func(action ast.Action) *Statement {
+			return Id("MessageKindAction_" + action.Name).Op(",")
+		}),
+	)
+
+	decls.File.Func().Params(Id("r").Id("*Room")).Id("elementCounts").Params().Map(Id("ElementKind")).Int().Block(
+		Return(Map(Id("ElementKind")).Int().Values(
+			ForEachTypeInAST(s.config, func(configType ast.ConfigType) *Statement {
+				return Id("ElementKind" + Title(configType.Name)).Op(":").Len(Id("r").Dot("state").Dot("State").Dot(Title(configType.Name))).Op(",")
+			}),
+		)),
+	)
+
+	decls.Render(s.buf)
+	return s
+}
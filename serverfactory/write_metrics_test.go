@@ -0,0 +1,25 @@
+package serverfactory
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jobergner/backent-cli/testutils"
+)
+
+func TestWriteMetrics(t *testing.T) {
+	t.Run("writes metrics", func(t *testing.T) {
+		sf := newServerFactory(newSimpleASTExample())
+		sf.writeMetrics()
+
+		actual := testutils.FormatCode(sf.buf.String())
+		expected := testutils.FormatCode(strings.Join([]string{
+			actionKinds_type,
+			elementCounts_Room_func,
+		}, "\n"))
+
+		if expected != actual {
+			t.Errorf(testutils.Diff(actual, expected))
+		}
+	})
+}
@@ -10,7 +10,7 @@ import (
 )
 
 func newSimpleASTExample() *ast.AST {
-	simpleAST := ast.Parse(configs.StateConfig, configs.ActionsConfig, configs.ResponsesConfig)
+	simpleAST := ast.Parse(configs.StateConfig, configs.ActionsConfig, configs.ResponsesConfig, configs.EventsConfig, map[interface{}]interface{}{})
 	return simpleAST
 }
 
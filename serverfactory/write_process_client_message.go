@@ -76,7 +76,7 @@ func (p processClientMessageWriter) returnErrorMessage() (*Statement, *Statement
 }
 
 func (p processClientMessageWriter) callAction() *Statement {
-	call := Id("r").Dot("actions").Dot(Title(p.a.Name)).Call(Id("params"), Id("r").Dot("state"))
+	call := Id("r").Dot("actions").Dot(Title(p.a.Name)).Call(Id("params"), Id("r").Dot("state"), Id("msg").Dot("client").Dot("identity"))
 	if p.a.Response != nil {
 		return Id("res").Op(":=").Add(call)
 	}
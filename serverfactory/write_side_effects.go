@@ -11,8 +11,10 @@ func (s *ServerFactory) writeSideEffects() *ServerFactory {
 
 	decls.File.Comment("easyjson:skip")
 	decls.File.Type().Id("SideEffects").Struct(
+		Id("OnClientConnect").Func().Params(Id("*Engine"), Id("Identity")),
 		Id("OnDeploy").Func().Params(Id("*Engine")),
-		Id("OnFrameTick").Func().Params(Id("*Engine")),
+		Id("OnFrameTick").Func().Params(Id("*Engine"), Id("TickInfo")),
+		Id("OnShutdown").Func().Params(Id("*Engine")),
 	)
 
 	decls.Render(s.buf)
@@ -15,8 +15,10 @@ func TestWriteSideEffects(t *testing.T) {
 		actual := testutils.FormatCode(sf.buf.String())
 		expected := testutils.FormatCode(strings.Join([]string{
 			`type SideEffects struct {
-	OnDeploy    func(*Engine)
-	OnFrameTick func(*Engine)
+	OnClientConnect func(*Engine, Identity)
+	OnDeploy        func(*Engine)
+	OnFrameTick     func(*Engine, TickInfo)
+	OnShutdown      func(*Engine)
 }`,
 		}, "\n"))
 
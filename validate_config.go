@@ -14,5 +14,11 @@ func validateConfig(c *config) []error {
 	if errs := validator.ValidateResponsesConfig(c.State, c.Actions, c.Responses); len(errs) != 0 {
 		return errs
 	}
+	if errs := validator.ValidateEventsConfig(c.State, c.Actions, c.Events); len(errs) != 0 {
+		return errs
+	}
+	if errs := validator.ValidateIndexesConfig(c.State, c.Indexes); len(errs) != 0 {
+		return errs
+	}
 	return nil
 }
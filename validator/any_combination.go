@@ -148,7 +148,8 @@ func stateConfigCombinationsFrom(data map[interface{}]interface{}) ([]map[interf
 		return nil, nonObjectErrs
 	}
 
-	cmb := newAnyOfTypeCombinator(data)
+	// sets are validated just like slices, optional values just like values
+	cmb := newAnyOfTypeCombinator(optionalValuesAsValues(setValuesAsSlices(data)))
 
 	invalidAnyOfDefinitionErrs := cmb.build()
 	if len(invalidAnyOfDefinitionErrs) != 0 {
@@ -199,6 +199,12 @@ func isBasicType(typeString string) bool {
 	return false
 }
 
+// bytes values are slices and can therefore not be compared,
+// time values can only be compared with time.Time.Equal
+func isUncomparableBasicType(typeString string) bool {
+	return typeString == "bytes" || typeString == "time"
+}
+
 func containsOnlyBasicTypes(declarationTypeString string) bool {
 	extractTypes := extractTypes(declarationTypeString)
 	for _, extractType := range extractTypes {
@@ -0,0 +1,35 @@
+package validator
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// "?foo" -> true
+// "?[]foo", "[]?foo" -> false
+func isOptionalValue(valueString string) bool {
+	re := regexp.MustCompile(`^\?[A-Za-z]+[0-9]*$`)
+	return re.MatchString(valueString)
+}
+
+// optionalValuesAsValues returns a copy of the data in which all `?T` values are replaced with `T`,
+// as optional values have the same restrictions as values. Only types defined in the data
+// can be optional, all other optional values are left as they are and end up as invalid value strings.
+func optionalValuesAsValues(data map[interface{}]interface{}) map[interface{}]interface{} {
+	newData := copyData(data)
+
+	for _, v := range newData {
+		valueObject := v.(map[interface{}]interface{})
+		for _k, _v := range valueObject {
+			valueString := fmt.Sprintf("%v", _v)
+			if !isOptionalValue(valueString) {
+				continue
+			}
+			if _, ok := data[valueString[len("?"):]]; ok {
+				valueObject[_k] = valueString[len("?"):]
+			}
+		}
+	}
+
+	return newData
+}
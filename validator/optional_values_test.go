@@ -0,0 +1,34 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOptionalValuesAsValues(t *testing.T) {
+	t.Run("replaces optional values of defined types with values", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			"foo": map[interface{}]interface{}{
+				"bar": "?foo",
+				"baz": "?string",
+				"ban": "?[]foo",
+				"fan": "[]?foo",
+				"lan": "int",
+			},
+		}
+
+		expected := map[interface{}]interface{}{
+			"foo": map[interface{}]interface{}{
+				"bar": "foo",
+				"baz": "?string",
+				"ban": "?[]foo",
+				"fan": "[]?foo",
+				"lan": "int",
+			},
+		}
+
+		assert.Equal(t, expected, optionalValuesAsValues(data))
+		assert.Equal(t, "?foo", data["foo"].(map[interface{}]interface{})["bar"])
+	})
+}
@@ -0,0 +1,37 @@
+package validator
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// "set<string>" -> true
+// "[]set<string>" -> false
+func isSetValue(valueString string) bool {
+	re := regexp.MustCompile(`^set<.+>$`)
+	return re.MatchString(valueString)
+}
+
+// "set<*foo>" -> "[]*foo"
+func setValueAsSlice(valueString string) string {
+	return "[]" + valueString[len("set<"):len(valueString)-len(">")]
+}
+
+// setValuesAsSlices returns a copy of the data in which all `set<T>` values are replaced with `[]T`,
+// as sets have the same restrictions as slices. Sets within other values (`[]set<T>`, `set<set<T>>`)
+// are left as they are and end up as invalid value strings.
+func setValuesAsSlices(data map[interface{}]interface{}) map[interface{}]interface{} {
+	newData := copyData(data)
+
+	for _, v := range newData {
+		valueObject := v.(map[interface{}]interface{})
+		for _k, _v := range valueObject {
+			valueString := fmt.Sprintf("%v", _v)
+			if isSetValue(valueString) {
+				valueObject[_k] = setValueAsSlice(valueString)
+			}
+		}
+	}
+
+	return newData
+}
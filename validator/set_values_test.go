@@ -0,0 +1,34 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetValuesAsSlices(t *testing.T) {
+	t.Run("replaces set values with slice values", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			"foo": map[interface{}]interface{}{
+				"bar": "set<string>",
+				"baz": "set<*foo>",
+				"ban": "set<anyOf<bar,foo>>",
+				"fan": "[]set<string>",
+				"lan": "int",
+			},
+		}
+
+		expected := map[interface{}]interface{}{
+			"foo": map[interface{}]interface{}{
+				"bar": "[]string",
+				"baz": "[]*foo",
+				"ban": "[]anyOf<bar,foo>",
+				"fan": "[]set<string>",
+				"lan": "int",
+			},
+		}
+
+		assert.Equal(t, expected, setValuesAsSlices(data))
+		assert.Equal(t, "set<string>", data["foo"].(map[interface{}]interface{})["bar"])
+	})
+}
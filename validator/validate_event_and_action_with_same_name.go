@@ -0,0 +1,28 @@
+package validator
+
+import (
+	"fmt"
+)
+
+func validateEventAndActionWithSameName(actionsData, eventsData map[interface{}]interface{}) (errs []error) {
+
+	var actionNames []string
+	for key := range actionsData {
+		actionName := fmt.Sprintf("%v", key)
+		actionNames = append(actionNames, actionName)
+	}
+
+	var eventNames []string
+	for key := range eventsData {
+		eventName := fmt.Sprintf("%v", key)
+		eventNames = append(eventNames, eventName)
+	}
+
+	for _, eventName := range eventNames {
+		if contains(actionNames, eventName) {
+			errs = append(errs, newValidationErrorEventAndActionWithSameName(eventName))
+		}
+	}
+
+	return
+}
@@ -0,0 +1,30 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateEventAndActionWithSameName(t *testing.T) {
+	t.Run("should fail on events with the same name as an action", func(t *testing.T) {
+		actionsData := map[interface{}]interface{}{
+			"foo": "int",
+			"bar": "string",
+		}
+		eventsData := map[interface{}]interface{}{
+			"foo": "int",
+			"baz": "string",
+		}
+
+		actualErrors := validateEventAndActionWithSameName(actionsData, eventsData)
+		expectedErrors := []error{
+			newValidationErrorEventAndActionWithSameName("foo"),
+		}
+
+		missingErrors, redundantErrors := matchErrors(actualErrors, expectedErrors)
+
+		assert.Empty(t, missingErrors)
+		assert.Empty(t, redundantErrors)
+	})
+}
@@ -0,0 +1,36 @@
+package validator
+
+import (
+	"fmt"
+)
+
+// validateInvalidIndex expects the state config to be valid
+func validateInvalidIndex(stateData, indexesData map[interface{}]interface{}) (errs []error) {
+
+	for key, value := range indexesData {
+		typeName := fmt.Sprintf("%v", key)
+
+		typeData, ok := stateData[typeName].(map[interface{}]interface{})
+		if !ok {
+			errs = append(errs, newValidationErrorTypeNotFound(typeName, "indexes"))
+			continue
+		}
+
+		fieldNames, ok := value.([]interface{})
+		if !ok {
+			errs = append(errs, newValidationErrorIllegalValue(typeName, "indexes"))
+			continue
+		}
+
+		for _, fieldNameValue := range fieldNames {
+			fieldName := fmt.Sprintf("%v", fieldNameValue)
+			// only fields of comparable basic types can be indexed
+			valueString, ok := typeData[fieldName].(string)
+			if !ok || !isBasicType(valueString) || isUncomparableBasicType(valueString) {
+				errs = append(errs, newValidationErrorInvalidIndex(fieldName, typeName))
+			}
+		}
+	}
+
+	return
+}
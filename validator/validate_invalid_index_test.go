@@ -0,0 +1,86 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateInvalidIndex(t *testing.T) {
+	t.Run("should not fail on indexes of basic fields", func(t *testing.T) {
+		stateData := map[interface{}]interface{}{
+			"foo": map[interface{}]interface{}{
+				"bar": "string",
+				"baz": "int",
+			},
+		}
+		indexesData := map[interface{}]interface{}{
+			"foo": []interface{}{"bar", "baz"},
+		}
+
+		actualErrors := validateInvalidIndex(stateData, indexesData)
+		expectedErrors := []error{}
+
+		missingErrors, redundantErrors := matchErrors(actualErrors, expectedErrors)
+
+		assert.Empty(t, missingErrors)
+		assert.Empty(t, redundantErrors)
+	})
+	t.Run("should fail on indexes of unknown types, unknown fields or non-basic fields", func(t *testing.T) {
+		stateData := map[interface{}]interface{}{
+			"foo": map[interface{}]interface{}{
+				"bar":  "string",
+				"baz":  "[]string",
+				"bam":  "*foo",
+				"bunt": "bar",
+			},
+			"bar": map[interface{}]interface{}{
+				"ban": "int",
+			},
+		}
+		indexesData := map[interface{}]interface{}{
+			"foo": []interface{}{"bar", "baz", "bam", "bunt", "qux"},
+			"bar": "ban",
+			"baf": []interface{}{"ban"},
+		}
+
+		actualErrors := validateInvalidIndex(stateData, indexesData)
+		expectedErrors := []error{
+			newValidationErrorInvalidIndex("baz", "foo"),
+			newValidationErrorInvalidIndex("bam", "foo"),
+			newValidationErrorInvalidIndex("bunt", "foo"),
+			newValidationErrorInvalidIndex("qux", "foo"),
+			newValidationErrorIllegalValue("bar", "indexes"),
+			newValidationErrorTypeNotFound("baf", "indexes"),
+		}
+
+		missingErrors, redundantErrors := matchErrors(actualErrors, expectedErrors)
+
+		assert.Empty(t, missingErrors)
+		assert.Empty(t, redundantErrors)
+	})
+	t.Run("should fail on indexes of time and bytes fields", func(t *testing.T) {
+		stateData := map[interface{}]interface{}{
+			"foo": map[interface{}]interface{}{
+				"bar": "time",
+				"baz": "duration",
+				"bam": "bytes",
+				"bat": "bigID",
+			},
+		}
+		indexesData := map[interface{}]interface{}{
+			"foo": []interface{}{"bar", "baz", "bam", "bat"},
+		}
+
+		actualErrors := validateInvalidIndex(stateData, indexesData)
+		expectedErrors := []error{
+			newValidationErrorInvalidIndex("bar", "foo"),
+			newValidationErrorInvalidIndex("bam", "foo"),
+		}
+
+		missingErrors, redundantErrors := matchErrors(actualErrors, expectedErrors)
+
+		assert.Empty(t, missingErrors)
+		assert.Empty(t, redundantErrors)
+	})
+}
@@ -74,7 +74,7 @@ func findIllegalMapKeys(valueString string, data map[interface{}]interface{}) []
 		if isReferenceType(mapKey) {
 			isInvalid = true
 		}
-		if containsUncomparableValue(mapKey, data) {
+		if isUncomparableBasicType(mapKey) || containsUncomparableValue(mapKey, data) {
 			isInvalid = true
 		}
 		if isInvalid {
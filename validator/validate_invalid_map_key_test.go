@@ -195,6 +195,24 @@ func TestContainsInvalidMapKeys(t *testing.T) {
 
 		assert.Equal(t, illegalMapKeys, []string{"foo"})
 	})
+	t.Run("should contain bytes as illegal map key", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			"bar": "map[bytes]int",
+		}
+
+		illegalMapKeys := findIllegalMapKeys("map[bytes]int", data)
+
+		assert.Equal(t, illegalMapKeys, []string{"bytes"})
+	})
+	t.Run("should contain time as illegal map key", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			"bar": "map[time]int",
+		}
+
+		illegalMapKeys := findIllegalMapKeys("map[time]int", data)
+
+		assert.Equal(t, illegalMapKeys, []string{"time"})
+	})
 	t.Run("should contain illegal nested map keys (1/3)", func(t *testing.T) {
 		data := map[interface{}]interface{}{
 			"bar": "map[map[bool]float]int",
@@ -6,7 +6,8 @@ import (
 	"reflect"
 )
 
-var golangBasicTypes = []string{"string", "bool", "int8", "uint8", "byte", "int16", "uint16", "int32", "rune", "uint32", "int64", "uint64", "int", "uint", "uintptr", "float32", "float64", "complex64", "complex128"}
+// time, duration, bytes and bigID are no basic types of golang, but are treated like them
+var golangBasicTypes = []string{"string", "bool", "int8", "uint8", "byte", "int16", "uint16", "int32", "rune", "uint32", "int64", "uint64", "int", "uint", "uintptr", "float32", "float64", "complex64", "complex128", "time", "duration", "bytes", "bigID"}
 
 const mockPackageName string = "foobar"
 
@@ -179,6 +180,25 @@ func ValidateResponsesConfig(stateConfigData, actionsConfigData, responsesConfig
 	return
 }
 
+func ValidateEventsConfig(stateConfigData, actionsConfigData, eventsConfigData map[interface{}]interface{}) (errs []error) {
+	// events and action share the same restrictions/requirements
+	eventsAsActionsValidationErrs := ValidateActionsConfig(stateConfigData, eventsConfigData)
+	errs = append(errs, eventsAsActionsValidationErrs...)
+
+	// events and actions share the same message kinds
+	eventAndActionWithSameNameErrs := validateEventAndActionWithSameName(actionsConfigData, eventsConfigData)
+	errs = append(errs, eventAndActionWithSameNameErrs...)
+
+	return
+}
+
+func ValidateIndexesConfig(stateConfigData, indexesConfigData map[interface{}]interface{}) (errs []error) {
+	invalidIndexErrs := validateInvalidIndex(stateConfigData, indexesConfigData)
+	errs = append(errs, invalidIndexErrs...)
+
+	return
+}
+
 func ValidateActionsConfig(stateConfigData map[interface{}]interface{}, actionsConfigData map[interface{}]interface{}) (errs []error) {
 	dataCombinations, prevalidationErrs := stateConfigCombinationsFrom(stateConfigData)
 	if len(prevalidationErrs) != 0 {
@@ -165,3 +165,20 @@ func newValidationErrorResponseToUnknownAction(responseName string) error {
 		),
 	)
 }
+func newValidationErrorEventAndActionWithSameName(name string) error {
+	return errors.New(
+		fmt.Sprintf(
+			"ErrEventAndActionWithSameName: event and action \"%s\" have the same name",
+			name,
+		),
+	)
+}
+func newValidationErrorInvalidIndex(fieldName, typeName string) error {
+	return errors.New(
+		fmt.Sprintf(
+			"ErrInvalidIndex: field \"%s\" of \"%s\" cannot be indexed",
+			fieldName,
+			typeName,
+		),
+	)
+}
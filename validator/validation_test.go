@@ -439,6 +439,40 @@ func TestValidateStateConfigWithAnyOfTypes(t *testing.T) {
 		assert.Empty(t, missingErrors)
 		assert.Empty(t, redundantErrors)
 	})
+	t.Run("returns errors of set values as if they were slices", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			"foo": map[interface{}]interface{}{
+				"fan": "set<bar>",
+			},
+		}
+
+		actualErrors := ValidateStateConfig(data)
+		expectedErrors := []error{
+			newValidationErrorTypeNotFound("bar", "foo"),
+		}
+
+		missingErrors, redundantErrors := matchErrors(actualErrors, expectedErrors)
+
+		assert.Empty(t, missingErrors)
+		assert.Empty(t, redundantErrors)
+	})
+	t.Run("returns errors of optional values of undefined or basic types", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			"foo": map[interface{}]interface{}{
+				"fan": "?string",
+			},
+		}
+
+		actualErrors := ValidateStateConfig(data)
+		expectedErrors := []error{
+			newValidationErrorInvalidValueString("?string", "fan", "foo"),
+		}
+
+		missingErrors, redundantErrors := matchErrors(actualErrors, expectedErrors)
+
+		assert.Empty(t, missingErrors)
+		assert.Empty(t, redundantErrors)
+	})
 	t.Run("does not cause any erros on valid definition", func(t *testing.T) {
 		data := map[interface{}]interface{}{
 			"foo": map[interface{}]interface{}{
@@ -452,9 +486,16 @@ func TestValidateStateConfigWithAnyOfTypes(t *testing.T) {
 				"ran": "[]anyOf<bar,foo>",
 				"kan": "*anyOf<bar,foo>",
 				"man": "[]*anyOf<bar,foo>",
+				"san": "set<*anyOf<bar,foo>>",
+				"tan": "set<string>",
+				"pan": "?fam",
 			},
 			"fam": map[interface{}]interface{}{
 				"lam": "int",
+				"dam": "time",
+				"gam": "[]duration",
+				"ham": "set<bytes>",
+				"jam": "set<bigID>",
 			},
 		}
 
@@ -500,6 +541,41 @@ func TestValidateResponsesConfig(t *testing.T) {
 	})
 }
 
+func TestValidateEventsConfig(t *testing.T) {
+	t.Run("validates events just like actions but returns error due to event with the name of an action", func(t *testing.T) {
+		stateConfigData := map[interface{}]interface{}{
+			"baz": map[interface{}]interface{}{
+				"ban": "string",
+			},
+		}
+		actionsConfigData := map[interface{}]interface{}{
+			"dooFoo": map[interface{}]interface{}{
+				"bar": "int",
+			},
+		}
+		eventsConfigData := map[interface{}]interface{}{
+			"dooFoo": map[interface{}]interface{}{
+				"ban": "bazID",
+			},
+			"fooBar": map[interface{}]interface{}{
+				"bau": "*baz",
+			},
+		}
+
+		actualErrors := ValidateEventsConfig(stateConfigData, actionsConfigData, eventsConfigData)
+		expectedErrors := []error{
+			newValidationErrorEventAndActionWithSameName("dooFoo"),
+			newValidationErrorDirectTypeUsage("fooBar", "*baz"),
+			newValidationErrorIllegalPointerParameter("fooBar", "bau"),
+		}
+
+		missingErrors, redundantErrors := matchErrors(actualErrors, expectedErrors)
+
+		assert.Empty(t, missingErrors)
+		assert.Empty(t, redundantErrors)
+	})
+}
+
 // func TestFoo(t *testing.T) {
 // 	var StateConfig = map[interface{}]interface{}{
 // 		"player": map[interface{}]interface{}{
@@ -17,9 +17,9 @@ func writeCode(c *config, configJson []byte) []byte {
 		buf.WriteString("\n" + imported_server_example_files)
 	}
 
-	enginefactory.WriteEngine(buf, c.State)
+	enginefactory.WriteEngine(buf, c.State, c.Indexes, *orderedFlag)
 	if !*engineOnlyFlag {
-		serverfactory.WriteServer(buf, c.State, c.Actions, c.Responses, configJson)
+		serverfactory.WriteServer(buf, c.State, c.Actions, c.Responses, c.Events, configJson)
 	}
 
 	return buf.Bytes()